@@ -68,6 +68,30 @@ const (
 	//
 	// NOTE: This is a non-blocking information condition.
 	HelmChartNameChangedCondition = "HelmChartNameChanged"
+	// PlacementReadyCondition indicates whether the [ClusterDeployment] has been placed into
+	// a [Region] satisfying its [ClusterPlacement].
+	PlacementReadyCondition = "PlacementReady"
+)
+
+const (
+	// PlacementRegionSelectorAnnotation is the annotation on a namespace defining the label selector
+	// of the default [ClusterPlacement] for the [ClusterDeployment] objects in the namespace.
+	// The value has the format of the kubectl label selectors, e.g. "tier=prod,zone in (a,b)".
+	PlacementRegionSelectorAnnotation = "k0rdent.mirantis.com/placement-region-selector"
+	// PlacementPreferenceAnnotation is the annotation on a namespace defining the preference
+	// of the default [ClusterPlacement] for the [ClusterDeployment] objects in the namespace.
+	PlacementPreferenceAnnotation = "k0rdent.mirantis.com/placement-preference"
+)
+
+// PlacementPreference defines how a [Region] is chosen among the ones matching a [ClusterPlacement].
+type PlacementPreference string
+
+const (
+	// PlacementPreferenceNone chooses the first matching [Region] ordered by name.
+	PlacementPreferenceNone PlacementPreference = ""
+	// PlacementPreferenceLeastLoaded chooses the matching [Region] with the least
+	// number of [ClusterDeployment] objects placed into it.
+	PlacementPreferenceLeastLoaded PlacementPreference = "LeastLoaded"
 )
 
 const (
@@ -89,6 +113,9 @@ const (
 	WaitingForClusterDataSourceDeletionReason = "WaitingForClusterDataSourceDeletion"
 	// DeletionCompletedReason indicates the cluster deletion is completed, and all the related resources have been deleted.
 	DeletionCompletedReason = "DeletionCompleted"
+	// NoMatchingRegionReason indicates that none of the [Region] objects satisfies the [ClusterPlacement]
+	// or none of them has a suitable [Credential].
+	NoMatchingRegionReason = "NoMatchingRegion"
 	// HelmChartNameChangedReason indicates the Helm chart name has changed compared to the currently deployed release.
 	HelmChartNameChangedReason = "HelmChartNameChanged"
 	// CAPIClusterMissingReason indicates the underlying CAPI Cluster object is unexpectedly
@@ -96,6 +123,17 @@ const (
 	CAPIClusterMissingReason = "CAPIClusterMissing"
 )
 
+const (
+	// DryRunPreviewManifestKey is the key in the dry-run preview ConfigMap holding the rendered manifest.
+	DryRunPreviewManifestKey = "manifest"
+	// DryRunPreviewDeployedManifestKey is the key in the dry-run preview ConfigMap holding the manifest
+	// of the currently deployed Helm release, if any.
+	DryRunPreviewDeployedManifestKey = "deployed"
+	// DryRunPreviewDiffKey is the key in the dry-run preview ConfigMap holding the object-level diff
+	// between the deployed and the rendered manifests.
+	DryRunPreviewDiffKey = "diff"
+)
+
 // ClusterDeploymentSpec defines the desired state of ClusterDeployment
 type ClusterDeploymentSpec struct {
 	// Config allows to provide parameters for template customization.
@@ -135,6 +173,29 @@ type ClusterDeploymentSpec struct {
 	// This is a best-effort cleanup, if there is no possibility to acquire
 	// a managed cluster's kubeconfig, the cleanup will NOT happen.
 	CleanupOnDeletion bool `json:"cleanupOnDeletion,omitempty"`
+
+	// MaintenanceWindow restricts template upgrades and service version changes to the given window.
+	// If not set, the window defined by the annotations of the namespace is used, if any.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Placement declares the requirements to the Region the ClusterDeployment is placed into.
+	// If Credential is not set, a matching Region and a Credential valid there are chosen automatically.
+	// If not set, the placement defined by the annotations of the namespace is used, if any.
+	Placement *ClusterPlacement `json:"placement,omitempty"`
+}
+
+// ClusterPlacement defines the requirements to the [Region] a [ClusterDeployment] is placed into.
+type ClusterPlacement struct {
+	// RegionSelector selects the Regions the ClusterDeployment may be placed into.
+	// An empty selector matches all Regions. The management cluster is never selected.
+	RegionSelector *metav1.LabelSelector `json:"regionSelector,omitempty"`
+
+	// +kubebuilder:validation:Enum="";LeastLoaded
+
+	// Preference defines how a Region is chosen among the matching ones.
+	// By default, the first matching Region ordered by name is chosen;
+	// LeastLoaded chooses the Region with the least number of ClusterDeployments.
+	Preference PlacementPreference `json:"preference,omitempty"`
 }
 
 // ClusterIPAMClaimType represents the IPAM claim configuration for a cluster deployment.
@@ -173,10 +234,50 @@ type ClusterDeploymentStatus struct {
 	// available.
 	AvailableUpgrades []string `json:"availableUpgrades,omitempty"`
 
+	// UpgradeBreakingChanges lists the breaking changes of the values schemas of the ClusterTemplates
+	// from the AvailableUpgrades affecting the configuration of the [ClusterDeployment]
+	// and not handled by the values migrations of the ClusterTemplates.
+	UpgradeBreakingChanges []UpgradeBreakingChanges `json:"upgradeBreakingChanges,omitempty"`
+
+	// DryRunPreview holds the result of rendering the [ClusterTemplate] with the provided
+	// configuration while DryRun is enabled.
+	DryRunPreview *DryRunPreview `json:"dryRunPreview,omitempty"`
+
 	// ObservedGeneration is the last observed generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// UpgradeBreakingChanges describes the breaking changes of an upgrade to a ClusterTemplate.
+type UpgradeBreakingChanges struct {
+	// Template is the name of the ClusterTemplate available for upgrade.
+	Template string `json:"template"`
+	// Changes are the human-readable descriptions of the breaking changes.
+	Changes []string `json:"changes"`
+}
+
+// DryRunPreview describes the rendered output of a [ClusterDeployment] in DryRun mode.
+type DryRunPreview struct {
+	// ConfigMapName is the name of the ConfigMap in the [ClusterDeployment] namespace
+	// containing the rendered manifest, the deployed manifest and their diff.
+	ConfigMapName string `json:"configMapName"`
+	// ManifestDigest is the digest of the rendered manifest.
+	ManifestDigest string `json:"manifestDigest,omitempty"`
+	// DeployedRevision is the revision of the deployed Helm release the rendered manifest
+	// has been compared against. Zero means there is no deployed release.
+	DeployedRevision int `json:"deployedRevision,omitempty"`
+	// Objects is the number of objects in the rendered manifest.
+	Objects int `json:"objects,omitempty"`
+	// Added is the number of objects that will be created.
+	Added int `json:"added,omitempty"`
+	// Changed is the number of objects that will be updated.
+	Changed int `json:"changed,omitempty"`
+	// Removed is the number of objects that will be deleted.
+	Removed int `json:"removed,omitempty"`
+	// TruncatedKeys lists the keys of the ConfigMap the values of which have been truncated
+	// to fit the size limit of the ConfigMap.
+	TruncatedKeys []string `json:"truncatedKeys,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
@@ -0,0 +1,167 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ClusterDeploymentMigrationKind is the string representation of the [ClusterDeploymentMigration].
+	ClusterDeploymentMigrationKind = "ClusterDeploymentMigration"
+	// ClusterDeploymentMigrationFinalizer is the finalizer set on the [ClusterDeploymentMigration]
+	// until the changes of the unfinished migration are rolled back.
+	ClusterDeploymentMigrationFinalizer = "k0rdent.mirantis.com/cluster-deployment-migration"
+
+	// ClusterDeploymentMigrationAnnotation is the annotation set on the [ClusterDeployment] being migrated
+	// with the name of the [ClusterDeploymentMigration]. The [ClusterDeployment] is not reconciled while
+	// the annotation is present. The annotation is also set on the [ServiceSet] objects rebuilt against
+	// the target region.
+	ClusterDeploymentMigrationAnnotation = "k0rdent.mirantis.com/migration"
+
+	// MigratingReason declares that the [ClusterDeployment] is paused since it is being migrated.
+	MigratingReason = "Migrating"
+)
+
+// ClusterDeploymentMigrationPhase represents the phase of the [ClusterDeploymentMigration].
+type ClusterDeploymentMigrationPhase string
+
+const (
+	// ClusterDeploymentMigrationPhasePending means the migration has not been started yet.
+	ClusterDeploymentMigrationPhasePending ClusterDeploymentMigrationPhase = "Pending"
+	// ClusterDeploymentMigrationPhasePausing means the HelmRelease and the CAPI cluster are being paused.
+	ClusterDeploymentMigrationPhasePausing ClusterDeploymentMigrationPhase = "Pausing"
+	// ClusterDeploymentMigrationPhaseMoving means the CAPI objects, cluster identities and secrets
+	// are being moved to the target cluster.
+	ClusterDeploymentMigrationPhaseMoving ClusterDeploymentMigrationPhase = "Moving"
+	// ClusterDeploymentMigrationPhaseRepointing means the [ClusterDeployment] is being re-pointed to the target [Credential]
+	// and its HelmRelease is being resumed against the target cluster.
+	ClusterDeploymentMigrationPhaseRepointing ClusterDeploymentMigrationPhase = "Repointing"
+	// ClusterDeploymentMigrationPhaseRebuildingServices means the [ServiceSet] objects of the [ClusterDeployment]
+	// are being rebuilt against the Sveltos of the target cluster.
+	ClusterDeploymentMigrationPhaseRebuildingServices ClusterDeploymentMigrationPhase = "RebuildingServices"
+	// ClusterDeploymentMigrationPhaseCompleted means the cluster has been migrated.
+	ClusterDeploymentMigrationPhaseCompleted ClusterDeploymentMigrationPhase = "Completed"
+	// ClusterDeploymentMigrationPhaseFailed means the migration cannot be started
+	// or has not been completed within the timeout. The changes made by the migration are rolled back.
+	ClusterDeploymentMigrationPhaseFailed ClusterDeploymentMigrationPhase = "Failed"
+)
+
+// ClusterDeploymentMigrationSpec defines the desired state of [ClusterDeploymentMigration].
+//
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Spec is immutable"
+type ClusterDeploymentMigrationSpec struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// ClusterDeployment is the name of the [ClusterDeployment] in the namespace to migrate.
+	ClusterDeployment string `json:"clusterDeployment"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// TargetCredential is the name of the [Credential] in the namespace to re-point
+	// the [ClusterDeployment] to. The cluster is migrated to the [Region] of the [Credential],
+	// or to the management cluster if the [Credential] has no region.
+	TargetCredential string `json:"targetCredential"`
+
+	// Timeout is the time the migration is given to complete since its start, defaults to 1h.
+	// Once the timeout is exceeded the migration fails and its changes are rolled back: the objects
+	// are moved back to the source cluster unless the [ClusterDeployment] has already been re-pointed
+	// to the target [Credential], and the [ClusterDeployment] is reconciled again.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ClusterDeploymentMigrationObject is an object copied to the target cluster by the [ClusterDeploymentMigration].
+type ClusterDeploymentMigrationObject struct {
+	// APIVersion is the API version of the object.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+	// Name is the name of the object in the namespace of the [ClusterDeployment].
+	Name string `json:"name"`
+	// SourceUID is the UID of the object in the source cluster.
+	SourceUID types.UID `json:"sourceUID"`
+	// TargetUID is the UID of the copy of the object in the target cluster.
+	TargetUID types.UID `json:"targetUID"`
+}
+
+// ClusterDeploymentMigrationStatus defines the observed state of [ClusterDeploymentMigration].
+type ClusterDeploymentMigrationStatus struct {
+	// StartTime is the time the migration has been started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the migration has been completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// SourceCredential is the [Credential] the [ClusterDeployment] was referencing before the migration.
+	SourceCredential string `json:"sourceCredential,omitempty"`
+	// SourceRegion is the [Region] the cluster is migrated from, empty for the management cluster.
+	SourceRegion string `json:"sourceRegion,omitempty"`
+	// TargetRegion is the [Region] the cluster is migrated to, empty for the management cluster.
+	TargetRegion string `json:"targetRegion,omitempty"`
+
+	// Phase is the current phase of the migration.
+	Phase ClusterDeploymentMigrationPhase `json:"phase,omitempty"`
+
+	// Conditions contains details for the current state of the [ClusterDeploymentMigration].
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// MovedObjects is the number of the objects moved to the target cluster.
+	MovedObjects int32 `json:"movedObjects,omitempty"`
+
+	// Objects are the objects copied to the target cluster ordered so that the owners precede
+	// their dependents. The objects are deleted from the source cluster only once recorded.
+	Objects []ClusterDeploymentMigrationObject `json:"objects,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cdmig
+// +kubebuilder:printcolumn:name="ClusterDeployment",type=string,JSONPath=`.spec.clusterDeployment`,description="ClusterDeployment being migrated",priority=0
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.status.sourceRegion`,description="Source Region",priority=0
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.status.targetRegion`,description="Target Region",priority=0
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of the migration",priority=0
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// ClusterDeploymentMigration is the Schema for the clusterdeploymentmigrations API.
+// It migrates a running [ClusterDeployment] between the management cluster and
+// the [Region] objects by moving its CAPI objects to the target cluster.
+type ClusterDeploymentMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterDeploymentMigrationSpec   `json:"spec,omitempty"`
+	Status ClusterDeploymentMigrationStatus `json:"status,omitempty"`
+}
+
+// IsFinished returns true if the migration has either been completed or failed.
+func (m *ClusterDeploymentMigration) IsFinished() bool {
+	return m.Status.Phase == ClusterDeploymentMigrationPhaseCompleted || m.Status.Phase == ClusterDeploymentMigrationPhaseFailed
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterDeploymentMigrationList contains a list of [ClusterDeploymentMigration].
+type ClusterDeploymentMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDeploymentMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDeploymentMigration{}, &ClusterDeploymentMigrationList{})
+}
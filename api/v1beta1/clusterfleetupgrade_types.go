@@ -0,0 +1,150 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterFleetUpgradeKind is the string representation of the [ClusterFleetUpgrade].
+	ClusterFleetUpgradeKind = "ClusterFleetUpgrade"
+
+	// ClusterFleetUpgradeHaltedReason declares that no more clusters are being upgraded
+	// because the upgrade of some of the clusters has failed.
+	ClusterFleetUpgradeHaltedReason = "Halted"
+)
+
+// ClusterFleetUpgradePhase represents the phase of the [ClusterFleetUpgrade].
+type ClusterFleetUpgradePhase string
+
+const (
+	// ClusterFleetUpgradePhaseProgressing means the clusters are being upgraded.
+	ClusterFleetUpgradePhaseProgressing ClusterFleetUpgradePhase = "Progressing"
+	// ClusterFleetUpgradePhaseHalted means the upgrade of some of the clusters
+	// has failed and no more clusters are being upgraded.
+	ClusterFleetUpgradePhaseHalted ClusterFleetUpgradePhase = "Halted"
+	// ClusterFleetUpgradePhaseCompleted means all of the matching clusters have been upgraded.
+	ClusterFleetUpgradePhaseCompleted ClusterFleetUpgradePhase = "Completed"
+)
+
+// ClusterUpgradePhase represents the phase of the upgrade of a single [ClusterDeployment].
+type ClusterUpgradePhase string
+
+const (
+	// ClusterUpgradePhasePending means the upgrade of the cluster has not been started yet.
+	ClusterUpgradePhasePending ClusterUpgradePhase = "Pending"
+	// ClusterUpgradePhaseUpgrading means the cluster is being upgraded to the next template of the upgrade path.
+	ClusterUpgradePhaseUpgrading ClusterUpgradePhase = "Upgrading"
+	// ClusterUpgradePhaseCompleted means the cluster has been upgraded to the target template and is ready.
+	ClusterUpgradePhaseCompleted ClusterUpgradePhase = "Completed"
+	// ClusterUpgradePhaseFailed means the upgrade of the cluster has failed.
+	ClusterUpgradePhaseFailed ClusterUpgradePhase = "Failed"
+	// ClusterUpgradePhaseBlocked means there is no upgrade path from the current template
+	// of the cluster to the target template.
+	ClusterUpgradePhaseBlocked ClusterUpgradePhase = "Blocked"
+)
+
+// ClusterFleetUpgradeSpec defines the desired state of [ClusterFleetUpgrade].
+type ClusterFleetUpgradeSpec struct {
+	// ClusterSelector identifies the [ClusterDeployment] objects in the namespace to upgrade.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// TargetTemplate is the name of the [ClusterTemplate] to upgrade the clusters to.
+	// The clusters are upgraded one hop at a time along the upgrade paths
+	// defined by the [ClusterTemplateChain] objects in the namespace.
+	TargetTemplate string `json:"targetTemplate"`
+
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxConcurrency is the maximum number of clusters being upgraded at the same time.
+	MaxConcurrency int32 `json:"maxConcurrency,omitempty"`
+}
+
+// ClusterUpgradeStatus is the progress of the upgrade of a single [ClusterDeployment].
+type ClusterUpgradeStatus struct {
+	// LastTransitionTime is the time the phase of the upgrade has changed.
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// StartTime is the time the [ClusterFleetUpgrade] has started upgrading the cluster,
+	// unset if the cluster has not been upgraded by the [ClusterFleetUpgrade].
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Name is the name of the [ClusterDeployment].
+	Name string `json:"name"`
+	// CurrentTemplate is the [ClusterTemplate] the cluster is currently deployed with.
+	CurrentTemplate string `json:"currentTemplate,omitempty"`
+	// RemainingTemplates is the list of the templates the cluster is yet to be upgraded to,
+	// the last of them is the target template.
+	RemainingTemplates []string `json:"remainingTemplates,omitempty"`
+	// Message is the human-readable details of the upgrade.
+	Message string `json:"message,omitempty"`
+	// Phase is the phase of the upgrade of the cluster.
+	Phase ClusterUpgradePhase `json:"phase"`
+}
+
+// ClusterFleetUpgradeStatus defines the observed state of [ClusterFleetUpgrade].
+type ClusterFleetUpgradeStatus struct {
+	// Clusters is the progress of the upgrade of each of the matching clusters.
+	Clusters []ClusterUpgradeStatus `json:"clusters,omitempty"`
+
+	// Conditions contains details for the current state of the [ClusterFleetUpgrade].
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Phase is the overall phase of the upgrade.
+	Phase ClusterFleetUpgradePhase `json:"phase,omitempty"`
+
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// UpgradedClusters is the number of the clusters upgraded to the target template.
+	UpgradedClusters int32 `json:"upgradedClusters,omitempty"`
+	// TotalClusters is the number of the matching clusters.
+	TotalClusters int32 `json:"totalClusters,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cfu
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.targetTemplate`,description="Target ClusterTemplate",priority=0
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of the upgrade",priority=0
+// +kubebuilder:printcolumn:name="Upgraded",type=integer,JSONPath=`.status.upgradedClusters`,description="Number of upgraded clusters",priority=0
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalClusters`,description="Number of matching clusters",priority=0
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// ClusterFleetUpgrade is the Schema for the clusterfleetupgrades API.
+// It upgrades the matching [ClusterDeployment] objects to the target [ClusterTemplate].
+type ClusterFleetUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterFleetUpgradeSpec   `json:"spec,omitempty"`
+	Status ClusterFleetUpgradeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterFleetUpgradeList contains a list of [ClusterFleetUpgrade].
+type ClusterFleetUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterFleetUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterFleetUpgrade{}, &ClusterFleetUpgradeList{})
+}
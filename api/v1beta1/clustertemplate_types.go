@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/Masterminds/semver/v3"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -45,6 +46,57 @@ type ClusterTemplateSpec struct {
 	// Providers represent required CAPI providers.
 	// Should be set if not present in the Helm chart metadata.
 	Providers Providers `json:"providers,omitempty"`
+	// ValuesMigrations is the ordered list of rules migrating the configuration
+	// of a [ClusterDeployment] written for another ClusterTemplate to this one.
+	// The rules are applied to the configuration when the [ClusterDeployment] is upgraded to this ClusterTemplate
+	// from one of the ClusterTemplates the rules have been written for.
+	ValuesMigrations []ValuesMigration `json:"valuesMigrations,omitempty"`
+}
+
+// ValuesMigrationType is the type of a [ValuesMigration] rule.
+type ValuesMigrationType string
+
+const (
+	// ValuesMigrationRename renames the key at the From path to the To name keeping it in the same object.
+	ValuesMigrationRename ValuesMigrationType = "Rename"
+	// ValuesMigrationMove moves the value at the From path to the To path.
+	ValuesMigrationMove ValuesMigrationType = "Move"
+	// ValuesMigrationDefault sets the Value at the To path unless a value is already set there.
+	ValuesMigrationDefault ValuesMigrationType = "Default"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.type == 'Default' || has(self.from)",message="from must be set for the Rename and Move migrations"
+// +kubebuilder:validation:XValidation:rule="self.type != 'Rename' || !self.to.contains('.')",message="to must be a key name for the Rename migration"
+// +kubebuilder:validation:XValidation:rule="self.type == 'Default' ? has(self.value) : !has(self.value)",message="value must be set only for the Default migration"
+// +kubebuilder:validation:XValidation:rule="has(self.fromTemplates) || has(self.fromVersions)",message="either fromTemplates or fromVersions must be set"
+
+// ValuesMigration is a rule migrating the configuration of a [ClusterDeployment].
+// The paths are dot-separated keys of nested objects, e.g. "controlPlane.instanceType".
+// If a value is already set at the destination of a Rename or Move migration, it is preserved
+// and the value at the source is dropped. The migration is skipped if the source is not set.
+// The migration applies only to the upgrades from the ClusterTemplates matching both
+// FromTemplates and FromVersions, if set.
+type ValuesMigration struct {
+	// Value is the value to set by the Default migration.
+	Value *apiextv1.JSON `json:"value,omitempty"`
+
+	// FromTemplates are the names of the ClusterTemplates the migration has been written for.
+	FromTemplates []string `json:"fromTemplates,omitempty"`
+	// FromVersions is the semantic version constraint on the chart version of the ClusterTemplates
+	// the migration has been written for, e.g. "< 1.2.0".
+	FromVersions string `json:"fromVersions,omitempty"`
+
+	// Type is the type of the migration.
+	//
+	// +kubebuilder:validation:Enum=Rename;Move;Default
+	Type ValuesMigrationType `json:"type"`
+	// From is the path of the value to rename or move.
+	From string `json:"from,omitempty"`
+	// To is the new key name for the Rename migration, the destination path for the Move migration
+	// or the path to set for the Default migration.
+	//
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
 }
 
 // ClusterTemplateStatus defines the observed state of ClusterTemplate
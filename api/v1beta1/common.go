@@ -14,7 +14,10 @@
 
 package v1beta1
 
-import corev1 "k8s.io/api/core/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 const (
 	// SucceededReason indicates a condition or event observed a success, for example when declared desired state
@@ -51,6 +54,36 @@ const (
 
 	// PausedCondition indicates whether an object is paused and not being reconciled.
 	PausedCondition = "Paused"
+
+	// MaintenanceWindowPendingCondition indicates that some of the disruptive changes
+	// are postponed until the maintenance window opens.
+	//
+	// NOTE: This is a non-blocking information condition.
+	MaintenanceWindowPendingCondition = "MaintenanceWindowPending"
+
+	// TemplateDeprecatedCondition indicates that the object uses deprecated
+	// or end-of-life templates.
+	//
+	// NOTE: This is a non-blocking information condition.
+	TemplateDeprecatedCondition = "TemplateDeprecated"
+)
+
+const (
+	// PendingMaintenanceWindowReason indicates that the changes are pending until the maintenance window opens.
+	PendingMaintenanceWindowReason = "PendingMaintenanceWindow"
+
+	// TemplateDeprecatedReason indicates that some of the used templates are deprecated.
+	TemplateDeprecatedReason = "Deprecated"
+	// TemplateEndOfLifeReason indicates that some of the used templates have reached the end of life.
+	TemplateEndOfLifeReason = "EndOfLife"
+
+	// MaintenanceWindowScheduleAnnotation is the annotation on a namespace defining the Cron schedule
+	// of the default [MaintenanceWindow] for the [ClusterDeployment] objects in the namespace.
+	MaintenanceWindowScheduleAnnotation = "k0rdent.mirantis.com/maintenance-window-schedule"
+	// MaintenanceWindowDurationAnnotation is the annotation on a namespace defining the duration
+	// of the default [MaintenanceWindow] for the [ClusterDeployment] objects in the namespace.
+	// Required along with the schedule, the window is ignored if any of the annotations is invalid.
+	MaintenanceWindowDurationAnnotation = "k0rdent.mirantis.com/maintenance-window-duration"
 )
 
 type (
@@ -81,3 +114,16 @@ type SecretKeyReference struct {
 	// Key is the name of the key for the given Secret reference where the value is stored.
 	Key string `json:"key"`
 }
+
+// MaintenanceWindow defines recurring periods of time when disruptive changes,
+// such as template upgrades and service version changes, are allowed to be applied.
+// +kubebuilder:validation:XValidation:rule="duration(self.duration) > duration('0s')",message="duration must be positive"
+type MaintenanceWindow struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Schedule is a Cron expression defining when the maintenance window opens.
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the maintenance window stays open.
+	Duration metav1.Duration `json:"duration"`
+}
@@ -15,6 +15,8 @@
 package v1beta1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -24,6 +26,27 @@ const (
 
 	// CredentialReadyCondition indicates if referenced Credential exists and has Ready state
 	CredentialReadyCondition = "CredentialReady"
+	// CredentialVerifiedCondition indicates whether the cloud provider accepts the credentials
+	// of the ClusterIdentity referenced by the [Credential].
+	CredentialVerifiedCondition = "CredentialVerified"
+
+	// CredentialRejectedReason declares that the cloud provider has rejected the credentials.
+	CredentialRejectedReason = "Rejected"
+	// CredentialVerificationUnsupportedReason declares that the credentials of the kind of
+	// the ClusterIdentity cannot be verified.
+	CredentialVerificationUnsupportedReason = "VerificationUnsupported"
+
+	// DefaultCredentialVerificationInterval is the default interval between the verifications
+	// of the credentials against the cloud provider.
+	DefaultCredentialVerificationInterval = time.Hour
+
+	// CredentialRotatedCondition indicates whether the rotation of the credentials
+	// has been propagated to all of the clusters using the [Credential].
+	CredentialRotatedCondition = "CredentialRotated"
+
+	// CredentialVersionAnnotation is the annotation set on the infrastructure cluster objects
+	// with the version of the credentials to trigger their re-reconciliation by the provider.
+	CredentialVersionAnnotation = "k0rdent.mirantis.com/credential-version"
 
 	// CredentialLabelKeyPrefix is a label key prefix applied to all ClusterIdentity objects and their references.
 	// Each managed ClusterIdentity will have this label set in format of:
@@ -47,6 +70,126 @@ type CredentialSpec struct {
 	Region string `json:"region,omitempty"`
 	// Description of the [Credential] object
 	Description string `json:"description,omitempty"` // WARN: noop
+
+	// Verification configures the verification of the credentials against the cloud provider.
+	// The credentials are verified only if enabled and the kind of the ClusterIdentity is supported.
+	Verification *CredentialVerification `json:"verification,omitempty"`
+
+	// Rotation configures the rotation of the data of the Secret behind the ClusterIdentity:
+	// the Secret itself if the identity is a Secret, otherwise the first Secret referenced by the identity.
+	Rotation *CredentialRotation `json:"rotation,omitempty"`
+}
+
+// CredentialRotation points to the version of the credentials the Secret behind the ClusterIdentity should hold.
+type CredentialRotation struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// SecretName is the name of the Secret holding the desired version of the credentials.
+	// The Secret must reside in the namespace of the Secret behind the ClusterIdentity and have the same layout.
+	// Once the name changes, the current data is preserved in a snapshot Secret, replaced with the data
+	// of the new Secret and propagated to the regions and the clusters using the [Credential].
+	// Changes of the data of the already rotated Secret are not tracked, a new Secret should be created instead.
+	// To roll back, set it to the previous version reported in the status.
+	SecretName string `json:"secretName"`
+}
+
+// CredentialRotationClusterState is the state of the rotation of the credentials for a cluster.
+type CredentialRotationClusterState string
+
+const (
+	// CredentialRotationClusterPending means the cluster has not been refreshed yet.
+	CredentialRotationClusterPending CredentialRotationClusterState = "Pending"
+	// CredentialRotationClusterRefreshed means the re-reconciliation of the cluster has been triggered.
+	CredentialRotationClusterRefreshed CredentialRotationClusterState = "Refreshed"
+	// CredentialRotationClusterUpdated means the cluster has picked up the rotated credentials.
+	CredentialRotationClusterUpdated CredentialRotationClusterState = "Updated"
+)
+
+// CredentialRotationStatus reports the progress of the last rotation of the credentials.
+type CredentialRotationStatus struct {
+	// StartTime is the time the rotation has started at.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time all of the clusters have picked up the rotated credentials at.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// SecretName is the name of the Secret the current version of the credentials has been taken from.
+	SecretName string `json:"secretName,omitempty"`
+	// Version is the hash of the current version of the credentials.
+	Version string `json:"version,omitempty"`
+	// PreviousSecretName is the name of the Secret holding the previous version of the credentials.
+	PreviousSecretName string `json:"previousSecretName,omitempty"`
+	// PreviousVersion is the hash of the previous version of the credentials.
+	PreviousVersion string `json:"previousVersion,omitempty"`
+
+	// Clusters reports the state of the rotation for each of the [ClusterDeployment] objects using the [Credential].
+	Clusters []CredentialRotationClusterStatus `json:"clusters,omitempty"`
+
+	// UpdatedClusters is the number of clusters which have picked up the rotated credentials.
+	UpdatedClusters int32 `json:"updatedClusters,omitempty"`
+	// TotalClusters is the number of clusters using the [Credential].
+	TotalClusters int32 `json:"totalClusters,omitempty"`
+}
+
+// CredentialRotationClusterStatus is the state of the rotation of the credentials for a [ClusterDeployment].
+type CredentialRotationClusterStatus struct {
+	// RefreshTime is the time the re-reconciliation of the cluster has been triggered at.
+	RefreshTime *metav1.Time `json:"refreshTime,omitempty"`
+	// Name is the name of the [ClusterDeployment].
+	Name string `json:"name"`
+	// State is the state of the rotation for the cluster.
+	State CredentialRotationClusterState `json:"state"`
+	// Message is the human-readable details of the state.
+	Message string `json:"message,omitempty"`
+}
+
+// CredentialVerification configures the verification of the credentials of a [Credential]
+// by a cheap authenticated call to the cloud provider.
+type CredentialVerification struct {
+	// Interval is the interval between the verifications. Defaults to 1h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// Endpoint overrides the endpoint of the cloud provider the credentials are verified against:
+	// the STS endpoint for AWS, the authority host for Azure, the token URI for GCP and the identity (Keystone)
+	// endpoint for OpenStack. For vSphere the endpoint is required and is the URL of the vCenter server.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Enabled enables the verification.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// GetInterval returns the interval between the verifications, the default one if unset.
+func (v *CredentialVerification) GetInterval() time.Duration {
+	if v == nil || v.Interval == nil || v.Interval.Duration <= 0 {
+		return DefaultCredentialVerificationInterval
+	}
+	return v.Interval.Duration
+}
+
+// IsEnabled returns true if the verification is enabled.
+func (v *CredentialVerification) IsEnabled() bool {
+	return v != nil && v.Enabled
+}
+
+// GetEndpoint returns the overridden endpoint of the cloud provider, empty if unset.
+func (v *CredentialVerification) GetEndpoint() string {
+	if v == nil {
+		return ""
+	}
+	return v.Endpoint
+}
+
+// CredentialVerificationStatus is the result of the last verification of the credentials.
+type CredentialVerificationStatus struct {
+	// LastVerificationTime is the time of the last verification.
+	LastVerificationTime metav1.Time `json:"lastVerificationTime"`
+	// ExpirationTime is the time the credentials expire at, if known.
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+	// Provider is the cloud provider the credentials have been verified against.
+	Provider string `json:"provider,omitempty"`
+	// Identity is the identity the cloud provider has authenticated with the credentials,
+	// e.g. the ARN of the AWS user or the client ID of the Azure service principal.
+	Identity string `json:"identity,omitempty"`
 }
 
 // CredentialStatus defines the observed state of Credential
@@ -62,6 +205,12 @@ type CredentialStatus struct {
 
 	// Ready holds the readiness of [Credential].
 	Ready bool `json:"ready"`
+
+	// Verification is the result of the last verification of the credentials against the cloud provider.
+	Verification *CredentialVerificationStatus `json:"verification,omitempty"`
+
+	// Rotation reports the progress of the last rotation of the credentials.
+	Rotation *CredentialRotationStatus `json:"rotation,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -443,21 +443,21 @@ func extractOwnerReferences(rawObj client.Object) []string {
 // management backup indexers
 
 // ManagementBackupIndexKey indexer field name to extract only [ManagementBackup] objects
-// that either has schedule or has NOT been completed yet.
+// that either has schedule or has NOT been completed or verified yet.
 const ManagementBackupIndexKey = "k0rdent.management-backup"
 
 func setupManagementBackupIndexer(ctx context.Context, mgr ctrl.Manager) error {
 	return mgr.GetFieldIndexer().IndexField(ctx, &ManagementBackup{}, ManagementBackupIndexKey, ExtractScheduledOrIncompleteBackups)
 }
 
-// ExtractScheduledOrIncompleteBackups returns either scheduled or incomplete backups.
+// ExtractScheduledOrIncompleteBackups returns either scheduled or incomplete or not yet verified backups.
 func ExtractScheduledOrIncompleteBackups(o client.Object) []string {
 	mb, ok := o.(*ManagementBackup)
 	if !ok {
 		return nil
 	}
 
-	if mb.Spec.Schedule != "" || !mb.IsCompleted() {
+	if mb.Spec.Schedule != "" || !mb.IsCompleted() || !mb.IsVerified() {
 		return []string{"true"}
 	}
 
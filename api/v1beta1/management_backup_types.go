@@ -15,6 +15,8 @@
 package v1beta1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
@@ -27,9 +29,33 @@ const (
 	GenericComponentNameLabel = "k0rdent.mirantis.com/component"
 	// Component label value for the KCM-related components.
 	GenericComponentLabelValueKCM = "kcm"
+	// ManagementBackupScopeLabelKeyPrefix is a label key prefix applied to all objects
+	// in the dependency closure of a scoped [ManagementBackup].
+	// The full label key format is "k0rdent.mirantis.com/backup-scope-<hash>", where the hash
+	// is derived from the name of the [ManagementBackup] to fit the label name length limit.
+	ManagementBackupScopeLabelKeyPrefix = "k0rdent.mirantis.com/backup-scope"
+	// ManagementBackupScopeFinalizer is the finalizer set on a scoped [ManagementBackup]
+	// to remove the scope label from the objects once the [ManagementBackup] is deleted.
+	ManagementBackupScopeFinalizer = "k0rdent.mirantis.com/management-backup-scope"
+)
+
+const (
+	// ManagementBackupVerifiedCondition indicates whether the contents of the most recent backups
+	// have been verified and contain all of the expected items.
+	ManagementBackupVerifiedCondition = "BackupVerified"
+
+	// ManagementBackupVerificationSucceededReason declares that all of the expected items are present in the backups.
+	ManagementBackupVerificationSucceededReason = "VerificationSucceeded"
+	// ManagementBackupVerificationInProgressReason declares that the backups contents are being fetched.
+	ManagementBackupVerificationInProgressReason = "VerificationInProgress"
+	// ManagementBackupVerificationFailedReason declares that the backups contents could not have been verified.
+	ManagementBackupVerificationFailedReason = "VerificationFailed"
+	// ManagementBackupMissingItemsReason declares that some of the expected items are absent in the backups.
+	ManagementBackupMissingItemsReason = "MissingItems"
 )
 
 // ManagementBackupSpec defines the desired state of [ManagementBackup].
+// +kubebuilder:validation:XValidation:rule="!has(self.retention) || (has(self.schedule) && size(self.schedule) > 0)",message="retention can only be set for a scheduled ManagementBackup"
 type ManagementBackupSpec struct {
 	// StorageLocation is the name of a [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.StorageLocation]
 	// where the backup should be stored.
@@ -41,6 +67,60 @@ type ManagementBackupSpec struct {
 	// should be created and stored in the [ManagementBackup] storage location if not default
 	// before the [Management] release upgrade.
 	PerformOnManagementUpgrade bool `json:"performOnManagementUpgrade,omitempty"`
+	// Retention defines which of the backups produced by the scheduled [ManagementBackup] are kept,
+	// the rest of them are pruned each time new backups are created.
+	// The policy is applied to the management and regional backups separately.
+	// If not set, backups are never pruned by the controller.
+	Retention *ManagementBackupRetention `json:"retention,omitempty"`
+	// Scope limits the backup to the selected [ClusterDeployment] objects
+	// and their dependencies, namely [Credential] objects, ClusterIdentity objects
+	// and their references, [ServiceSet] objects and Secrets and ConfigMaps the services values are taken from.
+	// If not set, everything related to KCM is backed up.
+	// The objects in the scope are labeled, the label is removed once the objects leave the scope,
+	// the scope is unset or the [ManagementBackup] is deleted.
+	Scope *ManagementBackupScope `json:"scope,omitempty"`
+	// Verify enables verification of the completed backups. The list of the backed up items
+	// is checked for the expected KCM objects, namely [Management], [Release], [ClusterDeployment] and [Credential] objects
+	// and the CAPI Clusters along with their infrastructure and control plane objects and kubeconfig Secrets
+	// in the corresponding regions.
+	// The result is reported in the BackupVerified condition.
+	Verify bool `json:"verify,omitempty"`
+}
+
+// ManagementBackupScope selects [ClusterDeployment] objects to back up.
+// A [ClusterDeployment] is selected if it matches any of the set criteria.
+// +kubebuilder:validation:XValidation:rule="has(self.selector) || has(self.namespaces) || has(self.clusterDeployments)",message="at least one of selector, namespaces or clusterDeployments must be set"
+type ManagementBackupScope struct {
+	// Selector selects [ClusterDeployment] objects by labels.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Namespaces selects all of the [ClusterDeployment] objects in the given namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// ClusterDeployments is the list of references to the [ClusterDeployment] objects.
+	ClusterDeployments []ClusterDeploymentRef `json:"clusterDeployments,omitempty"`
+}
+
+// ManagementBackupRetention defines the retention policy of the scheduled [ManagementBackup].
+// A backup is kept if it satisfies at least one of the rules,
+// backups that are still in progress are never pruned
+// and the most recently created backup is always kept.
+// Only completed backups satisfy the rules, failed backups
+// are kept until a newer backup completes.
+// +kubebuilder:validation:XValidation:rule="has(self.ttl) || has(self.keepLast) || has(self.keepDaily) || has(self.keepWeekly) || has(self.keepMonthly)",message="at least one retention rule must be set"
+type ManagementBackupRetention struct {
+	// TTL keeps all of the backups created within the given duration.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// KeepLast keeps the given number of the most recent backups.
+	// +kubebuilder:validation:Minimum=1
+	KeepLast *int32 `json:"keepLast,omitempty"`
+	// KeepDaily keeps the most recent backup for each of the given number of the last days.
+	// +kubebuilder:validation:Minimum=1
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+	// KeepWeekly keeps the most recent backup for each of the given number of the last ISO weeks.
+	// +kubebuilder:validation:Minimum=1
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+	// KeepMonthly keeps the most recent backup for each of the given number of the last months.
+	// +kubebuilder:validation:Minimum=1
+	KeepMonthly *int32 `json:"keepMonthly,omitempty"`
 }
 
 // ManagementBackupStatus defines the observed state of [ManagementBackup].
@@ -49,6 +129,14 @@ type ManagementBackupStatus struct {
 
 	// RegionsLastBackups denotes the status of the last backups in the corresponding regions.
 	RegionsLastBackups []ManagementBackupSingleStatus `json:"regions,omitempty"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+
+	// Conditions contains details for the current state of the [ManagementBackup].
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // ManagementBackupSingleStatus defines the observed state of a single entry of [ManagementBackupStatus].
@@ -67,6 +155,27 @@ type ManagementBackupSingleStatus struct {
 	// Region reflects the name of a region for which
 	// the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup] has been created.
 	Region string `json:"region,omitempty"`
+	// PrunedBackups lists names of the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup] objects
+	// that have been pruned during the last retention policy run.
+	PrunedBackups []string `json:"prunedBackups,omitempty"`
+	// PrunedBackupsCount is the total number of the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup] objects
+	// pruned according to the retention policy.
+	PrunedBackupsCount int `json:"prunedBackupsCount,omitempty"`
+	// VerifiedBackupName is the name of the most recently verified
+	// [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup].
+	VerifiedBackupName string `json:"verifiedBackupName,omitempty"`
+	// MissingItems lists the expected items absent in the most recently verified
+	// [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup].
+	MissingItems []string `json:"missingItems,omitempty"`
+}
+
+// IsVerificationPending checks if the most recent backup has been successfully finished but not yet verified.
+func (s *ManagementBackupSingleStatus) IsVerificationPending() bool {
+	if s.LastBackup == nil || s.LastBackupName == "" || s.VerifiedBackupName == s.LastBackupName {
+		return false
+	}
+
+	return s.LastBackup.Phase == velerov1.BackupPhaseCompleted || s.LastBackup.Phase == velerov1.BackupPhasePartiallyFailed
 }
 
 // IsSchedule checks if an instance of [ManagementBackup] is schedulable.
@@ -85,6 +194,33 @@ func (s *ManagementBackup) IsCompleted() bool {
 	return s.Status.LastBackup != nil && !s.Status.LastBackup.CompletionTimestamp.IsZero()
 }
 
+// IsVerified checks if all of the latest underlaying backups have been verified
+// in case the verification is enabled.
+func (s *ManagementBackup) IsVerified() bool {
+	if !s.Spec.Verify {
+		return true
+	}
+
+	for _, regionBackup := range s.Status.RegionsLastBackups {
+		if regionBackup.IsVerificationPending() {
+			return false
+		}
+	}
+
+	return !s.Status.IsVerificationPending()
+}
+
+// GetConditions returns [ManagementBackup] conditions
+func (s *ManagementBackup) GetConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+// ScopeLabelKey returns the label key applied to the objects in the scope of the [ManagementBackup].
+func (s *ManagementBackup) ScopeLabelKey() string {
+	sum := sha256.Sum256([]byte(s.Name))
+	return ManagementBackupScopeLabelKeyPrefix + "-" + hex.EncodeToString(sum[:8])
+}
+
 // TimestampedBackupName returns the backup name related to scheduled [ManagementBackup]
 // based on the given timestamp and the region name.
 func (s *ManagementBackup) TimestampedBackupName(timestamp time.Time, region string) string {
@@ -104,6 +240,7 @@ func (s *ManagementBackup) TimestampedBackupName(timestamp time.Time, region str
 // +kubebuilder:printcolumn:name="SinceLastBackup",type=date,JSONPath=`.status.lastBackupTime`,description="Time elapsed since last backup run",priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
 // +kubebuilder:printcolumn:name="Error",type=string,JSONPath=`.status.error`,description="Error during creation",priority=1
+// +kubebuilder:printcolumn:name="Verified",type=string,JSONPath=`.status.conditions[?(@.type=="BackupVerified")].status`,description="Whether the last backups have been verified",priority=1
 
 // ManagementBackup is the Schema for the managementbackups API
 type ManagementBackup struct {
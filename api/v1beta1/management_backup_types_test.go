@@ -0,0 +1,41 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestManagementBackupScopeLabelKey(t *testing.T) {
+	t.Parallel()
+
+	newBackup := func(name string) *ManagementBackup {
+		return &ManagementBackup{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	for _, name := range []string{"scoped", strings.Repeat("a", 253)} {
+		key := newBackup(name).ScopeLabelKey()
+		require.Empty(t, validation.IsQualifiedName(key), "label key %q for the name %q is invalid", key, name)
+		require.True(t, strings.HasPrefix(key, ManagementBackupScopeLabelKeyPrefix+"-"))
+		require.Equal(t, key, newBackup(name).ScopeLabelKey(), "label key must be stable")
+	}
+
+	require.NotEqual(t, newBackup("first").ScopeLabelKey(), newBackup("second").ScopeLabelKey())
+}
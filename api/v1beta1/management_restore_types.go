@@ -0,0 +1,177 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ManagementBackupReleaseLabel holds the name of the [Release] the
+	// [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup] object has been created with.
+	ManagementBackupReleaseLabel = "k0rdent.mirantis.com/management-backup-release"
+	// ManagementRestoreNameLabel holds a reference to the [ManagementRestore] object name.
+	ManagementRestoreNameLabel = "k0rdent.mirantis.com/management-restore"
+)
+
+const (
+	// ManagementRestorePreconditionsCondition indicates whether the preconditions of the [ManagementRestore] are met.
+	ManagementRestorePreconditionsCondition = "PreconditionsMet"
+
+	// ManagementRestoreBackupNotFoundReason declares that the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup]
+	// to restore from has not been found (yet).
+	ManagementRestoreBackupNotFoundReason = "BackupNotFound"
+	// ManagementRestoreBackupNotCompletedReason declares that the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup]
+	// to restore from has not been successfully completed.
+	ManagementRestoreBackupNotCompletedReason = "BackupNotCompleted"
+	// ManagementRestoreReleaseMismatchReason declares that the [Release] the backup has been created with
+	// differs from the current [Management] [Release].
+	ManagementRestoreReleaseMismatchReason = "ReleaseMismatch"
+)
+
+// ManagementRestorePhase represents the phase of the [ManagementRestore].
+type ManagementRestorePhase string
+
+const (
+	// ManagementRestorePhasePending means the preconditions of the [ManagementRestore] are being validated.
+	ManagementRestorePhasePending ManagementRestorePhase = "Pending"
+	// ManagementRestorePhaseInProgress means the restores are being performed.
+	ManagementRestorePhaseInProgress ManagementRestorePhase = "InProgress"
+	// ManagementRestorePhaseCompleted means all of the restores have been completed.
+	ManagementRestorePhaseCompleted ManagementRestorePhase = "Completed"
+	// ManagementRestorePhaseFailed means the preconditions are not met or at least one of the restores has failed.
+	ManagementRestorePhaseFailed ManagementRestorePhase = "Failed"
+)
+
+// ManagementRestoreSpec defines the desired state of [ManagementRestore].
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Spec is immutable"
+type ManagementRestoreSpec struct {
+	// ManagementBackup is the name of the [ManagementBackup] to restore from.
+	// The object itself is not required to exist, e.g. in case of a disaster recovery,
+	// as long as the corresponding [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup] objects
+	// are available in the management cluster.
+	// +kubebuilder:validation:MinLength=1
+	ManagementBackup string `json:"managementBackup"`
+	// BackupName is the name of the management [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup]
+	// produced by the [ManagementBackup] to restore from. The regional backups
+	// produced alongside with it are restored in the regions.
+	// If not set, the most recently created backup is used.
+	BackupName string `json:"backupName,omitempty"`
+	// Regions is the list of regions to restore after the management cluster
+	// in the given order. If not set, all of the regions with backups
+	// are restored in the alphabetical order.
+	Regions []string `json:"regions,omitempty"`
+	// SkipReleaseValidation disables the validation that the backups have been
+	// created with the same [Release] the [Management] currently has.
+	SkipReleaseValidation bool `json:"skipReleaseValidation,omitempty"`
+}
+
+// ManagementRestoreStatus defines the observed state of [ManagementRestore].
+type ManagementRestoreStatus struct {
+	ManagementRestoreSingleStatus `json:",inline"`
+
+	// Regions denotes the status of the restores in the corresponding regions.
+	Regions []ManagementRestoreSingleStatus `json:"regions,omitempty"`
+
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+
+	// Conditions contains details for the current state of the [ManagementRestore].
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Phase is the overall phase of the [ManagementRestore].
+	Phase ManagementRestorePhase `json:"phase,omitempty"`
+
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ManagementRestoreSingleStatus defines the observed state of a single entry of [ManagementRestoreStatus].
+type ManagementRestoreSingleStatus struct {
+	// Restore is the status of the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Restore].
+	Restore *velerov1.RestoreStatus `json:"restore,omitempty"`
+	// BackupName is the name of the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Backup] to restore from.
+	BackupName string `json:"backupName,omitempty"`
+	// RestoreName is the name of the created [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Restore].
+	RestoreName string `json:"restoreName,omitempty"`
+	// Error stores messages in case of failed restore.
+	Error string `json:"error,omitempty"`
+	// Region reflects the name of a region for which
+	// the [github.com/vmware-tanzu/velero/pkg/apis/velero/v1.Restore] has been created.
+	Region string `json:"region,omitempty"`
+}
+
+// IsFinished checks if the restore has been finished either successfully or not.
+func (s *ManagementRestoreSingleStatus) IsFinished() bool {
+	if s.Restore == nil {
+		return false
+	}
+
+	switch s.Restore.Phase {
+	case velerov1.RestorePhaseCompleted, velerov1.RestorePhasePartiallyFailed,
+		velerov1.RestorePhaseFailed, velerov1.RestorePhaseFailedValidation:
+		return true
+	}
+
+	return false
+}
+
+// IsFailed checks if the restore has failed.
+func (s *ManagementRestoreSingleStatus) IsFailed() bool {
+	return s.Restore != nil && (s.Restore.Phase == velerov1.RestorePhaseFailed || s.Restore.Phase == velerov1.RestorePhaseFailedValidation)
+}
+
+// GetConditions returns [ManagementRestore] conditions
+func (in *ManagementRestore) GetConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// IsFinished checks if the [ManagementRestore] has reached a terminal phase.
+func (in *ManagementRestore) IsFinished() bool {
+	return in.Status.Phase == ManagementRestorePhaseCompleted || in.Status.Phase == ManagementRestorePhaseFailed
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=kcmrestore;mgmtrestore
+// +kubebuilder:printcolumn:name="Backup",type=string,JSONPath=`.spec.managementBackup`,description="ManagementBackup to restore from",priority=0
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`,description="Phase of the restore",priority=0
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+// +kubebuilder:printcolumn:name="Error",type=string,JSONPath=`.status.error`,description="Error during restore",priority=1
+
+// ManagementRestore is the Schema for the managementrestores API
+type ManagementRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagementRestoreSpec   `json:"spec,omitempty"`
+	Status ManagementRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManagementRestoreList contains a list of ManagementRestore
+type ManagementRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManagementRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ManagementRestore{}, &ManagementRestoreList{})
+}
@@ -59,6 +59,43 @@ const (
 	ReleaseIsNotObserved = "ReleaseIsNotObserved"
 	// HasIncompatibleContractsReason declares that the [Management] object has incompatible CAPI contracts in providers.
 	HasIncompatibleContractsReason = "HasIncompatibleContracts"
+	// ReleaseUpgradePreflightFailedReason declares that the pre-flight checks of the upgrade
+	// to the referenced in the [Management] [Release] object have failed.
+	ReleaseUpgradePreflightFailedReason = "ReleaseUpgradePreflightFailed"
+)
+
+// ReleaseUpgradePhase represents the phase of the upgrade of the [Management] and [Region] objects to a new [Release].
+type ReleaseUpgradePhase string
+
+const (
+	// ReleaseUpgradePhasePreflight means the pre-flight checks of the upgrade are being run.
+	ReleaseUpgradePhasePreflight ReleaseUpgradePhase = "Preflight"
+	// ReleaseUpgradePhasePreflightFailed means the pre-flight checks have failed and nothing is being upgraded,
+	// the checks are retried until they succeed.
+	ReleaseUpgradePhasePreflightFailed ReleaseUpgradePhase = "PreflightFailed"
+	// ReleaseUpgradePhaseUpgradingManagement means the management components are being upgraded.
+	ReleaseUpgradePhaseUpgradingManagement ReleaseUpgradePhase = "UpgradingManagement"
+	// ReleaseUpgradePhaseUpgradingRegions means the regional components are being upgraded one region at a time.
+	ReleaseUpgradePhaseUpgradingRegions ReleaseUpgradePhase = "UpgradingRegions"
+	// ReleaseUpgradePhaseHalted means the upgrade of some of the regions has failed
+	// and no more regions are being upgraded until it recovers.
+	ReleaseUpgradePhaseHalted ReleaseUpgradePhase = "Halted"
+	// ReleaseUpgradePhaseCompleted means the management and all of the regions have been upgraded.
+	ReleaseUpgradePhaseCompleted ReleaseUpgradePhase = "Completed"
+)
+
+// RegionUpgradePhase represents the phase of the upgrade of a single [Region].
+type RegionUpgradePhase string
+
+const (
+	// RegionUpgradePhasePending means the upgrade of the region has not been started yet.
+	RegionUpgradePhasePending RegionUpgradePhase = "Pending"
+	// RegionUpgradePhaseUpgrading means the regional components are being upgraded.
+	RegionUpgradePhaseUpgrading RegionUpgradePhase = "Upgrading"
+	// RegionUpgradePhaseCompleted means the regional components have been upgraded and are ready.
+	RegionUpgradePhaseCompleted RegionUpgradePhase = "Completed"
+	// RegionUpgradePhaseFailed means the upgrade of the regional components has failed.
+	RegionUpgradePhaseFailed RegionUpgradePhase = "Failed"
 )
 
 const (
@@ -148,10 +185,94 @@ type ManagementStatus struct {
 	// ComponentsCommonStatus represents the status of enabled components.
 	ComponentsCommonStatus `json:",inline"`
 
+	// Upgrade is the progress of the latest upgrade to a new Release.
+	Upgrade *ReleaseUpgradeStatus `json:"upgrade,omitempty"`
+
 	// ObservedGeneration is the last observed generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// ReleaseUpgradeStatus is the progress of the upgrade of the [Management] and [Region] objects to a new [Release].
+type ReleaseUpgradeStatus struct {
+	// StartTime is the time the upgrade has been requested.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the management and all of the regions have been upgraded.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// From is the name of the Release being upgraded from.
+	From string `json:"from"`
+	// To is the name of the Release being upgraded to.
+	To string `json:"to"`
+	// Message is the human-readable details of the upgrade, e.g. the failed pre-flight checks.
+	Message string `json:"message,omitempty"`
+	// Phase is the overall phase of the upgrade.
+	Phase ReleaseUpgradePhase `json:"phase"`
+
+	// Impact is the estimated impact of the upgrade on the management and each of the regions.
+	Impact []ReleaseUpgradeImpact `json:"impact,omitempty"`
+	// Regions is the progress of the upgrade of each of the regions, upgraded in the given order.
+	Regions []RegionUpgradeStatus `json:"regions,omitempty"`
+}
+
+// ReleaseUpgradeImpact is the estimated impact of the upgrade on the management or a regional cluster.
+type ReleaseUpgradeImpact struct {
+	// Region is the name of the [Region], empty for the management cluster.
+	Region string `json:"region,omitempty"`
+	// Providers is the list of the CAPI providers being upgraded, hence restarted.
+	Providers []string `json:"providers,omitempty"`
+	// ClusterDeployments is the list of the namespaced names of the [ClusterDeployment] objects
+	// whose clusters are managed by the restarted providers.
+	ClusterDeployments []string `json:"clusterDeployments,omitempty"`
+}
+
+// RegionUpgradeStatus is the progress of the upgrade of a single [Region].
+type RegionUpgradeStatus struct {
+	// LastTransitionTime is the time the phase of the upgrade has changed.
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Name is the name of the [Region].
+	Name string `json:"name"`
+	// Message is the human-readable details of the upgrade.
+	Message string `json:"message,omitempty"`
+	// Phase is the phase of the upgrade of the region.
+	Phase RegionUpgradePhase `json:"phase"`
+}
+
+// RegionRelease returns the name of the [Release] the regional components of the given [Region]
+// are to be deployed with. During the upgrade to a new Release, the regions are kept on their
+// current Release until the management is upgraded and then they are upgraded one at a time.
+func (in *Management) RegionRelease(region *Region) string {
+	current := region.Status.Release
+	if current == "" {
+		current = in.Status.Release
+	}
+	if current == "" {
+		return in.Spec.Release
+	}
+
+	upgrade := in.Status.Upgrade
+	if upgrade == nil || upgrade.To != in.Spec.Release {
+		if in.Status.Release != in.Spec.Release {
+			// the upgrade has not been started yet
+			return current
+		}
+		return in.Spec.Release
+	}
+
+	switch upgrade.Phase {
+	case ReleaseUpgradePhasePreflight, ReleaseUpgradePhasePreflightFailed, ReleaseUpgradePhaseUpgradingManagement:
+		return current
+	case ReleaseUpgradePhaseUpgradingRegions, ReleaseUpgradePhaseHalted:
+		for _, rgn := range upgrade.Regions {
+			if rgn.Name == region.Name && rgn.Phase == RegionUpgradePhasePending {
+				return current
+			}
+		}
+	}
+
+	return upgrade.To
+}
+
 // ComponentStatus is the status of Management component installation
 type ComponentStatus struct {
 	// Template is the name of the Template associated with this component.
@@ -199,6 +320,7 @@ func (in *Management) GetComponentsStatus() *ComponentsCommonStatus {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Overall readiness of the Management resource"
 // +kubebuilder:printcolumn:name="Release",type="string",JSONPath=".status.release",description="Current release version"
+// +kubebuilder:printcolumn:name="Upgrade",type="string",JSONPath=".status.upgrade.phase",description="Phase of the latest Release upgrade",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of Management"
 
 // Management is the Schema for the managements API
@@ -0,0 +1,131 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegionRelease(t *testing.T) {
+	t.Parallel()
+
+	upgrade := func(phase ReleaseUpgradePhase, regions ...RegionUpgradeStatus) *ReleaseUpgradeStatus {
+		return &ReleaseUpgradeStatus{From: "old", To: "new", Phase: phase, Regions: regions}
+	}
+
+	tests := map[string]struct {
+		specRelease   string
+		statusRelease string
+		upgrade       *ReleaseUpgradeStatus
+		regionRelease string
+		expected      string
+	}{
+		"fresh installation": {
+			specRelease: "new",
+			expected:    "new",
+		},
+		"no upgrade": {
+			specRelease:   "new",
+			statusRelease: "new",
+			regionRelease: "new",
+			expected:      "new",
+		},
+		"upgrade not started yet": {
+			specRelease:   "new",
+			statusRelease: "old",
+			regionRelease: "old",
+			expected:      "old",
+		},
+		"new region during upgrade not started yet": {
+			specRelease:   "new",
+			statusRelease: "old",
+			expected:      "old",
+		},
+		"preflight failed": {
+			specRelease:   "new",
+			statusRelease: "old",
+			upgrade:       upgrade(ReleaseUpgradePhasePreflightFailed),
+			regionRelease: "old",
+			expected:      "old",
+		},
+		"upgrading management": {
+			specRelease:   "new",
+			statusRelease: "new",
+			upgrade:       upgrade(ReleaseUpgradePhaseUpgradingManagement),
+			regionRelease: "old",
+			expected:      "old",
+		},
+		"region pending": {
+			specRelease:   "new",
+			statusRelease: "new",
+			upgrade:       upgrade(ReleaseUpgradePhaseUpgradingRegions, RegionUpgradeStatus{Name: "rgn", Phase: RegionUpgradePhasePending}),
+			regionRelease: "old",
+			expected:      "old",
+		},
+		"region upgrading": {
+			specRelease:   "new",
+			statusRelease: "new",
+			upgrade:       upgrade(ReleaseUpgradePhaseUpgradingRegions, RegionUpgradeStatus{Name: "rgn", Phase: RegionUpgradePhaseUpgrading}),
+			regionRelease: "old",
+			expected:      "new",
+		},
+		"region pending while halted": {
+			specRelease:   "new",
+			statusRelease: "new",
+			upgrade: upgrade(ReleaseUpgradePhaseHalted,
+				RegionUpgradeStatus{Name: "failed", Phase: RegionUpgradePhaseFailed},
+				RegionUpgradeStatus{Name: "rgn", Phase: RegionUpgradePhasePending}),
+			regionRelease: "old",
+			expected:      "old",
+		},
+		"region created during upgrade": {
+			specRelease:   "new",
+			statusRelease: "new",
+			upgrade:       upgrade(ReleaseUpgradePhaseUpgradingRegions, RegionUpgradeStatus{Name: "other", Phase: RegionUpgradePhasePending}),
+			expected:      "new",
+		},
+		"upgrade completed": {
+			specRelease:   "new",
+			statusRelease: "new",
+			upgrade:       upgrade(ReleaseUpgradePhaseCompleted, RegionUpgradeStatus{Name: "rgn", Phase: RegionUpgradePhaseCompleted}),
+			regionRelease: "new",
+			expected:      "new",
+		},
+		"outdated upgrade": {
+			specRelease:   "newer",
+			statusRelease: "new",
+			upgrade:       upgrade(ReleaseUpgradePhaseCompleted),
+			regionRelease: "new",
+			expected:      "new",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mgmt := &Management{
+				Spec:   ManagementSpec{Release: tc.specRelease},
+				Status: ManagementStatus{Release: tc.statusRelease, Upgrade: tc.upgrade},
+			}
+			region := &Region{ObjectMeta: metav1.ObjectMeta{Name: "rgn"}, Status: RegionStatus{Release: tc.regionRelease}}
+
+			require.Equal(t, tc.expected, mgmt.RegionRelease(region))
+		})
+	}
+}
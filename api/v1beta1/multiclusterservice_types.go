@@ -19,6 +19,7 @@ import (
 	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -59,6 +60,9 @@ const (
 
 	// MultiClusterServiceDependencyValidationCondition defines the condition of MultiClusterService dependencies.
 	MultiClusterServiceDependencyValidationCondition = "MultiClusterServiceDependencyValidation"
+
+	// ServicesRolloutCondition shows the state of the staged rollout of the services across the matching clusters.
+	ServicesRolloutCondition = "ServicesRollout"
 )
 
 // Reasons are provided as utility, and not part of the declarative API.
@@ -79,6 +83,27 @@ const (
 	SveltosFeatureReadyReason = "SveltosFeatureReady"
 	// SveltosFeatureNotReadyReason signals that the feature managed by Sveltos on target cluster is not yet ready.
 	SveltosFeatureNotReadyReason = "SveltosFeatureNotReady"
+	// ServicesRolloutProgressingReason signals that the services are being rolled out to the clusters of the current wave.
+	ServicesRolloutProgressingReason = "RolloutProgressing"
+	// ServicesRolloutPausedReason signals that the current wave has been rolled out and the next one awaits the pause to elapse.
+	ServicesRolloutPausedReason = "RolloutPaused"
+	// ServicesRolloutHaltedReason signals that the rollout has been halted due to failed services in the current wave.
+	ServicesRolloutHaltedReason = "RolloutHalted"
+)
+
+// MultiClusterServiceRolloutPhase represents the phase of the staged rollout of a [MultiClusterService].
+type MultiClusterServiceRolloutPhase string
+
+const (
+	// MultiClusterServiceRolloutPhaseProgressing means the services are being rolled out to the clusters of the current wave.
+	MultiClusterServiceRolloutPhaseProgressing MultiClusterServiceRolloutPhase = "Progressing"
+	// MultiClusterServiceRolloutPhasePaused means the current wave has been rolled out and the next one awaits the pause to elapse.
+	MultiClusterServiceRolloutPhasePaused MultiClusterServiceRolloutPhase = "Paused"
+	// MultiClusterServiceRolloutPhaseHalted means some of the services have failed in the current wave
+	// and the rollout will not proceed until the [MultiClusterService] spec is changed.
+	MultiClusterServiceRolloutPhaseHalted MultiClusterServiceRolloutPhase = "Halted"
+	// MultiClusterServiceRolloutPhaseCompleted means the services have been rolled out to all of the matching clusters.
+	MultiClusterServiceRolloutPhaseCompleted MultiClusterServiceRolloutPhase = "Completed"
 )
 
 // Service represents a Service to be deployed.
@@ -128,6 +153,17 @@ type Service struct {
 
 	// Disable can be set to disable handling of this service.
 	Disable bool `json:"disable,omitempty"`
+
+	// RollbackPolicy defines whether and when the service is rolled back to the last
+	// successfully deployed version and values on failure. Overrides the policy defined in the [ServiceSpec].
+	RollbackPolicy *ServiceRollbackPolicy `json:"rollbackPolicy,omitempty"`
+}
+
+// ServiceRollbackPolicy defines the automatic rollback of a service which failed to deploy.
+type ServiceRollbackPolicy struct {
+	// FailureWindow is the duration the service is allowed to stay failed before
+	// it is rolled back to the last successfully deployed version and values.
+	FailureWindow metav1.Duration `json:"failureWindow"`
 }
 
 // ServiceDependsOn identifies a service by its release name and namespace.
@@ -311,6 +347,10 @@ type ServiceSpec struct {
 	//
 	// Deprecated: use .provider.config field to define provider-specific configuration.
 	ContinueOnError bool `json:"continueOnError,omitempty"`
+
+	// RollbackPolicy defines the default rollback policy for all of the services,
+	// unless overridden in a particular service.
+	RollbackPolicy *ServiceRollbackPolicy `json:"rollbackPolicy,omitempty"`
 }
 
 // MultiClusterServiceSpec defines the desired state of MultiClusterService
@@ -333,6 +373,35 @@ type MultiClusterServiceSpec struct {
 	// such clusters keep running, enabling per-cluster opt-in rollouts driven
 	// by selector changes.
 	KeepServicesOnSelectorMismatch bool `json:"keepServicesOnSelectorMismatch,omitempty"`
+
+	// RolloutStrategy defines how changes of the services are rolled out across the matching clusters.
+	// If not set, ServiceSets for all of the matching clusters are created or updated at once.
+	RolloutStrategy *MultiClusterServiceRolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// MaintenanceWindow restricts service version changes to the given window.
+	// If not set, the maintenance window of each of the matching clusters is respected.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MultiClusterServiceRolloutStrategy defines the staged rollout of the services.
+// The matching clusters are split into waves, each next wave is rolled out only after all of the services
+// in the previous waves are ready. The rollout is halted if any of the services fails.
+type MultiClusterServiceRolloutStrategy struct {
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:XValidation:rule="type(self) == int ? self >= 1 : self.matches('^[1-9][0-9]?%$|^100%$')",message="batchSize must be either a positive number or a percentage in range 1%-100%"
+
+	// BatchSize is the number of the clusters in a single wave, either an absolute number
+	// or a percentage of the matching clusters (rounded up).
+	BatchSize intstr.IntOrString `json:"batchSize"`
+
+	// OrderByLabel is the key of the label on the [ClusterDeployment] objects
+	// which values define the order of the clusters across the waves.
+	// Clusters without the label go last. Ties are resolved by the namespace and name.
+	// The management cluster, if self-management is enabled, always goes first.
+	OrderByLabel string `json:"orderByLabel,omitempty"`
+
+	// PauseBetweenWaves is the duration to wait after a wave has been rolled out before starting the next one.
+	PauseBetweenWaves *metav1.Duration `json:"pauseBetweenWaves,omitempty"`
 }
 
 // ServiceStatus contains details for the state of services.
@@ -366,10 +435,32 @@ type MultiClusterServiceStatus struct {
 
 	// Conditions contains details for the current state of the MultiClusterService.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Rollout contains details for the state of the staged rollout.
+	Rollout *MultiClusterServiceRolloutStatus `json:"rollout,omitempty"`
 	// ObservedGeneration is the last observed generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// MultiClusterServiceRolloutStatus defines the observed state of the staged rollout.
+type MultiClusterServiceRolloutStatus struct {
+	// WaveCompletionTime is the time the current wave has been rolled out.
+	WaveCompletionTime *metav1.Time `json:"waveCompletionTime,omitempty"`
+	// Phase is the phase of the rollout.
+	Phase MultiClusterServiceRolloutPhase `json:"phase,omitempty"`
+	// Message contains details on the rollout, e.g. the clusters with failed services.
+	Message string `json:"message,omitempty"`
+	// Generation is the [MultiClusterService] generation being rolled out.
+	Generation int64 `json:"generation,omitempty"`
+	// CurrentWave is the 1-based number of the wave being rolled out.
+	CurrentWave int32 `json:"currentWave,omitempty"`
+	// TotalWaves is the total number of waves.
+	TotalWaves int32 `json:"totalWaves,omitempty"`
+	// UpdatedClusters is the number of clusters with the ready services of the generation being rolled out.
+	UpdatedClusters int32 `json:"updatedClusters,omitempty"`
+	// TotalClusters is the total number of the clusters to roll out the services to.
+	TotalClusters int32 `json:"totalClusters,omitempty"`
+}
+
 type MatchingCluster struct {
 	*corev1.ObjectReference `json:",inline"`
 
@@ -416,6 +507,7 @@ type UpgradePath struct {
 // +kubebuilder:printcolumn:name="provider",type=string,JSONPath=`.spec.serviceSpec.provider.name`,description="StateManagementProvider name",priority=0
 // +kubebuilder:printcolumn:name="self-management",type=boolean,JSONPath=`.spec.serviceSpec.provider.selfManagement`,description="Is the MultiClusterService for self-management",priority=0
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+// +kubebuilder:printcolumn:name="Rollout",type=string,JSONPath=`.status.rollout.phase`,description="Phase of the staged rollout",priority=1
 
 // MultiClusterService is the Schema for the multiclusterservices API
 type MultiClusterService struct { //nolint:govet // false-positive
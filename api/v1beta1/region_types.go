@@ -15,6 +15,8 @@
 package v1beta1
 
 import (
+	"time"
+
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,9 +31,24 @@ const (
 	RegionPauseAnnotation = "k0rdent.mirantis.com/region-pause"
 )
 
+// DefaultRegionAutoPauseUnreachableFor is the duration the regional cluster must be unreachable
+// for before the [Region] is automatically paused when [RegionAutoPause.UnreachableFor] is unset.
+const DefaultRegionAutoPauseUnreachableFor = 5 * time.Minute
+
+const (
+	// RegionReachableCondition indicates whether the API server of the regional cluster is reachable.
+	RegionReachableCondition = "RegionReachable"
+)
+
 const (
 	// RegionConfigurationErrorReason declares that the [Region] object has configuration issues.
 	RegionConfigurationErrorReason = "ConfigurationError"
+	// RegionReachableReason declares that the last probe of the regional cluster succeeded.
+	RegionReachableReason = "Reachable"
+	// RegionUnreachableReason declares that the last probe of the regional cluster failed.
+	RegionUnreachableReason = "Unreachable"
+	// RegionProbePendingReason declares that the regional cluster has not been probed yet.
+	RegionProbePendingReason = "ProbePending"
 )
 
 // +kubebuilder:validation:XValidation:rule="has(self.kubeConfig) != has(self.clusterDeployment)",message="exactly one of kubeConfig or clusterDeployment must be set"
@@ -51,10 +68,33 @@ type RegionSpec struct {
 	// to be onboarded as a regional cluster.
 	ClusterDeployment *ClusterDeploymentRef `json:"clusterDeployment,omitempty"`
 
+	// AutoPause enables the automatic pause of the Region once the regional cluster
+	// becomes unreachable. While automatically paused, the dependent ClusterDeployments
+	// are not reconciled as if the Region had the k0rdent.mirantis.com/region-pause annotation.
+	// The Region is unpaused once the regional cluster is reachable again.
+	AutoPause *RegionAutoPause `json:"autoPause,omitempty"`
+
 	// ComponentsCommonSpec defines the desired state of regional components.
 	ComponentsCommonSpec `json:",inline"`
 }
 
+// RegionAutoPause defines the policy of the automatic pause of a [Region]
+// on the loss of connectivity to its regional cluster.
+type RegionAutoPause struct {
+	// UnreachableFor is the duration the regional cluster must be continuously
+	// unreachable for before the Region is paused. Defaults to 5m.
+	UnreachableFor *metav1.Duration `json:"unreachableFor,omitempty"`
+}
+
+// GetUnreachableFor returns the duration the regional cluster must be unreachable for
+// before the [Region] is paused, or [DefaultRegionAutoPauseUnreachableFor] if unset.
+func (in *RegionAutoPause) GetUnreachableFor() time.Duration {
+	if in == nil || in.UnreachableFor == nil {
+		return DefaultRegionAutoPauseUnreachableFor
+	}
+	return in.UnreachableFor.Duration
+}
+
 // ClusterDeploymentRef is the reference to the existing ClusterDeployment object.
 type ClusterDeploymentRef struct {
 	Namespace string `json:"namespace"`
@@ -84,6 +124,9 @@ type RegionStatus struct {
 	// Conditions represents the observations of a Region's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Release indicates the current Release object the regional components are deployed with.
+	Release string `json:"release,omitempty"`
+
 	// ObservedGeneration is the last observed generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
@@ -109,6 +152,17 @@ func (in *Region) GetConditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
 
+// IsAutoPaused reports whether the Region has been automatically paused
+// due to the loss of connectivity to the regional cluster.
+func (in *Region) IsAutoPaused() bool {
+	for _, c := range in.Status.Conditions {
+		if c.Type == PausedCondition {
+			return c.Status == metav1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // Components returns core components and a list of providers defined in the Region object
 func (in *Region) Components() ComponentsCommonSpec {
 	return in.Spec.ComponentsCommonSpec
@@ -139,6 +193,7 @@ func (in *Region) GetComponentsStatus() *ComponentsCommonStatus {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=rgn,scope=Cluster
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Overall readiness of the Region resource"
+// +kubebuilder:printcolumn:name="Release",type="string",JSONPath=".status.release",description="Current release version"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of Region"
 
 // Region is the Schema for the regions API
@@ -106,6 +106,10 @@ const (
 
 	// ServiceSetIsBeingDeletedEvent indicates the event for services set being deleted.
 	ServiceSetIsBeingDeletedEvent = "ServiceSetIsBeingDeleted"
+	// ServiceSetServiceRolledBackEvent indicates the event for a failed service being rolled back.
+	ServiceSetServiceRolledBackEvent = "ServiceSetServiceRolledBack"
+	// ServiceSetRollbackServiceEventAction is the action of the event for a failed service being rolled back.
+	ServiceSetRollbackServiceEventAction = "RollbackService"
 
 	ServiceSetPausedAnnotation = "k0rdent.mirantis.com/service-set-paused"
 )
@@ -131,6 +135,10 @@ type ServiceSetSpec struct {
 
 	// Services is the list of services to deploy.
 	Services []ServiceWithValues `json:"services,omitempty"`
+
+	// CredentialVersion is the version of the credentials propagated to the cluster, if any.
+	// Changes once the credentials are rotated so the ServiceSet is observed again.
+	CredentialVersion string `json:"credentialVersion,omitempty"`
 }
 
 // StateManagementProviderConfig contains all the spec related to the state management provider.
@@ -178,6 +186,10 @@ type ServiceWithValues struct {
 
 	// ValuesFrom is the list of sources of the values to pass to the ServiceTemplate.
 	ValuesFrom []ValuesFrom `json:"valuesFrom,omitempty"`
+
+	// RollbackPolicy defines whether and when the service is rolled back
+	// to the last successfully deployed revision on failure.
+	RollbackPolicy *ServiceRollbackPolicy `json:"rollbackPolicy,omitempty"`
 }
 
 // ValuesFrom is the source of the values to pass to the ServiceTemplate. The source
@@ -257,6 +269,18 @@ type ServiceState struct {
 	// FailureMessage is the reason why the Service failed to deploy
 	FailureMessage string `json:"failureMessage,omitempty"`
 
+	// DeploymentHash is the hash of the deployment configuration reported by the provider along with the State, if any.
+	DeploymentHash []byte `json:"deploymentHash,omitempty"`
+
+	// LastDeployed is the last revision of the Service which has been successfully deployed.
+	LastDeployed *ServiceRevision `json:"lastDeployed,omitempty"`
+
+	// LastDeployedHash is the DeploymentHash the LastDeployed revision has been observed with.
+	LastDeployedHash []byte `json:"lastDeployedHash,omitempty"`
+
+	// Rollback contains details of the rollback of the Service, if it has been rolled back.
+	Rollback *ServiceRollbackStatus `json:"rollback,omitempty"`
+
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -266,6 +290,37 @@ type ServiceState struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ServiceRevision is the revision of a Service, i.e. the template and the values it is deployed with.
+type ServiceRevision struct {
+	// Version is the version of the Service.
+	Version string `json:"version,omitempty"`
+
+	// Template is the name of the ServiceTemplate used to deploy the Service.
+	Template string `json:"template"`
+
+	// Values is the values passed to the ServiceTemplate.
+	Values string `json:"values,omitempty"`
+
+	// ValuesFrom is the list of sources of the values passed to the ServiceTemplate.
+	ValuesFrom []ValuesFrom `json:"valuesFrom,omitempty"`
+}
+
+// ServiceRollbackStatus describes the rollback of a Service which failed to deploy.
+type ServiceRollbackStatus struct {
+	// Time is the time the Service has been rolled back.
+	Time metav1.Time `json:"time"`
+
+	// FailedRevision is the revision which failed to deploy. The Service
+	// won't be deployed with this revision again until the desired revision changes.
+	FailedRevision ServiceRevision `json:"failedRevision"`
+
+	// RevertedTo is the revision the Service has been rolled back to.
+	RevertedTo ServiceRevision `json:"revertedTo"`
+
+	// Message is the failure message of the failed revision.
+	Message string `json:"message,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="cluster",type=string,JSONPath=`.spec.cluster`,description="Corresponding ClusterDeployment name",priority=0
@@ -21,8 +21,10 @@ import (
 	"strings"
 
 	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
@@ -30,6 +32,26 @@ const (
 	chartAnnoCAPIPrefix = "cluster.x-k8s.io/"
 
 	DefaultRepoName = "kcm-templates"
+
+	// ChartVerificationProviderCosign verifies the cosign signatures of the charts from OCI repositories.
+	ChartVerificationProviderCosign = "cosign"
+	// ChartVerificationProviderHelmProvenance verifies the Helm provenance (.prov) files
+	// of the charts from HTTP repositories.
+	ChartVerificationProviderHelmProvenance = "helm-provenance"
+)
+
+const (
+	// TemplateDeprecatedAnnotation is the annotation on a [ClusterTemplate] or [ServiceTemplate]
+	// marking it as deprecated. The value is an optional human-readable deprecation message.
+	// Deprecated templates keep working for the existing objects but cannot be used by the new ones.
+	TemplateDeprecatedAnnotation = "k0rdent.mirantis.com/deprecated"
+	// TemplateEndOfLifeAnnotation is the annotation on a [ClusterTemplate] or [ServiceTemplate]
+	// defining the date (YYYY-MM-DD) or the RFC 3339 time since which the template is no longer supported.
+	// The annotation implies the deprecation of the template.
+	TemplateEndOfLifeAnnotation = "k0rdent.mirantis.com/end-of-life"
+	// TemplateReplacementAnnotation is the annotation on a deprecated [ClusterTemplate] or [ServiceTemplate]
+	// naming the template to migrate to.
+	TemplateReplacementAnnotation = "k0rdent.mirantis.com/replacement"
 )
 
 var DefaultSourceRef = sourcev1.LocalHelmChartSourceReference{
@@ -55,6 +77,50 @@ type HelmSpec struct {
 
 	// ChartSource is a source of a Helm chart representing the template.
 	ChartSource *SourceSpec `json:"chartSource,omitempty"`
+
+	// Verify defines how the authenticity of the Helm chart must be verified
+	// before the template becomes valid. Applies in addition to the system-wide
+	// verification policy configured in the controller, which cannot be overridden.
+	// A cosign policy must match the system-wide cosign policy if both are set
+	// since the chart is verified by the source-controller against a single policy.
+	Verify *ChartVerification `json:"verify,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) || has(self.matchOIDCIdentity)",message="either secretRef or matchOIDCIdentity must be set"
+// +kubebuilder:validation:XValidation:rule="self.provider == 'cosign' || !has(self.matchOIDCIdentity)",message="matchOIDCIdentity is supported only by the cosign provider"
+
+// ChartVerification defines how the authenticity of a Helm chart is verified.
+type ChartVerification struct {
+	// SecretRef specifies the Secret containing the trusted public keys,
+	// either cosign public keys or PGP keyrings (armored or binary) for the helm-provenance provider.
+	// The Secret must be in the namespace of the template or in the system namespace for the [ProviderTemplate].
+	SecretRef *fluxmeta.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Provider specifies the technology used to sign the chart.
+	// The cosign provider is supported only for the charts from OCI repositories,
+	// the helm-provenance provider is supported only for the charts from HTTP repositories.
+	//
+	// +kubebuilder:default:=cosign
+	// +kubebuilder:validation:Enum=cosign;helm-provenance
+	Provider string `json:"provider"`
+
+	// MatchOIDCIdentity specifies the identity matching criteria to use
+	// while verifying a chart which was signed using cosign keyless signing.
+	// The chart is deemed to be verified if any of the matchers match against the identity.
+	MatchOIDCIdentity []sourcev1.OIDCIdentityMatch `json:"matchOIDCIdentity,omitempty"`
+}
+
+// ChartVerificationStatus defines the result of the chart verification.
+type ChartVerificationStatus struct {
+	// VerificationTime is the time the chart has been verified.
+	VerificationTime metav1.Time `json:"verificationTime"`
+	// Provider is the technology the chart has been verified with,
+	// comma-separated if the policies of several providers apply.
+	Provider string `json:"provider"`
+	// Signer identifies who signed the verified chart, semicolon-separated if several signers are reported.
+	Signer string `json:"signer,omitempty"`
+	// Digest is the digest of the verified chart artifact.
+	Digest string `json:"digest,omitempty"`
 }
 
 func (s *HelmSpec) String() string {
@@ -90,6 +156,9 @@ type TemplateStatusCommon struct {
 	// The ConfigMap's namespace is either in the system namespace for [ProviderTemplate]
 	// or is inherited from either a [ClusterTemplate] or a [ServiceTemplate].
 	SchemaConfigMapName string `json:"schemaConfigMapName,omitempty"`
+	// ChartVerification holds the result of the chart verification
+	// if a verification policy applies to the template.
+	ChartVerification *ChartVerificationStatus `json:"chartVerification,omitempty"`
 
 	TemplateValidationStatus `json:",inline"`
 
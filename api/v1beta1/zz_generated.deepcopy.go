@@ -239,6 +239,47 @@ func (in *AvailableUpgrade) DeepCopy() *AvailableUpgrade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartVerification) DeepCopyInto(out *ChartVerification) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(meta.LocalObjectReference)
+		**out = **in
+	}
+	if in.MatchOIDCIdentity != nil {
+		in, out := &in.MatchOIDCIdentity, &out.MatchOIDCIdentity
+		*out = make([]apiv1.OIDCIdentityMatch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartVerification.
+func (in *ChartVerification) DeepCopy() *ChartVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartVerificationStatus) DeepCopyInto(out *ChartVerificationStatus) {
+	*out = *in
+	in.VerificationTime.DeepCopyInto(&out.VerificationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartVerificationStatus.
+func (in *ChartVerificationStatus) DeepCopy() *ChartVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterAuditPolicy) DeepCopyInto(out *ClusterAuditPolicy) {
 	*out = *in
@@ -544,6 +585,135 @@ func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentMigration) DeepCopyInto(out *ClusterDeploymentMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentMigration.
+func (in *ClusterDeploymentMigration) DeepCopy() *ClusterDeploymentMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentMigrationList) DeepCopyInto(out *ClusterDeploymentMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeploymentMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentMigrationList.
+func (in *ClusterDeploymentMigrationList) DeepCopy() *ClusterDeploymentMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentMigrationObject) DeepCopyInto(out *ClusterDeploymentMigrationObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentMigrationObject.
+func (in *ClusterDeploymentMigrationObject) DeepCopy() *ClusterDeploymentMigrationObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentMigrationObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentMigrationSpec) DeepCopyInto(out *ClusterDeploymentMigrationSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentMigrationSpec.
+func (in *ClusterDeploymentMigrationSpec) DeepCopy() *ClusterDeploymentMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentMigrationStatus) DeepCopyInto(out *ClusterDeploymentMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ClusterDeploymentMigrationObject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentMigrationStatus.
+func (in *ClusterDeploymentMigrationStatus) DeepCopy() *ClusterDeploymentMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterDeploymentRef) DeepCopyInto(out *ClusterDeploymentRef) {
 	*out = *in
@@ -574,6 +744,16 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 	}
 	in.IPAMClaim.DeepCopyInto(&out.IPAMClaim)
 	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(ClusterPlacement)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
@@ -615,6 +795,18 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpgradeBreakingChanges != nil {
+		in, out := &in.UpgradeBreakingChanges, &out.UpgradeBreakingChanges
+		*out = make([]UpgradeBreakingChanges, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRunPreview != nil {
+		in, out := &in.DryRunPreview, &out.DryRunPreview
+		*out = new(DryRunPreview)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
@@ -627,6 +819,110 @@ func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFleetUpgrade) DeepCopyInto(out *ClusterFleetUpgrade) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFleetUpgrade.
+func (in *ClusterFleetUpgrade) DeepCopy() *ClusterFleetUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFleetUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterFleetUpgrade) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFleetUpgradeList) DeepCopyInto(out *ClusterFleetUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterFleetUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFleetUpgradeList.
+func (in *ClusterFleetUpgradeList) DeepCopy() *ClusterFleetUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFleetUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterFleetUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFleetUpgradeSpec) DeepCopyInto(out *ClusterFleetUpgradeSpec) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFleetUpgradeSpec.
+func (in *ClusterFleetUpgradeSpec) DeepCopy() *ClusterFleetUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFleetUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFleetUpgradeStatus) DeepCopyInto(out *ClusterFleetUpgradeStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterUpgradeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFleetUpgradeStatus.
+func (in *ClusterFleetUpgradeStatus) DeepCopy() *ClusterFleetUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFleetUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterIPAM) DeepCopyInto(out *ClusterIPAM) {
 	*out = *in
@@ -899,6 +1195,26 @@ func (in *ClusterIdentityReference) DeepCopy() *ClusterIdentityReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlacement) DeepCopyInto(out *ClusterPlacement) {
+	*out = *in
+	if in.RegionSelector != nil {
+		in, out := &in.RegionSelector, &out.RegionSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPlacement.
+func (in *ClusterPlacement) DeepCopy() *ClusterPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
 	*out = *in
@@ -1033,6 +1349,13 @@ func (in *ClusterTemplateSpec) DeepCopyInto(out *ClusterTemplateSpec) {
 		*out = make(Providers, len(*in))
 		copy(*out, *in)
 	}
+	if in.ValuesMigrations != nil {
+		in, out := &in.ValuesMigrations, &out.ValuesMigrations
+		*out = make([]ValuesMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateSpec.
@@ -1073,6 +1396,34 @@ func (in *ClusterTemplateStatus) DeepCopy() *ClusterTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeStatus) DeepCopyInto(out *ClusterUpgradeStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RemainingTemplates != nil {
+		in, out := &in.RemainingTemplates, &out.RemainingTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStatus.
+func (in *ClusterUpgradeStatus) DeepCopy() *ClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in CompatibilityContracts) DeepCopyInto(out *CompatibilityContracts) {
 	{
@@ -1298,13 +1649,87 @@ func (in *CredentialList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CredentialSpec) DeepCopyInto(out *CredentialSpec) {
+func (in *CredentialRotation) DeepCopyInto(out *CredentialRotation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotation.
+func (in *CredentialRotation) DeepCopy() *CredentialRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationClusterStatus) DeepCopyInto(out *CredentialRotationClusterStatus) {
+	*out = *in
+	if in.RefreshTime != nil {
+		in, out := &in.RefreshTime, &out.RefreshTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationClusterStatus.
+func (in *CredentialRotationClusterStatus) DeepCopy() *CredentialRotationClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationStatus) DeepCopyInto(out *CredentialRotationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]CredentialRotationClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationStatus.
+func (in *CredentialRotationStatus) DeepCopy() *CredentialRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialSpec) DeepCopyInto(out *CredentialSpec) {
 	*out = *in
 	if in.IdentityRef != nil {
 		in, out := &in.IdentityRef, &out.IdentityRef
 		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(CredentialVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(CredentialRotation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialSpec.
@@ -1327,6 +1752,16 @@ func (in *CredentialStatus) DeepCopyInto(out *CredentialStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(CredentialVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(CredentialRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStatus.
@@ -1339,6 +1774,46 @@ func (in *CredentialStatus) DeepCopy() *CredentialStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialVerification) DeepCopyInto(out *CredentialVerification) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialVerification.
+func (in *CredentialVerification) DeepCopy() *CredentialVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialVerificationStatus) DeepCopyInto(out *CredentialVerificationStatus) {
+	*out = *in
+	in.LastVerificationTime.DeepCopyInto(&out.LastVerificationTime)
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialVerificationStatus.
+func (in *CredentialVerificationStatus) DeepCopy() *CredentialVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataSource) DeepCopyInto(out *DataSource) {
 	*out = *in
@@ -1440,6 +1915,26 @@ func (in *DataSourceSpec) DeepCopy() *DataSourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunPreview) DeepCopyInto(out *DryRunPreview) {
+	*out = *in
+	if in.TruncatedKeys != nil {
+		in, out := &in.TruncatedKeys, &out.TruncatedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunPreview.
+func (in *DryRunPreview) DeepCopy() *DryRunPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmbeddedBucketSpec) DeepCopyInto(out *EmbeddedBucketSpec) {
 	*out = *in
@@ -1521,6 +2016,11 @@ func (in *HelmSpec) DeepCopyInto(out *HelmSpec) {
 		*out = new(SourceSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(ChartVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmSpec.
@@ -1563,6 +2063,22 @@ func (in *LocalSourceRef) DeepCopy() *LocalSourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Management) DeepCopyInto(out *Management) {
 	*out = *in
@@ -1595,7 +2111,7 @@ func (in *ManagementBackup) DeepCopyInto(out *ManagementBackup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1636,13 +2152,267 @@ func (in *ManagementBackupList) DeepCopy() *ManagementBackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementBackupList)
+	out := new(ManagementBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupRetention) DeepCopyInto(out *ManagementBackupRetention) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepDaily != nil {
+		in, out := &in.KeepDaily, &out.KeepDaily
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepWeekly != nil {
+		in, out := &in.KeepWeekly, &out.KeepWeekly
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepMonthly != nil {
+		in, out := &in.KeepMonthly, &out.KeepMonthly
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupRetention.
+func (in *ManagementBackupRetention) DeepCopy() *ManagementBackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupScope) DeepCopyInto(out *ManagementBackupScope) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterDeployments != nil {
+		in, out := &in.ClusterDeployments, &out.ClusterDeployments
+		*out = make([]ClusterDeploymentRef, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupScope.
+func (in *ManagementBackupScope) DeepCopy() *ManagementBackupScope {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupSingleStatus) DeepCopyInto(out *ManagementBackupSingleStatus) {
+	*out = *in
+	if in.NextAttempt != nil {
+		in, out := &in.NextAttempt, &out.NextAttempt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBackup != nil {
+		in, out := &in.LastBackup, &out.LastBackup
+		*out = new(velerov1.BackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrunedBackups != nil {
+		in, out := &in.PrunedBackups, &out.PrunedBackups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingItems != nil {
+		in, out := &in.MissingItems, &out.MissingItems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupSingleStatus.
+func (in *ManagementBackupSingleStatus) DeepCopy() *ManagementBackupSingleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupSingleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupSpec) DeepCopyInto(out *ManagementBackupSpec) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ManagementBackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(ManagementBackupScope)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupSpec.
+func (in *ManagementBackupSpec) DeepCopy() *ManagementBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupStatus) DeepCopyInto(out *ManagementBackupStatus) {
+	*out = *in
+	in.ManagementBackupSingleStatus.DeepCopyInto(&out.ManagementBackupSingleStatus)
+	if in.RegionsLastBackups != nil {
+		in, out := &in.RegionsLastBackups, &out.RegionsLastBackups
+		*out = make([]ManagementBackupSingleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupStatus.
+func (in *ManagementBackupStatus) DeepCopy() *ManagementBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementList) DeepCopyInto(out *ManagementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Management, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementList.
+func (in *ManagementList) DeepCopy() *ManagementList {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementRestore) DeepCopyInto(out *ManagementRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementRestore.
+func (in *ManagementRestore) DeepCopy() *ManagementRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementRestoreList) DeepCopyInto(out *ManagementRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ManagementRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementRestoreList.
+func (in *ManagementRestoreList) DeepCopy() *ManagementRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementBackupList) DeepCopyObject() runtime.Object {
+func (in *ManagementRestoreList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1650,103 +2420,75 @@ func (in *ManagementBackupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementBackupSingleStatus) DeepCopyInto(out *ManagementBackupSingleStatus) {
+func (in *ManagementRestoreSingleStatus) DeepCopyInto(out *ManagementRestoreSingleStatus) {
 	*out = *in
-	if in.NextAttempt != nil {
-		in, out := &in.NextAttempt, &out.NextAttempt
-		*out = (*in).DeepCopy()
-	}
-	if in.LastBackupTime != nil {
-		in, out := &in.LastBackupTime, &out.LastBackupTime
-		*out = (*in).DeepCopy()
-	}
-	if in.LastBackup != nil {
-		in, out := &in.LastBackup, &out.LastBackup
-		*out = new(velerov1.BackupStatus)
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(velerov1.RestoreStatus)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupSingleStatus.
-func (in *ManagementBackupSingleStatus) DeepCopy() *ManagementBackupSingleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementRestoreSingleStatus.
+func (in *ManagementRestoreSingleStatus) DeepCopy() *ManagementRestoreSingleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementBackupSingleStatus)
+	out := new(ManagementRestoreSingleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementBackupSpec) DeepCopyInto(out *ManagementBackupSpec) {
+func (in *ManagementRestoreSpec) DeepCopyInto(out *ManagementRestoreSpec) {
 	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupSpec.
-func (in *ManagementBackupSpec) DeepCopy() *ManagementBackupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementRestoreSpec.
+func (in *ManagementRestoreSpec) DeepCopy() *ManagementRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementBackupSpec)
+	out := new(ManagementRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementBackupStatus) DeepCopyInto(out *ManagementBackupStatus) {
+func (in *ManagementRestoreStatus) DeepCopyInto(out *ManagementRestoreStatus) {
 	*out = *in
-	in.ManagementBackupSingleStatus.DeepCopyInto(&out.ManagementBackupSingleStatus)
-	if in.RegionsLastBackups != nil {
-		in, out := &in.RegionsLastBackups, &out.RegionsLastBackups
-		*out = make([]ManagementBackupSingleStatus, len(*in))
+	in.ManagementRestoreSingleStatus.DeepCopyInto(&out.ManagementRestoreSingleStatus)
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]ManagementRestoreSingleStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupStatus.
-func (in *ManagementBackupStatus) DeepCopy() *ManagementBackupStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(ManagementBackupStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementList) DeepCopyInto(out *ManagementList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Management, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementList.
-func (in *ManagementList) DeepCopy() *ManagementList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementRestoreStatus.
+func (in *ManagementRestoreStatus) DeepCopy() *ManagementRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementList)
+	out := new(ManagementRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagementSpec) DeepCopyInto(out *ManagementSpec) {
 	*out = *in
@@ -1774,6 +2516,11 @@ func (in *ManagementStatus) DeepCopyInto(out *ManagementStatus) {
 		}
 	}
 	in.ComponentsCommonStatus.DeepCopyInto(&out.ComponentsCommonStatus)
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ReleaseUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementStatus.
@@ -1869,6 +2616,46 @@ func (in *MultiClusterServiceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterServiceRolloutStatus) DeepCopyInto(out *MultiClusterServiceRolloutStatus) {
+	*out = *in
+	if in.WaveCompletionTime != nil {
+		in, out := &in.WaveCompletionTime, &out.WaveCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceRolloutStatus.
+func (in *MultiClusterServiceRolloutStatus) DeepCopy() *MultiClusterServiceRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterServiceRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterServiceRolloutStrategy) DeepCopyInto(out *MultiClusterServiceRolloutStrategy) {
+	*out = *in
+	out.BatchSize = in.BatchSize
+	if in.PauseBetweenWaves != nil {
+		in, out := &in.PauseBetweenWaves, &out.PauseBetweenWaves
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceRolloutStrategy.
+func (in *MultiClusterServiceRolloutStrategy) DeepCopy() *MultiClusterServiceRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterServiceRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiClusterServiceSpec) DeepCopyInto(out *MultiClusterServiceSpec) {
 	*out = *in
@@ -1879,6 +2666,16 @@ func (in *MultiClusterServiceSpec) DeepCopyInto(out *MultiClusterServiceSpec) {
 		copy(*out, *in)
 	}
 	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(MultiClusterServiceRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceSpec.
@@ -1922,6 +2719,11 @@ func (in *MultiClusterServiceStatus) DeepCopyInto(out *MultiClusterServiceStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(MultiClusterServiceRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceStatus.
@@ -2279,6 +3081,26 @@ func (in *Region) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionAutoPause) DeepCopyInto(out *RegionAutoPause) {
+	*out = *in
+	if in.UnreachableFor != nil {
+		in, out := &in.UnreachableFor, &out.UnreachableFor
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionAutoPause.
+func (in *RegionAutoPause) DeepCopy() *RegionAutoPause {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionAutoPause)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegionList) DeepCopyInto(out *RegionList) {
 	*out = *in
@@ -2324,6 +3146,11 @@ func (in *RegionSpec) DeepCopyInto(out *RegionSpec) {
 		*out = new(ClusterDeploymentRef)
 		**out = **in
 	}
+	if in.AutoPause != nil {
+		in, out := &in.AutoPause, &out.AutoPause
+		*out = new(RegionAutoPause)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ComponentsCommonSpec.DeepCopyInto(&out.ComponentsCommonSpec)
 }
 
@@ -2360,6 +3187,25 @@ func (in *RegionStatus) DeepCopy() *RegionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionUpgradeStatus) DeepCopyInto(out *RegionUpgradeStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionUpgradeStatus.
+func (in *RegionUpgradeStatus) DeepCopy() *RegionUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Release) DeepCopyInto(out *Release) {
 	*out = *in
@@ -2464,6 +3310,68 @@ func (in *ReleaseStatus) DeepCopy() *ReleaseStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReleaseUpgradeImpact) DeepCopyInto(out *ReleaseUpgradeImpact) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterDeployments != nil {
+		in, out := &in.ClusterDeployments, &out.ClusterDeployments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseUpgradeImpact.
+func (in *ReleaseUpgradeImpact) DeepCopy() *ReleaseUpgradeImpact {
+	if in == nil {
+		return nil
+	}
+	out := new(ReleaseUpgradeImpact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReleaseUpgradeStatus) DeepCopyInto(out *ReleaseUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Impact != nil {
+		in, out := &in.Impact, &out.Impact
+		*out = make([]ReleaseUpgradeImpact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]RegionUpgradeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseUpgradeStatus.
+func (in *ReleaseUpgradeStatus) DeepCopy() *ReleaseUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReleaseUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteSourceSpec) DeepCopyInto(out *RemoteSourceSpec) {
 	*out = *in
@@ -2548,6 +3456,11 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = make([]ServiceDependsOn, len(*in))
 		copy(*out, *in)
 	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(ServiceRollbackPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
@@ -2681,6 +3594,60 @@ func (in *ServiceHelmOptions) DeepCopy() *ServiceHelmOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRevision) DeepCopyInto(out *ServiceRevision) {
+	*out = *in
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesFrom, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRevision.
+func (in *ServiceRevision) DeepCopy() *ServiceRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRollbackPolicy) DeepCopyInto(out *ServiceRollbackPolicy) {
+	*out = *in
+	out.FailureWindow = in.FailureWindow
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRollbackPolicy.
+func (in *ServiceRollbackPolicy) DeepCopy() *ServiceRollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceRollbackStatus) DeepCopyInto(out *ServiceRollbackStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	in.FailedRevision.DeepCopyInto(&out.FailedRevision)
+	in.RevertedTo.DeepCopyInto(&out.RevertedTo)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRollbackStatus.
+func (in *ServiceRollbackStatus) DeepCopy() *ServiceRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceSet) DeepCopyInto(out *ServiceSet) {
 	*out = *in
@@ -2835,6 +3802,11 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(ServiceRollbackPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
@@ -2859,6 +3831,26 @@ func (in *ServiceState) DeepCopyInto(out *ServiceState) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DeploymentHash != nil {
+		in, out := &in.DeploymentHash, &out.DeploymentHash
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDeployed != nil {
+		in, out := &in.LastDeployed, &out.LastDeployed
+		*out = new(ServiceRevision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastDeployedHash != nil {
+		in, out := &in.LastDeployedHash, &out.LastDeployedHash
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(ServiceRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -3119,6 +4111,11 @@ func (in *ServiceWithValues) DeepCopyInto(out *ServiceWithValues) {
 		*out = make([]ValuesFrom, len(*in))
 		copy(*out, *in)
 	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(ServiceRollbackPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceWithValues.
@@ -3412,6 +4409,11 @@ func (in *TemplateStatusCommon) DeepCopyInto(out *TemplateStatusCommon) {
 		*out = new(v2.CrossNamespaceSourceReference)
 		**out = **in
 	}
+	if in.ChartVerification != nil {
+		in, out := &in.ChartVerification, &out.ChartVerification
+		*out = new(ChartVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	out.TemplateValidationStatus = in.TemplateValidationStatus
 }
 
@@ -3440,6 +4442,26 @@ func (in *TemplateValidationStatus) DeepCopy() *TemplateValidationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeBreakingChanges) DeepCopyInto(out *UpgradeBreakingChanges) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeBreakingChanges.
+func (in *UpgradeBreakingChanges) DeepCopy() *UpgradeBreakingChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeBreakingChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradePath) DeepCopyInto(out *UpgradePath) {
 	*out = *in
@@ -3479,3 +4501,28 @@ func (in *ValuesFrom) DeepCopy() *ValuesFrom {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValuesMigration) DeepCopyInto(out *ValuesMigration) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FromTemplates != nil {
+		in, out := &in.FromTemplates, &out.FromTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValuesMigration.
+func (in *ValuesMigration) DeepCopy() *ValuesMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ValuesMigration)
+	in.DeepCopyInto(out)
+	return out
+}
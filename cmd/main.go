@@ -61,6 +61,9 @@ type config struct {
 	templatesRepoURL              string
 	defaultHelmTimeout            time.Duration
 	capiClusterPollInterval       time.Duration
+	templateGCInterval            time.Duration
+	templateGCKeepVersions        int
+	chartVerification             *kcmv1.ChartVerification
 	maxConcurrentReconciles       int
 	insecureRegistry              bool
 	createAccessManagement        bool
@@ -107,8 +110,14 @@ func main() {
 		enableSveltosExpireCtrl       bool
 		defaultHelmTimeout            time.Duration
 		capiClusterPollInterval       time.Duration
+		templateGCInterval            time.Duration
+		templateGCKeepVersions        int
 		maxConcurrentReconciles       int
 		fluxEnabled                   bool
+		chartVerificationProvider     string
+		chartVerificationSecret       string
+		chartVerificationOIDCIssuer   string
+		chartVerificationOIDCSubject  string
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -148,9 +157,21 @@ func main() {
 	flag.BoolVar(&enableSveltosCtrl, "enable-sveltos-ctrl", true, "Enable Sveltos built-in provider controller")
 	flag.BoolVar(&enableSveltosExpireCtrl, "enable-sveltos-expire-ctrl", false, "Enable SveltosCluster stuck (expired) tokens controller")
 	flag.DurationVar(&defaultHelmTimeout, "default-helm-timeout", 0, "Specifies the timeout duration for Helm install or upgrade operations. If unset, Flux’s default value will be used")
-	flag.DurationVar(&capiClusterPollInterval, "capi-cluster-poll-interval", time.Minute, "Polling interval for the periodic CAPI Cluster status check used by the ClusterDeployment controller. Set to 0 to disable the poller.")
+	flag.DurationVar(&capiClusterPollInterval, "capi-cluster-poll-interval", 10*time.Minute, "Polling interval for the periodic CAPI Cluster status check used by the ClusterDeployment controller as a safety net for the CAPI Cluster watches. Set to 0 to disable the poller.")
+	flag.DurationVar(&templateGCInterval, "template-gc-interval", 0,
+		"Interval of the garbage collection of the templates not referenced by any object. Set to 0 to disable the garbage collection.")
+	flag.IntVar(&templateGCKeepVersions, "template-gc-keep-versions", 3,
+		"Number of the most recent versions of each template kept by the templates garbage collection regardless of their usage.")
 	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 10, "Specifies the maximum number of concurrent reconciles that will be run for each controller.")
 	flag.BoolVar(&fluxEnabled, "flux-enabled", true, "The flag that indicates whether Flux integration is enabled")
+	flag.StringVar(&chartVerificationProvider, "chart-verification-provider", "",
+		"The provider of the system-wide verification of the templates charts, either 'cosign' or 'helm-provenance'. Empty value disables the verification.")
+	flag.StringVar(&chartVerificationSecret, "chart-verification-secret", "",
+		"Name of a Secret in the system namespace containing the trusted cosign public keys or PGP keyrings to verify the templates charts with.")
+	flag.StringVar(&chartVerificationOIDCIssuer, "chart-verification-oidc-issuer", "",
+		"Regular expression to match the OIDC issuer of the cosign keyless signatures of the templates charts.")
+	flag.StringVar(&chartVerificationOIDCSubject, "chart-verification-oidc-subject", "",
+		"Regular expression to match the OIDC subject of the cosign keyless signatures of the templates charts.")
 
 	// TODO: remove in one of the upcoming releases
 	_ = flag.Bool("enable-telemetry", false, "[Deprecated] Has no effect, use a dedicated telemetry chart")
@@ -192,6 +213,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	chartVerification, err := helmutil.ChartVerificationFromFlags(chartVerificationProvider, chartVerificationSecret, chartVerificationOIDCIssuer, chartVerificationOIDCSubject)
+	if err != nil {
+		setupLog.Error(err, "invalid chart verification configuration")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -282,6 +309,9 @@ func main() {
 		enableSveltosExpireCtrl:       enableSveltosExpireCtrl,
 		defaultHelmTimeout:            defaultHelmTimeout,
 		capiClusterPollInterval:       capiClusterPollInterval,
+		templateGCInterval:            templateGCInterval,
+		templateGCKeepVersions:        templateGCKeepVersions,
+		chartVerification:             chartVerification,
 		fluxEnabled:                   fluxEnabled,
 	}
 	if err := setupControllers(mgr, systemNamespace, cfg); err != nil {
@@ -317,9 +347,10 @@ func main() {
 func setupControllers(mgr ctrl.Manager, currentNamespace string, cfg config) error {
 	var err error
 	templateReconciler := controller.TemplateReconciler{
-		Client:           mgr.GetClient(),
-		CreateManagement: cfg.createManagement,
-		SystemNamespace:  currentNamespace,
+		Client:            mgr.GetClient(),
+		CreateManagement:  cfg.createManagement,
+		SystemNamespace:   currentNamespace,
+		ChartVerification: cfg.chartVerification,
 		DefaultRegistryConfig: helm.DefaultRegistryConfig{
 			URL:                   cfg.templatesRepoURL,
 			RepoType:              cfg.determinedRepositoryType,
@@ -370,6 +401,10 @@ func setupControllers(mgr ctrl.Manager, currentNamespace string, cfg config) err
 		setupLog.Error(err, "unable to create controller", "controller", "Management")
 		return err
 	}
+	if err = mgr.Add(kubeutil.DefaultRegionalClientPool); err != nil {
+		setupLog.Error(err, "unable to add regional client pool")
+		return err
+	}
 	if err = (&region.Reconciler{
 		MgmtClient:             mgr.GetClient(),
 		IsDisabledValidationWH: !cfg.enableWebhook,
@@ -378,6 +413,7 @@ func setupControllers(mgr ctrl.Manager, currentNamespace string, cfg config) err
 		RegistryCertSecretName: cfg.registryCertSecretName,
 		ImagePullSecretName:    cfg.imagePullSecretName,
 		DefaultHelmTimeout:     cfg.defaultHelmTimeout,
+		ClientPool:             kubeutil.DefaultRegionalClientPool,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Region")
 		return err
@@ -406,6 +442,16 @@ func setupControllers(mgr ctrl.Manager, currentNamespace string, cfg config) err
 		setupLog.Error(err, "unable to create controller", "controller", "ServiceTemplateChain")
 		return err
 	}
+	if err = (&controller.ClusterFleetUpgradeReconciler{}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterFleetUpgrade")
+		return err
+	}
+	if err = (&controller.ClusterDeploymentMigrationReconciler{
+		SystemNamespace: currentNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterDeploymentMigration")
+		return err
+	}
 
 	if err = (&controller.ReleaseReconciler{
 		Client:                mgr.GetClient(),
@@ -429,6 +475,18 @@ func setupControllers(mgr ctrl.Manager, currentNamespace string, cfg config) err
 		return err
 	}
 
+	if cfg.templateGCInterval > 0 {
+		if err = (&controller.TemplateGCReconciler{
+			Client:          mgr.GetClient(),
+			SystemNamespace: currentNamespace,
+			Interval:        cfg.templateGCInterval,
+			KeepVersions:    cfg.templateGCKeepVersions,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "TemplateGC")
+			return err
+		}
+	}
+
 	if err = (&controller.CredentialReconciler{
 		SystemNamespace: currentNamespace,
 		MgmtClient:      mgr.GetClient(),
@@ -444,6 +502,13 @@ func setupControllers(mgr ctrl.Manager, currentNamespace string, cfg config) err
 		setupLog.Error(err, "unable to create controller", "controller", "ManagementBackup")
 		return err
 	}
+	if err = (&controller.ManagementRestoreReconciler{
+		Client:          mgr.GetClient(),
+		SystemNamespace: currentNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ManagementRestore")
+		return err
+	}
 
 	if err = (&ipam.ClusterIPAMClaimReconciler{
 		Client: mgr.GetClient(),
@@ -526,6 +591,10 @@ func setupWebhooks(mgr ctrl.Manager, systemNamespace string, validateClusterUpgr
 		setupLog.Error(err, "unable to create webhook", "webhook", "ServiceTemplateChain")
 		return err
 	}
+	if err := (&kcmwebhook.MultiClusterServiceValidator{SystemNamespace: systemNamespace}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "MultiClusterService")
+		return err
+	}
 
 	templateValidator := kcmwebhook.TemplateValidator{
 		SystemNamespace: systemNamespace,
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	"github.com/K0rdent/kcm/internal/telemetry"
@@ -58,7 +59,7 @@ func main() {
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&secureMetrics, "metrics-secure", false, "If set the metrics endpoint is served securely")
+	flag.BoolVar(&secureMetrics, "metrics-secure", false, "If set the metrics endpoint is served securely with authentication and authorization. Required to serve the telemetry query endpoint")
 	flag.StringVar(&pprofBindAddress, "pprof-bind-address", "", "The TCP address that the controller should bind to for serving pprof, \"0\" or empty value disables pprof. Works only if --metrics-secure=true")
 
 	opts := zap.Options{Development: true}
@@ -71,16 +72,19 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	if !secureMetrics {
+	metricsOpts := metricsserver.Options{
+		BindAddress:   metricsAddr,
+		SecureServing: secureMetrics,
+	}
+	if secureMetrics {
+		metricsOpts.FilterProvider = filters.WithAuthenticationAndAuthorization
+	} else {
 		pprofBindAddress = "0"
 	}
 
 	managerOpts := ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress:   metricsAddr,
-			SecureServing: secureMetrics,
-		},
+		Scheme:                 scheme,
+		Metrics:                metricsOpts,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         true,
 		LeaderElectionID:       fmt.Sprintf("%x.k0rdent.mirantis.com", md5.Sum([]byte(telemetryCfg.Mode))),
@@ -111,6 +115,17 @@ func main() {
 			setupLog.Error(err, "unable to add telemetry runner")
 			os.Exit(1)
 		}
+
+		if h := tr.QueryHandler(); h != nil {
+			if secureMetrics {
+				if err := mgr.AddMetricsServerExtraHandler(telemetry.QueryPath, h); err != nil {
+					setupLog.Error(err, "unable to add telemetry query handler")
+					os.Exit(1)
+				}
+			} else {
+				setupLog.Info("Telemetry query endpoint is not served since the metrics endpoint is not secure", "path", telemetry.QueryPath)
+			}
+		}
 	} else {
 		setupLog.Info("Telemetry collection is effectively disabled, will keep the instance without exiting")
 	}
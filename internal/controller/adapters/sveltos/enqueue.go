@@ -19,17 +19,24 @@ import (
 	"fmt"
 
 	addoncontrollerv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	"github.com/projectsveltos/addon-controller/lib/clusterops"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	multiclusterutil "github.com/K0rdent/kcm/internal/util/multicluster"
 	pollerutil "github.com/K0rdent/kcm/internal/util/poller"
 )
 
 // enqueueClusterSummary returns a [pollerutil.EnqueueFunc] that walks every
 // [kcmv1.ServiceSet] and emits the ones whose observed Status.Services drift
 // from the regional ClusterSummary. Regional clients are cached per tick.
+//
+// The changes are normally delivered by the watches set up with [serviceSetForSveltosObject],
+// the poller is a low-frequency safety net for the events missed by the watches,
+// e.g. while the regional informers are being (re)started.
 func enqueueClusterSummary(cl client.Client, systemNamespace string) pollerutil.EnqueueFunc[*kcmv1.ServiceSet] {
 	return func(ctx context.Context) ([]*kcmv1.ServiceSet, error) {
 		logger := ctrl.LoggerFrom(ctx)
@@ -88,3 +95,42 @@ func enqueueClusterSummary(cl client.Client, systemNamespace string) pollerutil.
 		return out, nil
 	}
 }
+
+// serviceSetForSveltosObject returns a [multiclusterutil.MapFunc] that maps the given
+// Profile, ClusterProfile or ClusterSummary to the [kcmv1.ServiceSet] it has been
+// produced for. The objects which have not been produced for an existing ServiceSet are ignored.
+func serviceSetForSveltosObject(cl client.Client, systemNamespace string) multiclusterutil.MapFunc[*kcmv1.ServiceSet] {
+	return func(ctx context.Context, region string, obj client.Object) []*kcmv1.ServiceSet {
+		var key client.ObjectKey
+		switch o := obj.(type) {
+		case *addoncontrollerv1beta1.Profile:
+			key = client.ObjectKeyFromObject(o)
+		case *addoncontrollerv1beta1.ClusterProfile:
+			// ClusterProfiles are produced only for the self-managed ServiceSets
+			// which reside in the system namespace
+			key = client.ObjectKey{Namespace: systemNamespace, Name: o.Name}
+		case *addoncontrollerv1beta1.ClusterSummary:
+			if name, ok := o.Labels[clusterops.ProfileLabelName]; ok {
+				key = client.ObjectKey{Namespace: o.Namespace, Name: name}
+			} else if name, ok := o.Labels[clusterops.ClusterProfileLabelName]; ok {
+				key = client.ObjectKey{Namespace: systemNamespace, Name: name}
+			} else {
+				return nil
+			}
+		default:
+			return nil
+		}
+
+		serviceSet := new(kcmv1.ServiceSet)
+		if err := cl.Get(ctx, key, serviceSet); err != nil {
+			if !apierrors.IsNotFound(err) {
+				ctrl.LoggerFrom(ctx).Error(err, "failed to get ServiceSet", "service_set", key, "region", region)
+			}
+			return nil
+		}
+
+		ctrl.LoggerFrom(ctx).V(1).Info("Sveltos object has changed, scheduling reconcile",
+			"kind", fmt.Sprintf("%T", obj), "object", client.ObjectKeyFromObject(obj), "region", region, "service_set", key)
+		return []*kcmv1.ServiceSet{serviceSet}
+	}
+}
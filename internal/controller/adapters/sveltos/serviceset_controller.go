@@ -49,8 +49,10 @@ import (
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
 	"github.com/K0rdent/kcm/internal/record"
+	"github.com/K0rdent/kcm/internal/serviceset"
 	helmutil "github.com/K0rdent/kcm/internal/util/helm"
 	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	multiclusterutil "github.com/K0rdent/kcm/internal/util/multicluster"
 	pointerutil "github.com/K0rdent/kcm/internal/util/pointer"
 	pollerutil "github.com/K0rdent/kcm/internal/util/poller"
 	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
@@ -64,6 +66,9 @@ const (
   value: ok`
 
 	managementSveltosCluster = "mgmt"
+
+	// clusterSummaryPollInterval is the interval of the safety net poller of the ClusterSummaries.
+	clusterSummaryPollInterval = 5 * time.Minute
 )
 
 var (
@@ -251,7 +256,16 @@ func (r *ServiceSetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// finally we'll roll back the services which failed to deploy, if configured so,
+	// the ServiceSet spec will be reverted by the controller owning the ServiceSet.
+	rolledBack, requeueAfter := serviceset.TrackServiceRevisions(serviceSet, r.timeFunc())
+	for _, svc := range rolledBack {
+		record.Warnf(serviceSet, nil, kcmv1.ServiceSetServiceRolledBackEvent, kcmv1.ServiceSetRollbackServiceEventAction,
+			"Service %s/%s failed to deploy with template %s, rolling back to template %s", svc.Namespace, svc.Name,
+			svc.Rollback.FailedRevision.Template, svc.Rollback.RevertedTo.Template)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *ServiceSetReconciler) reconcileDelete(ctx context.Context, rgnClient client.Client, serviceSet *kcmv1.ServiceSet) (ctrl.Result, error) {
@@ -322,12 +336,34 @@ func (r *ServiceSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 	r.requeueInterval = 10 * time.Second
 
+	// the changes of the Profiles, ClusterProfiles and ClusterSummaries are watched
+	// on the management and regional clusters, only the affected ServiceSets are enqueued.
+	watcher := multiclusterutil.NewWatcher(
+		r.Client,
+		mgr.GetCache(),
+		r.SystemNamespace,
+		serviceSetForSveltosObject(r.Client, r.SystemNamespace),
+		[]client.Object{
+			&addoncontrollerv1beta1.Profile{},
+			&addoncontrollerv1beta1.ClusterProfile{},
+			&addoncontrollerv1beta1.ClusterSummary{},
+		},
+		multiclusterutil.WithRegionalScheme(schemeutil.GetRegionalSchemeWithSveltos),
+		multiclusterutil.WithBufferSize(r.MaxConcurrentReconciles*10),
+		multiclusterutil.WithName("watcher-cluster-summaries"),
+	)
+
+	if err := mgr.Add(watcher); err != nil {
+		return fmt.Errorf("failed to add multicluster watcher to manager: %w", err)
+	}
+
 	// in case reconciliation will slowdown and occasionally poller will produce
 	// events faster than controller will reconcile objects, we will have a 10-fold
-	// capacity reserve for event channel.
+	// capacity reserve for event channel. The poller is a safety net for the events
+	// missed by the watcher, hence it runs with the low frequency.
 	poller := pollerutil.NewRunner(
 		enqueueClusterSummary(r.Client, r.SystemNamespace),
-		pollerutil.WithInterval(r.requeueInterval),
+		pollerutil.WithInterval(clusterSummaryPollInterval),
 		pollerutil.WithBufferSize(r.MaxConcurrentReconciles*10),
 		pollerutil.WithName("poller-cluster-summaries"),
 	)
@@ -393,6 +429,7 @@ func (r *ServiceSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}
 			return requests, nil
 		})).
+		WatchesRawSource(source.TypedChannel(watcher.GetEventChannel(), &handler.TypedEnqueueRequestForObject[*kcmv1.ServiceSet]{})).
 		WatchesRawSource(source.TypedChannel(poller.GetEventChannel(), &handler.TypedEnqueueRequestForObject[*kcmv1.ServiceSet]{})).
 		Complete(r)
 }
@@ -1592,7 +1629,7 @@ func resolveRegionalClient(
 	rgn := cl
 	if cred.Spec.Region != "" {
 		var err error
-		rgn, err = kubeutil.GetRegionalClientByRegionName(ctx, cl, systemNamespace, cred.Spec.Region, schemeutil.GetRegionalSchemeWithSveltos)
+		rgn, err = kubeutil.GetRegionalClientByRegionName(ctx, cl, systemNamespace, cred.Spec.Region, schemeutil.RegionalWithSveltos)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get regional client for region %s: %w", cred.Spec.Region, err)
 		}
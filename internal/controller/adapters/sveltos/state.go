@@ -100,6 +100,9 @@ func servicesStateFromSummary(
 
 		newState.Type = svc.Type
 		newState.LastStateTransitionTime = svc.LastStateTransitionTime
+		newState.LastDeployed = svc.LastDeployed
+		newState.LastDeployedHash = svc.LastDeployedHash
+		newState.Rollback = svc.Rollback
 
 		switch svc.Type {
 		case kcmv1.ServiceTypeKustomize:
@@ -141,6 +144,7 @@ func featureKustomize(newState *kcmv1.ServiceState, summary *addoncontrollerv1be
 
 	for _, feature := range summary.Status.FeatureSummaries {
 		if feature.FeatureID == libsveltosv1beta1.FeatureKustomize {
+			newState.DeploymentHash = feature.Hash
 			// We cannot determine which kustomizations or policies were failed, hence we'll treat them as failed
 			// in case feature summary contains failure message. This message will be copied to the ServiceSet status
 			// thus user will be able to see the reason of failure.
@@ -172,6 +176,7 @@ func featureResources(newState *kcmv1.ServiceState, summary *addoncontrollerv1be
 
 	for _, feature := range summary.Status.FeatureSummaries {
 		if feature.FeatureID == libsveltosv1beta1.FeatureResources {
+			newState.DeploymentHash = feature.Hash
 			policiesDeployed = feature.Status == libsveltosv1beta1.FeatureStatusProvisioned
 			if feature.FailureMessage != nil {
 				policiesFailed = true
@@ -199,6 +204,7 @@ func featureHelm(
 
 	for _, feature := range summary.Status.FeatureSummaries {
 		if feature.FeatureID == libsveltosv1beta1.FeatureHelm {
+			newState.DeploymentHash = feature.Hash
 			helmFeatureStatus = feature.Status
 			// NOTE: The FailureMessage for the Helm Feature is simply a concatenation of
 			// each individual `helmReleaseSummaries[].FailureMessage` since Sveltos v1.7.0.
@@ -23,6 +23,7 @@ import (
 	certmanagerv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
@@ -31,6 +32,7 @@ import (
 // getBackupTemplateSpec creates a Velero backup specification that is region-aware.
 // For management backups (empty region), it only includes non-regional resources.
 // For regional backups, it only includes resources specific to that region.
+// For scoped backups, it only includes the dependency closure of the selected ClusterDeployments.
 func getBackupTemplateSpec(s *scope, region string) *velerov1.BackupSpec {
 	bs := &velerov1.BackupSpec{
 		IncludedNamespaces: []string{"*"},
@@ -38,6 +40,11 @@ func getBackupTemplateSpec(s *scope, region string) *velerov1.BackupSpec {
 		TTL:                metav1.Duration{Duration: 30 * 24 * time.Hour}, // velero's default, set it for the sake of UX
 	}
 
+	if s.scoped != nil {
+		setScopedSelectors(bs, s, region)
+		return bs
+	}
+
 	orSelectors := []*metav1.LabelSelector{
 		// fixed ones
 		selector(kcmv1.GenericComponentNameLabel, kcmv1.GenericComponentLabelValueKCM),
@@ -114,6 +121,44 @@ func getClusterDeploymentsSelectors(s *scope, region string) []*metav1.LabelSele
 	return selectors
 }
 
+// setScopedSelectors limits the given backup spec to the objects related to the ClusterDeployments
+// selected by the scoped ManagementBackup in the given region.
+// The management backup includes objects labeled with the scope label, while the regional one
+// includes copies of the ClusterIdentity objects labeled by the corresponding Credential.
+func setScopedSelectors(bs *velerov1.BackupSpec, s *scope, region string) {
+	var (
+		orSelectors []*metav1.LabelSelector
+		namespaces  []string
+	)
+
+	if region == "" {
+		orSelectors = append(orSelectors, selector(s.mgmtBackup.ScopeLabelKey(), "true"))
+		namespaces = append(namespaces, s.scoped.namespaces...)
+	}
+
+	for _, cld := range s.scoped.clusterDeployments {
+		if cld.Status.Region != region {
+			continue
+		}
+
+		orSelectors = append(orSelectors,
+			selector(kcmv1.FluxHelmChartNameKey, cld.Name),
+			selector(clusterapiv1.ClusterNameLabel, cld.Name),
+		)
+		namespaces = append(namespaces, cld.Namespace)
+
+		if region != "" && cld.Spec.Credential != "" {
+			orSelectors = append(orSelectors, selector(strings.Join([]string{kcmv1.CredentialLabelKeyPrefix, cld.Namespace, cld.Spec.Credential}, "."), "true"))
+		}
+	}
+
+	slices.Sort(namespaces)
+
+	bs.IncludedNamespaces = slices.Compact(namespaces)
+	bs.IncludeClusterResources = ptr.To(true) // cluster-scoped ClusterIdentity objects
+	bs.OrLabelSelectors = sortDedup(orSelectors)
+}
+
 func selector(k, v string) *metav1.LabelSelector {
 	return &metav1.LabelSelector{
 		MatchLabels: map[string]string{k: v},
@@ -24,15 +24,18 @@ type Reconciler struct {
 
 	regionalFactory RegionalClientFactory
 
+	resourceListFetcher ResourceListFetcher
+
 	systemNamespace string
 }
 
 // NewReconciler creates instance of the [Reconciler].
 func NewReconciler(cl client.Client, systemNamespace string, opts ...ReconcilerOption) *Reconciler {
 	r := &Reconciler{
-		mgmtCl:          cl,
-		systemNamespace: systemNamespace,
-		regionalFactory: defaultRegionalClientFactory,
+		mgmtCl:              cl,
+		systemNamespace:     systemNamespace,
+		regionalFactory:     defaultRegionalClientFactory,
+		resourceListFetcher: fetchResourceList,
 	}
 
 	for _, opt := range opts {
@@ -51,3 +54,10 @@ func WithRegionalClientFactory(factory RegionalClientFactory) ReconcilerOption {
 		r.regionalFactory = factory
 	}
 }
+
+// WithResourceListFetcher returns an option to set a custom fetcher of the backups resource lists.
+func WithResourceListFetcher(fetcher ResourceListFetcher) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.resourceListFetcher = fetcher
+	}
+}
@@ -54,3 +54,17 @@ func withRegionLabel(region string) createOpt {
 		b.Labels[kcmv1.KCMRegionLabelKey] = region
 	}
 }
+
+// withReleaseLabel returns a createOpt that adds a label with the [github.com/K0rdent/kcm/api/v1beta1.Release] name
+// to a backup if the release is not empty.
+func withReleaseLabel(release string) createOpt {
+	return func(b *velerov1.Backup) {
+		if release == "" {
+			return
+		}
+		if b.Labels == nil {
+			b.Labels = make(map[string]string)
+		}
+		b.Labels[kcmv1.ManagementBackupReleaseLabel] = release
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/label"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// pruneAllScheduledBackups applies the retention policy of the scheduled ManagementBackup
+// to the management and all of the regional backups and updates the
+// pruning-related fields of the corresponding ManagementBackup status entries.
+// Pruning errors are not fatal and only logged, the pruning will be retried with the next scheduled backup.
+func (r *Reconciler) pruneAllScheduledBackups(ctx context.Context, s *scope, now time.Time) {
+	mgmtBackup := s.mgmtBackup
+	if mgmtBackup.Spec.Retention == nil {
+		return
+	}
+
+	l := ctrl.LoggerFrom(ctx)
+
+	pruned, err := r.pruneBackups(ctx, r.mgmtCl, mgmtBackup, "", now)
+	if err != nil {
+		l.Error(err, "failed to prune management backups")
+	}
+	setPruned(&mgmtBackup.Status.ManagementBackupSingleStatus, pruned)
+
+	for region, loadedCl := range s.regionClients {
+		if region == "" || !loadedCl.loaded { // sanity check
+			l.V(1).Info("Skip backups pruning", "region", region, "client_loaded", loadedCl.loaded)
+			continue
+		}
+
+		pruned, err := r.pruneBackups(ctx, loadedCl.cl, mgmtBackup, region, now)
+		if err != nil {
+			l.Error(err, "failed to prune regional backups", "region", region)
+		}
+
+		idx := slices.IndexFunc(mgmtBackup.Status.RegionsLastBackups, func(rb kcmv1.ManagementBackupSingleStatus) bool {
+			return rb.Region == region
+		})
+		if idx == -1 {
+			continue
+		}
+		setPruned(&mgmtBackup.Status.RegionsLastBackups[idx], pruned)
+	}
+}
+
+func setPruned(status *kcmv1.ManagementBackupSingleStatus, pruned []string) {
+	status.PrunedBackups = pruned
+	status.PrunedBackupsCount += len(pruned)
+}
+
+// pruneBackups lists backups produced by the scheduled ManagementBackup in the given region
+// (or management cluster if the region is empty), and requests deletion of those
+// not satisfying the retention policy. Returns names of the backups requested to be deleted by this run.
+func (r *Reconciler) pruneBackups(ctx context.Context, cl client.Client, mgmtBackup *kcmv1.ManagementBackup, region string, now time.Time) ([]string, error) {
+	matchingLabels := client.MatchingLabels{scheduleMgmtNameLabel: mgmtBackup.Name}
+	if region != "" {
+		matchingLabels[kcmv1.KCMRegionLabelKey] = region
+	}
+
+	backups := new(velerov1.BackupList)
+	if err := cl.List(ctx, backups, client.InNamespace(r.systemNamespace), matchingLabels); err != nil {
+		return nil, fmt.Errorf("failed to list velero Backups: %w", err)
+	}
+
+	// because of the single BSL (HACK), regional backups might be synced to the management cluster
+	if region == "" {
+		backups.Items = slices.DeleteFunc(backups.Items, func(b velerov1.Backup) bool {
+			return b.Labels[kcmv1.KCMRegionLabelKey] != ""
+		})
+	}
+
+	lastBackupName := mgmtBackup.Status.LastBackupName
+	if region != "" {
+		for _, rb := range mgmtBackup.Status.RegionsLastBackups {
+			if rb.Region == region {
+				lastBackupName = rb.LastBackupName
+				break
+			}
+		}
+	}
+
+	ldebug := ctrl.LoggerFrom(ctx).V(1)
+
+	var pruned []string
+	for _, b := range backupsToPrune(mgmtBackup.Name, region, backups.Items, mgmtBackup.Spec.Retention, lastBackupName, now) {
+		dbr := &velerov1.DeleteBackupRequest{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: velerov1.SchemeGroupVersion.String(),
+				Kind:       "DeleteBackupRequest",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      label.GetValidName(b.Name + "-pruned"),
+				Namespace: r.systemNamespace,
+				Labels: map[string]string{
+					velerov1.BackupNameLabel: label.GetValidName(b.Name),
+					velerov1.BackupUIDLabel:  string(b.UID),
+					scheduleMgmtNameLabel:    mgmtBackup.Name,
+				},
+			},
+			Spec: velerov1.DeleteBackupRequestSpec{
+				BackupName: b.Name,
+			},
+		}
+
+		if err := cl.Create(ctx, dbr); err != nil {
+			if apierrors.IsAlreadyExists(err) { // already requested by one of the previous runs
+				continue
+			}
+			return pruned, fmt.Errorf("failed to create velero DeleteBackupRequest for the Backup %s: %w", b.Name, err)
+		}
+
+		ldebug.Info("Requested backup deletion according to the retention policy", "backup", b.Name, "region", region)
+		pruned = append(pruned, b.Name)
+	}
+
+	return pruned, nil
+}
+
+// backupsToPrune returns backups that do not satisfy any of the rules of the given retention policy.
+// Only completed backups satisfy the rules, the failed ones are kept until a newer backup completes.
+// Backups which are not finished yet or already being deleted, as well as the protected one, are never returned.
+func backupsToPrune(mgmtBackupName, region string, backups []velerov1.Backup, retention *kcmv1.ManagementBackupRetention, protected string, now time.Time) []velerov1.Backup {
+	if retention == nil {
+		return nil
+	}
+
+	type timedBackup struct {
+		t time.Time
+		b velerov1.Backup
+	}
+
+	candidates := make([]timedBackup, 0, len(backups))
+	for _, b := range backups {
+		switch b.Status.Phase {
+		case "", velerov1.BackupPhaseNew, velerov1.BackupPhaseQueued, velerov1.BackupPhaseReadyToStart, velerov1.BackupPhaseInProgress, velerov1.BackupPhaseDeleting,
+			velerov1.BackupPhaseWaitingForPluginOperations, velerov1.BackupPhaseWaitingForPluginOperationsPartiallyFailed,
+			velerov1.BackupPhaseFinalizing, velerov1.BackupPhaseFinalizingPartiallyFailed:
+			continue
+		}
+
+		candidates = append(candidates, timedBackup{t: backupTime(mgmtBackupName, region, &b), b: b})
+	}
+
+	// newest first
+	slices.SortStableFunc(candidates, func(a, b timedBackup) int {
+		return b.t.Compare(a.t)
+	})
+
+	bucketRules := []struct {
+		keep   *int32
+		bucket func(time.Time) string
+	}{
+		{retention.KeepDaily, func(t time.Time) string { return t.Format(time.DateOnly) }},
+		{retention.KeepWeekly, func(t time.Time) string {
+			y, w := t.ISOWeek()
+			return strconv.Itoa(y) + "-" + strconv.Itoa(w)
+		}},
+		{retention.KeepMonthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	bucketsLeft := make([]int32, len(bucketRules))
+	lastBuckets := make([]string, len(bucketRules))
+	for i, rule := range bucketRules {
+		if rule.keep != nil {
+			bucketsLeft[i] = *rule.keep
+		}
+	}
+
+	var (
+		toPrune   []velerov1.Backup
+		keepLast  int32
+		completed int32
+	)
+	if retention.KeepLast != nil {
+		keepLast = *retention.KeepLast
+	}
+
+	for _, c := range candidates {
+		keep := c.b.Name == protected
+
+		if c.b.Status.Phase != velerov1.BackupPhaseCompleted {
+			if !keep && completed > 0 {
+				toPrune = append(toPrune, c.b)
+			}
+			continue
+		}
+
+		if completed < keepLast {
+			keep = true
+		}
+		completed++
+
+		if retention.TTL != nil && now.Sub(c.t) < retention.TTL.Duration {
+			keep = true
+		}
+
+		for j, rule := range bucketRules {
+			if bucketsLeft[j] == 0 {
+				continue
+			}
+
+			if bucket := rule.bucket(c.t); bucket != lastBuckets[j] {
+				lastBuckets[j] = bucket
+				bucketsLeft[j]--
+				keep = true
+			}
+		}
+
+		if !keep {
+			toPrune = append(toPrune, c.b)
+		}
+	}
+
+	return toPrune
+}
+
+// backupTime returns the time the given backup has been produced at.
+// The time is parsed from the backup name, falling back to the start
+// or the creation timestamps, e.g. if the name has been changed or the object has been restored.
+func backupTime(mgmtBackupName, region string, backup *velerov1.Backup) time.Time {
+	prefix := mgmtBackupName + "-"
+	if region != "" {
+		prefix += region + "-"
+	}
+
+	if t, err := time.Parse("20060102150405", strings.TrimPrefix(backup.Name, prefix)); err == nil {
+		return t
+	}
+
+	if backup.Status.StartTimestamp != nil {
+		return backup.Status.StartTimestamp.UTC()
+	}
+
+	return backup.CreationTimestamp.UTC()
+}
@@ -0,0 +1,235 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func Test_backupsToPrune(t *testing.T) {
+	const scheduleName = "test-schedule"
+
+	// Wednesday
+	now := time.Date(2026, time.March, 18, 12, 0, 0, 0, time.UTC)
+
+	// one completed backup each 12 hours during 70 days, newest first
+	backups := make([]velerov1.Backup, 0, 140)
+	for i := range 140 {
+		ts := now.Add(-time.Duration(i) * 12 * time.Hour)
+		backups = append(backups, velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + ts.Format(tsFormat)},
+			Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted},
+		})
+	}
+
+	tcases := []struct {
+		name      string
+		retention *kcmv1.ManagementBackupRetention
+		backups   []velerov1.Backup
+		protected string
+		wantKept  int
+	}{
+		{
+			name:     "no retention",
+			backups:  backups,
+			wantKept: len(backups),
+		},
+		{
+			name:      "keep last",
+			retention: &kcmv1.ManagementBackupRetention{KeepLast: ptr.To[int32](5)},
+			backups:   backups,
+			wantKept:  5,
+		},
+		{
+			name:      "ttl",
+			retention: &kcmv1.ManagementBackupRetention{TTL: &metav1.Duration{Duration: 72 * time.Hour}},
+			backups:   backups,
+			wantKept:  6,
+		},
+		{
+			name:      "daily",
+			retention: &kcmv1.ManagementBackupRetention{KeepDaily: ptr.To[int32](7)},
+			backups:   backups,
+			wantKept:  7,
+		},
+		{
+			name:      "weekly",
+			retention: &kcmv1.ManagementBackupRetention{KeepWeekly: ptr.To[int32](4)},
+			backups:   backups,
+			wantKept:  4,
+		},
+		{
+			name:      "monthly exceeds the available months",
+			retention: &kcmv1.ManagementBackupRetention{KeepMonthly: ptr.To[int32](12)},
+			backups:   backups,
+			wantKept:  3, // March, February, January
+		},
+		{
+			name: "combined rules overlap",
+			retention: &kcmv1.ManagementBackupRetention{
+				KeepLast:  ptr.To[int32](2),
+				KeepDaily: ptr.To[int32](2),
+			},
+			backups:  backups,
+			wantKept: 3, // 2 of today + the latest of yesterday
+		},
+		{
+			name:      "protected backup is kept",
+			retention: &kcmv1.ManagementBackupRetention{KeepLast: ptr.To[int32](1)},
+			backups:   backups,
+			protected: backups[10].Name,
+			wantKept:  2,
+		},
+		{
+			name:      "unfinished backups are neither pruned nor counted",
+			retention: &kcmv1.ManagementBackupRetention{KeepLast: ptr.To[int32](1)},
+			backups: []velerov1.Backup{
+				{ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + now.Format(tsFormat)}, Status: velerov1.BackupStatus{Phase: velerov1.BackupPhaseInProgress}},
+				{ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + now.Add(-time.Hour).Format(tsFormat)}, Status: velerov1.BackupStatus{Phase: velerov1.BackupPhaseDeleting}},
+				{ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + now.Add(-2*time.Hour).Format(tsFormat)}, Status: velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted}},
+				{ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + now.Add(-3*time.Hour).Format(tsFormat)}, Status: velerov1.BackupStatus{Phase: velerov1.BackupPhaseFailed}},
+			},
+			wantKept: 3,
+		},
+		{
+			name:      "failed backups fill no slots",
+			retention: &kcmv1.ManagementBackupRetention{KeepLast: ptr.To[int32](2), KeepDaily: ptr.To[int32](1)},
+			backups: append([]velerov1.Backup{
+				{ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + now.Add(time.Hour).Format(tsFormat)}, Status: velerov1.BackupStatus{Phase: velerov1.BackupPhaseFailed}},
+				{ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + now.Add(30*time.Minute).Format(tsFormat)}, Status: velerov1.BackupStatus{Phase: velerov1.BackupPhasePartiallyFailed}},
+				{ObjectMeta: metav1.ObjectMeta{Name: scheduleName + "-" + now.Add(15*time.Minute).Format(tsFormat)}, Status: velerov1.BackupStatus{Phase: velerov1.BackupPhaseFailed}},
+			}, backups...),
+			wantKept: 3 + 2, // the failed ones newer than the last completed + 2 completed
+		},
+	}
+
+	for _, tc := range tcases {
+		t.Run(tc.name, func(t *testing.T) {
+			toPrune := backupsToPrune(scheduleName, "", tc.backups, tc.retention, tc.protected, now)
+			if kept := len(tc.backups) - len(toPrune); kept != tc.wantKept {
+				t.Errorf("kept %d backups, want %d", kept, tc.wantKept)
+			}
+
+			if tc.protected != "" && slices.ContainsFunc(toPrune, func(b velerov1.Backup) bool { return b.Name == tc.protected }) {
+				t.Errorf("protected backup %s is pruned", tc.protected)
+			}
+		})
+	}
+}
+
+func Test_pruneAllScheduledBackups(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		scheduleName    = "test-schedule"
+		region          = "rgn"
+	)
+
+	scheme := runtime.NewScheme()
+	if err := velerov1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme: %v", err)
+	}
+
+	now := time.Now().UTC()
+
+	newBackup := func(region string, age time.Duration) *velerov1.Backup {
+		b := &velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      (&kcmv1.ManagementBackup{ObjectMeta: metav1.ObjectMeta{Name: scheduleName}}).TimestampedBackupName(now.Add(-age), region),
+				Namespace: systemNamespace,
+			},
+			Status: velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted},
+		}
+		withScheduleLabel(scheduleName)(b)
+		withRegionLabel(region)(b)
+		return b
+	}
+
+	mgmtNew, mgmtOld := newBackup("", time.Hour), newBackup("", 48*time.Hour)
+	rgnNew, rgnOld := newBackup(region, time.Hour), newBackup(region, 48*time.Hour)
+
+	mgmtBackup := &kcmv1.ManagementBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: scheduleName},
+		Spec: kcmv1.ManagementBackupSpec{
+			Schedule:  "@every 1h",
+			Retention: &kcmv1.ManagementBackupRetention{TTL: &metav1.Duration{Duration: 24 * time.Hour}},
+		},
+		Status: kcmv1.ManagementBackupStatus{
+			ManagementBackupSingleStatus: kcmv1.ManagementBackupSingleStatus{LastBackupName: mgmtNew.Name},
+			RegionsLastBackups:           []kcmv1.ManagementBackupSingleStatus{{Region: region, LastBackupName: rgnNew.Name, PrunedBackupsCount: 1}},
+		},
+	}
+
+	// regional backups are synced to the management cluster via the shared BSL
+	mgmtCl := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(mgmtNew, mgmtOld, rgnNew, rgnOld).Build()
+	rgnCl := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(rgnNew.DeepCopy(), rgnOld.DeepCopy()).Build()
+
+	r := NewReconciler(mgmtCl, systemNamespace)
+	s := &scope{
+		mgmtBackup:    mgmtBackup,
+		regionClients: map[string]loadedClient{region: {cl: rgnCl, loaded: true}},
+	}
+
+	r.pruneAllScheduledBackups(t.Context(), s, now)
+
+	if got := mgmtBackup.Status.PrunedBackups; !slices.Equal(got, []string{mgmtOld.Name}) {
+		t.Errorf("management pruned backups = %v, want %v", got, []string{mgmtOld.Name})
+	}
+	if got := mgmtBackup.Status.PrunedBackupsCount; got != 1 {
+		t.Errorf("management pruned backups count = %d, want 1", got)
+	}
+	if got := mgmtBackup.Status.RegionsLastBackups[0].PrunedBackups; !slices.Equal(got, []string{rgnOld.Name}) {
+		t.Errorf("regional pruned backups = %v, want %v", got, []string{rgnOld.Name})
+	}
+	if got := mgmtBackup.Status.RegionsLastBackups[0].PrunedBackupsCount; got != 2 {
+		t.Errorf("regional pruned backups count = %d, want 2", got)
+	}
+
+	// the deletion has already been requested, hence must not be counted twice
+	r.pruneAllScheduledBackups(t.Context(), s, now)
+
+	if got := mgmtBackup.Status.PrunedBackupsCount; got != 1 {
+		t.Errorf("management pruned backups count after the retry = %d, want 1", got)
+	}
+	if got := mgmtBackup.Status.RegionsLastBackups[0].PrunedBackupsCount; got != 2 {
+		t.Errorf("regional pruned backups count after the retry = %d, want 2", got)
+	}
+
+	for _, tc := range []struct {
+		cl   client.Client
+		want string
+	}{
+		{mgmtCl, mgmtOld.Name},
+		{rgnCl, rgnOld.Name},
+	} {
+		dbrs := new(velerov1.DeleteBackupRequestList)
+		if err := tc.cl.List(t.Context(), dbrs); err != nil {
+			t.Fatalf("failed to list DeleteBackupRequests: %v", err)
+		}
+		if len(dbrs.Items) != 1 || dbrs.Items[0].Spec.BackupName != tc.want {
+			t.Errorf("unexpected DeleteBackupRequests %v, want a single one for the %s", dbrs.Items, tc.want)
+		}
+	}
+}
@@ -30,8 +30,10 @@ import (
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/metrics"
 	labelsutil "github.com/K0rdent/kcm/internal/util/labels"
 )
 
@@ -49,6 +51,16 @@ func (r *Reconciler) ReconcileBackup(ctx context.Context, mgmtBackup *kcmv1.Mana
 		return ctrl.Result{}, nil
 	}
 
+	if mgmtBackup.Spec.Scope == nil {
+		if err := r.Finalize(ctx, mgmtBackup); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if controllerutil.AddFinalizer(mgmtBackup, kcmv1.ManagementBackupScopeFinalizer) {
+		if err := r.mgmtCl.Update(ctx, mgmtBackup); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to ManagementBackup %s: %w", mgmtBackup.Name, err)
+		}
+	}
+
 	s, err := getScope(ctx, r.mgmtCl, r.systemNamespace, r.regionalFactory)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to construct backup scope: %w", err)
@@ -97,8 +109,8 @@ func allBackupsInitiated(mgmtBackup *kcmv1.ManagementBackup) bool {
 
 // updateAllBackupsStatus updates the status of all existing backups (management and regional)
 // setting exclusively the LastBackup field.
-// It retrieves the current status of each backup from the Velero API and updates
-// the ManagementBackup status accordingly.
+// It retrieves the current status of each backup from the Velero API, tracks the backup metrics,
+// verifies the finished backups if requested and updates the ManagementBackup status accordingly.
 func (r *Reconciler) updateAllBackupsStatus(ctx context.Context, s *scope) (ctrl.Result, error) {
 	mgmtBackup := s.mgmtBackup
 	l := ctrl.LoggerFrom(ctx)
@@ -123,6 +135,9 @@ func (r *Reconciler) updateAllBackupsStatus(ctx context.Context, s *scope) (ctrl
 			mgmtBackup.Status.LastBackup = &veleroBackup.Status
 			updateStatus = true
 		}
+
+		// metrics are tracked unconditionally to be restored after the controller restart
+		metrics.TrackMetricManagementBackup(ctx, mgmtBackup.Name, "", &veleroBackup.Status)
 	}
 
 	for region, loadedCl := range s.regionClients {
@@ -167,6 +182,27 @@ func (r *Reconciler) updateAllBackupsStatus(ctx context.Context, s *scope) (ctrl
 			mgmtBackup.Status.RegionsLastBackups[regionBackupStatusIdx].LastBackup = &veleroBackup.Status
 			updateStatus = true
 		}
+
+		metrics.TrackMetricManagementBackup(ctx, mgmtBackup.Name, region, &veleroBackup.Status)
+	}
+
+	var (
+		result          ctrl.Result
+		verificationErr error
+	)
+	if !mgmtBackup.IsVerified() {
+		var pending bool
+		pending, verificationErr = r.verifyAllBackups(ctx, s)
+		if pending {
+			result.RequeueAfter = verificationPollInterval
+		}
+		updateStatus = true
+	}
+
+	for _, status := range append([]kcmv1.ManagementBackupSingleStatus{mgmtBackup.Status.ManagementBackupSingleStatus}, mgmtBackup.Status.RegionsLastBackups...) {
+		if status.VerifiedBackupName != "" {
+			metrics.TrackMetricManagementBackupMissingItems(ctx, mgmtBackup.Name, status.Region, len(status.MissingItems))
+		}
 	}
 
 	if updateStatus {
@@ -175,7 +211,12 @@ func (r *Reconciler) updateAllBackupsStatus(ctx context.Context, s *scope) (ctrl
 		}
 	}
 
-	return ctrl.Result{}, nil
+	// errors are requeued with the backoff of the controller rate limiter
+	if verificationErr != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to verify backups: %w", verificationErr)
+	}
+
+	return result, nil
 }
 
 // backupStatusEqual compares two Velero backup statuses for equality.
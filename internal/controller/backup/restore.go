@@ -0,0 +1,348 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
+)
+
+const (
+	// restorePollInterval is the interval to check the progress of the velero Restore objects.
+	restorePollInterval = 10 * time.Second
+	// restorePreconditionsRetryInterval is the interval to re-validate not (yet) met preconditions.
+	restorePreconditionsRetryInterval = 30 * time.Second
+)
+
+// ReconcileRestore is the main reconciliation function for ManagementRestore resources.
+// It validates the preconditions, then restores the management cluster from the
+// corresponding backup, and after that restores each of the regions one by one.
+func (r *Reconciler) ReconcileRestore(ctx context.Context, mgmtRestore *kcmv1.ManagementRestore) (_ ctrl.Result, err error) {
+	if mgmtRestore == nil || mgmtRestore.IsFinished() {
+		return ctrl.Result{}, nil
+	}
+
+	defer func() {
+		mgmtRestore.Status.ObservedGeneration = mgmtRestore.Generation
+		if statusErr := r.mgmtCl.Status().Update(ctx, mgmtRestore); statusErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update ManagementRestore %s status: %w", mgmtRestore.Name, statusErr))
+		}
+	}()
+
+	if mgmtRestore.Status.RestoreName == "" {
+		if requeue, err := r.validateRestorePreconditions(ctx, mgmtRestore); err != nil || requeue {
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: restorePreconditionsRetryInterval}, nil
+		}
+
+		if mgmtRestore.Status.Phase == kcmv1.ManagementRestorePhaseFailed {
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// management always goes first
+	if done, err := r.reconcileSingleRestore(ctx, r.mgmtCl, mgmtRestore, &mgmtRestore.Status.ManagementRestoreSingleStatus); err != nil || !done {
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: restorePollInterval}, nil
+	}
+
+	if mgmtRestore.Status.IsFailed() {
+		mgmtRestore.Status.Phase = kcmv1.ManagementRestorePhaseFailed
+		return ctrl.Result{}, nil
+	}
+
+	// regions are restored strictly one after another
+	l := ctrl.LoggerFrom(ctx)
+	for i := range mgmtRestore.Status.Regions {
+		regionStatus := &mgmtRestore.Status.Regions[i]
+		if isRegionRestoreDone(regionStatus) {
+			continue
+		}
+
+		rgn := new(kcmv1.Region)
+		if err := r.mgmtCl.Get(ctx, client.ObjectKey{Name: regionStatus.Region}, rgn); err != nil {
+			if apierrors.IsNotFound(err) {
+				l.Info("Region not found, skipping regional restore", "region", regionStatus.Region)
+				regionStatus.Error = fmt.Sprintf("Region %s not found", regionStatus.Region)
+				continue
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to get Region %s: %w", regionStatus.Region, err)
+		}
+
+		rgnClient, _, err := r.regionalFactory(ctx, r.mgmtCl, r.systemNamespace, rgn, schemeutil.Regional)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get regional client for the Region %s: %w", rgn.Name, err)
+		}
+
+		done, err := r.reconcileSingleRestore(ctx, rgnClient, mgmtRestore, regionStatus)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !done {
+			return ctrl.Result{RequeueAfter: restorePollInterval}, nil
+		}
+	}
+
+	mgmtRestore.Status.Phase = kcmv1.ManagementRestorePhaseCompleted
+	for _, rs := range mgmtRestore.Status.Regions {
+		if rs.IsFailed() || rs.RestoreName == "" {
+			mgmtRestore.Status.Phase = kcmv1.ManagementRestorePhaseFailed
+			break
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// isRegionRestoreDone checks whether the regional restore either has been finished
+// or could not have been even started.
+func isRegionRestoreDone(s *kcmv1.ManagementRestoreSingleStatus) bool {
+	return s.IsFinished() || (s.RestoreName == "" && s.Error != "")
+}
+
+// reconcileSingleRestore creates a velero Restore in the cluster of the given client if it has not been created yet,
+// and updates the given status with the current state of the Restore. Returns true if the Restore is finished.
+func (r *Reconciler) reconcileSingleRestore(ctx context.Context, cl client.Client, mgmtRestore *kcmv1.ManagementRestore, status *kcmv1.ManagementRestoreSingleStatus) (bool, error) {
+	if status.IsFinished() {
+		return true, nil
+	}
+
+	l := ctrl.LoggerFrom(ctx)
+
+	if status.RestoreName == "" {
+		restoreName := mgmtRestore.Name
+		if status.Region != "" {
+			restoreName += "-" + status.Region
+		}
+
+		veleroRestore := &velerov1.Restore{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: velerov1.SchemeGroupVersion.String(),
+				Kind:       "Restore",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      restoreName,
+				Namespace: r.systemNamespace,
+				Labels:    map[string]string{kcmv1.ManagementRestoreNameLabel: mgmtRestore.Name},
+			},
+			Spec: velerov1.RestoreSpec{
+				BackupName: status.BackupName,
+			},
+		}
+		if status.Region != "" {
+			veleroRestore.Labels[kcmv1.KCMRegionLabelKey] = status.Region
+		}
+
+		if err := cl.Create(ctx, veleroRestore); client.IgnoreAlreadyExists(err) != nil { // avoid err-loop on status update error
+			return false, fmt.Errorf("failed to create velero Restore %s: %w", restoreName, err)
+		}
+
+		l.Info("Created velero Restore", "restore", restoreName, "backup", status.BackupName, "region", status.Region)
+		status.RestoreName = restoreName
+		status.Error = ""
+	}
+
+	veleroRestore := new(velerov1.Restore)
+	if err := cl.Get(ctx, client.ObjectKey{Name: status.RestoreName, Namespace: r.systemNamespace}, veleroRestore); err != nil {
+		if apierrors.IsNotFound(err) { // might not be in the cache yet
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get velero Restore %s: %w", status.RestoreName, err)
+	}
+
+	status.Restore = &veleroRestore.Status
+	switch {
+	case status.IsFailed():
+		reasons := slices.DeleteFunc(append([]string{veleroRestore.Status.FailureReason}, veleroRestore.Status.ValidationErrors...), func(s string) bool { return s == "" })
+		status.Error = fmt.Sprintf("restore %s has failed: %s", status.RestoreName, strings.Join(reasons, "; "))
+	case veleroRestore.Status.Phase == velerov1.RestorePhasePartiallyFailed:
+		status.Error = fmt.Sprintf("restore %s has partially failed with %d errors", status.RestoreName, veleroRestore.Status.Errors)
+	}
+
+	return status.IsFinished(), nil
+}
+
+// validateRestorePreconditions finds the backups to restore from and validates
+// them against the current state of the management cluster.
+// On success, fills the backup names for the management and each region to restore.
+// Returns true if the preconditions are not (yet) met and should be re-validated later.
+// A terminal failure is denoted by the Failed phase.
+func (r *Reconciler) validateRestorePreconditions(ctx context.Context, mgmtRestore *kcmv1.ManagementRestore) (bool, error) {
+	mgmtRestore.Status.Phase = kcmv1.ManagementRestorePhasePending
+
+	veleroBackups := new(velerov1.BackupList)
+	if err := r.mgmtCl.List(ctx, veleroBackups, client.InNamespace(r.systemNamespace)); err != nil {
+		if isMetaError(err) {
+			setRestorePreconditions(mgmtRestore, metav1.ConditionFalse, kcmv1.FailedReason, "Probably Velero is not installed: "+err.Error())
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to list velero Backups in management cluster: %w", err)
+	}
+
+	mgmtBackup := new(kcmv1.ManagementBackup)
+	if err := r.mgmtCl.Get(ctx, client.ObjectKey{Name: mgmtRestore.Spec.ManagementBackup}, mgmtBackup); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to get ManagementBackup %s: %w", mgmtRestore.Spec.ManagementBackup, err)
+	}
+
+	backup, ok := getBackupToRestore(mgmtRestore, mgmtBackup, veleroBackups.Items)
+	if !ok {
+		setRestorePreconditions(mgmtRestore, metav1.ConditionFalse, kcmv1.ManagementRestoreBackupNotFoundReason,
+			fmt.Sprintf("No velero Backup produced by the ManagementBackup %s has been found", mgmtRestore.Spec.ManagementBackup))
+		return true, nil
+	}
+
+	switch backup.Status.Phase {
+	case velerov1.BackupPhaseCompleted, velerov1.BackupPhasePartiallyFailed:
+	case velerov1.BackupPhaseFailed, velerov1.BackupPhaseFailedValidation, velerov1.BackupPhaseDeleting:
+		setRestorePreconditions(mgmtRestore, metav1.ConditionFalse, kcmv1.ManagementRestoreBackupNotCompletedReason,
+			fmt.Sprintf("velero Backup %s is in the %s phase", backup.Name, backup.Status.Phase))
+		mgmtRestore.Status.Phase = kcmv1.ManagementRestorePhaseFailed
+		return false, nil
+	default:
+		setRestorePreconditions(mgmtRestore, metav1.ConditionFalse, kcmv1.ManagementRestoreBackupNotCompletedReason,
+			fmt.Sprintf("velero Backup %s has not been completed yet", backup.Name))
+		return true, nil
+	}
+
+	if !mgmtRestore.Spec.SkipReleaseValidation {
+		mgmt := new(kcmv1.Management)
+		if err := r.mgmtCl.Get(ctx, client.ObjectKey{Name: kcmv1.ManagementName}, mgmt); err != nil {
+			return false, fmt.Errorf("failed to get Management: %w", err)
+		}
+
+		if backupRelease, currentRelease := backup.Labels[kcmv1.ManagementBackupReleaseLabel], managementRelease(mgmt); backupRelease != "" && backupRelease != currentRelease {
+			setRestorePreconditions(mgmtRestore, metav1.ConditionFalse, kcmv1.ManagementRestoreReleaseMismatchReason,
+				fmt.Sprintf("velero Backup %s has been created with the Release %s, but the current Release is %s", backup.Name, backupRelease, currentRelease))
+			mgmtRestore.Status.Phase = kcmv1.ManagementRestorePhaseFailed
+			return false, nil
+		}
+	}
+
+	mgmtRestore.Status.BackupName = backup.Name
+	mgmtRestore.Status.Regions = mgmtRestore.Status.Regions[:0]
+	for _, region := range getRegionsToRestore(mgmtRestore, mgmtBackup, backup.Name, veleroBackups.Items) {
+		mgmtRestore.Status.Regions = append(mgmtRestore.Status.Regions, kcmv1.ManagementRestoreSingleStatus{
+			Region:     region,
+			BackupName: regionalBackupName(mgmtRestore.Spec.ManagementBackup, backup.Name, region),
+		})
+	}
+
+	setRestorePreconditions(mgmtRestore, metav1.ConditionTrue, kcmv1.SucceededReason, "Restoring from velero Backup "+backup.Name)
+	mgmtRestore.Status.Phase = kcmv1.ManagementRestorePhaseInProgress
+
+	return false, nil
+}
+
+func setRestorePreconditions(mgmtRestore *kcmv1.ManagementRestore, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&mgmtRestore.Status.Conditions, metav1.Condition{
+		Type:               kcmv1.ManagementRestorePreconditionsCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: mgmtRestore.Generation,
+	})
+}
+
+// getBackupToRestore returns the management velero Backup to restore from.
+// It is either the explicitly set one, or the last one from the ManagementBackup status,
+// or the one found among the given backups if the ManagementBackup object does not exist.
+func getBackupToRestore(mgmtRestore *kcmv1.ManagementRestore, mgmtBackup *kcmv1.ManagementBackup, backups []velerov1.Backup) (*velerov1.Backup, bool) {
+	mgmtBackupName := mgmtRestore.Spec.ManagementBackup
+
+	name := mgmtRestore.Spec.BackupName
+	if name == "" {
+		name = mgmtBackup.Status.LastBackupName
+	}
+
+	if name == "" {
+		if idx := slices.IndexFunc(backups, func(b velerov1.Backup) bool { return b.Name == mgmtBackupName }); idx > -1 {
+			return &backups[idx], true // single backup
+		}
+
+		return getMostRecentlyProducedBackup(mgmtBackupName, backups, "")
+	}
+
+	idx := slices.IndexFunc(backups, func(b velerov1.Backup) bool { return b.Name == name })
+	if idx == -1 {
+		return nil, false
+	}
+
+	return &backups[idx], true
+}
+
+// getRegionsToRestore returns the regions to restore, either explicitly set or the regions
+// having a backup produced alongside with the given management backup, in the alphabetical order.
+func getRegionsToRestore(mgmtRestore *kcmv1.ManagementRestore, mgmtBackup *kcmv1.ManagementBackup, backupName string, backups []velerov1.Backup) []string {
+	if len(mgmtRestore.Spec.Regions) > 0 {
+		return mgmtRestore.Spec.Regions
+	}
+
+	var regions []string
+	// because we utilize a single BSL across clusters (HACK), regional backups
+	// should also exist on the mgmt cluster
+	for _, b := range backups {
+		region := b.Labels[kcmv1.KCMRegionLabelKey]
+		if region != "" && b.Name == regionalBackupName(mgmtRestore.Spec.ManagementBackup, backupName, region) {
+			regions = append(regions, region)
+		}
+	}
+
+	if mgmtBackup.Status.LastBackupName == backupName {
+		for _, rb := range mgmtBackup.Status.RegionsLastBackups {
+			if rb.Region != "" && rb.LastBackupName != "" {
+				regions = append(regions, rb.Region)
+			}
+		}
+	}
+
+	slices.Sort(regions)
+	return slices.Compact(regions)
+}
+
+// regionalBackupName returns the name of the regional backup produced alongside with the given management backup.
+func regionalBackupName(mgmtBackupName, backupName, region string) string {
+	if backupName == mgmtBackupName { // single backup
+		return mgmtBackupName + "-" + region
+	}
+
+	return mgmtBackupName + "-" + region + "-" + strings.TrimPrefix(backupName, mgmtBackupName+"-")
+}
+
+// managementRelease returns the current [github.com/K0rdent/kcm/api/v1beta1.Release] name of the given Management.
+func managementRelease(mgmt *kcmv1.Management) string {
+	if mgmt.Status.Release != "" {
+		return mgmt.Status.Release
+	}
+
+	return mgmt.Spec.Release
+}
@@ -0,0 +1,189 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
+)
+
+func Test_regionalBackupName(t *testing.T) {
+	for _, tc := range []struct {
+		backupName, want string
+	}{
+		{"mb", "mb-rgn"},
+		{"mb-20260102030405", "mb-rgn-20260102030405"},
+	} {
+		if got := regionalBackupName("mb", tc.backupName, "rgn"); got != tc.want {
+			t.Errorf("regionalBackupName(%q) = %q, want %q", tc.backupName, got, tc.want)
+		}
+	}
+}
+
+func Test_ReconcileRestore(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		scheduleName    = "test-schedule"
+		release         = "kcm-1-0-0"
+	)
+
+	scheme := runtime.NewScheme()
+	for _, f := range []func(*runtime.Scheme) error{kcmv1.AddToScheme, velerov1.AddToScheme} {
+		if err := f(scheme); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+
+	ts := time.Now().UTC().Add(-time.Hour)
+	mb := &kcmv1.ManagementBackup{ObjectMeta: metav1.ObjectMeta{Name: scheduleName}}
+
+	newBackup := func(region, release string) *velerov1.Backup {
+		b := &velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: mb.TimestampedBackupName(ts, region), Namespace: systemNamespace},
+			Status:     velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted},
+		}
+		withScheduleLabel(scheduleName)(b)
+		withRegionLabel(region)(b)
+		withReleaseLabel(release)(b)
+		return b
+	}
+
+	newClients := func(backupRelease string) (client.Client, client.Client) {
+		mgmtCl := clientfake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&kcmv1.ManagementRestore{}).
+			WithObjects(
+				&kcmv1.Management{
+					ObjectMeta: metav1.ObjectMeta{Name: kcmv1.ManagementName},
+					Spec:       kcmv1.ManagementSpec{Release: release},
+				},
+				&kcmv1.Region{ObjectMeta: metav1.ObjectMeta{Name: "rgn-b"}},
+				&kcmv1.ManagementRestore{
+					ObjectMeta: metav1.ObjectMeta{Name: "restore"},
+					Spec:       kcmv1.ManagementRestoreSpec{ManagementBackup: scheduleName},
+				},
+				// ManagementBackup object does not exist, regional backups are synced via the shared BSL
+				newBackup("", backupRelease), newBackup("rgn-a", backupRelease), newBackup("rgn-b", backupRelease),
+			).Build()
+		rgnCl := clientfake.NewClientBuilder().WithScheme(scheme).Build()
+		return mgmtCl, rgnCl
+	}
+
+	reconcile := func(t *testing.T, r *Reconciler, cl client.Client) *kcmv1.ManagementRestore {
+		t.Helper()
+
+		mr := new(kcmv1.ManagementRestore)
+		if err := cl.Get(t.Context(), client.ObjectKey{Name: "restore"}, mr); err != nil {
+			t.Fatalf("failed to get ManagementRestore: %v", err)
+		}
+		if _, err := r.ReconcileRestore(t.Context(), mr); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return mr
+	}
+
+	completeRestore := func(t *testing.T, cl client.Client, name string) {
+		t.Helper()
+
+		restore := new(velerov1.Restore)
+		if err := cl.Get(t.Context(), client.ObjectKey{Name: name, Namespace: systemNamespace}, restore); err != nil {
+			t.Fatalf("failed to get velero Restore %s: %v", name, err)
+		}
+		restore.Status.Phase = velerov1.RestorePhaseCompleted
+		if err := cl.Update(t.Context(), restore); err != nil {
+			t.Fatalf("failed to update velero Restore %s: %v", name, err)
+		}
+	}
+
+	t.Run("release mismatch", func(t *testing.T) {
+		mgmtCl, _ := newClients("kcm-0-9-0")
+		r := NewReconciler(mgmtCl, systemNamespace)
+
+		mr := reconcile(t, r, mgmtCl)
+		if mr.Status.Phase != kcmv1.ManagementRestorePhaseFailed {
+			t.Errorf("phase = %s, want %s", mr.Status.Phase, kcmv1.ManagementRestorePhaseFailed)
+		}
+		if cond := apimeta.FindStatusCondition(mr.Status.Conditions, kcmv1.ManagementRestorePreconditionsCondition); cond == nil || cond.Reason != kcmv1.ManagementRestoreReleaseMismatchReason {
+			t.Errorf("unexpected preconditions condition %+v", cond)
+		}
+
+		restores := new(velerov1.RestoreList)
+		if err := mgmtCl.List(t.Context(), restores); err != nil {
+			t.Fatalf("failed to list velero Restores: %v", err)
+		}
+		if len(restores.Items) != 0 {
+			t.Errorf("expected no velero Restores, got %d", len(restores.Items))
+		}
+	})
+
+	t.Run("management then regions in order", func(t *testing.T) {
+		mgmtCl, rgnCl := newClients(release)
+		r := NewReconciler(mgmtCl, systemNamespace, WithRegionalClientFactory(
+			func(context.Context, client.Client, string, *kcmv1.Region, schemeutil.RegionalScheme) (client.Client, *rest.Config, error) {
+				return rgnCl, nil, nil
+			}),
+		)
+
+		mr := reconcile(t, r, mgmtCl)
+		if mr.Status.Phase != kcmv1.ManagementRestorePhaseInProgress {
+			t.Fatalf("phase = %s, want %s", mr.Status.Phase, kcmv1.ManagementRestorePhaseInProgress)
+		}
+		if mr.Status.BackupName != mb.TimestampedBackupName(ts, "") || mr.Status.RestoreName != "restore" {
+			t.Errorf("unexpected management restore status %+v", mr.Status.ManagementRestoreSingleStatus)
+		}
+		if regions := []string{mr.Status.Regions[0].Region, mr.Status.Regions[1].Region}; !slices.Equal(regions, []string{"rgn-a", "rgn-b"}) {
+			t.Errorf("regions = %v, want [rgn-a rgn-b]", regions)
+		}
+
+		// regions must wait for the management restore
+		mr = reconcile(t, r, mgmtCl)
+		if mr.Status.Regions[0].RestoreName != "" || mr.Status.Regions[1].RestoreName != "" {
+			t.Errorf("regional restores started before the management one has finished")
+		}
+
+		completeRestore(t, mgmtCl, "restore")
+
+		// rgn-a has no Region object, rgn-b goes next
+		mr = reconcile(t, r, mgmtCl)
+		if mr.Status.Regions[0].Error == "" {
+			t.Errorf("expected error for the region without Region object")
+		}
+		if want := mb.TimestampedBackupName(ts, "rgn-b"); mr.Status.Regions[1].RestoreName != "restore-rgn-b" || mr.Status.Regions[1].BackupName != want {
+			t.Errorf("unexpected regional restore status %+v", mr.Status.Regions[1])
+		}
+
+		completeRestore(t, rgnCl, "restore-rgn-b")
+
+		mr = reconcile(t, r, mgmtCl)
+		if mr.Status.Phase != kcmv1.ManagementRestorePhaseFailed {
+			t.Errorf("phase = %s, want %s", mr.Status.Phase, kcmv1.ManagementRestorePhaseFailed)
+		}
+		if mr.Status.Regions[1].Restore == nil || mr.Status.Regions[1].Restore.Phase != velerov1.RestorePhaseCompleted {
+			t.Errorf("unexpected regional restore status %+v", mr.Status.Regions[1])
+		}
+	})
+}
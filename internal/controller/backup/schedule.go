@@ -88,8 +88,9 @@ func anyBackupInitiated(mgmtBackup *kcmv1.ManagementBackup) bool {
 }
 
 // createAllScheduledBackups creates scheduled backups for management cluster and all regions.
-// It tracks which regions have been processed to avoid duplicates, and updates the
-// ManagementBackup status with backup names, timestamps, and next attempt time.
+// It tracks which regions have been processed to avoid duplicates, prunes outdated backups
+// according to the retention policy, and updates the ManagementBackup status
+// with backup names, timestamps, pruned backups, and next attempt time.
 func (r *Reconciler) createAllScheduledBackups(ctx context.Context, s *scope, nextAttemptTime time.Time) (ctrl.Result, error) {
 	mgmtBackup := s.mgmtBackup
 	now := time.Now().UTC()
@@ -100,10 +101,15 @@ func (r *Reconciler) createAllScheduledBackups(ctx context.Context, s *scope, ne
 		mgmtBackup.Status.RegionsLastBackups = []kcmv1.ManagementBackupSingleStatus{}
 	}
 
+	if err := r.ensureScopeLabels(ctx, s); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure scoped backup labels: %w", err)
+	}
+
 	mgmtBackupName := mgmtBackup.TimestampedBackupName(now, "")
 	if err := r.createNewVeleroBackup(ctx, r.mgmtCl, "", s, mgmtBackupName,
 		withStorageLocation(mgmtBackup.Spec.StorageLocation),
 		withScheduleLabel(mgmtBackup.Name),
+		withReleaseLabel(s.release),
 	); err != nil {
 		if isMetaError(err) {
 			return r.propagateMetaError(ctx, "", mgmtBackup, err.Error())
@@ -130,6 +136,7 @@ func (r *Reconciler) createAllScheduledBackups(ctx context.Context, s *scope, ne
 		if err := r.createNewVeleroBackup(ctx, loadedCl.cl, region, s, backupName,
 			withRegionLabel(region),
 			withStorageLocation(mgmtBackup.Spec.StorageLocation),
+			withReleaseLabel(s.release),
 			withScheduleLabel(mgmtBackup.Name),
 		); err != nil {
 			l.Error(err, "failed to create scheduled regional backup", "region", region, "storage_location", mgmtBackup.Spec.StorageLocation)
@@ -173,6 +180,8 @@ func (r *Reconciler) createAllScheduledBackups(ctx context.Context, s *scope, ne
 		}
 	}
 
+	r.pruneAllScheduledBackups(ctx, s, now)
+
 	if err := r.mgmtCl.Status().Update(ctx, mgmtBackup); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update ManagementBackup %s status: %w", mgmtBackup.Name, err)
 	}
@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,7 +29,7 @@ import (
 )
 
 // RegionalClientFactory is a function type for creating regional clients
-type RegionalClientFactory func(context.Context, client.Client, string, *kcmv1.Region, func() (*runtime.Scheme, error)) (client.Client, *rest.Config, error)
+type RegionalClientFactory func(context.Context, client.Client, string, *kcmv1.Region, schemeutil.RegionalScheme) (client.Client, *rest.Config, error)
 
 // defaultRegionalClientFactory uses the real implementation
 var defaultRegionalClientFactory RegionalClientFactory = kubeutil.GetRegionalClient
@@ -41,6 +40,8 @@ type (
 		regionClients      map[string]loadedClient
 		mgmtBackup         *kcmv1.ManagementBackup
 		clusterDeployments []*kcmv1.ClusterDeployment
+		scoped             *scopedClosure
+		release            string
 	}
 
 	// loadedClient holds the Client instance and an indicator whether the client
@@ -85,8 +86,14 @@ func getScope(ctx context.Context, mgmtCl client.Client, systemNamespace string,
 		}
 	}
 
+	mgmt := new(kcmv1.Management)
+	if err := mgmtCl.Get(ctx, client.ObjectKey{Name: kcmv1.ManagementName}, mgmt); client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get Management: %w", err)
+	}
+
 	cs := &scope{
 		clusterDeployments: clusterDeployments,
+		release:            managementRelease(mgmt),
 	}
 
 	regionClients := make(map[string]loadedClient)
@@ -124,7 +131,7 @@ func getScope(ctx context.Context, mgmtCl client.Client, systemNamespace string,
 			return nil, fmt.Errorf("failed to get Region %s: %w", regionName, err)
 		}
 
-		regionalCl, _, err := clientFactory(ctx, mgmtCl, systemNamespace, rgn, schemeutil.GetRegionalScheme)
+		regionalCl, _, err := clientFactory(ctx, mgmtCl, systemNamespace, rgn, schemeutil.Regional)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get regional client for the Region %s: %w", rgn.Name, err)
 		}
@@ -0,0 +1,399 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/providerinterface"
+)
+
+// scopedClosure holds the ClusterDeployments selected by a scoped ManagementBackup
+// and the namespaces of the objects in their dependency closure.
+type scopedClosure struct {
+	clusterDeployments []*kcmv1.ClusterDeployment
+	namespaces         []string
+}
+
+// ensureScopeLabels computes the dependency closure of the ClusterDeployments selected by the scoped ManagementBackup,
+// labels all of the objects in the closure with the scope label and removes the label from the objects left the closure.
+// It also limits the regional clients to the regions of the selected ClusterDeployments only.
+// Does nothing if the ManagementBackup is not scoped.
+func (r *Reconciler) ensureScopeLabels(ctx context.Context, s *scope) error {
+	scopeSpec := s.mgmtBackup.Spec.Scope
+	if scopeSpec == nil {
+		return nil
+	}
+
+	cds, err := selectScopedClusterDeployments(scopeSpec, s.clusterDeployments)
+	if err != nil {
+		return fmt.Errorf("failed to select ClusterDeployments: %w", err)
+	}
+
+	closure := make(map[string]client.Object)
+	for _, cd := range cds {
+		objs, err := r.collectClusterDeploymentClosure(ctx, cd)
+		if err != nil {
+			return fmt.Errorf("failed to collect dependencies of the ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+		}
+
+		for _, o := range objs {
+			id, err := objectID(r.mgmtCl, o)
+			if err != nil {
+				return err
+			}
+			closure[id] = o
+		}
+	}
+
+	scopeLabelKey := s.mgmtBackup.ScopeLabelKey()
+	namespaces := make([]string, 0, len(closure))
+	for _, o := range closure {
+		if err := patchScopeLabel(ctx, r.mgmtCl, o, scopeLabelKey, true); err != nil {
+			return err
+		}
+
+		if o.GetNamespace() != "" {
+			namespaces = append(namespaces, o.GetNamespace())
+		}
+	}
+
+	if err := r.releaseScopeLabels(ctx, closure, scopeLabelKey); err != nil {
+		return err
+	}
+
+	slices.Sort(namespaces)
+	s.scoped = &scopedClosure{
+		clusterDeployments: cds,
+		namespaces:         slices.Compact(namespaces),
+	}
+
+	for region := range s.regionClients {
+		if !slices.ContainsFunc(cds, func(cd *kcmv1.ClusterDeployment) bool { return cd.Status.Region == region }) {
+			delete(s.regionClients, region)
+		}
+	}
+
+	return nil
+}
+
+// Finalize removes the scope label from all of the objects and the scope finalizer from the given ManagementBackup
+// once it is either being deleted or not scoped anymore. Does nothing if the ManagementBackup has no scope finalizer.
+func (r *Reconciler) Finalize(ctx context.Context, mgmtBackup *kcmv1.ManagementBackup) error {
+	if !controllerutil.ContainsFinalizer(mgmtBackup, kcmv1.ManagementBackupScopeFinalizer) {
+		return nil
+	}
+
+	if err := r.releaseScopeLabels(ctx, nil, mgmtBackup.ScopeLabelKey()); err != nil {
+		return fmt.Errorf("failed to remove scope labels of ManagementBackup %s: %w", mgmtBackup.Name, err)
+	}
+
+	patch := client.MergeFrom(mgmtBackup.DeepCopy())
+	controllerutil.RemoveFinalizer(mgmtBackup, kcmv1.ManagementBackupScopeFinalizer)
+	if err := r.mgmtCl.Patch(ctx, mgmtBackup, patch); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to remove finalizer from ManagementBackup %s: %w", mgmtBackup.Name, err)
+	}
+	return nil
+}
+
+// releaseScopeLabels removes the scope label from the objects which are not in the given closure anymore.
+func (r *Reconciler) releaseScopeLabels(ctx context.Context, closure map[string]client.Object, scopeLabelKey string) error {
+	lists := []client.ObjectList{
+		new(kcmv1.ClusterDeploymentList),
+		new(kcmv1.CredentialList),
+		new(kcmv1.ServiceSetList),
+		new(corev1.SecretList),
+		new(corev1.ConfigMapList),
+	}
+
+	// ClusterIdentity kinds and the kinds of their references are not known beforehand,
+	// so the ones defined by the ProviderInterfaces and the ones in the current closure are checked
+	identityGVKs, err := r.clusterIdentityGVKs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, o := range closure {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			identityGVKs[u.GroupVersionKind()] = struct{}{}
+		}
+	}
+	for gvk := range identityGVKs {
+		list := new(unstructured.UnstructuredList)
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		lists = append(lists, list)
+	}
+
+	var errs error
+	for _, list := range lists {
+		if err := r.mgmtCl.List(ctx, list, client.MatchingLabels{scopeLabelKey: "true"}); err != nil {
+			if apimeta.IsNoMatchError(err) { // the provider of the kind is not installed
+				continue
+			}
+			errs = errors.Join(errs, fmt.Errorf("failed to list %T with the scope label: %w", list, err))
+			continue
+		}
+
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to extract list items: %w", err))
+			continue
+		}
+
+		for _, item := range items {
+			o, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			id, err := objectID(r.mgmtCl, o)
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+
+			if _, ok := closure[id]; ok {
+				continue
+			}
+
+			errs = errors.Join(errs, patchScopeLabel(ctx, r.mgmtCl, o, scopeLabelKey, false))
+		}
+	}
+
+	return errs
+}
+
+// clusterIdentityGVKs returns the kinds of the ClusterIdentity objects and their references defined
+// by the ProviderInterfaces except for Secrets and ConfigMaps.
+func (r *Reconciler) clusterIdentityGVKs(ctx context.Context) (map[schema.GroupVersionKind]struct{}, error) {
+	providerInterfaces := new(kcmv1.ProviderInterfaceList)
+	if err := r.mgmtCl.List(ctx, providerInterfaces); err != nil {
+		return nil, fmt.Errorf("failed to list ProviderInterfaces: %w", err)
+	}
+
+	gvks := make(map[schema.GroupVersionKind]struct{})
+	add := func(gvk kcmv1.GroupVersionKind) {
+		if gvk.Group == "" && (gvk.Kind == "Secret" || gvk.Kind == "ConfigMap") {
+			return
+		}
+		gvks[schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}] = struct{}{}
+	}
+	for _, pi := range providerInterfaces.Items {
+		for _, ci := range pi.Spec.ClusterIdentities {
+			add(ci.GroupVersionKind)
+			for _, ref := range ci.References {
+				add(ref.GroupVersionKind)
+			}
+		}
+	}
+
+	return gvks, nil
+}
+
+// collectClusterDeploymentClosure returns the ClusterDeployment and all of the objects
+// it depends on in the management cluster.
+func (r *Reconciler) collectClusterDeploymentClosure(ctx context.Context, cd *kcmv1.ClusterDeployment) ([]client.Object, error) {
+	l := ctrl.LoggerFrom(ctx)
+
+	result := []client.Object{cd}
+
+	if cd.Spec.Credential != "" {
+		cred := new(kcmv1.Credential)
+		if err := r.mgmtCl.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Credential}, cred); err != nil {
+			return nil, fmt.Errorf("failed to get Credential %s: %w", cd.Spec.Credential, err)
+		}
+		result = append(result, cred)
+
+		identityObjs, err := r.collectClusterIdentityClosure(ctx, cred)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, identityObjs...)
+	}
+
+	valuesFrom := make([]kcmv1.ValuesFrom, 0, len(cd.Spec.ServiceSpec.Services))
+	for _, svc := range cd.Spec.ServiceSpec.Services {
+		valuesFrom = append(valuesFrom, svc.ValuesFrom...)
+	}
+
+	serviceSets := new(kcmv1.ServiceSetList)
+	if err := r.mgmtCl.List(ctx, serviceSets, client.InNamespace(cd.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ServiceSets: %w", err)
+	}
+	for _, ss := range serviceSets.Items {
+		if ss.Spec.Cluster != cd.Name {
+			continue
+		}
+		result = append(result, &ss)
+
+		for _, svc := range ss.Spec.Services {
+			valuesFrom = append(valuesFrom, svc.ValuesFrom...)
+		}
+	}
+
+	for _, vf := range valuesFrom {
+		var obj client.Object
+		switch vf.Kind {
+		case "Secret":
+			obj = new(corev1.Secret)
+		case "ConfigMap":
+			obj = new(corev1.ConfigMap)
+		default:
+			continue
+		}
+
+		if err := r.mgmtCl.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: vf.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				l.V(1).Info("Services values source not found, skipping", "kind", vf.Kind, "name", vf.Name, "namespace", cd.Namespace)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %s %s/%s: %w", vf.Kind, cd.Namespace, vf.Name, err)
+		}
+		result = append(result, obj)
+	}
+
+	return result, nil
+}
+
+// collectClusterIdentityClosure returns the ClusterIdentity object referenced by the given Credential
+// along with the objects it references according to the ProviderInterface definitions.
+func (r *Reconciler) collectClusterIdentityClosure(ctx context.Context, cred *kcmv1.Credential) ([]client.Object, error) {
+	if cred.Spec.IdentityRef == nil {
+		return nil, nil
+	}
+
+	l := ctrl.LoggerFrom(ctx)
+
+	clIdty := new(unstructured.Unstructured)
+	clIdty.SetAPIVersion(cred.Spec.IdentityRef.APIVersion)
+	clIdty.SetKind(cred.Spec.IdentityRef.Kind)
+	if err := r.mgmtCl.Get(ctx, client.ObjectKey{Namespace: cred.Spec.IdentityRef.Namespace, Name: cred.Spec.IdentityRef.Name}, clIdty); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.Info("ClusterIdentity not found, skipping", "cluster identity", cred.Spec.IdentityRef.String())
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ClusterIdentity %s: %w", cred.Spec.IdentityRef.String(), err)
+	}
+
+	result := []client.Object{clIdty}
+
+	ci, err := providerinterface.FindClusterIdentity(ctx, r.mgmtCl, cred.Spec.IdentityRef)
+	if err != nil {
+		if errors.Is(err, providerinterface.ErrMissingClusterIdentityRef) {
+			return result, nil
+		}
+		return nil, fmt.Errorf("failed to get ClusterIdentity definition for %s: %w", cred.Spec.IdentityRef.String(), err)
+	}
+
+	for _, reference := range ci.References {
+		name, _, err := unstructured.NestedString(clIdty.Object, strings.Split(reference.NameFieldPath, ".")...)
+		if err != nil || name == "" {
+			l.Info("ClusterIdentity reference name not found, skipping", "cluster identity", cred.Spec.IdentityRef.String(), "path", reference.NameFieldPath)
+			continue
+		}
+
+		namespace := r.systemNamespace
+		if reference.NamespaceFieldPath != "" {
+			if ns, _, _ := unstructured.NestedString(clIdty.Object, strings.Split(reference.NamespaceFieldPath, ".")...); ns != "" {
+				namespace = ns
+			}
+		}
+
+		ref := new(unstructured.Unstructured)
+		ref.SetGroupVersionKind(schema.GroupVersionKind{Group: reference.Group, Version: reference.Version, Kind: reference.Kind})
+		if err := r.mgmtCl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, ref); err != nil {
+			if apierrors.IsNotFound(err) {
+				l.Info("ClusterIdentity reference not found, skipping", "kind", reference.Kind, "name", name, "namespace", namespace)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get ClusterIdentity reference %s %s/%s: %w", reference.Kind, namespace, name, err)
+		}
+		result = append(result, ref)
+	}
+
+	return result, nil
+}
+
+// selectScopedClusterDeployments returns ClusterDeployments matching any of the criteria of the given scope.
+func selectScopedClusterDeployments(scopeSpec *kcmv1.ManagementBackupScope, cds []*kcmv1.ClusterDeployment) ([]*kcmv1.ClusterDeployment, error) {
+	var result []*kcmv1.ClusterDeployment
+
+	for _, cd := range cds {
+		selected := slices.Contains(scopeSpec.Namespaces, cd.Namespace) ||
+			slices.ContainsFunc(scopeSpec.ClusterDeployments, func(ref kcmv1.ClusterDeploymentRef) bool {
+				return ref.Namespace == cd.Namespace && ref.Name == cd.Name
+			})
+
+		if !selected && scopeSpec.Selector != nil {
+			sel, err := metav1.LabelSelectorAsSelector(scopeSpec.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse selector: %w", err)
+			}
+			selected = sel.Matches(labels.Set(cd.Labels))
+		}
+
+		if selected {
+			result = append(result, cd)
+		}
+	}
+
+	return result, nil
+}
+
+// patchScopeLabel either sets or removes the scope label on the given object.
+func patchScopeLabel(ctx context.Context, cl client.Client, o client.Object, scopeLabelKey string, set bool) error {
+	_, ok := o.GetLabels()[scopeLabelKey]
+	if ok == set {
+		return nil
+	}
+
+	value := `null`
+	if set {
+		value = `"true"`
+	}
+
+	patch := fmt.Appendf(nil, `{"metadata":{"labels":{"%s":%s}}}`, scopeLabelKey, value)
+	if err := cl.Patch(ctx, o, client.RawPatch(types.MergePatchType, patch)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to patch labels of %s %s: %w", o.GetObjectKind().GroupVersionKind().Kind, client.ObjectKeyFromObject(o), err)
+	}
+
+	return nil
+}
+
+func objectID(cl client.Client, o client.Object) (string, error) {
+	gvk, err := apiutil.GVKForObject(o, cl.Scheme())
+	if err != nil {
+		return "", fmt.Errorf("failed to get GVK of %s: %w", client.ObjectKeyFromObject(o), err)
+	}
+
+	return gvk.GroupKind().String() + "/" + client.ObjectKeyFromObject(o).String(), nil
+}
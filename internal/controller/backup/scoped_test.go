@@ -0,0 +1,259 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/objects/clusteridentity"
+	"github.com/K0rdent/kcm/test/objects/credential"
+	"github.com/K0rdent/kcm/test/objects/providerinterface"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func Test_selectScopedClusterDeployments(t *testing.T) {
+	cds := []*kcmv1.ClusterDeployment{
+		clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("a1"), clusterdeployment.WithNamespace("a")),
+		clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("a2"), clusterdeployment.WithNamespace("a")),
+		clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("b1"), clusterdeployment.WithNamespace("b")),
+		clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("c1"), clusterdeployment.WithNamespace("c")),
+	}
+	cds[2].Labels = map[string]string{"team": "x"}
+
+	for _, tc := range []struct {
+		name      string
+		scopeSpec *kcmv1.ManagementBackupScope
+		want      []string
+	}{
+		{
+			name:      "namespaces",
+			scopeSpec: &kcmv1.ManagementBackupScope{Namespaces: []string{"a"}},
+			want:      []string{"a1", "a2"},
+		},
+		{
+			name:      "explicit refs",
+			scopeSpec: &kcmv1.ManagementBackupScope{ClusterDeployments: []kcmv1.ClusterDeploymentRef{{Namespace: "a", Name: "a2"}, {Namespace: "b", Name: "a1"}}},
+			want:      []string{"a2"},
+		},
+		{
+			name: "any of the criteria",
+			scopeSpec: &kcmv1.ManagementBackupScope{
+				Selector:           &metav1.LabelSelector{MatchLabels: map[string]string{"team": "x"}},
+				ClusterDeployments: []kcmv1.ClusterDeploymentRef{{Namespace: "c", Name: "c1"}},
+			},
+			want: []string{"b1", "c1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := selectScopedClusterDeployments(tc.scopeSpec, cds)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, cd := range selected {
+				got = append(got, cd.Name)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("selected %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_ensureScopeLabels(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		namespace       = "tenant"
+	)
+
+	mgmtBackup := &kcmv1.ManagementBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "scoped"},
+		Spec: kcmv1.ManagementBackupSpec{
+			Scope: &kcmv1.ManagementBackupScope{ClusterDeployments: []kcmv1.ClusterDeploymentRef{{Namespace: namespace, Name: "cd"}}},
+		},
+	}
+	scopeLabelKey := mgmtBackup.ScopeLabelKey()
+
+	identity := clusteridentity.New(
+		clusteridentity.WithAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2"),
+		clusteridentity.WithKind("AWSClusterStaticIdentity"),
+		clusteridentity.WithName("aws-identity"),
+		clusteridentity.WithData(map[string]any{"spec": map[string]any{"secretRef": "aws-secret"}}),
+	)
+
+	cd := clusterdeployment.NewClusterDeployment(
+		clusterdeployment.WithName("cd"),
+		clusterdeployment.WithNamespace(namespace),
+		clusterdeployment.WithCredential("cred"),
+		clusterdeployment.WithServiceSpec(kcmv1.ServiceSpec{Services: []kcmv1.Service{{
+			Name: "svc", Template: "tpl", ValuesFrom: []kcmv1.ValuesFrom{{Kind: "ConfigMap", Name: "values"}},
+		}}}),
+	)
+	otherCD := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("other"), clusterdeployment.WithNamespace(namespace))
+
+	objects := []client.Object{
+		cd, otherCD,
+		credential.NewCredential(
+			credential.WithName("cred"),
+			credential.WithNamespace(namespace),
+			credential.WithIdentityRef(&corev1.ObjectReference{APIVersion: identity.GetAPIVersion(), Kind: identity.GetKind(), Name: identity.GetName()}),
+		),
+		identity,
+		providerinterface.NewProviderInterface(providerinterface.WithClusterIdentities([]kcmv1.ClusterIdentity{{
+			GroupVersionKind: kcmv1.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSClusterStaticIdentity"},
+			References: []kcmv1.ClusterIdentityReference{{
+				GroupVersionKind: kcmv1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+				NameFieldPath:    "spec.secretRef",
+			}},
+		}})),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "aws-secret", Namespace: systemNamespace}},
+		&kcmv1.ServiceSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "cd-ss", Namespace: namespace},
+			Spec: kcmv1.ServiceSetSpec{Cluster: "cd", Services: []kcmv1.ServiceWithValues{{
+				Name: "svc", Template: "tpl", ValuesFrom: []kcmv1.ValuesFrom{{Kind: "Secret", Name: "secret-values"}},
+			}}},
+		},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret-values", Namespace: namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "stale", Namespace: namespace, Labels: map[string]string{scopeLabelKey: "true"}}},
+	}
+
+	cl := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+	r := NewReconciler(cl, systemNamespace)
+	s := &scope{
+		mgmtBackup:         mgmtBackup,
+		clusterDeployments: []*kcmv1.ClusterDeployment{cd, otherCD},
+		regionClients:      map[string]loadedClient{"unrelated": {}},
+	}
+
+	if err := r.ensureScopeLabels(t.Context(), s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	checks := []struct {
+		obj     client.Object
+		key     client.ObjectKey
+		labeled bool
+	}{
+		{new(kcmv1.ClusterDeployment), client.ObjectKey{Namespace: namespace, Name: "cd"}, true},
+		{new(kcmv1.Credential), client.ObjectKey{Namespace: namespace, Name: "cred"}, true},
+		{identity.DeepCopy(), client.ObjectKey{Name: "aws-identity"}, true},
+		{new(corev1.Secret), client.ObjectKey{Namespace: systemNamespace, Name: "aws-secret"}, true},
+		{new(kcmv1.ServiceSet), client.ObjectKey{Namespace: namespace, Name: "cd-ss"}, true},
+		{new(corev1.ConfigMap), client.ObjectKey{Namespace: namespace, Name: "values"}, true},
+		{new(corev1.Secret), client.ObjectKey{Namespace: namespace, Name: "secret-values"}, true},
+		{new(corev1.Secret), client.ObjectKey{Namespace: namespace, Name: "stale"}, false},
+		{new(kcmv1.ClusterDeployment), client.ObjectKey{Namespace: namespace, Name: "other"}, false},
+	}
+	for _, c := range checks {
+		if err := cl.Get(t.Context(), c.key, c.obj); err != nil {
+			t.Fatalf("failed to get %T %s: %v", c.obj, c.key, err)
+		}
+		if _, ok := c.obj.GetLabels()[scopeLabelKey]; ok != c.labeled {
+			t.Errorf("%T %s: labeled = %v, want %v", c.obj, c.key, ok, c.labeled)
+		}
+	}
+
+	if len(s.regionClients) != 0 {
+		t.Errorf("expected regional clients unrelated to the scope to be dropped, got %v", s.regionClients)
+	}
+
+	bs := getBackupTemplateSpec(s, "")
+	if want := []string{systemNamespace, namespace}; !slices.Equal(bs.IncludedNamespaces, want) {
+		t.Errorf("included namespaces = %v, want %v", bs.IncludedNamespaces, want)
+	}
+	for _, want := range []*metav1.LabelSelector{
+		selector(scopeLabelKey, "true"),
+		selector(kcmv1.FluxHelmChartNameKey, "cd"),
+		selector(clusterapiv1.ClusterNameLabel, "cd"),
+	} {
+		if !slices.ContainsFunc(bs.OrLabelSelectors, func(s *metav1.LabelSelector) bool { return maps.Equal(s.MatchLabels, want.MatchLabels) }) {
+			t.Errorf("selector %v not found in %v", want.MatchLabels, bs.OrLabelSelectors)
+		}
+	}
+	if slices.ContainsFunc(bs.OrLabelSelectors, func(s *metav1.LabelSelector) bool {
+		return s.MatchLabels[kcmv1.GenericComponentNameLabel] != ""
+	}) {
+		t.Errorf("scoped backup must not include all of the KCM components")
+	}
+}
+
+func TestReconciler_Finalize(t *testing.T) {
+	const namespace = "tenant"
+
+	mgmtBackup := &kcmv1.ManagementBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "unscoped", Finalizers: []string{kcmv1.ManagementBackupScopeFinalizer}},
+	}
+	scopeLabelKey := mgmtBackup.ScopeLabelKey()
+	labels := map[string]string{scopeLabelKey: "true"}
+
+	identity := clusteridentity.New(
+		clusteridentity.WithAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2"),
+		clusteridentity.WithKind("AWSClusterStaticIdentity"),
+		clusteridentity.WithName("aws-identity"),
+	)
+	identity.SetLabels(labels)
+
+	cd := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("cd"), clusterdeployment.WithNamespace(namespace))
+	cd.Labels = labels
+
+	cl := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		mgmtBackup, cd, identity,
+		providerinterface.NewProviderInterface(providerinterface.WithClusterIdentities([]kcmv1.ClusterIdentity{{
+			GroupVersionKind: kcmv1.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSClusterStaticIdentity"},
+		}})),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: namespace, Labels: labels}},
+	).Build()
+	r := NewReconciler(cl, "kcm-system")
+
+	if err := r.Finalize(t.Context(), mgmtBackup); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range []struct {
+		obj client.Object
+		key client.ObjectKey
+	}{
+		{new(kcmv1.ClusterDeployment), client.ObjectKeyFromObject(cd)},
+		{identity.DeepCopy(), client.ObjectKeyFromObject(identity)},
+		{new(corev1.Secret), client.ObjectKey{Namespace: namespace, Name: "values"}},
+	} {
+		if err := cl.Get(t.Context(), c.key, c.obj); err != nil {
+			t.Fatalf("failed to get %T %s: %v", c.obj, c.key, err)
+		}
+		if _, ok := c.obj.GetLabels()[scopeLabelKey]; ok {
+			t.Errorf("%T %s: scope label must be removed", c.obj, c.key)
+		}
+	}
+
+	got := new(kcmv1.ManagementBackup)
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(mgmtBackup), got); err != nil {
+		t.Fatalf("failed to get ManagementBackup: %v", err)
+	}
+	if slices.Contains(got.Finalizers, kcmv1.ManagementBackupScopeFinalizer) {
+		t.Errorf("scope finalizer must be removed, got %v", got.Finalizers)
+	}
+}
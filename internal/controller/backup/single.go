@@ -39,6 +39,10 @@ func (r *Reconciler) createAllSingleBackups(ctx context.Context, s *scope) (ctrl
 		mgmtBackup.Status.RegionsLastBackups = []kcmv1.ManagementBackupSingleStatus{}
 	}
 
+	if err := r.ensureScopeLabels(ctx, s); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure scoped backup labels: %w", err)
+	}
+
 	// skip if management backup has already been created
 	if mgmtBackup.Status.LastBackupName == "" {
 		// always create management backup first
@@ -46,6 +50,7 @@ func (r *Reconciler) createAllSingleBackups(ctx context.Context, s *scope) (ctrl
 
 		if err := r.createNewVeleroBackup(ctx, r.mgmtCl, "", s, mgmtBackupName,
 			withStorageLocation(mgmtBackup.Spec.StorageLocation),
+			withReleaseLabel(s.release),
 		); err != nil {
 			if isMetaError(err) {
 				return r.propagateMetaError(ctx, "", mgmtBackup, err.Error())
@@ -80,6 +85,7 @@ func (r *Reconciler) createAllSingleBackups(ctx context.Context, s *scope) (ctrl
 		if err := r.createNewVeleroBackup(ctx, loadedCl.cl, region, s, backupName,
 			withRegionLabel(region),
 			withStorageLocation(mgmtBackup.Spec.StorageLocation),
+			withReleaseLabel(s.release),
 		); err != nil {
 			l.Error(err, "failed to create single regional backup", "region", region, "storage_location", mgmtBackup.Spec.StorageLocation)
 			// on error set the error to the corresponding backup and proceed to the next region;
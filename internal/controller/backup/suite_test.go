@@ -27,7 +27,6 @@ import (
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -37,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
 )
 
 // These tests use Ginkgo (BDD-style Go testing framework). Refer to
@@ -64,7 +64,7 @@ func TestControllers(t *testing.T) {
 var mockRegionalClientFactory RegionalClientFactory
 
 func setupMockClientFactory() {
-	mockRegionalClientFactory = func(_ context.Context, mgmtClient client.Client, _ string, _ *kcmv1.Region, _ func() (*kruntime.Scheme, error)) (client.Client, *rest.Config, error) {
+	mockRegionalClientFactory = func(_ context.Context, mgmtClient client.Client, _ string, _ *kcmv1.Region, _ schemeutil.RegionalScheme) (client.Client, *rest.Config, error) {
 		return mgmtClient, nil, nil
 	}
 }
@@ -0,0 +1,406 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/label"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+)
+
+// verificationPollInterval is the interval to check whether the backups resource lists are ready to be downloaded.
+const verificationPollInterval = 10 * time.Second
+
+// ResourceListFetcher downloads the gzipped backup resource list from the given URL
+// using the given optional CA bundle and decodes it into a map of
+// "<group>/<version>/<kind>" keys to lists of "<namespace>/<name>" or "<name>" entries.
+type ResourceListFetcher func(ctx context.Context, url string, caCert []byte) (map[string][]string, error)
+
+// backupItem is an object expected to be present in a backup.
+type backupItem struct {
+	groupKind schema.GroupKind
+	key       client.ObjectKey
+}
+
+// entry returns the item representation used in the velero resource lists.
+func (i backupItem) entry() string {
+	if i.key.Namespace == "" {
+		return i.key.Name
+	}
+	return i.key.String()
+}
+
+func (i backupItem) String() string {
+	return i.groupKind.String() + " " + i.entry()
+}
+
+// verifyAllBackups verifies the most recent finished management and regional backups that have not yet been verified
+// and sets the verified condition.
+// Returns true if the verification is still in progress, and the joined verification errors if any.
+func (r *Reconciler) verifyAllBackups(ctx context.Context, s *scope) (pending bool, errs error) {
+	mgmtBackup := s.mgmtBackup
+	if !mgmtBackup.Spec.Verify {
+		return false, nil
+	}
+
+	done, err := r.verifySingleBackup(ctx, r.mgmtCl, s, &mgmtBackup.Status.ManagementBackupSingleStatus)
+	if err != nil {
+		errs = errors.Join(errs, fmt.Errorf("management backup %s: %w", mgmtBackup.Status.LastBackupName, err))
+	}
+	pending = !done && err == nil
+
+	for i := range mgmtBackup.Status.RegionsLastBackups {
+		regionStatus := &mgmtBackup.Status.RegionsLastBackups[i]
+
+		// there might be no Region objects yet thus we can't retrieve the regional client
+		// but because we utilize a single BSL across clusters (HACK), all velero Backup objects
+		// should also exist on the mgmt cluster
+		crClient := r.mgmtCl
+		if loadedCl, ok := s.regionClients[regionStatus.Region]; ok && loadedCl.loaded {
+			crClient = loadedCl.cl
+		}
+
+		done, err := r.verifySingleBackup(ctx, crClient, s, regionStatus)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("region %s backup %s: %w", regionStatus.Region, regionStatus.LastBackupName, err))
+		}
+		pending = pending || (!done && err == nil)
+	}
+
+	setVerifiedCondition(mgmtBackup, pending, errs)
+
+	return pending, errs
+}
+
+// verifySingleBackup requests the resource list of the most recent backup from velero and checks that
+// all of the expected items are present in the list, the result is stored in the given status.
+// Returns true if there is nothing to verify or the verification has been finished.
+func (r *Reconciler) verifySingleBackup(ctx context.Context, cl client.Client, s *scope, status *kcmv1.ManagementBackupSingleStatus) (bool, error) {
+	if !status.IsVerificationPending() {
+		return true, nil
+	}
+
+	downloadReq := &velerov1.DownloadRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      label.GetValidName(status.LastBackupName + "-verify"),
+			Namespace: r.systemNamespace,
+		},
+	}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(downloadReq), downloadReq); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get velero DownloadRequest %s: %w", downloadReq.Name, err)
+		}
+
+		downloadReq.Labels = map[string]string{
+			velerov1.BackupNameLabel: label.GetValidName(status.LastBackupName),
+			scheduleMgmtNameLabel:    s.mgmtBackup.Name,
+		}
+		downloadReq.Spec.Target = velerov1.DownloadTarget{
+			Kind: velerov1.DownloadTargetKindBackupResourceList,
+			Name: status.LastBackupName,
+		}
+		if err := cl.Create(ctx, downloadReq); client.IgnoreAlreadyExists(err) != nil {
+			return false, fmt.Errorf("failed to create velero DownloadRequest %s: %w", downloadReq.Name, err)
+		}
+
+		return false, nil
+	}
+
+	if downloadReq.Status.Phase != velerov1.DownloadRequestPhaseProcessed || downloadReq.Status.DownloadURL == "" {
+		return false, nil
+	}
+
+	// the signed URL might have expired, the request is recreated on the next attempt
+	defer func() {
+		if err := cl.Delete(ctx, downloadReq); client.IgnoreNotFound(err) != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to delete velero DownloadRequest", "download_request", downloadReq.Name)
+		}
+	}()
+
+	veleroBackup := new(velerov1.Backup)
+	if err := cl.Get(ctx, client.ObjectKey{Name: status.LastBackupName, Namespace: r.systemNamespace}, veleroBackup); err != nil {
+		return false, fmt.Errorf("failed to get velero Backup %s: %w", status.LastBackupName, err)
+	}
+
+	caCert, err := storageLocationCACert(ctx, cl, r.systemNamespace, veleroBackup.Spec.StorageLocation)
+	if err != nil {
+		return false, err
+	}
+
+	resourceList, err := r.resourceListFetcher(ctx, downloadReq.Status.DownloadURL, caCert)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch resource list of the velero Backup %s: %w", status.LastBackupName, err)
+	}
+
+	expected, err := expectedBackupItems(ctx, cl, s, status.Region, veleroBackup.Status.StartTimestamp)
+	if err != nil {
+		return false, err
+	}
+
+	status.VerifiedBackupName = status.LastBackupName
+	status.MissingItems = missingBackupItems(expected, resourceList)
+
+	return true, nil
+}
+
+// expectedBackupItems returns the items that are expected to be present in the backup of the given region.
+// Only objects created before the backup start are expected.
+// The management backup is expected to contain the [kcmv1.Management], [kcmv1.Release] and all of the [kcmv1.ClusterDeployment]
+// objects along with their [kcmv1.Credential] objects, while each of the backups including a CAPI cluster
+// is expected to contain its infrastructure and control plane objects and its kubeconfig Secret.
+// The CAPI Cluster itself is excluded from the backups, see [getBackupTemplateSpec].
+func expectedBackupItems(ctx context.Context, cl client.Client, s *scope, region string, startedAt *metav1.Time) ([]backupItem, error) {
+	createdBefore := func(o client.Object) bool {
+		return startedAt.IsZero() || o.GetCreationTimestamp().Time.Before(startedAt.Time)
+	}
+
+	cds := s.clusterDeployments
+	if scopeSpec := s.mgmtBackup.Spec.Scope; scopeSpec != nil {
+		var err error
+		if cds, err = selectScopedClusterDeployments(scopeSpec, cds); err != nil {
+			return nil, fmt.Errorf("failed to select ClusterDeployments: %w", err)
+		}
+	}
+
+	var result []backupItem
+
+	kcmGK := func(kind string) schema.GroupKind { return kcmv1.GroupVersion.WithKind(kind).GroupKind() }
+
+	if region == "" {
+		// scoped backups include only the dependencies of the ClusterDeployments
+		if s.mgmtBackup.Spec.Scope == nil {
+			result = append(result, backupItem{groupKind: kcmGK(kcmv1.ManagementKind), key: client.ObjectKey{Name: kcmv1.ManagementName}})
+			if s.release != "" {
+				result = append(result, backupItem{groupKind: kcmGK(kcmv1.ReleaseKind), key: client.ObjectKey{Name: s.release}})
+			}
+		}
+
+		for _, cd := range cds {
+			if !createdBefore(cd) {
+				continue
+			}
+
+			result = append(result, backupItem{groupKind: kcmGK(kcmv1.ClusterDeploymentKind), key: client.ObjectKeyFromObject(cd)})
+			if cd.Spec.Credential != "" {
+				result = append(result, backupItem{groupKind: kcmGK(kcmv1.CredentialKind), key: client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Credential}})
+			}
+		}
+	}
+
+	for _, cd := range cds {
+		if cd.Status.Region != region {
+			continue
+		}
+
+		cluster := new(clusterapiv1.Cluster)
+		clusterFound := true
+		if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), cluster); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get CAPI Cluster of the ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+			}
+			clusterFound = false
+		}
+
+		// the referenced objects are created along with the cluster by the same chart
+		if clusterFound && createdBefore(cluster) {
+			for _, ref := range []clusterapiv1.ContractVersionedObjectReference{cluster.Spec.InfrastructureRef, cluster.Spec.ControlPlaneRef} {
+				if ref.IsDefined() {
+					result = append(result, backupItem{groupKind: ref.GroupKind(), key: client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}})
+				}
+			}
+		}
+
+		kubeconfigSecret := new(corev1.Secret)
+		if err := cl.Get(ctx, kubeutil.GetKubeconfigSecretKey(client.ObjectKeyFromObject(cd)), kubeconfigSecret); err != nil {
+			if apierrors.IsNotFound(err) { // the cluster has not been provisioned yet
+				continue
+			}
+			return nil, fmt.Errorf("failed to get kubeconfig Secret of the ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+		}
+
+		if createdBefore(kubeconfigSecret) {
+			result = append(result, backupItem{groupKind: schema.GroupKind{Kind: "Secret"}, key: client.ObjectKeyFromObject(kubeconfigSecret)})
+		}
+	}
+
+	return result, nil
+}
+
+// missingBackupItems returns the sorted list of the expected items absent in the given velero resource list.
+func missingBackupItems(expected []backupItem, resourceList map[string][]string) []string {
+	present := make(map[schema.GroupKind][]string, len(resourceList))
+	for gvk, entries := range resourceList {
+		idx := strings.LastIndex(gvk, "/")
+		if idx < 0 {
+			continue
+		}
+
+		gv, err := schema.ParseGroupVersion(gvk[:idx])
+		if err != nil {
+			continue
+		}
+
+		gk := gv.WithKind(gvk[idx+1:]).GroupKind()
+		present[gk] = append(present[gk], entries...)
+	}
+
+	var missing []string
+	for _, item := range expected {
+		if !slices.Contains(present[item.groupKind], item.entry()) {
+			missing = append(missing, item.String())
+		}
+	}
+
+	slices.Sort(missing)
+
+	return slices.Compact(missing)
+}
+
+// setVerifiedCondition sets the verified condition according to the verification results in the status.
+func setVerifiedCondition(mgmtBackup *kcmv1.ManagementBackup, pending bool, verificationErr error) {
+	cond := metav1.Condition{
+		Type:               kcmv1.ManagementBackupVerifiedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             kcmv1.ManagementBackupVerificationSucceededReason,
+		Message:            "All of the expected items are present in the backups",
+		ObservedGeneration: mgmtBackup.Generation,
+	}
+
+	var missing []string
+	for _, status := range append([]kcmv1.ManagementBackupSingleStatus{mgmtBackup.Status.ManagementBackupSingleStatus}, mgmtBackup.Status.RegionsLastBackups...) {
+		if len(status.MissingItems) == 0 {
+			continue
+		}
+
+		prefix := "management"
+		if status.Region != "" {
+			prefix = "region " + status.Region
+		}
+		missing = append(missing, fmt.Sprintf("%s backup %s: %s", prefix, status.VerifiedBackupName, strings.Join(status.MissingItems, ", ")))
+	}
+
+	switch {
+	case verificationErr != nil:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = kcmv1.ManagementBackupVerificationFailedReason
+		cond.Message = verificationErr.Error()
+	case len(missing) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = kcmv1.ManagementBackupMissingItemsReason
+		cond.Message = "Missing items in " + strings.Join(missing, "; ")
+	case pending:
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = kcmv1.ManagementBackupVerificationInProgressReason
+		cond.Message = "Waiting for the backups resource lists"
+	}
+
+	apimeta.SetStatusCondition(&mgmtBackup.Status.Conditions, cond)
+}
+
+// storageLocationCACert returns the CA bundle of the given velero BackupStorageLocation if any.
+func storageLocationCACert(ctx context.Context, cl client.Client, namespace, name string) ([]byte, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	bsl := new(velerov1.BackupStorageLocation)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, bsl); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get velero BackupStorageLocation %s: %w", name, err)
+	}
+
+	if bsl.Spec.ObjectStorage == nil {
+		return nil, nil
+	}
+
+	if ref := bsl.Spec.ObjectStorage.CACertRef; ref != nil {
+		secret := new(corev1.Secret)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get CA certificate Secret %s of the velero BackupStorageLocation %s: %w", ref.Name, name, err)
+		}
+		return secret.Data[ref.Key], nil
+	}
+
+	return bsl.Spec.ObjectStorage.CACert, nil
+}
+
+// fetchResourceList is the default [ResourceListFetcher].
+func fetchResourceList(ctx context.Context, url string, caCert []byte) (map[string][]string, error) {
+	caPool, err := x509.SystemCertPool()
+	if err != nil {
+		caPool = x509.NewCertPool()
+	}
+	if len(caCert) > 0 {
+		caPool.AppendCertsFromPEM(caCert)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: caPool, MinVersion: tls.VersionTLS12}
+	httpClient := &http.Client{Transport: transport, Timeout: time.Minute}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download resource list: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	gzipReader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress resource list: %w", err)
+	}
+	defer gzipReader.Close()
+
+	var resourceList map[string][]string
+	if err := json.NewDecoder(gzipReader).Decode(&resourceList); err != nil {
+		return nil, fmt.Errorf("failed to decode resource list: %w", err)
+	}
+
+	return resourceList, nil
+}
@@ -0,0 +1,275 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func Test_missingBackupItems(t *testing.T) {
+	expected := []backupItem{
+		{groupKind: kcmv1.GroupVersion.WithKind(kcmv1.ManagementKind).GroupKind(), key: client.ObjectKey{Name: kcmv1.ManagementName}},
+		{groupKind: kcmv1.GroupVersion.WithKind(kcmv1.ClusterDeploymentKind).GroupKind(), key: client.ObjectKey{Namespace: "ns", Name: "cd"}},
+		{groupKind: corev1.SchemeGroupVersion.WithKind("Secret").GroupKind(), key: client.ObjectKey{Namespace: "ns", Name: "cd-kubeconfig"}},
+	}
+
+	missing := missingBackupItems(expected, map[string][]string{
+		// the version does not matter
+		"k0rdent.mirantis.com/v1alpha1/Management": {"kcm"},
+		"k0rdent.mirantis.com/v1beta1/Credential":  {"ns/cd"},
+		"v1/Secret": {"ns/cd-kubeconfig"},
+	})
+
+	if want := []string{"ClusterDeployment.k0rdent.mirantis.com ns/cd"}; !slices.Equal(missing, want) {
+		t.Errorf("missing items = %v, want %v", missing, want)
+	}
+}
+
+func Test_verifyAllBackups(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		namespace       = "tenant"
+		release         = "kcm-1-0-0"
+	)
+
+	scheme := runtime.NewScheme()
+	for _, f := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, kcmv1.AddToScheme, velerov1.AddToScheme, clusterapiv1.AddToScheme} {
+		if err := f(scheme); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+
+	startedAt := metav1.NewTime(time.Now().Add(-time.Minute))
+	newBackup := func(name string) *velerov1.Backup {
+		return &velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: systemNamespace},
+			Status: velerov1.BackupStatus{
+				Phase:               velerov1.BackupPhaseCompleted,
+				StartTimestamp:      &startedAt,
+				CompletionTimestamp: &metav1.Time{Time: time.Now()},
+			},
+		}
+	}
+
+	mgmtCD := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "mgmt-cd", Namespace: namespace},
+		Spec:       kcmv1.ClusterDeploymentSpec{Credential: "cred"},
+	}
+	regionalCD := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "rgn-cd", Namespace: namespace},
+		Spec:       kcmv1.ClusterDeploymentSpec{Credential: "rgn-cred"},
+		Status:     kcmv1.ClusterDeploymentStatus{Region: "rgn"},
+	}
+	// the regional cluster has not been provisioned yet, thus there is no kubeconfig
+	notProvisionedCD := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "not-provisioned", Namespace: namespace},
+		Status:     kcmv1.ClusterDeploymentStatus{Region: "rgn"},
+	}
+
+	newCluster := func(name string) *clusterapiv1.Cluster {
+		return &clusterapiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: clusterapiv1.ClusterSpec{
+				InfrastructureRef: clusterapiv1.ContractVersionedObjectReference{APIGroup: "infrastructure.cluster.x-k8s.io", Kind: "AWSCluster", Name: name},
+				ControlPlaneRef:   clusterapiv1.ContractVersionedObjectReference{APIGroup: "controlplane.cluster.x-k8s.io", Kind: "K0smotronControlPlane", Name: name + "-cp"},
+			},
+		}
+	}
+
+	mgmtCl := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newBackup("mb"),
+		newCluster("mgmt-cd"),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mgmt-cd-kubeconfig", Namespace: namespace}},
+	).Build()
+	rgnCl := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newBackup("mb-rgn"),
+		newCluster("rgn-cd"),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "rgn-cd-kubeconfig", Namespace: namespace}},
+	).Build()
+
+	mgmtBackup := &kcmv1.ManagementBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "mb"},
+		Spec:       kcmv1.ManagementBackupSpec{Verify: true},
+		Status: kcmv1.ManagementBackupStatus{
+			ManagementBackupSingleStatus: kcmv1.ManagementBackupSingleStatus{LastBackupName: "mb", LastBackup: &newBackup("").Status},
+			RegionsLastBackups: []kcmv1.ManagementBackupSingleStatus{
+				{Region: "rgn", LastBackupName: "mb-rgn", LastBackup: &newBackup("").Status},
+			},
+		},
+	}
+	s := &scope{
+		mgmtBackup:         mgmtBackup,
+		clusterDeployments: []*kcmv1.ClusterDeployment{mgmtCD, regionalCD, notProvisionedCD},
+		regionClients:      map[string]loadedClient{"rgn": {cl: rgnCl, loaded: true}},
+		release:            release,
+	}
+
+	// the backups contain the objects present in the clusters except for the ones excluded by the backup spec
+	backedUp := func(region string, objects map[string][]string) map[string][]string {
+		excluded := getBackupTemplateSpec(s, region).ExcludedResources
+		for gvk := range objects {
+			idx := strings.LastIndex(gvk, "/")
+			gv, err := schema.ParseGroupVersion(gvk[:idx])
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", gvk, err)
+			}
+			resource := strings.ToLower(gvk[idx+1:]) + "s"
+			if gv.Group != "" {
+				resource += "." + gv.Group
+			}
+			if slices.Contains(excluded, resource) {
+				delete(objects, gvk)
+			}
+		}
+		return objects
+	}
+	resourceLists := map[string]map[string][]string{
+		"https://mgmt": backedUp("", map[string][]string{
+			"k0rdent.mirantis.com/v1beta1/Management":                     {kcmv1.ManagementName},
+			"k0rdent.mirantis.com/v1beta1/Release":                        {release},
+			"k0rdent.mirantis.com/v1beta1/ClusterDeployment":              {namespace + "/mgmt-cd", namespace + "/not-provisioned", namespace + "/rgn-cd"},
+			"k0rdent.mirantis.com/v1beta1/Credential":                     {namespace + "/cred", namespace + "/rgn-cred"},
+			"cluster.x-k8s.io/v1beta2/Cluster":                            {namespace + "/mgmt-cd"},
+			"infrastructure.cluster.x-k8s.io/v1beta2/AWSCluster":          {namespace + "/mgmt-cd"},
+			"controlplane.cluster.x-k8s.io/v1beta1/K0smotronControlPlane": {namespace + "/mgmt-cd-cp"},
+		}),
+		"https://rgn": backedUp("rgn", map[string][]string{
+			"v1/Secret":                        {namespace + "/rgn-cd-kubeconfig"},
+			"cluster.x-k8s.io/v1beta2/Cluster": {namespace + "/rgn-cd"},
+			"infrastructure.cluster.x-k8s.io/v1beta2/AWSCluster": {namespace + "/rgn-cd"},
+		}),
+	}
+
+	r := NewReconciler(mgmtCl, systemNamespace, WithResourceListFetcher(func(_ context.Context, url string, _ []byte) (map[string][]string, error) {
+		return resourceLists[url], nil
+	}))
+
+	if pending, err := r.verifyAllBackups(t.Context(), s); !pending || err != nil {
+		t.Fatalf("expected verification to be pending, got pending %t, error %v", pending, err)
+	}
+	if cond := apimeta.FindStatusCondition(mgmtBackup.Status.Conditions, kcmv1.ManagementBackupVerifiedCondition); cond == nil || cond.Status != metav1.ConditionUnknown {
+		t.Errorf("unexpected verified condition %+v", cond)
+	}
+
+	for cl, url := range map[client.Client]string{mgmtCl: "https://mgmt", rgnCl: "https://rgn"} {
+		downloadReqs := new(velerov1.DownloadRequestList)
+		if err := cl.List(t.Context(), downloadReqs); err != nil {
+			t.Fatalf("failed to list velero DownloadRequests: %v", err)
+		}
+		if len(downloadReqs.Items) != 1 || downloadReqs.Items[0].Spec.Target.Kind != velerov1.DownloadTargetKindBackupResourceList {
+			t.Fatalf("unexpected velero DownloadRequests %+v", downloadReqs.Items)
+		}
+
+		downloadReq := &downloadReqs.Items[0]
+		downloadReq.Status = velerov1.DownloadRequestStatus{Phase: velerov1.DownloadRequestPhaseProcessed, DownloadURL: url}
+		if err := cl.Update(t.Context(), downloadReq); err != nil {
+			t.Fatalf("failed to update velero DownloadRequest: %v", err)
+		}
+	}
+
+	if pending, err := r.verifyAllBackups(t.Context(), s); pending || err != nil {
+		t.Fatalf("expected verification to be finished, got pending %t, error %v", pending, err)
+	}
+
+	if want := []string{"Secret " + namespace + "/mgmt-cd-kubeconfig"}; !slices.Equal(mgmtBackup.Status.MissingItems, want) {
+		t.Errorf("management missing items = %v, want %v", mgmtBackup.Status.MissingItems, want)
+	}
+	if want := []string{"K0smotronControlPlane.controlplane.cluster.x-k8s.io " + namespace + "/rgn-cd-cp"}; !slices.Equal(mgmtBackup.Status.RegionsLastBackups[0].MissingItems, want) {
+		t.Errorf("regional missing items = %v, want %v", mgmtBackup.Status.RegionsLastBackups[0].MissingItems, want)
+	}
+	if !mgmtBackup.IsVerified() {
+		t.Errorf("expected ManagementBackup to be verified")
+	}
+
+	cond := apimeta.FindStatusCondition(mgmtBackup.Status.Conditions, kcmv1.ManagementBackupVerifiedCondition)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != kcmv1.ManagementBackupMissingItemsReason {
+		t.Errorf("unexpected verified condition %+v", cond)
+	}
+
+	for _, cl := range []client.Client{mgmtCl, rgnCl} {
+		downloadReqs := new(velerov1.DownloadRequestList)
+		if err := cl.List(t.Context(), downloadReqs); err != nil {
+			t.Fatalf("failed to list velero DownloadRequests: %v", err)
+		}
+		if len(downloadReqs.Items) != 0 {
+			t.Errorf("expected velero DownloadRequests to be cleaned up, got %d", len(downloadReqs.Items))
+		}
+	}
+}
+
+func Test_verifyAllBackupsError(t *testing.T) {
+	const systemNamespace = "kcm-system"
+
+	scheme := runtime.NewScheme()
+	for _, f := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, kcmv1.AddToScheme, velerov1.AddToScheme, clusterapiv1.AddToScheme} {
+		if err := f(scheme); err != nil {
+			t.Fatalf("AddToScheme: %v", err)
+		}
+	}
+
+	mgmtCl := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&velerov1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "mb", Namespace: systemNamespace}},
+		&velerov1.DownloadRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "mb-verify", Namespace: systemNamespace},
+			Status:     velerov1.DownloadRequestStatus{Phase: velerov1.DownloadRequestPhaseProcessed, DownloadURL: "https://mgmt"},
+		},
+	).Build()
+
+	r := NewReconciler(mgmtCl, systemNamespace, WithResourceListFetcher(func(context.Context, string, []byte) (map[string][]string, error) {
+		return nil, errors.New("access denied")
+	}))
+
+	mgmtBackup := &kcmv1.ManagementBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "mb"},
+		Spec:       kcmv1.ManagementBackupSpec{Verify: true},
+		Status: kcmv1.ManagementBackupStatus{
+			ManagementBackupSingleStatus: kcmv1.ManagementBackupSingleStatus{
+				LastBackupName: "mb",
+				LastBackup:     &velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted},
+			},
+		},
+	}
+
+	pending, err := r.verifyAllBackups(t.Context(), &scope{mgmtBackup: mgmtBackup})
+	if pending || err == nil {
+		t.Fatalf("expected verification to fail, got pending %t, error %v", pending, err)
+	}
+	if mgmtBackup.IsVerified() {
+		t.Errorf("expected ManagementBackup not to be verified")
+	}
+
+	cond := apimeta.FindStatusCondition(mgmtBackup.Status.Conditions, kcmv1.ManagementBackupVerifiedCondition)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != kcmv1.ManagementBackupVerificationFailedReason {
+		t.Errorf("unexpected verified condition %+v", cond)
+	}
+}
@@ -97,7 +97,7 @@ func (r *ClusterDeploymentReconciler) resolveCAPIClusterClient(ctx context.Conte
 		return cl, nil
 	}
 
-	cl, err := kubeutil.GetRegionalClientByRegionName(ctx, r.MgmtClient, r.SystemNamespace, cred.Spec.Region, schemeutil.GetRegionalScheme)
+	cl, err := kubeutil.GetRegionalClientByRegionName(ctx, r.MgmtClient, r.SystemNamespace, cred.Spec.Region, schemeutil.Regional)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get regional client for region %s: %w", cred.Spec.Region, err)
 	}
@@ -109,11 +109,7 @@ func (r *ClusterDeploymentReconciler) resolveCAPIClusterClient(ctx context.Conte
 
 // capiClusterConditionDrifted reports whether the CAPIClusterSummary
 // condition computed from the CAPI Cluster fetched via cl differs from the
-// one currently set on cd. When the CAPI Cluster is missing, drift is
-// reported so the controller can surface the disappearance (promote
-// Deleting -> DeletionCompleted, or mark previously-known Clusters as
-// Missing). A CD that never had a CAPIClusterSummary condition is treated
-// as legitimately Cluster-less (e.g., a template that does not produce one).
+// one currently set on cd, see [capiClusterSummaryDrifted].
 func (*ClusterDeploymentReconciler) capiClusterConditionDrifted(ctx context.Context, cl client.Client, cd *kcmv1.ClusterDeployment) (bool, error) {
 	clusters := new(clusterapiv1.ClusterList)
 	if err := cl.List(
@@ -125,9 +121,24 @@ func (*ClusterDeploymentReconciler) capiClusterConditionDrifted(ctx context.Cont
 		return false, fmt.Errorf("failed to list CAPI Clusters for %s: %w", client.ObjectKeyFromObject(cd), err)
 	}
 
+	var cluster *clusterapiv1.Cluster
+	if len(clusters.Items) > 0 {
+		cluster = &clusters.Items[0]
+	}
+
+	return capiClusterSummaryDrifted(cd, cluster)
+}
+
+// capiClusterSummaryDrifted reports whether the CAPIClusterSummary condition
+// computed from the given CAPI Cluster differs from the one currently set on cd.
+// When the CAPI Cluster is nil, i.e. missing, drift is reported so the controller
+// can surface the disappearance (promote Deleting -> DeletionCompleted, or mark
+// previously-known Clusters as Missing). A CD that never had a CAPIClusterSummary
+// condition is treated as legitimately Cluster-less (e.g., a template that does not produce one).
+func capiClusterSummaryDrifted(cd *kcmv1.ClusterDeployment, cluster *clusterapiv1.Cluster) (bool, error) {
 	curr := apimeta.FindStatusCondition(cd.Status.Conditions, kcmv1.CAPIClusterSummaryCondition)
 
-	if len(clusters.Items) == 0 {
+	if cluster == nil {
 		if curr == nil {
 			// never observed a CAPI Cluster; nothing to surface
 			return false, nil
@@ -142,7 +153,7 @@ func (*ClusterDeploymentReconciler) capiClusterConditionDrifted(ctx context.Cont
 		}
 	}
 
-	next, err := conditionsutil.GetCAPIClusterSummaryCondition(cd, &clusters.Items[0])
+	next, err := conditionsutil.GetCAPIClusterSummaryCondition(cd, cluster)
 	if err != nil {
 		return false, fmt.Errorf("failed to compute CAPI summary condition: %w", err)
 	}
@@ -0,0 +1,87 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// capiClusterToClusterDeployment is the [multicluster.MapFunc] mapping a CAPI Cluster
+// observed on the management or a regional cluster to its ClusterDeployment.
+// The ClusterDeployment is emitted only if the CAPI Cluster belongs to the region
+// of the ClusterDeployment and either is being deleted or the CAPIClusterSummary
+// condition computed from it differs from the one already set.
+func (r *ClusterDeploymentReconciler) capiClusterToClusterDeployment(ctx context.Context, region string, obj client.Object) []*kcmv1.ClusterDeployment {
+	l := ctrl.LoggerFrom(ctx)
+
+	cluster, ok := obj.(*clusterapiv1.Cluster)
+	if !ok {
+		return nil
+	}
+
+	name, ok := cluster.Labels[kcmv1.FluxHelmChartNameKey]
+	if !ok {
+		return nil
+	}
+
+	cd := new(kcmv1.ClusterDeployment)
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: name}
+	if err := r.MgmtClient.Get(ctx, key, cd); err != nil {
+		if !apierrors.IsNotFound(err) {
+			l.Error(err, "failed to get ClusterDeployment for CAPI Cluster", "clusterdeployment", key, "region", region)
+		}
+		return nil
+	}
+	if !cd.DeletionTimestamp.IsZero() || cd.Spec.DryRun {
+		// dry-run CDs never reconcile infrastructure, deleted ones are requeued on their own
+		return nil
+	}
+
+	cred := new(kcmv1.Credential)
+	credKey := client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Credential}
+	if err := r.MgmtClient.Get(ctx, credKey, cred); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.V(1).Info("Credential of ClusterDeployment not found", "clusterdeployment", key, "credential", credKey)
+		} else {
+			l.Error(err, "failed to get Credential of ClusterDeployment", "clusterdeployment", key, "credential", credKey)
+		}
+		return nil
+	}
+	if cred.Spec.Region != region {
+		// the CAPI Cluster is not the one managed by the ClusterDeployment
+		return nil
+	}
+
+	if cluster.DeletionTimestamp.IsZero() {
+		drifted, err := capiClusterSummaryDrifted(cd, cluster)
+		if err != nil {
+			l.Error(err, "failed to evaluate CAPIClusterSummary condition", "clusterdeployment", key)
+			return nil
+		}
+		if !drifted {
+			return nil
+		}
+	}
+
+	l.V(1).Info("CAPI Cluster has changed, scheduling reconcile", "clusterdeployment", key, "region", region)
+	return []*kcmv1.ClusterDeployment{cd}
+}
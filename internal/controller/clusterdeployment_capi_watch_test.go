@@ -0,0 +1,99 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	testscheme "github.com/K0rdent/kcm/test/scheme"
+)
+
+func Test_capiClusterToClusterDeployment(t *testing.T) {
+	t.Parallel()
+
+	readyConds := getCAPIClusterReadyConditions()
+
+	tests := map[string]struct {
+		objects   []client.Object
+		cluster   *clusterapiv1.Cluster
+		region    string
+		wantNames []string
+	}{
+		"drifting CD is enqueued": {
+			objects:   []client.Object{newClusterDeployment(t, "a"), newCredential(t, "a", "")},
+			cluster:   newCAPICluster(t, "a", readyConds),
+			wantNames: []string{"a"},
+		},
+		"steady CD is not enqueued": {
+			objects: []client.Object{
+				newClusterDeployment(t, "a", withMatchingSummaryCondition(t, newCAPICluster(t, "a", readyConds))),
+				newCredential(t, "a", ""),
+			},
+			cluster: newCAPICluster(t, "a", readyConds),
+		},
+		"steady CD is enqueued once the CAPI Cluster is being deleted": {
+			objects: []client.Object{
+				newClusterDeployment(t, "a", withMatchingSummaryCondition(t, newCAPICluster(t, "a", readyConds))),
+				newCredential(t, "a", ""),
+			},
+			cluster: func() *clusterapiv1.Cluster {
+				cluster := newCAPICluster(t, "a", readyConds)
+				cluster.DeletionTimestamp = new(metav1.Now())
+				return cluster
+			}(),
+			wantNames: []string{"a"},
+		},
+		"regional CD is enqueued for the CAPI Cluster of its region": {
+			objects:   []client.Object{newClusterDeployment(t, "a"), newCredential(t, "a", "region1")},
+			cluster:   newCAPICluster(t, "a", readyConds),
+			region:    "region1",
+			wantNames: []string{"a"},
+		},
+		"regional CD is not enqueued for the CAPI Cluster of another cluster": {
+			objects: []client.Object{newClusterDeployment(t, "a"), newCredential(t, "a", "region1")},
+			cluster: newCAPICluster(t, "a", readyConds),
+		},
+		"dry-run CD is not enqueued": {
+			objects: []client.Object{newClusterDeployment(t, "a", withDryRun()), newCredential(t, "a", "")},
+			cluster: newCAPICluster(t, "a", readyConds),
+		},
+		"CAPI Cluster without CD is ignored": {
+			cluster: newCAPICluster(t, "a", readyConds),
+		},
+		"CAPI Cluster not produced by a CD is ignored": {
+			objects: []client.Object{newClusterDeployment(t, "a"), newCredential(t, "a", "")},
+			cluster: &clusterapiv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: pollerTestNamespace}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mgmt := fake.NewClientBuilder().WithScheme(testscheme.Scheme).WithObjects(tc.objects...).Build()
+			r := &ClusterDeploymentReconciler{MgmtClient: mgmt, SystemNamespace: "kcm-system"}
+
+			gotNames := enqueuedNames(t, r.capiClusterToClusterDeployment(t.Context(), tc.region, tc.cluster))
+			if !equalUnordered(t, gotNames, tc.wantNames) {
+				t.Fatalf("enqueued = %v, want %v", gotNames, tc.wantNames)
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
 	corev1 "k8s.io/api/core/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -63,12 +64,16 @@ import (
 	"github.com/K0rdent/kcm/internal/serviceset"
 	authutil "github.com/K0rdent/kcm/internal/util/auth"
 	conditionsutil "github.com/K0rdent/kcm/internal/util/conditions"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
 	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
 	labelsutil "github.com/K0rdent/kcm/internal/util/labels"
+	maintenanceutil "github.com/K0rdent/kcm/internal/util/maintenance"
+	multiclusterutil "github.com/K0rdent/kcm/internal/util/multicluster"
 	pollerutil "github.com/K0rdent/kcm/internal/util/poller"
 	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
 	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
 	validationutil "github.com/K0rdent/kcm/internal/util/validation"
+	valuesschemautil "github.com/K0rdent/kcm/internal/util/valuesschema"
 )
 
 var (
@@ -85,7 +90,7 @@ var (
 type helmActor interface {
 	DownloadChartFromArtifact(ctx context.Context, artifact *fluxmeta.Artifact) (*chart.Chart, error)
 	InitializeConfiguration(clusterDeployment *kcmv1.ClusterDeployment, log action.DebugLog) (*action.Configuration, error)
-	EnsureReleaseWithValues(ctx context.Context, actionConfig *action.Configuration, hcChart *chart.Chart, clusterDeployment *kcmv1.ClusterDeployment) error
+	EnsureReleaseWithValues(ctx context.Context, actionConfig *action.Configuration, hcChart *chart.Chart, clusterDeployment *kcmv1.ClusterDeployment) (*release.Release, error)
 }
 
 type clusterDeletionState struct {
@@ -108,7 +113,7 @@ type ClusterDeploymentReconciler struct {
 	CldRegistryCredSecretName string
 
 	DefaultHelmTimeout      time.Duration
-	CAPIClusterPollInterval time.Duration // interval for the periodic CAPI Cluster status poller; 0 disables the poller
+	CAPIClusterPollInterval time.Duration // interval for the periodic CAPI Cluster status poller complementing the watches; 0 disables the poller
 	defaultRequeueTime      time.Duration
 
 	IsDisabledValidationWH bool // is webhook disabled set via the controller flags
@@ -124,6 +129,13 @@ type (
 		audit         *auditConfig
 		rgnClient     client.Client
 		deletionState *clusterDeletionState
+
+		renderedManifest string // manifest rendered during the configuration validation
+
+		maintenance    maintenanceutil.State // state of the maintenance window applicable to the cluster
+		pendingChanges []string              // changes postponed until the maintenance window opens
+
+		nextEndOfLife time.Time // the closest time one of the used templates reaches end of life
 	}
 
 	authConfig struct {
@@ -165,6 +177,11 @@ func (r *ClusterDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	oldCD := clusterDeployment.DeepCopy()
+	if placed, err := r.placeClusterDeployment(ctx, clusterDeployment); err != nil || placed {
+		// the update of the Credential retriggers the reconciliation
+		return ctrl.Result{}, errors.Join(err, r.updateStatus(ctx, oldCD, clusterDeployment, nil))
+	}
+
 	scope, err := r.getClusterScope(ctx, clusterDeployment)
 	if err != nil {
 		statusErr := r.updateStatus(ctx, oldCD, clusterDeployment, nil)
@@ -287,7 +304,7 @@ func (r *ClusterDeploymentReconciler) getClusterScope(ctx context.Context, cd *k
 		if err := r.MgmtClient.Get(ctx, client.ObjectKey{Name: cred.Spec.Region}, rgn); err != nil {
 			return nil, fmt.Errorf("failed to get %s region: %w", cred.Spec.Region, err)
 		}
-		if scope.rgnClient, _, err = kubeutil.GetRegionalClient(ctx, r.MgmtClient, r.SystemNamespace, rgn, schemeutil.GetRegionalScheme); err != nil {
+		if scope.rgnClient, _, err = kubeutil.GetRegionalClient(ctx, r.MgmtClient, r.SystemNamespace, rgn, schemeutil.Regional); err != nil {
 			return nil, fmt.Errorf("failed to get client for %s region: %w", cred.Spec.Region, err)
 		}
 		scope.region = rgn
@@ -296,6 +313,21 @@ func (r *ClusterDeploymentReconciler) getClusterScope(ctx context.Context, cd *k
 	return scope, nil
 }
 
+// regionPauseReason returns the reason and the message of the Paused condition of
+// the ClusterDeployments of the given [github.com/K0rdent/kcm/api/v1beta1.Region]
+// if the Region is paused either manually or automatically.
+func regionPauseReason(region *kcmv1.Region) (reason, message string, paused bool) {
+	if _, ok := region.Annotations[kcmv1.RegionPauseAnnotation]; ok {
+		return kcmv1.PausedReason, fmt.Sprintf("Related Region %s is paused", region.Name), true
+	}
+
+	if region.IsAutoPaused() {
+		return kcmv1.RegionUnreachableReason, fmt.Sprintf("Related Region %s is automatically paused since its cluster is unreachable", region.Name), true
+	}
+
+	return "", "", false
+}
+
 func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, scope *clusterScope) (_ ctrl.Result, err error) {
 	l := ctrl.LoggerFrom(ctx)
 
@@ -321,14 +353,28 @@ func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, scope
 		err = errors.Join(err, r.updateStatus(ctx, oldCD, cd, clusterTpl))
 	}()
 
+	if migration, ok := cd.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation]; ok {
+		l.Info("ClusterDeployment is being migrated, skipping reconciliation", "migration", migration)
+		if apimeta.SetStatusCondition(&cd.Status.Conditions, metav1.Condition{
+			Type:               kcmv1.PausedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             kcmv1.MigratingReason,
+			Message:            fmt.Sprintf("ClusterDeployment is being migrated by the ClusterDeploymentMigration %s", migration),
+			ObservedGeneration: cd.Generation,
+		}) {
+			r.eventf(cd, "MigrationStarted", "ClusterDeployment is being migrated by the ClusterDeploymentMigration %s, skipping reconciliation", migration)
+		}
+		return ctrl.Result{}, nil
+	}
+
 	if scope.region != nil {
-		if _, ok := scope.region.Annotations[kcmv1.RegionPauseAnnotation]; ok {
-			l.Info("Region is paused for this ClusterDeployment, skipping reconciliation")
+		if reason, message, paused := regionPauseReason(scope.region); paused {
+			l.Info("Region is paused for this ClusterDeployment, skipping reconciliation", "reason", reason)
 			if apimeta.SetStatusCondition(&cd.Status.Conditions, metav1.Condition{
 				Type:               kcmv1.PausedCondition,
 				Status:             metav1.ConditionTrue,
-				Reason:             kcmv1.PausedReason,
-				Message:            fmt.Sprintf("Related Region %s is paused", scope.region.Name),
+				Reason:             reason,
+				Message:            message,
 				ObservedGeneration: cd.Generation,
 			}) {
 				r.eventf(cd, "RegionPaused", "Region %s is paused, skipping reconciliation", scope.region.Name)
@@ -345,6 +391,9 @@ func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, scope
 		}) {
 			r.eventf(cd, "RegionUnpaused", "Region %s has been unpaused", scope.region.Name)
 		}
+	} else {
+		// the ClusterDeployment might have been migrated from a region to the management cluster
+		apimeta.RemoveStatusCondition(&cd.Status.Conditions, kcmv1.PausedCondition)
 	}
 
 	if err := r.handleCertificateSecrets(ctx, scope.rgnClient, cd); err != nil {
@@ -367,6 +416,10 @@ func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, scope
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reportTemplatesDeprecation(ctx, scope); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	ipamEnabled := cd.Spec.IPAMClaim.ClusterIPAMClaimRef != "" || cd.Spec.IPAMClaim.ClusterIPAMClaimSpec != nil
 	if ipamEnabled {
 		// we need to wait until IPAM is bound before processing ClusterDeployment, otherwise we will
@@ -394,13 +447,44 @@ func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, scope
 		}
 	}
 
+	window, err := maintenanceutil.ForClusterDeployment(ctx, r.MgmtClient, cd)
+	if errors.Is(err, maintenanceutil.ErrInvalidNamespaceWindow) {
+		// the namespace annotations are not validated on admission, so the invalid window
+		// is ignored rather than blocking the reconciliation of every cluster in the namespace
+		r.warnf(cd, "InvalidMaintenanceWindow", "Ignoring the maintenance window: %v", err)
+		window, err = nil, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get maintenance window: %w", err)
+	}
+	if scope.maintenance, err = maintenanceutil.Evaluate(window, time.Now()); err != nil {
+		r.warnf(cd, "InvalidMaintenanceWindow", err.Error())
+		return ctrl.Result{}, err
+	}
+
 	clusterRes, clusterErr := r.updateCluster(ctx, clusterTpl, scope)
-	servicesErr := r.updateServices(ctx, cd)
+	servicesErr := r.updateServices(ctx, scope)
+	r.setMaintenanceWindowPendingCondition(scope)
 
 	if err := errors.Join(clusterErr, servicesErr); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if len(scope.pendingChanges) > 0 {
+		requeueAfter := time.Until(scope.maintenance.NextOpening)
+		if clusterRes.RequeueAfter == 0 || requeueAfter < clusterRes.RequeueAfter {
+			clusterRes.RequeueAfter = requeueAfter
+		}
+	}
+
+	// the deprecation condition must switch to the end of life in time
+	if !scope.nextEndOfLife.IsZero() {
+		requeueAfter := time.Until(scope.nextEndOfLife)
+		if clusterRes.RequeueAfter == 0 || requeueAfter < clusterRes.RequeueAfter {
+			clusterRes.RequeueAfter = requeueAfter
+		}
+	}
+
 	if !clusterRes.IsZero() {
 		return clusterRes, nil
 	}
@@ -437,7 +521,11 @@ func (r *ClusterDeploymentReconciler) updateCluster(
 	}
 
 	if cd.Spec.DryRun {
-		return r.handleDryRun(ctx, cd, clusterTpl)
+		return r.handleDryRun(ctx, scope, clusterTpl)
+	}
+
+	if err := r.deleteDryRunPreview(ctx, cd); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	requeue, err := r.ensureClusterResources(ctx, scope)
@@ -465,9 +553,13 @@ func (r *ClusterDeploymentReconciler) validateAndPrepareCluster(
 		}
 	}
 
-	if err := r.validateConfig(ctx, cd, clusterTpl); err != nil {
+	rel, err := r.validateConfig(ctx, cd, clusterTpl)
+	if err != nil {
 		return fmt.Errorf("failed to validate ClusterDeployment configuration: %w", err)
 	}
+	if rel != nil {
+		scope.renderedManifest = rel.Manifest
+	}
 
 	if !r.IsDisabledValidationWH {
 		if !scope.cred.Status.Ready {
@@ -482,16 +574,159 @@ func (r *ClusterDeploymentReconciler) validateAndPrepareCluster(
 	return nil
 }
 
-func (r *ClusterDeploymentReconciler) handleDryRun(ctx context.Context, cd *kcmv1.ClusterDeployment, clusterTpl *kcmv1.ClusterTemplate) (ctrl.Result, error) {
+func (r *ClusterDeploymentReconciler) handleDryRun(ctx context.Context, scope *clusterScope, clusterTpl *kcmv1.ClusterTemplate) (ctrl.Result, error) {
+	cd := scope.cd
+
 	if err := r.detectHelmChartNameChange(ctx, cd, clusterTpl); err != nil {
 		ctrl.LoggerFrom(ctx).Error(err, "Detecting Helm chart name change")
 		return ctrl.Result{}, fmt.Errorf("detecting Helm chart name change: %w", err)
 	}
 
+	if err := r.ensureDryRunPreview(ctx, scope); err != nil {
+		err = fmt.Errorf("failed to ensure dry-run preview: %w", err)
+		r.warnf(cd, "DryRunPreviewFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
 	r.eventf(cd, "DryRunEnabled", "DryRun mode is enabled. Remove spec.dryRun to proceed with the deployment")
 	return ctrl.Result{}, nil
 }
 
+// ensureDryRunPreview stores the rendered manifest, the manifest of the currently deployed Helm release
+// and the diff between them in a ConfigMap owned by the ClusterDeployment, and reflects the summary
+// in the ClusterDeployment status.
+func (r *ClusterDeploymentReconciler) ensureDryRunPreview(ctx context.Context, scope *clusterScope) error {
+	cd := scope.cd
+
+	deployedManifest, revision, err := r.getDeployedManifest(ctx, scope)
+	if err != nil {
+		return fmt.Errorf("failed to get deployed manifest: %w", err)
+	}
+
+	diff, err := helm.DiffManifests(deployedManifest, scope.renderedManifest)
+	if err != nil {
+		return fmt.Errorf("failed to diff manifests: %w", err)
+	}
+
+	cmName := r.getDryRunPreviewConfigMapName(cd.Name)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmName,
+			Namespace: cd.Namespace,
+		},
+	}
+
+	data := map[string]string{
+		kcmv1.DryRunPreviewManifestKey:         scope.renderedManifest,
+		kcmv1.DryRunPreviewDeployedManifestKey: deployedManifest,
+		kcmv1.DryRunPreviewDiffKey:             diff.String(),
+	}
+	truncated := truncateDryRunPreview(data, dryRunPreviewMaxSize,
+		kcmv1.DryRunPreviewDiffKey, kcmv1.DryRunPreviewManifestKey, kcmv1.DryRunPreviewDeployedManifestKey)
+
+	operation, err := controllerutil.CreateOrUpdate(ctx, r.MgmtClient, configMap, func() error {
+		configMap.Data = data
+
+		return controllerutil.SetControllerReference(cd, configMap, r.MgmtClient.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update dry-run preview ConfigMap %s/%s: %w", cd.Namespace, cmName, err)
+	}
+
+	if operation == controllerutil.OperationResultCreated {
+		r.eventf(cd, "DryRunPreviewCreated", "Successfully created dry-run preview ConfigMap %s/%s", cd.Namespace, cmName)
+	}
+	if operation == controllerutil.OperationResultUpdated {
+		r.eventf(cd, "DryRunPreviewUpdated", "Successfully updated dry-run preview ConfigMap %s/%s", cd.Namespace, cmName)
+	}
+
+	digest := sha256.Sum256([]byte(scope.renderedManifest))
+	cd.Status.DryRunPreview = &kcmv1.DryRunPreview{
+		ConfigMapName:    cmName,
+		ManifestDigest:   "sha256:" + hex.EncodeToString(digest[:]),
+		DeployedRevision: revision,
+		Objects:          diff.Objects,
+		Added:            len(diff.Added),
+		Changed:          len(diff.Changed),
+		Removed:          len(diff.Removed),
+		TruncatedKeys:    truncated,
+	}
+
+	return nil
+}
+
+// dryRunPreviewMaxSize is the maximum total size of the values of the dry-run preview ConfigMap,
+// leaving room for the keys and the metadata within the 1 MiB size limit of an object.
+const dryRunPreviewMaxSize = 1000 << 10
+
+// truncateDryRunPreview truncates the values of the given data to fit their total size into the given limit.
+// The values are fitted in the given order of the keys, so the values of the latter keys are truncated first.
+// The truncated values are cut at a line boundary and end with a marker. Returns the truncated keys.
+func truncateDryRunPreview(data map[string]string, limit int, keys ...string) []string {
+	var truncated []string
+	for _, key := range keys {
+		value := data[key]
+		if len(value) <= limit {
+			limit -= len(value)
+			continue
+		}
+
+		marker := fmt.Sprintf("# ... truncated, %d bytes in total\n", len(value))
+		kept := value[:max(limit-len(marker), 0)]
+		if i := strings.LastIndexByte(kept, '\n'); i >= 0 {
+			kept = kept[:i+1]
+		} else {
+			kept = ""
+		}
+
+		data[key] = kept + marker
+		limit = max(limit-len(data[key]), 0)
+		truncated = append(truncated, key)
+	}
+
+	return truncated
+}
+
+// getDeployedManifest returns the manifest and the revision of the Helm release
+// currently deployed for the ClusterDeployment, if any.
+func (r *ClusterDeploymentReconciler) getDeployedManifest(ctx context.Context, scope *clusterScope) (string, int, error) {
+	hr := new(helmcontrollerv2.HelmRelease)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKeyFromObject(scope.cd), hr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", 0, nil
+		}
+		return "", 0, fmt.Errorf("failed to get HelmRelease %s: %w", client.ObjectKeyFromObject(scope.cd), err)
+	}
+
+	// the release storage resides in the cluster the HelmRelease targets
+	return helm.GetDeployedManifest(ctx, scope.rgnClient, hr)
+}
+
+// deleteDryRunPreview removes the dry-run preview ConfigMap and clears
+// the corresponding status once the DryRun mode is disabled.
+func (r *ClusterDeploymentReconciler) deleteDryRunPreview(ctx context.Context, cd *kcmv1.ClusterDeployment) error {
+	if cd.Status.DryRunPreview == nil {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cd.Status.DryRunPreview.ConfigMapName,
+			Namespace: cd.Namespace,
+		},
+	}
+	err := r.MgmtClient.Delete(ctx, configMap)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete dry-run preview ConfigMap %s: %w", client.ObjectKeyFromObject(configMap), err)
+	}
+	if err == nil {
+		r.eventf(cd, "DryRunPreviewDeleted", "Deleted dry-run preview ConfigMap %s", client.ObjectKeyFromObject(configMap))
+	}
+
+	cd.Status.DryRunPreview = nil
+	return nil
+}
+
 func (r *ClusterDeploymentReconciler) ensureClusterResources(ctx context.Context, scope *clusterScope) (requeue bool, err error) {
 	cd := scope.cd
 
@@ -549,6 +784,16 @@ func (r *ClusterDeploymentReconciler) reconcileHelmRelease(
 		return ctrl.Result{}, fmt.Errorf("failed to get HelmRelease reconcile opts: %w", err)
 	}
 
+	heldHR, err := r.heldTemplateUpgrade(ctx, clusterTpl, scope)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if heldHR != nil {
+		// the values may only be valid for the chart of the new template
+		hrReconcileOpts.ChartRef = heldHR.Spec.ChartRef
+		hrReconcileOpts.Values = heldHR.Spec.Values
+	}
+
 	// Now create the CAPI cluster by helm releasing the helm chart associated with the cluster template.
 	capiClusterKey := client.ObjectKeyFromObject(cd)
 	hr, operation, err := helm.ReconcileHelmRelease(ctx, r.MgmtClient, capiClusterKey.Name, capiClusterKey.Namespace, hrReconcileOpts)
@@ -595,6 +840,70 @@ func (r *ClusterDeploymentReconciler) reconcileHelmRelease(
 	return ctrl.Result{}, nil
 }
 
+// heldTemplateUpgrade returns the existing HelmRelease if it has been installed from a chart other
+// than the one of the given ClusterTemplate and the maintenance window is closed, so the chart and
+// the values of the HelmRelease are kept until the window opens while the rest of the changes are applied.
+// Otherwise returns nil.
+func (r *ClusterDeploymentReconciler) heldTemplateUpgrade(ctx context.Context, clusterTpl *kcmv1.ClusterTemplate, scope *clusterScope) (*helmcontrollerv2.HelmRelease, error) {
+	cd := scope.cd
+	if scope.maintenance.Open || clusterTpl.Status.ChartRef == nil {
+		return nil, nil //nolint:nilnil // nothing is held
+	}
+
+	existingHR := &helmcontrollerv2.HelmRelease{}
+	if err := r.MgmtClient.Get(ctx, client.ObjectKeyFromObject(cd), existingHR); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the initial installation is not held
+			return nil, nil //nolint:nilnil // nothing is held
+		}
+		return nil, fmt.Errorf("failed to get HelmRelease %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+
+	if existingHR.Spec.ChartRef == nil ||
+		(existingHR.Spec.ChartRef.Name == clusterTpl.Status.ChartRef.Name &&
+			existingHR.Spec.ChartRef.Namespace == clusterTpl.Status.ChartRef.Namespace) {
+		return nil, nil //nolint:nilnil // nothing is held
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Postponing ClusterTemplate upgrade until the maintenance window opens",
+		"template", clusterTpl.Name, "next opening", scope.maintenance.NextOpening)
+	scope.pendingChanges = append(scope.pendingChanges, "upgrade to ClusterTemplate "+clusterTpl.Name)
+	return existingHR, nil
+}
+
+// setMaintenanceWindowPendingCondition reports the changes postponed until the maintenance window opens.
+func (r *ClusterDeploymentReconciler) setMaintenanceWindowPendingCondition(scope *clusterScope) {
+	cd := scope.cd
+	if len(scope.pendingChanges) == 0 {
+		_ = apimeta.RemoveStatusCondition(cd.GetConditions(), kcmv1.MaintenanceWindowPendingCondition)
+		return
+	}
+
+	msg := maintenanceutil.PendingMessage(strings.Join(scope.pendingChanges, ", "), scope.maintenance)
+	if r.setCondition(cd, kcmv1.MaintenanceWindowPendingCondition, kcmv1.PendingMaintenanceWindowReason, metav1.ConditionTrue, errors.New(msg)) {
+		r.eventf(cd, kcmv1.PendingMaintenanceWindowReason, msg)
+	}
+}
+
+// reportTemplatesDeprecation reports the deprecated templates used by the cluster
+// in the status and the events, and tracks the usage of the templates.
+func (r *ClusterDeploymentReconciler) reportTemplatesDeprecation(ctx context.Context, scope *clusterScope) error {
+	cd := scope.cd
+	templates, err := deprecationutil.Resolve(ctx, r.MgmtClient, cd.Namespace, cd.Spec.Template, serviceTemplateNames(cd.Spec.ServiceSpec.Services))
+	if err != nil {
+		return fmt.Errorf("failed to get deprecation of the templates: %w", err)
+	}
+
+	now := time.Now()
+	if cond, changed := setTemplateDeprecatedCondition(&cd.Status.Conditions, templates, now, cd.Generation); changed {
+		r.warnf(cd, "Template"+cond.Reason, cond.Message)
+	}
+	trackTemplatesUsage(ctx, kcmv1.ClusterDeploymentKind, cd.ObjectMeta, templates, now, kcmv1.ClusterTemplateKind, kcmv1.ServiceTemplateKind)
+	scope.nextEndOfLife = deprecationutil.NextEndOfLife(templates, now)
+
+	return nil
+}
+
 // detectHelmChartNameChange checks if the Helm chart name in the new ClusterTemplate differs from
 // the chart name in the currently deployed HelmRelease
 func (r *ClusterDeploymentReconciler) detectHelmChartNameChange(ctx context.Context, cd *kcmv1.ClusterDeployment, clusterTpl *kcmv1.ClusterTemplate) error {
@@ -1202,6 +1511,10 @@ func (*ClusterDeploymentReconciler) getAuditPolicyConfigMapName(cdName string) s
 	return cdName + "-audit-policy"
 }
 
+func (*ClusterDeploymentReconciler) getDryRunPreviewConfigMapName(cdName string) string {
+	return cdName + "-dry-run-preview"
+}
+
 // fillDataSourceValues passes the ClusterDataSource secrets to all the ClusterDeployments if
 // the [github.com/K0rdent/kcm/api/v1beta1.DataSource] was provided in the
 // [github.com/K0rdent/kcm/api/v1beta1.ClusterDeployment] spec and
@@ -1233,14 +1546,14 @@ func (*ClusterDeploymentReconciler) fillDataSourceValues(scope *clusterScope, va
 	values["dataSource"] = val
 }
 
-func (r *ClusterDeploymentReconciler) validateConfig(ctx context.Context, cd *kcmv1.ClusterDeployment, clusterTpl *kcmv1.ClusterTemplate) error {
+func (r *ClusterDeploymentReconciler) validateConfig(ctx context.Context, cd *kcmv1.ClusterDeployment, clusterTpl *kcmv1.ClusterTemplate) (*release.Release, error) {
 	helmChartArtifact, err := r.getSourceArtifact(ctx, clusterTpl.Status.ChartRef)
 	if err != nil {
 		err = fmt.Errorf("failed to get HelmChart Artifact: %w", err)
 		if r.setCondition(cd, kcmv1.HelmChartReadyCondition, kcmv1.FailedReason, metav1.ConditionFalse, err) {
 			r.warnf(cd, "InvalidSource", err.Error())
 		}
-		return err
+		return nil, err
 	}
 
 	l := ctrl.LoggerFrom(ctx)
@@ -1251,26 +1564,27 @@ func (r *ClusterDeploymentReconciler) validateConfig(ctx context.Context, cd *kc
 		if r.setCondition(cd, kcmv1.HelmChartReadyCondition, kcmv1.FailedReason, metav1.ConditionFalse, err) {
 			r.warnf(cd, "HelmChartDownloadFailed", err.Error())
 		}
-		return err
+		return nil, err
 	}
 
 	l.Info("Initializing Helm client")
 	actionConfig, err := r.InitializeConfiguration(cd, l.WithName("helm-actor").V(1).Info)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	l.Info("Validating Helm chart with provided values")
-	if err := r.EnsureReleaseWithValues(ctx, actionConfig, hcChart, cd); err != nil {
+	rel, err := r.EnsureReleaseWithValues(ctx, actionConfig, hcChart, cd)
+	if err != nil {
 		err = fmt.Errorf("failed to validate template with provided configuration: %w", err)
 		if r.setCondition(cd, kcmv1.HelmChartReadyCondition, kcmv1.FailedReason, metav1.ConditionFalse, err) {
 			r.warnf(cd, "ValidationError", "Invalid configuration provided: %s", err)
 		}
-		return err
+		return nil, err
 	}
 
 	r.setCondition(cd, kcmv1.HelmChartReadyCondition, kcmv1.SucceededReason, metav1.ConditionTrue, nil)
-	return nil
+	return rel, nil
 }
 
 func (*ClusterDeploymentReconciler) initClusterConditions(cd *kcmv1.ClusterDeployment) (changed bool) {
@@ -1400,7 +1714,8 @@ func (*ClusterDeploymentReconciler) setCondition(cd *kcmv1.ClusterDeployment, ty
 }
 
 // updateServices reconciles services provided in ClusterDeployment.Spec.ServiceSpec.
-func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kcmv1.ClusterDeployment) error {
+func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, scope *clusterScope) error {
+	cd := scope.cd
 	l := ctrl.LoggerFrom(ctx)
 	l.Info("Reconciling Services")
 
@@ -1415,7 +1730,7 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 		r.setCondition(cd, kcmv1.ServicesDependencyValidationCondition, kcmv1.SucceededReason, metav1.ConditionTrue, nil)
 	}
 
-	err := r.createOrUpdateServiceSet(ctx, cd)
+	err := r.createOrUpdateServiceSet(ctx, cd, propagatedCredentialVersion(cd, scope.cred), !scope.maintenance.Open)
 	if err != nil {
 		return fmt.Errorf("failed to create or update ServiceSet for ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
 	}
@@ -1429,6 +1744,17 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 	// we'll update services' statuses
 	cd.Status.Services = r.collectServicesStatuses(serviceSetList.Items)
 
+	if !scope.maintenance.Open {
+		for _, serviceSet := range serviceSetList.Items {
+			if serviceSet.Name != cd.Name || serviceSet.Spec.MultiClusterService != "" {
+				continue
+			}
+			if pending := serviceset.PendingUpgrades(cd.Spec.ServiceSpec.Services, &serviceSet); len(pending) > 0 {
+				scope.pendingChanges = append(scope.pendingChanges, "upgrade of services "+strings.Join(pending, ", "))
+			}
+		}
+	}
+
 	// we'll update services' upgrade paths
 	upgradePaths, err := serviceset.ServicesUpgradePaths(ctx, r.MgmtClient, cd.Spec.ServiceSpec.Services, cd.Namespace)
 	cd.Status.ServicesUpgradePaths = upgradePaths
@@ -1521,6 +1847,10 @@ func (r *ClusterDeploymentReconciler) updateStatus(ctx context.Context, oldObj,
 		return fmt.Errorf("failed to set available upgrades: %w", err)
 	}
 
+	if err := r.setUpgradeBreakingChanges(ctx, newObj, template); err != nil {
+		return fmt.Errorf("failed to set upgrade breaking changes: %w", err)
+	}
+
 	if equality.Semantic.DeepEqual(oldObj.Status, newObj.Status) {
 		return nil
 	}
@@ -2027,6 +2357,55 @@ func (r *ClusterDeploymentReconciler) setAvailableUpgrades(ctx context.Context,
 	return nil
 }
 
+// setUpgradeBreakingChanges reports the breaking changes of the values schemas of the available upgrades
+// affecting the configuration of the ClusterDeployment.
+func (r *ClusterDeploymentReconciler) setUpgradeBreakingChanges(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment, clusterTpl *kcmv1.ClusterTemplate) error {
+	clusterDeployment.Status.UpgradeBreakingChanges = nil
+	if clusterTpl == nil || len(clusterDeployment.Status.AvailableUpgrades) == 0 {
+		return nil
+	}
+
+	currentSchema, err := valuesschemautil.Get(ctx, r.MgmtClient, clusterTpl.Namespace, clusterTpl.Status.SchemaConfigMapName)
+	if err != nil {
+		return err
+	}
+	if len(currentSchema) == 0 {
+		return nil
+	}
+
+	for _, name := range clusterDeployment.Status.AvailableUpgrades {
+		target := new(kcmv1.ClusterTemplate)
+		if err := r.MgmtClient.Get(ctx, client.ObjectKey{Namespace: clusterTpl.Namespace, Name: name}, target); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", clusterTpl.Namespace, name, err)
+		}
+
+		targetSchema, err := valuesschemautil.Get(ctx, r.MgmtClient, target.Namespace, target.Status.SchemaConfigMapName)
+		if err != nil {
+			return err
+		}
+
+		changes, err := valuesschemautil.UpgradeChanges(currentSchema, targetSchema, clusterDeployment.Spec.Config, clusterTpl, target)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to compare values schemas", "template", name)
+			continue
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		breaking := kcmv1.UpgradeBreakingChanges{Template: name, Changes: make([]string, 0, len(changes))}
+		for _, c := range changes {
+			breaking.Changes = append(breaking.Changes, c.String())
+		}
+		clusterDeployment.Status.UpgradeBreakingChanges = append(clusterDeployment.Status.UpgradeBreakingChanges, breaking)
+	}
+
+	return nil
+}
+
 // templatesValidUpdateSource is a source of update and create events which enqueues ClusterDeployment objects if the referenced ServiceTemplate or ClusterTemplate object gets the valid status.
 func (*ClusterDeploymentReconciler) templatesValidUpdateSource(cl client.Client, cache crcache.Cache, obj client.Object) source.TypedSource[ctrl.Request] {
 	var isServiceTemplateKind bool // quick kludge to avoid complicated switches
@@ -2245,12 +2624,16 @@ func configNeedsUpdate(config *apiextv1.JSON, providerData []kcmv1.ClusterIPAMPr
 func (r *ClusterDeploymentReconciler) createOrUpdateServiceSet(
 	ctx context.Context,
 	cd *kcmv1.ClusterDeployment,
+	credentialVersion string,
+	holdUpgrades bool,
 ) error {
 	serviceSetObjectKey := client.ObjectKeyFromObject(cd)
 	opRequisites := serviceset.OperationRequisites{
-		ObjectKey:       serviceSetObjectKey,
-		CD:              cd,
-		SystemNamespace: r.SystemNamespace,
+		ObjectKey:         serviceSetObjectKey,
+		CD:                cd,
+		SystemNamespace:   r.SystemNamespace,
+		HoldUpgrades:      holdUpgrades,
+		CredentialVersion: credentialVersion,
 	}
 
 	serviceSet, op, err := serviceset.GetServiceSetWithOperation(ctx, r.MgmtClient, opRequisites)
@@ -2264,6 +2647,15 @@ func (r *ClusterDeploymentReconciler) createOrUpdateServiceSet(
 	return serviceset.NewProcessor(r.MgmtClient).CreateOrUpdateServiceSet(ctx, op, serviceSet)
 }
 
+// propagatedCredentialVersion returns the version of the rotated credentials
+// propagated to the given ClusterDeployment, empty if none.
+func propagatedCredentialVersion(cd *kcmv1.ClusterDeployment, cred *kcmv1.Credential) string {
+	if cd.Spec.PropagateCredentials == nil || !*cd.Spec.PropagateCredentials || cred == nil || cred.Status.Rotation == nil {
+		return ""
+	}
+	return cred.Status.Rotation.Version
+}
+
 // TODO: FIXME: pass meaningful non-empty action
 func (*ClusterDeploymentReconciler) eventf(cd *kcmv1.ClusterDeployment, reason, message string, args ...any) {
 	record.Eventf(cd, nil, reason, "Reconcile", message, args...)
@@ -2373,6 +2765,16 @@ func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&kcmv1.Credential{},
 			kubeutil.EnqueueRequestsFromMapFunc(mapObjectsToClusterDeployments(kcmv1.ClusterDeploymentCredentialIndexKey)),
 		).
+		Watches(
+			&kcmv1.ClusterTemplate{},
+			kubeutil.EnqueueRequestsFromMapFunc(mapObjectsToClusterDeployments(kcmv1.ClusterDeploymentTemplateIndexKey)),
+			builder.WithPredicates(templateDeprecationChangedPredicate),
+		).
+		Watches(
+			&kcmv1.ServiceTemplate{},
+			kubeutil.EnqueueRequestsFromMapFunc(mapObjectsToClusterDeployments(kcmv1.ClusterDeploymentServiceTemplatesIndexKey)),
+			builder.WithPredicates(templateDeprecationChangedPredicate),
+		).
 		Watches(
 			&kcmv1.ClusterAuthentication{},
 			kubeutil.EnqueueRequestsFromMapFunc(mapObjectsToClusterDeployments(kcmv1.ClusterDeploymentAuthenticationIndexKey)),
@@ -2429,7 +2831,13 @@ func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					}
 					_, okn := tue.ObjectNew.GetAnnotations()[kcmv1.RegionPauseAnnotation]
 					_, oko := tue.ObjectOld.GetAnnotations()[kcmv1.RegionPauseAnnotation]
-					return !okn && oko // new without; old with
+					if !okn && oko { // new without; old with
+						return true
+					}
+
+					newRgn, okn := tue.ObjectNew.(*kcmv1.Region)
+					oldRgn, oko := tue.ObjectOld.(*kcmv1.Region)
+					return okn && oko && newRgn.IsAutoPaused() != oldRgn.IsAutoPaused()
 				},
 			}),
 		).
@@ -2446,6 +2854,23 @@ func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		setupLog.Info("Validations are disabled, watcher for ClusterTemplate objects is set")
 	}
 
+	// the changes of the CAPI Clusters are watched on the management and regional clusters
+	capiWatcher := multiclusterutil.NewWatcher(
+		r.MgmtClient,
+		mgr.GetCache(),
+		r.SystemNamespace,
+		r.capiClusterToClusterDeployment,
+		[]client.Object{&clusterapiv1.Cluster{}},
+		multiclusterutil.WithName("clusterdeployment_capi_watcher"),
+	)
+
+	if err := mgr.Add(capiWatcher); err != nil {
+		return fmt.Errorf("failed to add ClusterDeployment CAPI Cluster watcher: %w", err)
+	}
+
+	managedController.WatchesRawSource(source.TypedChannel(capiWatcher.GetEventChannel(), &handler.TypedEnqueueRequestForObject[*kcmv1.ClusterDeployment]{}))
+
+	// the poller is a low-frequency safety net for the events missed by the watcher
 	if r.CAPIClusterPollInterval > 0 {
 		capiPoller := pollerutil.NewRunner(
 			r.capiClusterPollEnqueue,
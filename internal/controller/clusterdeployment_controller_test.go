@@ -15,8 +15,11 @@
 package controller
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -35,7 +38,9 @@ import (
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
 	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -56,6 +61,7 @@ import (
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
 	conditionsutil "github.com/K0rdent/kcm/internal/util/conditions"
+	maintenanceutil "github.com/K0rdent/kcm/internal/util/maintenance"
 	testscheme "github.com/K0rdent/kcm/test/scheme"
 )
 
@@ -184,8 +190,8 @@ func (*fakeHelmActor) InitializeConfiguration(_ *kcmv1.ClusterDeployment, _ acti
 	return &action.Configuration{}, nil
 }
 
-func (*fakeHelmActor) EnsureReleaseWithValues(_ context.Context, _ *action.Configuration, _ *chart.Chart, _ *kcmv1.ClusterDeployment) error {
-	return nil
+func (*fakeHelmActor) EnsureReleaseWithValues(_ context.Context, _ *action.Configuration, _ *chart.Chart, _ *kcmv1.ClusterDeployment) (*release.Release, error) {
+	return &release.Release{}, nil
 }
 
 // cldTestCase holds parameters for a single ClusterDeployment reconciliation test.
@@ -439,6 +445,64 @@ func (tc *cldTestCase) testClusterDeploymentReconciliation(reconciler *ClusterDe
 					))))
 			}).Should(Succeed())
 		})
+
+		By("Should not reconcile ClusterDeployment if region is automatically paused", func() {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: tc.region}, region)).To(Succeed())
+
+			meta.SetStatusCondition(&region.Status.Conditions, metav1.Condition{
+				Type:    kcmv1.PausedCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  kcmv1.RegionUnreachableReason,
+				Message: "Regional cluster has been unreachable",
+			})
+			Expect(k8sClient.Status().Update(ctx, region)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(mgrClient.Get(ctx, types.NamespacedName{Name: tc.region}, region)).To(Succeed())
+				g.Expect(region.IsAutoPaused()).To(BeTrue())
+			}).Should(Succeed())
+
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: cldName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			Eventually(func(g Gomega) {
+				g.Expect(mgrClient.Get(ctx, cldName, cld)).To(Succeed())
+				g.Expect(cld).Should(
+					HaveField("Status.Conditions", ContainElement(SatisfyAll(
+						HaveField("Type", kcmv1.PausedCondition),
+						HaveField("Status", metav1.ConditionTrue),
+						HaveField("Reason", kcmv1.RegionUnreachableReason),
+						HaveField("Message", Equal(fmt.Sprintf("Related Region %s is automatically paused since its cluster is unreachable", tc.region))),
+					))))
+			}).Should(Succeed())
+		})
+
+		By("Should reconcile ClusterDeployment when region is automatically unpaused", func() {
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: tc.region}, region)).To(Succeed())
+
+			meta.RemoveStatusCondition(&region.Status.Conditions, kcmv1.PausedCondition)
+			Expect(k8sClient.Status().Update(ctx, region)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(mgrClient.Get(ctx, types.NamespacedName{Name: tc.region}, region)).To(Succeed())
+				g.Expect(region.IsAutoPaused()).To(BeFalse())
+			}).Should(Succeed())
+
+			result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: cldName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			Eventually(func(g Gomega) {
+				g.Expect(mgrClient.Get(ctx, cldName, cld)).To(Succeed())
+				g.Expect(cld).Should(
+					HaveField("Status.Conditions", ContainElement(SatisfyAll(
+						HaveField("Type", kcmv1.PausedCondition),
+						HaveField("Status", metav1.ConditionFalse),
+						HaveField("Reason", kcmv1.NotPausedReason),
+					))))
+			}).Should(Succeed())
+		})
 	}
 
 	result, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: cldName})
@@ -553,6 +617,14 @@ func (tc *cldTestCase) testClusterDeploymentReconciliation(reconciler *ClusterDe
 			hr := &helmcontrollerv2.HelmRelease{}
 			Expect(apierrors.IsNotFound(k8sClient.Get(ctx, crclient.ObjectKeyFromObject(cld), hr))).To(BeTrue())
 		})
+		By("Expect dry-run preview ConfigMap to be created in DryRun mode", func() {
+			Expect(mgrClient.Get(ctx, cldName, cld)).To(Succeed())
+			Expect(cld.Status.DryRunPreview).NotTo(BeNil())
+
+			cm := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, crclient.ObjectKey{Namespace: cld.Namespace, Name: cld.Status.DryRunPreview.ConfigMapName}, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKey(kcmv1.DryRunPreviewManifestKey))
+		})
 		return
 	}
 
@@ -2900,3 +2972,304 @@ func Test_ensureAuditPolicyConfigMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_ensureDryRunPreview(t *testing.T) {
+	const (
+		cdName      = "test-cd"
+		cdNamespace = "default"
+		cmName      = cdName + "-dry-run-preview"
+	)
+
+	clusterManifest := func(replicas int) string {
+		return fmt.Sprintf(`---
+# Source: chart/templates/cluster.yaml
+apiVersion: cluster.x-k8s.io/v1beta2
+kind: Cluster
+metadata:
+  name: %[1]s
+---
+# Source: chart/templates/machinedeployment.yaml
+apiVersion: cluster.x-k8s.io/v1beta2
+kind: MachineDeployment
+metadata:
+  name: %[1]s-md
+spec:
+  replicas: %[2]d
+`, cdName, replicas)
+	}
+
+	oldManifest := clusterManifest(1) + `---
+# Source: chart/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: ` + cdName + `-old
+`
+	newManifest := clusterManifest(3) + `---
+# Source: chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ` + cdName + `-new
+`
+
+	encodeRelease := func(t *testing.T, rel *release.Release) []byte {
+		t.Helper()
+
+		b, err := json.Marshal(rel)
+		if err != nil {
+			t.Fatalf("failed to marshal release: %v", err)
+		}
+
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(b); err != nil {
+			t.Fatalf("failed to compress release: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed to compress release: %v", err)
+		}
+
+		return []byte(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	}
+
+	tests := []struct {
+		name             string
+		deployedRelease  *release.Release
+		expectedPreview  *kcmv1.DryRunPreview
+		expectedDiff     string
+		expectedDeployed string
+	}{
+		{
+			name: "no HelmRelease, all objects are added",
+			expectedPreview: &kcmv1.DryRunPreview{
+				ConfigMapName: cmName,
+				Objects:       3,
+				Added:         3,
+			},
+			expectedDiff: "+ Cluster.cluster.x-k8s.io/" + cdName + "\n" +
+				"+ ConfigMap/" + cdName + "-new\n" +
+				"+ MachineDeployment.cluster.x-k8s.io/" + cdName + "-md\n",
+		},
+		{
+			name:            "deployed release, objects are added, changed and removed",
+			deployedRelease: &release.Release{Name: cdName, Namespace: cdNamespace, Version: 2, Manifest: oldManifest},
+			expectedPreview: &kcmv1.DryRunPreview{
+				ConfigMapName:    cmName,
+				DeployedRevision: 2,
+				Objects:          3,
+				Added:            1,
+				Changed:          1,
+				Removed:          1,
+			},
+			expectedDiff: "+ ConfigMap/" + cdName + "-new\n" +
+				"~ MachineDeployment.cluster.x-k8s.io/" + cdName + "-md\n" +
+				"- Secret/" + cdName + "-old\n",
+			expectedDeployed: oldManifest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cd := &kcmv1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cdName,
+					Namespace: cdNamespace,
+					UID:       "test-uid",
+				},
+			}
+
+			var objects []crclient.Object
+			if tt.deployedRelease != nil {
+				objects = append(objects,
+					&helmcontrollerv2.HelmRelease{
+						ObjectMeta: metav1.ObjectMeta{Name: cdName, Namespace: cdNamespace},
+						Status: helmcontrollerv2.HelmReleaseStatus{
+							History: helmcontrollerv2.Snapshots{
+								{Name: tt.deployedRelease.Name, Namespace: cdNamespace, Version: tt.deployedRelease.Version},
+							},
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fmt.Sprintf("sh.helm.release.v1.%s.v%d", tt.deployedRelease.Name, tt.deployedRelease.Version),
+							Namespace: cdNamespace,
+						},
+						Data: map[string][]byte{"release": encodeRelease(t, tt.deployedRelease)},
+					},
+				)
+			}
+
+			c := fake.NewClientBuilder().
+				WithScheme(testscheme.Scheme).
+				WithObjects(objects...).
+				Build()
+
+			r := &ClusterDeploymentReconciler{
+				MgmtClient: c,
+			}
+
+			scope := &clusterScope{
+				cd:               cd,
+				rgnClient:        c,
+				renderedManifest: newManifest,
+			}
+
+			if err := r.ensureDryRunPreview(t.Context(), scope); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			preview := cd.Status.DryRunPreview
+			if preview == nil {
+				t.Fatal("expected DryRunPreview status to be set")
+			}
+			if !strings.HasPrefix(preview.ManifestDigest, "sha256:") {
+				t.Errorf("expected manifest digest to be set, got %q", preview.ManifestDigest)
+			}
+			preview.ManifestDigest = ""
+			if !reflect.DeepEqual(preview, tt.expectedPreview) {
+				t.Errorf("unexpected DryRunPreview status: got %+v, want %+v", preview, tt.expectedPreview)
+			}
+
+			cm := &corev1.ConfigMap{}
+			if err := c.Get(t.Context(), crclient.ObjectKey{Name: cmName, Namespace: cdNamespace}, cm); err != nil {
+				t.Fatalf("expected configmap %s to exist, but got error: %v", cmName, err)
+			}
+			if cm.Data[kcmv1.DryRunPreviewManifestKey] != newManifest {
+				t.Errorf("unexpected rendered manifest in configmap: %q", cm.Data[kcmv1.DryRunPreviewManifestKey])
+			}
+			if cm.Data[kcmv1.DryRunPreviewDeployedManifestKey] != tt.expectedDeployed {
+				t.Errorf("unexpected deployed manifest in configmap: %q", cm.Data[kcmv1.DryRunPreviewDeployedManifestKey])
+			}
+			if cm.Data[kcmv1.DryRunPreviewDiffKey] != tt.expectedDiff {
+				t.Errorf("unexpected diff in configmap: got %q, want %q", cm.Data[kcmv1.DryRunPreviewDiffKey], tt.expectedDiff)
+			}
+			if !metav1.IsControlledBy(cm, cd) {
+				t.Error("expected configmap to be controlled by the ClusterDeployment")
+			}
+
+			if err := r.deleteDryRunPreview(t.Context(), cd); err != nil {
+				t.Fatalf("unexpected error on preview deletion: %v", err)
+			}
+			if cd.Status.DryRunPreview != nil {
+				t.Errorf("expected DryRunPreview status to be cleared, got %+v", cd.Status.DryRunPreview)
+			}
+			if err := c.Get(t.Context(), crclient.ObjectKey{Name: cmName, Namespace: cdNamespace}, cm); !apierrors.IsNotFound(err) {
+				t.Errorf("expected configmap %s to be deleted, got error: %v", cmName, err)
+			}
+		})
+	}
+}
+
+func Test_heldTemplateUpgrade(t *testing.T) {
+	t.Parallel()
+
+	const namespace = "ns"
+
+	clusterTpl := &kcmv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "template-2"},
+		Status: kcmv1.ClusterTemplateStatus{TemplateStatusCommon: kcmv1.TemplateStatusCommon{
+			ChartRef: &helmcontrollerv2.CrossNamespaceSourceReference{Kind: sourcev1.HelmChartKind, Namespace: namespace, Name: "chart-2"},
+		}},
+	}
+	newHelmRelease := func(chart string) *helmcontrollerv2.HelmRelease {
+		return &helmcontrollerv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"},
+			Spec: helmcontrollerv2.HelmReleaseSpec{
+				ChartRef: &helmcontrollerv2.CrossNamespaceSourceReference{Kind: sourcev1.HelmChartKind, Namespace: namespace, Name: chart},
+				Values:   &apiextv1.JSON{Raw: []byte(`{"old":"values"}`)},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		hr       *helmcontrollerv2.HelmRelease
+		open     bool
+		wantHeld bool
+	}{
+		"initial installation": {},
+		"maintenance window is open": {
+			hr:   newHelmRelease("chart-1"),
+			open: true,
+		},
+		"template is not changed": {
+			hr: newHelmRelease("chart-2"),
+		},
+		"template upgrade is held": {
+			hr:       newHelmRelease("chart-1"),
+			wantHeld: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder().WithScheme(testscheme.Scheme)
+			if tt.hr != nil {
+				builder = builder.WithObjects(tt.hr)
+			}
+			r := &ClusterDeploymentReconciler{MgmtClient: builder.Build()}
+			scope := &clusterScope{
+				cd:          &kcmv1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"}},
+				maintenance: maintenanceutil.State{Open: tt.open},
+			}
+
+			held, err := r.heldTemplateUpgrade(t.Context(), clusterTpl, scope)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantHeld {
+				if held != nil || len(scope.pendingChanges) != 0 {
+					t.Errorf("expected nothing to be held, got %v, pending changes %v", held, scope.pendingChanges)
+				}
+				return
+			}
+
+			if held == nil {
+				t.Fatal("expected the template upgrade to be held")
+			}
+			if held.Spec.ChartRef.Name != "chart-1" || string(held.Spec.Values.Raw) != `{"old":"values"}` {
+				t.Errorf("expected the chart and the values of the existing HelmRelease to be held, got %v %s", held.Spec.ChartRef, held.Spec.Values.Raw)
+			}
+			if want := []string{"upgrade to ClusterTemplate template-2"}; !reflect.DeepEqual(scope.pendingChanges, want) {
+				t.Errorf("pending changes = %v, want %v", scope.pendingChanges, want)
+			}
+		})
+	}
+}
+
+func Test_truncateDryRunPreview(t *testing.T) {
+	const diff = "+ ConfigMap/a\n+ ConfigMap/b\n"
+	manifest := strings.Repeat("kind: ConfigMap\n", 8)
+
+	t.Run("fits the limit", func(t *testing.T) {
+		data := map[string]string{kcmv1.DryRunPreviewDiffKey: diff, kcmv1.DryRunPreviewManifestKey: manifest}
+		if truncated := truncateDryRunPreview(data, len(diff)+len(manifest), kcmv1.DryRunPreviewDiffKey, kcmv1.DryRunPreviewManifestKey); len(truncated) != 0 {
+			t.Errorf("expected no truncated keys, got %v", truncated)
+		}
+		if data[kcmv1.DryRunPreviewDiffKey] != diff || data[kcmv1.DryRunPreviewManifestKey] != manifest {
+			t.Errorf("expected values to be kept, got %v", data)
+		}
+	})
+
+	t.Run("latter keys are truncated first", func(t *testing.T) {
+		data := map[string]string{kcmv1.DryRunPreviewDiffKey: diff, kcmv1.DryRunPreviewManifestKey: manifest}
+		limit := len(diff) + 64
+		truncated := truncateDryRunPreview(data, limit, kcmv1.DryRunPreviewDiffKey, kcmv1.DryRunPreviewManifestKey)
+		if !reflect.DeepEqual(truncated, []string{kcmv1.DryRunPreviewManifestKey}) {
+			t.Errorf("unexpected truncated keys %v", truncated)
+		}
+		if data[kcmv1.DryRunPreviewDiffKey] != diff {
+			t.Errorf("expected diff to be kept, got %q", data[kcmv1.DryRunPreviewDiffKey])
+		}
+
+		want := "kind: ConfigMap\n# ... truncated, 128 bytes in total\n"
+		if got := data[kcmv1.DryRunPreviewManifestKey]; got != want {
+			t.Errorf("unexpected truncated manifest: got %q, want %q", got, want)
+		}
+		if size := len(data[kcmv1.DryRunPreviewDiffKey]) + len(data[kcmv1.DryRunPreviewManifestKey]); size > limit {
+			t.Errorf("total size %d exceeds the limit %d", size, limit)
+		}
+	})
+}
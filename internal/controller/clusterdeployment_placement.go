@@ -0,0 +1,81 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	placementutil "github.com/K0rdent/kcm/internal/util/placement"
+)
+
+// placeClusterDeployment places the ClusterDeployment without a Credential into a Region
+// satisfying its placement, if any, and sets the Credential valid there. The Region is recorded
+// in the status once the ClusterDeployment is reconciled with the chosen Credential.
+//
+// Normally, the placement is done by the defaulting webhook, thus this is a fallback for
+// the case the webhook is disabled. It reports whether the ClusterDeployment has been updated.
+func (r *ClusterDeploymentReconciler) placeClusterDeployment(ctx context.Context, cd *kcmv1.ClusterDeployment) (bool, error) {
+	if cd.Spec.Credential != "" || !cd.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+
+	clusterPlacement, err := placementutil.ForClusterDeployment(ctx, r.MgmtClient, cd)
+	if err != nil {
+		return false, fmt.Errorf("failed to get placement: %w", err)
+	}
+	if clusterPlacement == nil {
+		return false, nil
+	}
+
+	clusterTpl := new(kcmv1.ClusterTemplate)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Template}, clusterTpl); err != nil {
+		err = fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", cd.Namespace, cd.Spec.Template, err)
+		r.setCondition(cd, kcmv1.PlacementReadyCondition, kcmv1.FailedReason, metav1.ConditionFalse, err)
+		return false, err
+	}
+
+	region, cred, err := placementutil.Select(ctx, r.MgmtClient, r.SystemNamespace, cd, clusterTpl, clusterPlacement)
+	if err != nil {
+		if r.setCondition(cd, kcmv1.PlacementReadyCondition, kcmv1.NoMatchingRegionReason, metav1.ConditionFalse, err) {
+			r.warnf(cd, "PlacementFailed", err.Error())
+		}
+		return false, fmt.Errorf("failed to place ClusterDeployment: %w", err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Placing ClusterDeployment", "region", region.Name, "credential", cred.Name)
+
+	cd.Spec.Credential = cred.Name
+	if err := r.MgmtClient.Update(ctx, cd); err != nil {
+		return false, fmt.Errorf("failed to set Credential %s of the ClusterDeployment: %w", cred.Name, err)
+	}
+
+	apimeta.SetStatusCondition(&cd.Status.Conditions, metav1.Condition{
+		Type:               kcmv1.PlacementReadyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             kcmv1.SucceededReason,
+		Message:            fmt.Sprintf("Placed into the Region %s with the Credential %s", region.Name, cred.Name),
+		ObservedGeneration: cd.Generation,
+	})
+	r.eventf(cd, "Placed", "Placed into the Region %s with the Credential %s", region.Name, cred.Name)
+
+	return true, nil
+}
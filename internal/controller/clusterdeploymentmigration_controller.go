@@ -0,0 +1,810 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	addoncontrollerv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/controller/credential"
+	"github.com/K0rdent/kcm/internal/record"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	placementutil "github.com/K0rdent/kcm/internal/util/placement"
+	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
+	validationutil "github.com/K0rdent/kcm/internal/util/validation"
+)
+
+// hostedControlPlaneKind is the kind of the control planes running in the cluster hosting
+// the CAPI objects, such clusters cannot be migrated by moving the objects.
+const hostedControlPlaneKind = "K0smotronControlPlane"
+
+// defaultMigrationTimeout is the time the migration is given to complete unless set in the spec.
+const defaultMigrationTimeout = time.Hour
+
+// errInvalidMigration is the error of the migration which cannot be started.
+var errInvalidMigration = errors.New("invalid migration")
+
+// ClusterDeploymentMigrationReconciler reconciles a ClusterDeploymentMigration object
+type ClusterDeploymentMigrationReconciler struct {
+	MgmtClient      client.Client
+	SystemNamespace string
+
+	timeFunc func() time.Time
+	// getRegionalClientFunc returns the client of the given region, the management cluster for the empty one.
+	getRegionalClientFunc func(ctx context.Context, region string) (client.Client, error)
+
+	defaultRequeueTime time.Duration
+}
+
+func (r *ClusterDeploymentMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("Reconciling ClusterDeploymentMigration")
+
+	migration := new(kcmv1.ClusterDeploymentMigration)
+	if err := r.MgmtClient.Get(ctx, req.NamespacedName, migration); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.Info("ClusterDeploymentMigration not found, ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get ClusterDeploymentMigration %s: %w", req.NamespacedName, err)
+	}
+	if !migration.DeletionTimestamp.IsZero() || migration.IsFinished() {
+		return ctrl.Result{}, r.finalize(ctx, migration)
+	}
+
+	if controllerutil.AddFinalizer(migration, kcmv1.ClusterDeploymentMigrationFinalizer) {
+		if err := r.MgmtClient.Update(ctx, migration); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to ClusterDeploymentMigration %s: %w", req.NamespacedName, err)
+		}
+	}
+
+	oldStatus := migration.Status.DeepCopy()
+	err := r.reconcileMigration(ctx, migration)
+	if err != nil {
+		r.setReadyCondition(migration, metav1.ConditionFalse, kcmv1.ProgressingReason, err.Error())
+	}
+
+	if !equality.Semantic.DeepEqual(*oldStatus, migration.Status) {
+		migration.Status.ObservedGeneration = migration.Generation
+		if updErr := r.MgmtClient.Status().Update(ctx, migration); updErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update ClusterDeploymentMigration %s status: %w", req.NamespacedName, updErr))
+		}
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if migration.IsFinished() {
+		return ctrl.Result{}, r.finalize(ctx, migration)
+	}
+	return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
+}
+
+// finalize rolls back the changes of the migration unless it has been completed and removes the finalizer.
+func (r *ClusterDeploymentMigrationReconciler) finalize(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration) error {
+	if !controllerutil.ContainsFinalizer(migration, kcmv1.ClusterDeploymentMigrationFinalizer) {
+		return nil
+	}
+
+	if migration.Status.Phase != kcmv1.ClusterDeploymentMigrationPhaseCompleted {
+		if err := r.rollback(ctx, migration); err != nil {
+			return fmt.Errorf("failed to roll back ClusterDeploymentMigration %s: %w", client.ObjectKeyFromObject(migration), err)
+		}
+	}
+
+	patch := client.MergeFrom(migration.DeepCopy())
+	controllerutil.RemoveFinalizer(migration, kcmv1.ClusterDeploymentMigrationFinalizer)
+	if err := r.MgmtClient.Patch(ctx, migration, patch); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to remove finalizer from ClusterDeploymentMigration %s: %w", client.ObjectKeyFromObject(migration), err)
+	}
+	return nil
+}
+
+// rollback reverts the changes made by the unfinished migration. Unless the ClusterDeployment has been
+// re-pointed to the target Credential, the objects are moved back to the source cluster and the CAPI cluster
+// is resumed there, otherwise the cluster stays in the target cluster it is managed from. In both cases the
+// ClusterDeployment is reconciled again and its HelmRelease is resumed.
+func (r *ClusterDeploymentMigrationReconciler) rollback(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration) error {
+	// nothing has been changed before the migration has been started
+	if migration.Status.StartTime == nil {
+		return nil
+	}
+
+	cd := new(kcmv1.ClusterDeployment)
+	cdKey := client.ObjectKey{Namespace: migration.Namespace, Name: migration.Spec.ClusterDeployment}
+	if err := r.MgmtClient.Get(ctx, cdKey, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get ClusterDeployment %s: %w", cdKey, err)
+	}
+
+	repointed := cd.Spec.Credential == migration.Spec.TargetCredential
+	if !repointed {
+		if err := r.moveBack(ctx, migration, cd); err != nil {
+			return err
+		}
+	} else {
+		serviceSets := new(kcmv1.ServiceSetList)
+		if err := r.MgmtClient.List(ctx, serviceSets, client.InNamespace(cd.Namespace), client.MatchingFields{kcmv1.ServiceSetClusterIndexKey: cd.Name}); err != nil {
+			return fmt.Errorf("failed to list ServiceSets matching %s ClusterDeployment: %w", cdKey, err)
+		}
+		for i := range serviceSets.Items {
+			if err := r.removeMigrationAnnotation(ctx, &serviceSets.Items[i]); err != nil {
+				return fmt.Errorf("failed to clean up ServiceSet %s: %w", client.ObjectKeyFromObject(&serviceSets.Items[i]), err)
+			}
+		}
+	}
+
+	if err := r.removeMigrationAnnotation(ctx, cd); err != nil {
+		return fmt.Errorf("failed to resume reconciliation of ClusterDeployment %s: %w", cdKey, err)
+	}
+
+	if repointed {
+		hr := new(helmcontrollerv2.HelmRelease)
+		if err := r.MgmtClient.Get(ctx, cdKey, hr); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get HelmRelease %s: %w", cdKey, err)
+		}
+		// the HelmRelease must not be resumed against the source cluster the objects have been moved from
+		ok, err := r.helmReleaseRepointed(ctx, migration, cd, hr)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("the HelmRelease has not been re-pointed to the target cluster yet")
+		}
+	}
+	if err := r.setHelmReleaseSuspended(ctx, cd, false); err != nil {
+		return err
+	}
+
+	region := migration.Status.SourceRegion
+	if repointed {
+		region = migration.Status.TargetRegion
+	}
+	record.Eventf(migration, cd, "MigrationRolledBack", "MigrateCluster", "ClusterDeployment %s is managed from the %s", cd.Name, regionDisplayName(region))
+	return nil
+}
+
+// moveBack moves the objects copied to the target cluster back to the source cluster and resumes the CAPI cluster there.
+// The objects still present in the source cluster are kept, the copies of the objects are deleted from the target cluster
+// including the ones not recorded in the status yet.
+func (r *ClusterDeploymentMigrationReconciler) moveBack(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration, cd *kcmv1.ClusterDeployment) error {
+	sourceClient, err := r.regionalClient(ctx, migration.Status.SourceRegion)
+	if err != nil {
+		return err
+	}
+	targetClient, err := r.regionalClient(ctx, migration.Status.TargetRegion)
+	if err != nil {
+		return err
+	}
+
+	var (
+		missing   []*unstructured.Unstructured
+		knownUIDs = make(map[types.UID]types.UID, len(migration.Status.Objects))
+	)
+	for _, ref := range migration.Status.Objects {
+		key := client.ObjectKey{Namespace: cd.Namespace, Name: ref.Name}
+		obj := new(unstructured.Unstructured)
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		err := sourceClient.Get(ctx, key, obj)
+		switch {
+		case err == nil:
+			knownUIDs[ref.TargetUID] = obj.GetUID()
+			continue
+		case !apierrors.IsNotFound(err):
+			return fmt.Errorf("failed to get %s %s from the source cluster: %w", ref.Kind, key, err)
+		}
+
+		if err := targetClient.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get %s %s from the target cluster: %w", ref.Kind, key, err)
+		}
+		missing = append(missing, obj)
+	}
+
+	clusterName := cd.Name
+	for _, cl := range []client.Client{sourceClient, targetClient} {
+		cluster, err := getCAPICluster(ctx, cl, cd)
+		if err != nil {
+			return err
+		}
+		if cluster != nil {
+			clusterName = cluster.Name
+			break
+		}
+	}
+
+	mover := &clusterObjectsMover{
+		source:      targetClient,
+		target:      sourceClient,
+		namespace:   cd.Namespace,
+		clusterName: clusterName,
+		releaseName: cd.Name,
+		knownUIDs:   knownUIDs,
+	}
+	if len(missing) > 0 {
+		if _, err := mover.copyToTarget(ctx, missing); err != nil {
+			return err
+		}
+	}
+
+	copies, err := mover.discover(ctx)
+	if err != nil {
+		return err
+	}
+	copied := make([]kcmv1.ClusterDeploymentMigrationObject, 0, len(copies))
+	for _, obj := range copies {
+		copied = append(copied, kcmv1.ClusterDeploymentMigrationObject{APIVersion: obj.GetAPIVersion(), Kind: obj.GetKind(), Name: obj.GetName(), SourceUID: obj.GetUID()})
+	}
+	if err := mover.deleteFromSource(ctx, copied); err != nil {
+		return err
+	}
+
+	return setCAPIClusterPaused(ctx, sourceClient, cd, false)
+}
+
+// reconcileMigration performs the current phase of the migration and advances it to the next one once the phase is done.
+func (r *ClusterDeploymentMigrationReconciler) reconcileMigration(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration) error {
+	if migration.Status.StartTime != nil {
+		timeout := defaultMigrationTimeout
+		if migration.Spec.Timeout != nil {
+			timeout = migration.Spec.Timeout.Duration
+		}
+		if r.timeFunc().After(migration.Status.StartTime.Add(timeout)) {
+			r.fail(migration, fmt.Errorf("the migration has not been completed within %s, stuck in the %s phase", timeout, migration.Status.Phase))
+			return nil
+		}
+	}
+
+	cd := new(kcmv1.ClusterDeployment)
+	cdKey := client.ObjectKey{Namespace: migration.Namespace, Name: migration.Spec.ClusterDeployment}
+	if err := r.MgmtClient.Get(ctx, cdKey, cd); err != nil {
+		if apierrors.IsNotFound(err) && migration.Status.Phase == "" {
+			r.fail(migration, fmt.Errorf("%w: ClusterDeployment %s is not found", errInvalidMigration, cdKey))
+			return nil
+		}
+		return fmt.Errorf("failed to get ClusterDeployment %s: %w", cdKey, err)
+	}
+
+	switch migration.Status.Phase {
+	case "", kcmv1.ClusterDeploymentMigrationPhasePending:
+		return r.start(ctx, migration, cd)
+	case kcmv1.ClusterDeploymentMigrationPhasePausing:
+		return r.pause(ctx, migration, cd)
+	case kcmv1.ClusterDeploymentMigrationPhaseMoving:
+		return r.move(ctx, migration, cd)
+	case kcmv1.ClusterDeploymentMigrationPhaseRepointing:
+		return r.repoint(ctx, migration, cd)
+	case kcmv1.ClusterDeploymentMigrationPhaseRebuildingServices:
+		return r.rebuildServices(ctx, migration, cd)
+	default:
+		return fmt.Errorf("unknown phase %s of ClusterDeploymentMigration %s", migration.Status.Phase, client.ObjectKeyFromObject(migration))
+	}
+}
+
+// start validates the migration, records the source and the target of the migration
+// and marks the ClusterDeployment as being migrated so that it is no longer reconciled.
+func (r *ClusterDeploymentMigrationReconciler) start(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration, cd *kcmv1.ClusterDeployment) error {
+	sourceCred, targetCred, err := r.validate(ctx, migration, cd)
+	if errors.Is(err, errInvalidMigration) {
+		r.fail(migration, err)
+		return nil
+	}
+	if err != nil {
+		migration.Status.Phase = kcmv1.ClusterDeploymentMigrationPhasePending
+		return err
+	}
+
+	if cd.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation] != migration.Name {
+		patch := client.MergeFrom(cd.DeepCopy())
+		if cd.Annotations == nil {
+			cd.Annotations = make(map[string]string)
+		}
+		cd.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation] = migration.Name
+		if err := r.MgmtClient.Patch(ctx, cd, patch); err != nil {
+			return fmt.Errorf("failed to mark ClusterDeployment %s as being migrated: %w", client.ObjectKeyFromObject(cd), err)
+		}
+	}
+
+	migration.Status.StartTime = &metav1.Time{Time: r.timeFunc()}
+	migration.Status.SourceCredential = sourceCred.Name
+	migration.Status.SourceRegion = sourceCred.Spec.Region
+	migration.Status.TargetRegion = targetCred.Spec.Region
+	r.setPhase(migration, kcmv1.ClusterDeploymentMigrationPhasePausing, "Pausing the HelmRelease and the CAPI cluster")
+	record.Eventf(migration, cd, "MigrationStarted", "MigrateCluster", "Migrating ClusterDeployment %s from the %s to the %s",
+		cd.Name, regionDisplayName(migration.Status.SourceRegion), regionDisplayName(migration.Status.TargetRegion))
+
+	return nil
+}
+
+// validate checks that the ClusterDeployment can be migrated to the Region of the target Credential
+// and returns the source and the target Credentials. The returned error wraps [errInvalidMigration]
+// if the migration cannot be started at all.
+func (r *ClusterDeploymentMigrationReconciler) validate(
+	ctx context.Context,
+	migration *kcmv1.ClusterDeploymentMigration,
+	cd *kcmv1.ClusterDeployment,
+) (sourceCred, targetCred *kcmv1.Credential, _ error) {
+	switch {
+	case !cd.DeletionTimestamp.IsZero():
+		return nil, nil, fmt.Errorf("%w: ClusterDeployment %s is being deleted", errInvalidMigration, cd.Name)
+	case cd.Spec.DryRun:
+		return nil, nil, fmt.Errorf("%w: ClusterDeployment %s is in the dry-run mode", errInvalidMigration, cd.Name)
+	}
+	if name, ok := cd.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation]; ok && name != migration.Name {
+		return nil, nil, fmt.Errorf("%w: ClusterDeployment %s is being migrated by the ClusterDeploymentMigration %s", errInvalidMigration, cd.Name, name)
+	}
+
+	sourceCred = new(kcmv1.Credential)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Credential}, sourceCred); err != nil {
+		return nil, nil, fmt.Errorf("failed to get Credential %s/%s: %w", cd.Namespace, cd.Spec.Credential, err)
+	}
+
+	targetCred = new(kcmv1.Credential)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: migration.Spec.TargetCredential}, targetCred); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("%w: Credential %s/%s is not found", errInvalidMigration, cd.Namespace, migration.Spec.TargetCredential)
+		}
+		return nil, nil, fmt.Errorf("failed to get Credential %s/%s: %w", cd.Namespace, migration.Spec.TargetCredential, err)
+	}
+	if sourceCred.Spec.Region == targetCred.Spec.Region {
+		return nil, nil, fmt.Errorf("%w: ClusterDeployment %s is already deployed in the %s", errInvalidMigration, cd.Name, regionDisplayName(targetCred.Spec.Region))
+	}
+	if !targetCred.Status.Ready {
+		return nil, nil, fmt.Errorf("target Credential %s is not ready", targetCred.Name)
+	}
+
+	var targetRegion *kcmv1.Region
+	if targetCred.Spec.Region != "" {
+		targetRegion = new(kcmv1.Region)
+		if err := r.MgmtClient.Get(ctx, client.ObjectKey{Name: targetCred.Spec.Region}, targetRegion); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("%w: Region %s is not found", errInvalidMigration, targetCred.Spec.Region)
+			}
+			return nil, nil, fmt.Errorf("failed to get Region %s: %w", targetCred.Spec.Region, err)
+		}
+		if !targetRegion.DeletionTimestamp.IsZero() {
+			return nil, nil, fmt.Errorf("%w: Region %s is being deleted", errInvalidMigration, targetRegion.Name)
+		}
+		if _, paused := targetRegion.Annotations[kcmv1.RegionPauseAnnotation]; paused || targetRegion.IsAutoPaused() {
+			return nil, nil, fmt.Errorf("target Region %s is paused", targetRegion.Name)
+		}
+		if !apimeta.IsStatusConditionTrue(targetRegion.Status.Conditions, kcmv1.ReadyCondition) {
+			return nil, nil, fmt.Errorf("target Region %s is not ready", targetRegion.Name)
+		}
+	}
+
+	clusterPlacement, err := placementutil.ForClusterDeployment(ctx, r.MgmtClient, cd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := placementutil.Allows(clusterPlacement, targetRegion); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errInvalidMigration, err)
+	}
+
+	clusterTpl := new(kcmv1.ClusterTemplate)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Template}, clusterTpl); err != nil {
+		return nil, nil, fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", cd.Namespace, cd.Spec.Template, err)
+	}
+	candidate := cd.DeepCopy()
+	candidate.Spec.Credential = targetCred.Name
+	if err := validationutil.ClusterDeployCredential(ctx, r.MgmtClient, r.SystemNamespace, candidate, clusterTpl); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errInvalidMigration, err)
+	}
+
+	sourceClient, err := r.regionalClient(ctx, sourceCred.Spec.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+	cluster, err := getCAPICluster(ctx, sourceClient, cd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cluster != nil && cluster.Spec.ControlPlaneRef.Kind == hostedControlPlaneKind {
+		return nil, nil, fmt.Errorf("%w: the control plane of the cluster is hosted in the %s", errInvalidMigration, regionDisplayName(sourceCred.Spec.Region))
+	}
+
+	return sourceCred, targetCred, nil
+}
+
+// pause suspends the HelmRelease of the ClusterDeployment and pauses the CAPI cluster in the source cluster.
+func (r *ClusterDeploymentMigrationReconciler) pause(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration, cd *kcmv1.ClusterDeployment) error {
+	if err := r.setHelmReleaseSuspended(ctx, cd, true); err != nil {
+		return err
+	}
+
+	sourceClient, err := r.regionalClient(ctx, migration.Status.SourceRegion)
+	if err != nil {
+		return err
+	}
+	if err := setCAPIClusterPaused(ctx, sourceClient, cd, true); err != nil {
+		return err
+	}
+
+	r.setPhase(migration, kcmv1.ClusterDeploymentMigrationPhaseMoving, "Moving the CAPI objects to the target cluster")
+	return nil
+}
+
+// move moves the CAPI objects and the secrets of the cluster and the cluster identities
+// of the target Credential to the target cluster and resumes the CAPI cluster there.
+func (r *ClusterDeploymentMigrationReconciler) move(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration, cd *kcmv1.ClusterDeployment) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	sourceClient, err := r.regionalClient(ctx, migration.Status.SourceRegion)
+	if err != nil {
+		return err
+	}
+	targetClient, err := r.regionalClient(ctx, migration.Status.TargetRegion)
+	if err != nil {
+		return err
+	}
+
+	targetCred := new(kcmv1.Credential)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: migration.Spec.TargetCredential}, targetCred); err != nil {
+		return fmt.Errorf("failed to get Credential %s/%s: %w", cd.Namespace, migration.Spec.TargetCredential, err)
+	}
+	if err := credential.CopyClusterIdentities(ctx, r.MgmtClient, targetClient, targetCred, r.SystemNamespace); err != nil {
+		return err
+	}
+
+	clusterName := cd.Name
+	cluster, err := getCAPICluster(ctx, sourceClient, cd)
+	if err != nil {
+		return err
+	}
+	if cluster != nil {
+		clusterName = cluster.Name
+	}
+
+	mover := &clusterObjectsMover{
+		source:      sourceClient,
+		target:      targetClient,
+		namespace:   cd.Namespace,
+		clusterName: clusterName,
+		releaseName: cd.Name,
+	}
+	if err := mover.ensureNamespace(ctx); err != nil {
+		return err
+	}
+
+	// the objects are deleted from the source cluster only once recorded in the status, so that the move
+	// can be resumed even if the source cluster no longer holds some of the objects
+	if len(migration.Status.Objects) == 0 {
+		objs, err := mover.discover(ctx)
+		if err != nil {
+			return err
+		}
+		if len(objs) > 0 {
+			l.Info("Moving objects to the target cluster", "count", len(objs), "target", regionDisplayName(migration.Status.TargetRegion))
+			copied, err := mover.copyToTarget(ctx, objs)
+			if err != nil {
+				return err
+			}
+			migration.Status.Objects = copied
+			migration.Status.MovedObjects = int32(len(copied))
+			r.setReadyCondition(migration, metav1.ConditionFalse, kcmv1.ProgressingReason, "Deleting the moved objects from the source cluster")
+			return nil
+		}
+	}
+	if err := mover.deleteFromSource(ctx, migration.Status.Objects); err != nil {
+		return err
+	}
+
+	if err := setCAPIClusterPaused(ctx, targetClient, cd, false); err != nil {
+		return err
+	}
+
+	record.Eventf(migration, cd, "ClusterMoved", "MigrateCluster", "Moved %d objects of ClusterDeployment %s to the %s",
+		migration.Status.MovedObjects, cd.Name, regionDisplayName(migration.Status.TargetRegion))
+	r.setPhase(migration, kcmv1.ClusterDeploymentMigrationPhaseRepointing, "Re-pointing the ClusterDeployment to the target Credential")
+	return nil
+}
+
+// repoint re-points the ClusterDeployment to the target Credential, resumes its reconciliation and
+// once the HelmRelease targets the target cluster, resumes the HelmRelease.
+func (r *ClusterDeploymentMigrationReconciler) repoint(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration, cd *kcmv1.ClusterDeployment) error {
+	if cd.Spec.Credential != migration.Spec.TargetCredential {
+		patch := client.MergeFrom(cd.DeepCopy())
+		cd.Spec.Credential = migration.Spec.TargetCredential
+		if err := r.MgmtClient.Patch(ctx, cd, patch); err != nil {
+			return fmt.Errorf("failed to re-point ClusterDeployment %s to Credential %s: %w", client.ObjectKeyFromObject(cd), migration.Spec.TargetCredential, err)
+		}
+	}
+
+	hr := new(helmcontrollerv2.HelmRelease)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKeyFromObject(cd), hr); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get HelmRelease %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+	// the ClusterDeployment controller never unsets the kubeconfig of the HelmRelease
+	if migration.Status.TargetRegion == "" && hr.Spec.KubeConfig != nil {
+		patch := client.MergeFrom(hr.DeepCopy())
+		hr.Spec.KubeConfig = nil
+		if err := r.MgmtClient.Patch(ctx, hr, patch); err != nil {
+			return fmt.Errorf("failed to re-point HelmRelease %s to the management cluster: %w", client.ObjectKeyFromObject(hr), err)
+		}
+	}
+
+	if err := r.removeMigrationAnnotation(ctx, cd); err != nil {
+		return fmt.Errorf("failed to resume reconciliation of ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+
+	repointed, err := r.helmReleaseRepointed(ctx, migration, cd, hr)
+	if err != nil {
+		return err
+	}
+	if !repointed {
+		r.setReadyCondition(migration, metav1.ConditionFalse, kcmv1.ProgressingReason, "Waiting for the HelmRelease to be re-pointed to the target cluster")
+		return nil
+	}
+
+	if err := r.setHelmReleaseSuspended(ctx, cd, false); err != nil {
+		return err
+	}
+
+	r.setPhase(migration, kcmv1.ClusterDeploymentMigrationPhaseRebuildingServices, "Rebuilding the ServiceSets against the target cluster")
+	return nil
+}
+
+// helmReleaseRepointed reports whether the ClusterDeployment has been reconciled against the target
+// Region and its HelmRelease, if any, targets the target cluster.
+func (r *ClusterDeploymentMigrationReconciler) helmReleaseRepointed(
+	ctx context.Context,
+	migration *kcmv1.ClusterDeploymentMigration,
+	cd *kcmv1.ClusterDeployment,
+	hr *helmcontrollerv2.HelmRelease,
+) (bool, error) {
+	if cd.Status.Region != migration.Status.TargetRegion {
+		return false, nil
+	}
+	if hr.Name == "" {
+		return true, nil
+	}
+	if migration.Status.TargetRegion == "" {
+		return hr.Spec.KubeConfig == nil, nil
+	}
+
+	region := new(kcmv1.Region)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Name: migration.Status.TargetRegion}, region); err != nil {
+		return false, fmt.Errorf("failed to get Region %s: %w", migration.Status.TargetRegion, err)
+	}
+	kubeconfigRef, err := kubeutil.GetRegionalKubeconfigSecretRef(region)
+	if err != nil {
+		return false, fmt.Errorf("failed to get kubeconfig secret reference for %s region: %w", region.Name, err)
+	}
+
+	return hr.Spec.KubeConfig != nil && hr.Spec.KubeConfig.SecretRef != nil && *hr.Spec.KubeConfig.SecretRef == *kubeconfigRef, nil
+}
+
+// rebuildServices deletes the Sveltos Profiles of the ServiceSets of the ClusterDeployment from the
+// source cluster and triggers the reconciliation of the ServiceSets to recreate the Profiles in the target cluster.
+// The Profiles are deleted once the CAPI cluster has been moved, hence the Sveltos of the source cluster
+// has no access to the cluster and does not withdraw the deployed services.
+func (r *ClusterDeploymentMigrationReconciler) rebuildServices(ctx context.Context, migration *kcmv1.ClusterDeploymentMigration, cd *kcmv1.ClusterDeployment) error {
+	serviceSets := new(kcmv1.ServiceSetList)
+	if err := r.MgmtClient.List(ctx, serviceSets, client.InNamespace(cd.Namespace), client.MatchingFields{kcmv1.ServiceSetClusterIndexKey: cd.Name}); err != nil {
+		return fmt.Errorf("failed to list ServiceSets matching %s/%s ClusterDeployment: %w", cd.Namespace, cd.Name, err)
+	}
+
+	sourceClient, err := r.regionalClient(ctx, migration.Status.SourceRegion)
+	if err != nil {
+		return err
+	}
+
+	var pending int
+	for i := range serviceSets.Items {
+		serviceSet := &serviceSets.Items[i]
+		if serviceSet.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation] == migration.Name {
+			continue
+		}
+
+		profile := new(unstructured.Unstructured)
+		profile.SetGroupVersionKind(addoncontrollerv1beta1.GroupVersion.WithKind(addoncontrollerv1beta1.ProfileKind))
+		err := sourceClient.Get(ctx, client.ObjectKeyFromObject(serviceSet), profile)
+		switch {
+		case err == nil:
+			pending++
+			if profile.GetDeletionTimestamp().IsZero() {
+				if err := sourceClient.Delete(ctx, profile); client.IgnoreNotFound(err) != nil {
+					return fmt.Errorf("failed to delete Profile %s from the source cluster: %w", client.ObjectKeyFromObject(profile), err)
+				}
+			}
+			continue
+		case apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err):
+		default:
+			return fmt.Errorf("failed to get Profile %s from the source cluster: %w", client.ObjectKeyFromObject(serviceSet), err)
+		}
+
+		patch := client.MergeFrom(serviceSet.DeepCopy())
+		if serviceSet.Annotations == nil {
+			serviceSet.Annotations = make(map[string]string)
+		}
+		serviceSet.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation] = migration.Name
+		if err := r.MgmtClient.Patch(ctx, serviceSet, patch); err != nil {
+			return fmt.Errorf("failed to rebuild ServiceSet %s: %w", client.ObjectKeyFromObject(serviceSet), err)
+		}
+	}
+
+	if pending > 0 {
+		r.setReadyCondition(migration, metav1.ConditionFalse, kcmv1.ProgressingReason,
+			fmt.Sprintf("Waiting for %d Profiles to be deleted from the source cluster", pending))
+		return nil
+	}
+
+	for i := range serviceSets.Items {
+		if err := r.removeMigrationAnnotation(ctx, &serviceSets.Items[i]); err != nil {
+			return fmt.Errorf("failed to clean up ServiceSet %s: %w", client.ObjectKeyFromObject(&serviceSets.Items[i]), err)
+		}
+	}
+	if err := r.removeMigrationAnnotation(ctx, cd); err != nil {
+		return fmt.Errorf("failed to clean up ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+
+	migration.Status.Phase = kcmv1.ClusterDeploymentMigrationPhaseCompleted
+	migration.Status.CompletionTime = &metav1.Time{Time: r.timeFunc()}
+	msg := fmt.Sprintf("ClusterDeployment %s has been migrated from the %s to the %s",
+		cd.Name, regionDisplayName(migration.Status.SourceRegion), regionDisplayName(migration.Status.TargetRegion))
+	r.setReadyCondition(migration, metav1.ConditionTrue, kcmv1.SucceededReason, msg)
+	record.Eventf(migration, cd, "MigrationCompleted", "MigrateCluster", msg)
+
+	return nil
+}
+
+func (r *ClusterDeploymentMigrationReconciler) setHelmReleaseSuspended(ctx context.Context, cd *kcmv1.ClusterDeployment, suspend bool) error {
+	hr := new(helmcontrollerv2.HelmRelease)
+	if err := r.MgmtClient.Get(ctx, client.ObjectKeyFromObject(cd), hr); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if hr.Spec.Suspend == suspend {
+		return nil
+	}
+
+	patch := client.MergeFrom(hr.DeepCopy())
+	hr.Spec.Suspend = suspend
+	if err := r.MgmtClient.Patch(ctx, hr, patch); err != nil {
+		return fmt.Errorf("failed to set suspend of HelmRelease %s to %t: %w", client.ObjectKeyFromObject(hr), suspend, err)
+	}
+	return nil
+}
+
+// removeMigrationAnnotation removes the [kcmv1.ClusterDeploymentMigrationAnnotation] from the given object if it is set.
+func (r *ClusterDeploymentMigrationReconciler) removeMigrationAnnotation(ctx context.Context, obj client.Object) error {
+	if _, ok := obj.GetAnnotations()[kcmv1.ClusterDeploymentMigrationAnnotation]; !ok {
+		return nil
+	}
+
+	patch := fmt.Appendf(nil, `{"metadata":{"annotations":{%q:null}}}`, kcmv1.ClusterDeploymentMigrationAnnotation)
+	return r.MgmtClient.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch))
+}
+
+// fail marks the migration as failed with the given error.
+func (r *ClusterDeploymentMigrationReconciler) fail(migration *kcmv1.ClusterDeploymentMigration, err error) {
+	migration.Status.Phase = kcmv1.ClusterDeploymentMigrationPhaseFailed
+	migration.Status.CompletionTime = &metav1.Time{Time: r.timeFunc()}
+	r.setReadyCondition(migration, metav1.ConditionFalse, kcmv1.FailedReason, err.Error())
+	record.Warnf(migration, nil, "MigrationFailed", "MigrateCluster", err.Error())
+}
+
+func (r *ClusterDeploymentMigrationReconciler) setPhase(migration *kcmv1.ClusterDeploymentMigration, phase kcmv1.ClusterDeploymentMigrationPhase, message string) {
+	migration.Status.Phase = phase
+	r.setReadyCondition(migration, metav1.ConditionFalse, kcmv1.ProgressingReason, message)
+}
+
+func (*ClusterDeploymentMigrationReconciler) setReadyCondition(migration *kcmv1.ClusterDeploymentMigration, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&migration.Status.Conditions, metav1.Condition{
+		Type:               kcmv1.ReadyCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: migration.Generation,
+	})
+}
+
+// regionalClient returns the client of the given region, the management cluster for the empty one.
+func (r *ClusterDeploymentMigrationReconciler) regionalClient(ctx context.Context, region string) (client.Client, error) {
+	if r.getRegionalClientFunc != nil {
+		return r.getRegionalClientFunc(ctx, region)
+	}
+	return kubeutil.GetRegionalClientByRegionName(ctx, r.MgmtClient, r.SystemNamespace, region, schemeutil.Regional)
+}
+
+// getCAPICluster returns the CAPI Cluster of the given ClusterDeployment, nil if there is none.
+func getCAPICluster(ctx context.Context, cl client.Client, cd *kcmv1.ClusterDeployment) (*clusterapiv1.Cluster, error) {
+	clusters := new(clusterapiv1.ClusterList)
+	if err := cl.List(ctx, clusters, client.InNamespace(cd.Namespace), client.MatchingLabels{kcmv1.FluxHelmChartNameKey: cd.Name}, client.Limit(1)); err != nil {
+		return nil, fmt.Errorf("failed to list CAPI Clusters for %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+	if len(clusters.Items) == 0 {
+		return nil, nil //nolint:nilnil // no cluster
+	}
+	return &clusters.Items[0], nil
+}
+
+// setCAPIClusterPaused sets the paused field of the CAPI Cluster of the given ClusterDeployment if it exists.
+func setCAPIClusterPaused(ctx context.Context, cl client.Client, cd *kcmv1.ClusterDeployment, paused bool) error {
+	cluster, err := getCAPICluster(ctx, cl, cd)
+	if err != nil || cluster == nil {
+		return err
+	}
+	if cluster.Spec.Paused != nil && *cluster.Spec.Paused == paused {
+		return nil
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Spec.Paused = &paused
+	if err := cl.Patch(ctx, cluster, patch); err != nil {
+		return fmt.Errorf("failed to set paused of CAPI Cluster %s to %t: %w", client.ObjectKeyFromObject(cluster), paused, err)
+	}
+	return nil
+}
+
+// regionDisplayName returns the human-readable name of the given region, empty name stands for the management cluster.
+func regionDisplayName(region string) string {
+	if region == "" {
+		return "management cluster"
+	}
+	return "Region " + region
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterDeploymentMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.MgmtClient = mgr.GetClient()
+	if r.timeFunc == nil {
+		r.timeFunc = time.Now
+	}
+	r.defaultRequeueTime = 10 * time.Second
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimitutil.DefaultFastSlow(),
+		}).
+		For(&kcmv1.ClusterDeploymentMigration{}).
+		Watches(&kcmv1.ClusterDeployment{}, kubeutil.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) ([]ctrl.Request, error) {
+			migrations := new(kcmv1.ClusterDeploymentMigrationList)
+			if err := r.MgmtClient.List(ctx, migrations, client.InNamespace(o.GetNamespace())); err != nil {
+				return nil, fmt.Errorf("failed to list ClusterDeploymentMigrations in namespace %s: %w", o.GetNamespace(), err)
+			}
+
+			var requests []ctrl.Request
+			for _, migration := range migrations.Items {
+				if migration.Spec.ClusterDeployment == o.GetName() && !migration.IsFinished() {
+					requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&migration)})
+				}
+			}
+			return requests, nil
+		})).
+		Complete(r)
+}
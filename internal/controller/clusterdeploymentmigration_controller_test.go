@@ -0,0 +1,670 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	testscheme "github.com/K0rdent/kcm/test/scheme"
+)
+
+func Test_orderByOwners(t *testing.T) {
+	t.Parallel()
+
+	newObj := func(uid string, owners ...string) *unstructured.Unstructured {
+		obj := new(unstructured.Unstructured)
+		obj.SetName(uid)
+		obj.SetUID(types.UID(uid))
+		refs := make([]metav1.OwnerReference, 0, len(owners))
+		for _, owner := range owners {
+			refs = append(refs, metav1.OwnerReference{UID: types.UID(owner)})
+		}
+		obj.SetOwnerReferences(refs)
+		return obj
+	}
+
+	for _, tc := range []struct {
+		name string
+		objs []*unstructured.Unstructured
+		want []string
+	}{
+		{
+			name: "owners precede dependents",
+			objs: []*unstructured.Unstructured{newObj("machine", "machineset"), newObj("machineset", "cluster"), newObj("cluster")},
+			want: []string{"cluster", "machineset", "machine"},
+		},
+		{
+			name: "owners which are not moved are ignored",
+			objs: []*unstructured.Unstructured{newObj("secret", "external"), newObj("cluster")},
+			want: []string{"secret", "cluster"},
+		},
+		{
+			name: "circular ownership is appended last",
+			objs: []*unstructured.Unstructured{newObj("a", "b"), newObj("b", "a"), newObj("c")},
+			want: []string{"c", "a", "b"},
+		},
+	} {
+		var got []string
+		for _, obj := range orderByOwners(tc.objs) {
+			got = append(got, obj.GetName())
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: order = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// newCAPICRD returns the CRD of the given CAPI kind served by the cluster-api provider.
+func newCAPICRD(plural, kind string, scope apiextv1.ResourceScope) *apiextv1.CustomResourceDefinition {
+	return &apiextv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   plural + "." + clusterapiv1.GroupVersion.Group,
+			Labels: map[string]string{clusterapiv1.ProviderNameLabel: "cluster-api"},
+		},
+		Spec: apiextv1.CustomResourceDefinitionSpec{
+			Group: clusterapiv1.GroupVersion.Group,
+			Names: apiextv1.CustomResourceDefinitionNames{Plural: plural, Kind: kind},
+			Scope: scope,
+			Versions: []apiextv1.CustomResourceDefinitionVersion{
+				{Name: "v1beta1"},
+				{Name: clusterapiv1.GroupVersion.Version, Storage: true},
+			},
+		},
+	}
+}
+
+func Test_clusterObjectsMover(t *testing.T) {
+	t.Parallel()
+
+	const namespace = "ns"
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextv1.AddToScheme(scheme))
+	utilruntime.Must(clusterapiv1.AddToScheme(scheme))
+
+	cluster := &clusterapiv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace, Name: "cluster", UID: "cluster-uid",
+			Labels:     map[string]string{kcmv1.FluxHelmChartNameKey: "cd"},
+			Finalizers: []string{clusterapiv1.ClusterFinalizer},
+		},
+		Spec: clusterapiv1.ClusterSpec{Paused: new(true)},
+	}
+	clusterOwner := metav1.OwnerReference{APIVersion: clusterapiv1.GroupVersion.String(), Kind: "Cluster", Name: "cluster", UID: "cluster-uid"}
+	machine := &clusterapiv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace, Name: "machine", UID: "machine-uid",
+			Labels:          map[string]string{clusterapiv1.ClusterNameLabel: "cluster"},
+			OwnerReferences: []metav1.OwnerReference{clusterOwner},
+			Finalizers:      []string{clusterapiv1.MachineFinalizer},
+		},
+	}
+	kubeconfig := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace, Name: "cluster-kubeconfig", UID: "kubeconfig-uid",
+		Labels:          map[string]string{clusterapiv1.ClusterNameLabel: "cluster"},
+		OwnerReferences: []metav1.OwnerReference{clusterOwner, {Kind: "KubeadmControlPlane", Name: "external", UID: "external-uid"}},
+	}}
+	release := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace, Name: "sh.helm.release.v1.cd.v1", UID: "release-uid",
+		Labels: map[string]string{helmStorageOwnerLabel: "helm", helmStorageNameLabel: "cd"},
+	}}
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "unrelated", UID: "unrelated-uid"}}
+
+	source := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newCAPICRD("clusters", "Cluster", apiextv1.NamespaceScoped),
+			newCAPICRD("machines", "Machine", apiextv1.NamespaceScoped),
+			newCAPICRD("clusterclasses", "ClusterClass", apiextv1.ClusterScoped),
+			cluster, machine, kubeconfig, release, unrelated,
+		).
+		Build()
+	target := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c crclient.WithWatch, obj crclient.Object, opts ...crclient.CreateOption) error {
+				obj.SetUID(types.UID("new-" + obj.GetName()))
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	mover := &clusterObjectsMover{source: source, target: target, namespace: namespace, clusterName: "cluster", releaseName: "cd"}
+	ctx := t.Context()
+
+	if err := mover.ensureNamespace(ctx); err != nil {
+		t.Fatalf("failed to ensure namespace: %v", err)
+	}
+
+	objs, err := mover.discover(ctx)
+	if err != nil {
+		t.Fatalf("failed to discover objects: %v", err)
+	}
+	var names []string
+	for _, obj := range objs {
+		names = append(names, obj.GetName())
+	}
+	if want := []string{"cluster", "machine", "cluster-kubeconfig", "sh.helm.release.v1.cd.v1"}; !slices.Equal(names, want) {
+		t.Fatalf("discovered objects = %v, want %v", names, want)
+	}
+
+	var copied []kcmv1.ClusterDeploymentMigrationObject
+	for range 2 { // copying is idempotent
+		copied, err = mover.copyToTarget(ctx, objs)
+		if err != nil {
+			t.Fatalf("failed to copy objects: %v", err)
+		}
+		if len(copied) != len(objs) {
+			t.Errorf("moved objects = %d, want %d", len(copied), len(objs))
+		}
+	}
+	if got := copied[0]; got.Kind != "Cluster" || got.SourceUID != "cluster-uid" || got.TargetUID != "new-cluster" {
+		t.Errorf("copied Cluster = %+v, want UIDs mapped from cluster-uid to new-cluster", got)
+	}
+
+	movedMachine := new(clusterapiv1.Machine)
+	if err := target.Get(ctx, crclient.ObjectKeyFromObject(machine), movedMachine); err != nil {
+		t.Fatalf("failed to get moved Machine: %v", err)
+	}
+	if refs := movedMachine.OwnerReferences; len(refs) != 1 || refs[0].UID != "new-cluster" {
+		t.Errorf("owner references of the moved Machine = %v, want the moved Cluster", refs)
+	}
+	movedKubeconfig := new(corev1.Secret)
+	if err := target.Get(ctx, crclient.ObjectKeyFromObject(kubeconfig), movedKubeconfig); err != nil {
+		t.Fatalf("failed to get moved kubeconfig: %v", err)
+	}
+	if refs := movedKubeconfig.OwnerReferences; len(refs) != 1 || refs[0].UID != "new-cluster" {
+		t.Errorf("owner references of the moved kubeconfig = %v, want the moved Cluster only", refs)
+	}
+
+	// the release secret has been recreated under the same name and is not the copied object anymore
+	if err := source.Delete(ctx, release); err != nil {
+		t.Fatalf("failed to delete release secret: %v", err)
+	}
+	recreated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: release.Name, UID: "recreated-uid"}}
+	if err := source.Create(ctx, recreated); err != nil {
+		t.Fatalf("failed to recreate release secret: %v", err)
+	}
+
+	for range 2 { // deletion is idempotent
+		if err := mover.deleteFromSource(ctx, copied); err != nil {
+			t.Fatalf("failed to delete objects from source: %v", err)
+		}
+	}
+	for _, obj := range []crclient.Object{cluster, machine, kubeconfig} {
+		if err := source.Get(ctx, crclient.ObjectKeyFromObject(obj), obj); !apierrors.IsNotFound(err) {
+			t.Errorf("expected %s to be deleted from source, got %v", obj.GetName(), err)
+		}
+	}
+	for _, obj := range []crclient.Object{unrelated, recreated} {
+		if err := source.Get(ctx, crclient.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Errorf("expected %s to be kept in source, got %v", obj.GetName(), err)
+		}
+	}
+}
+
+func Test_ClusterDeploymentMigrationReconciler_start(t *testing.T) {
+	t.Parallel()
+
+	const namespace = metav1.NamespaceDefault
+
+	newCred := func(name, region string) *kcmv1.Credential {
+		return &kcmv1.Credential{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       kcmv1.CredentialSpec{Region: region},
+			Status:     kcmv1.CredentialStatus{Ready: true},
+		}
+	}
+	newCD := func(dryRun bool, annotations map[string]string) *kcmv1.ClusterDeployment {
+		return &kcmv1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd", Annotations: annotations},
+			Spec:       kcmv1.ClusterDeploymentSpec{Credential: "mgmt-cred", DryRun: dryRun},
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		cd          *kcmv1.ClusterDeployment
+		target      string
+		wantMessage string
+	}{
+		{name: "dry-run", cd: newCD(true, nil), target: "region-cred", wantMessage: "is in the dry-run mode"},
+		{name: "concurrent migration", cd: newCD(false, map[string]string{kcmv1.ClusterDeploymentMigrationAnnotation: "other"}), target: "region-cred", wantMessage: "is being migrated by the ClusterDeploymentMigration other"},
+		{name: "missing target Credential", cd: newCD(false, nil), target: "missing", wantMessage: "Credential default/missing is not found"},
+		{name: "same region", cd: newCD(false, nil), target: "other-mgmt-cred", wantMessage: "is already deployed in the management cluster"},
+		{name: "missing target Region", cd: newCD(false, nil), target: "region-cred", wantMessage: "Region region is not found"},
+	} {
+		migration := &kcmv1.ClusterDeploymentMigration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "migration"},
+			Spec:       kcmv1.ClusterDeploymentMigrationSpec{ClusterDeployment: "cd", TargetCredential: tc.target},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(testscheme.Scheme).
+			WithObjects(tc.cd, newCred("mgmt-cred", ""), newCred("other-mgmt-cred", ""), newCred("region-cred", "region")).
+			Build()
+		r := &ClusterDeploymentMigrationReconciler{MgmtClient: c, timeFunc: time.Now}
+
+		if err := r.reconcileMigration(t.Context(), migration); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if migration.Status.Phase != kcmv1.ClusterDeploymentMigrationPhaseFailed {
+			t.Errorf("%s: phase = %s, want %s", tc.name, migration.Status.Phase, kcmv1.ClusterDeploymentMigrationPhaseFailed)
+		}
+		cond := apimeta.FindStatusCondition(migration.Status.Conditions, kcmv1.ReadyCondition)
+		if cond == nil || !strings.Contains(cond.Message, tc.wantMessage) {
+			t.Errorf("%s: Ready condition = %v, want message containing %q", tc.name, cond, tc.wantMessage)
+		}
+	}
+}
+
+func Test_ClusterDeploymentMigrationReconciler_repoint(t *testing.T) {
+	t.Parallel()
+
+	const namespace = metav1.NamespaceDefault
+
+	cd := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace, Name: "cd",
+			Annotations: map[string]string{kcmv1.ClusterDeploymentMigrationAnnotation: "migration"},
+		},
+		Spec:   kcmv1.ClusterDeploymentSpec{Credential: "region-cred"},
+		Status: kcmv1.ClusterDeploymentStatus{Region: "region"},
+	}
+	hr := &helmcontrollerv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"},
+		Spec: helmcontrollerv2.HelmReleaseSpec{
+			Suspend:    true,
+			KubeConfig: &fluxmeta.KubeConfigReference{SecretRef: &fluxmeta.SecretKeyReference{Name: "region-kubeconfig"}},
+		},
+	}
+	migration := &kcmv1.ClusterDeploymentMigration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "migration"},
+		Spec:       kcmv1.ClusterDeploymentMigrationSpec{ClusterDeployment: "cd", TargetCredential: "mgmt-cred"},
+		Status: kcmv1.ClusterDeploymentMigrationStatus{
+			Phase:            kcmv1.ClusterDeploymentMigrationPhaseRepointing,
+			SourceCredential: "region-cred",
+			SourceRegion:     "region",
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(testscheme.Scheme).WithObjects(cd, hr).WithStatusSubresource(cd).Build()
+	r := &ClusterDeploymentMigrationReconciler{MgmtClient: c, timeFunc: time.Now}
+	ctx := t.Context()
+
+	if err := r.reconcileMigration(ctx, migration); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migration.Status.Phase != kcmv1.ClusterDeploymentMigrationPhaseRepointing {
+		t.Errorf("phase = %s, want %s until the ClusterDeployment is reconciled", migration.Status.Phase, kcmv1.ClusterDeploymentMigrationPhaseRepointing)
+	}
+
+	if err := c.Get(ctx, crclient.ObjectKeyFromObject(cd), cd); err != nil {
+		t.Fatalf("failed to get ClusterDeployment: %v", err)
+	}
+	if cd.Spec.Credential != "mgmt-cred" {
+		t.Errorf("credential = %s, want mgmt-cred", cd.Spec.Credential)
+	}
+	if _, ok := cd.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation]; ok {
+		t.Error("expected the migration annotation to be removed from the ClusterDeployment")
+	}
+	if err := c.Get(ctx, crclient.ObjectKeyFromObject(hr), hr); err != nil {
+		t.Fatalf("failed to get HelmRelease: %v", err)
+	}
+	if hr.Spec.KubeConfig != nil || !hr.Spec.Suspend {
+		t.Errorf("HelmRelease kubeconfig = %v, suspend = %t, want no kubeconfig and suspended", hr.Spec.KubeConfig, hr.Spec.Suspend)
+	}
+
+	// the ClusterDeployment controller has reconciled the ClusterDeployment against the management cluster
+	cd.Status.Region = ""
+	if err := c.Status().Update(ctx, cd); err != nil {
+		t.Fatalf("failed to update ClusterDeployment status: %v", err)
+	}
+
+	if err := r.reconcileMigration(ctx, migration); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migration.Status.Phase != kcmv1.ClusterDeploymentMigrationPhaseRebuildingServices {
+		t.Errorf("phase = %s, want %s", migration.Status.Phase, kcmv1.ClusterDeploymentMigrationPhaseRebuildingServices)
+	}
+	if err := c.Get(ctx, crclient.ObjectKeyFromObject(hr), hr); err != nil {
+		t.Fatalf("failed to get HelmRelease: %v", err)
+	}
+	if hr.Spec.Suspend {
+		t.Error("expected the HelmRelease to be resumed")
+	}
+}
+
+func Test_ClusterDeploymentMigrationReconciler_timeout(t *testing.T) {
+	t.Parallel()
+
+	const namespace = metav1.NamespaceDefault
+
+	now := time.Now()
+	for _, tc := range []struct {
+		name      string
+		timeout   *metav1.Duration
+		startTime time.Time
+		wantPhase kcmv1.ClusterDeploymentMigrationPhase
+	}{
+		{name: "default timeout not exceeded", startTime: now.Add(-time.Minute), wantPhase: kcmv1.ClusterDeploymentMigrationPhaseRepointing},
+		{name: "default timeout exceeded", startTime: now.Add(-2 * time.Hour), wantPhase: kcmv1.ClusterDeploymentMigrationPhaseFailed},
+		{name: "custom timeout exceeded", timeout: &metav1.Duration{Duration: time.Minute}, startTime: now.Add(-2 * time.Minute), wantPhase: kcmv1.ClusterDeploymentMigrationPhaseFailed},
+	} {
+		cd := &kcmv1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"},
+			Spec:       kcmv1.ClusterDeploymentSpec{Credential: "mgmt-cred"},
+			Status:     kcmv1.ClusterDeploymentStatus{Region: "region"},
+		}
+		migration := &kcmv1.ClusterDeploymentMigration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "migration"},
+			Spec:       kcmv1.ClusterDeploymentMigrationSpec{ClusterDeployment: "cd", TargetCredential: "mgmt-cred", Timeout: tc.timeout},
+			Status: kcmv1.ClusterDeploymentMigrationStatus{
+				Phase:        kcmv1.ClusterDeploymentMigrationPhaseRepointing,
+				StartTime:    &metav1.Time{Time: tc.startTime},
+				SourceRegion: "region",
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(testscheme.Scheme).WithObjects(cd).Build()
+		r := &ClusterDeploymentMigrationReconciler{MgmtClient: c, timeFunc: func() time.Time { return now }}
+
+		if err := r.reconcileMigration(t.Context(), migration); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if migration.Status.Phase != tc.wantPhase {
+			t.Errorf("%s: phase = %s, want %s", tc.name, migration.Status.Phase, tc.wantPhase)
+		}
+		if tc.wantPhase != kcmv1.ClusterDeploymentMigrationPhaseFailed {
+			continue
+		}
+		cond := apimeta.FindStatusCondition(migration.Status.Conditions, kcmv1.ReadyCondition)
+		if cond == nil || !strings.Contains(cond.Message, "stuck in the Repointing phase") {
+			t.Errorf("%s: Ready condition = %v, want the timeout message", tc.name, cond)
+		}
+	}
+}
+
+func Test_ClusterDeploymentMigrationReconciler_rebuildServices(t *testing.T) {
+	t.Parallel()
+
+	const namespace = metav1.NamespaceDefault
+
+	cd := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"},
+		Spec:       kcmv1.ClusterDeploymentSpec{Credential: "mgmt-cred"},
+	}
+	newServiceSet := func(name string, annotations map[string]string) *kcmv1.ServiceSet {
+		return &kcmv1.ServiceSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations},
+			Spec:       kcmv1.ServiceSetSpec{Cluster: cd.Name},
+		}
+	}
+	rebuilt := newServiceSet("rebuilt", map[string]string{kcmv1.ClusterDeploymentMigrationAnnotation: "migration"})
+	pending := newServiceSet("pending", nil)
+	migration := &kcmv1.ClusterDeploymentMigration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "migration"},
+		Spec:       kcmv1.ClusterDeploymentMigrationSpec{ClusterDeployment: "cd", TargetCredential: "mgmt-cred"},
+		Status:     kcmv1.ClusterDeploymentMigrationStatus{Phase: kcmv1.ClusterDeploymentMigrationPhaseRebuildingServices},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(testscheme.Scheme).
+		WithObjects(cd, rebuilt, pending).
+		WithIndex(&kcmv1.ServiceSet{}, kcmv1.ServiceSetClusterIndexKey, kcmv1.ExtractServiceSetCluster).
+		Build()
+	r := &ClusterDeploymentMigrationReconciler{MgmtClient: c, timeFunc: time.Now}
+	ctx := t.Context()
+
+	if err := r.reconcileMigration(ctx, migration); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migration.Status.Phase != kcmv1.ClusterDeploymentMigrationPhaseCompleted {
+		t.Errorf("phase = %s, want %s", migration.Status.Phase, kcmv1.ClusterDeploymentMigrationPhaseCompleted)
+	}
+	for _, serviceSet := range []*kcmv1.ServiceSet{rebuilt, pending} {
+		if err := c.Get(ctx, crclient.ObjectKeyFromObject(serviceSet), serviceSet); err != nil {
+			t.Fatalf("failed to get ServiceSet %s: %v", serviceSet.Name, err)
+		}
+		if _, ok := serviceSet.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation]; ok {
+			t.Errorf("expected the migration annotation to be removed from the ServiceSet %s", serviceSet.Name)
+		}
+	}
+}
+
+func Test_ClusterDeploymentMigrationReconciler_rollback(t *testing.T) {
+	t.Parallel()
+
+	const namespace = metav1.NamespaceDefault
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextv1.AddToScheme(scheme))
+	utilruntime.Must(clusterapiv1.AddToScheme(scheme))
+	utilruntime.Must(kcmv1.AddToScheme(scheme))
+	utilruntime.Must(helmcontrollerv2.AddToScheme(scheme))
+
+	now := time.Now()
+	newCluster := func(uid types.UID) *clusterapiv1.Cluster {
+		return &clusterapiv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace, Name: "cluster", UID: uid,
+				Labels:     map[string]string{kcmv1.FluxHelmChartNameKey: "cd"},
+				Finalizers: []string{clusterapiv1.ClusterFinalizer},
+			},
+			Spec: clusterapiv1.ClusterSpec{Paused: new(true)},
+		}
+	}
+	newClusterObjects := func(clusterUID types.UID) []crclient.Object {
+		return []crclient.Object{
+			newCAPICRD("clusters", "Cluster", apiextv1.NamespaceScoped),
+			newCAPICRD("machines", "Machine", apiextv1.NamespaceScoped),
+			newCluster(clusterUID),
+		}
+	}
+	newMgmtClient := func(objs ...crclient.Object) crclient.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&kcmv1.ClusterDeploymentMigration{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c crclient.WithWatch, obj crclient.Object, opts ...crclient.CreateOption) error {
+					obj.SetUID(types.UID("back-" + obj.GetName()))
+					return c.Create(ctx, obj, opts...)
+				},
+			}).
+			Build()
+	}
+	newMigration := func(phase kcmv1.ClusterDeploymentMigrationPhase) *kcmv1.ClusterDeploymentMigration {
+		return &kcmv1.ClusterDeploymentMigration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "migration", Finalizers: []string{kcmv1.ClusterDeploymentMigrationFinalizer}},
+			Spec: kcmv1.ClusterDeploymentMigrationSpec{
+				ClusterDeployment: "cd", TargetCredential: "region-cred", Timeout: &metav1.Duration{Duration: time.Minute},
+			},
+			Status: kcmv1.ClusterDeploymentMigrationStatus{
+				Phase:            phase,
+				StartTime:        &metav1.Time{Time: now.Add(-2 * time.Minute)},
+				SourceCredential: "mgmt-cred",
+				TargetRegion:     "region",
+			},
+		}
+	}
+	cd := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace, Name: "cd",
+			Annotations: map[string]string{kcmv1.ClusterDeploymentMigrationAnnotation: "migration"},
+		},
+		Spec: kcmv1.ClusterDeploymentSpec{Credential: "mgmt-cred"},
+	}
+	hr := &helmcontrollerv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"},
+		Spec:       helmcontrollerv2.HelmReleaseSpec{Suspend: true},
+	}
+
+	// expectRolledBack checks that the ClusterDeployment is managed from the source cluster as before the migration
+	expectRolledBack := func(t *testing.T, mgmt, target crclient.Client) {
+		t.Helper()
+
+		ctx := t.Context()
+		gotCD, gotHR, gotCluster := new(kcmv1.ClusterDeployment), new(helmcontrollerv2.HelmRelease), new(clusterapiv1.Cluster)
+		if err := mgmt.Get(ctx, crclient.ObjectKeyFromObject(cd), gotCD); err != nil {
+			t.Fatalf("failed to get ClusterDeployment: %v", err)
+		}
+		if _, ok := gotCD.Annotations[kcmv1.ClusterDeploymentMigrationAnnotation]; ok {
+			t.Error("expected the migration annotation to be removed from the ClusterDeployment")
+		}
+		if err := mgmt.Get(ctx, crclient.ObjectKeyFromObject(hr), gotHR); err != nil {
+			t.Fatalf("failed to get HelmRelease: %v", err)
+		}
+		if gotHR.Spec.Suspend {
+			t.Error("expected the HelmRelease to be resumed")
+		}
+		if err := mgmt.Get(ctx, crclient.ObjectKey{Namespace: namespace, Name: "cluster"}, gotCluster); err != nil {
+			t.Fatalf("failed to get CAPI Cluster from the source cluster: %v", err)
+		}
+		if gotCluster.Spec.Paused == nil || *gotCluster.Spec.Paused {
+			t.Error("expected the CAPI Cluster to be resumed in the source cluster")
+		}
+
+		for _, list := range []crclient.ObjectList{new(clusterapiv1.ClusterList), new(clusterapiv1.MachineList), new(corev1.SecretList)} {
+			if err := target.List(ctx, list, crclient.InNamespace(namespace)); err != nil {
+				t.Fatalf("failed to list objects in the target cluster: %v", err)
+			}
+			if n := apimeta.LenList(list); n != 0 {
+				t.Errorf("expected the copies to be deleted from the target cluster, got %d %T", n, list)
+			}
+		}
+	}
+
+	t.Run("timeout while moving", func(t *testing.T) {
+		t.Parallel()
+
+		migration := newMigration(kcmv1.ClusterDeploymentMigrationPhaseMoving)
+		migration.Status.Objects = []kcmv1.ClusterDeploymentMigrationObject{
+			{APIVersion: clusterapiv1.GroupVersion.String(), Kind: "Cluster", Name: "cluster", SourceUID: "cluster-uid", TargetUID: "new-cluster"},
+			{APIVersion: clusterapiv1.GroupVersion.String(), Kind: "Machine", Name: "machine", SourceUID: "machine-uid", TargetUID: "new-machine"},
+		}
+
+		// the Machine has already been deleted from the source cluster
+		mgmt := newMgmtClient(append(newClusterObjects("cluster-uid"), cd.DeepCopy(), hr.DeepCopy(), migration)...)
+		target := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(append(newClusterObjects("new-cluster"),
+				&clusterapiv1.Machine{ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace, Name: "machine", UID: "new-machine",
+					Labels:          map[string]string{clusterapiv1.ClusterNameLabel: "cluster"},
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: clusterapiv1.GroupVersion.String(), Kind: "Cluster", Name: "cluster", UID: "new-cluster"}},
+					Finalizers:      []string{clusterapiv1.MachineFinalizer},
+				}},
+				// copied but not recorded yet
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace, Name: "cluster-kubeconfig", UID: "new-kubeconfig",
+					Labels: map[string]string{clusterapiv1.ClusterNameLabel: "cluster"},
+				}},
+			)...).
+			Build()
+
+		r := &ClusterDeploymentMigrationReconciler{
+			MgmtClient: mgmt,
+			timeFunc:   func() time.Time { return now },
+			getRegionalClientFunc: func(_ context.Context, region string) (crclient.Client, error) {
+				if region == "region" {
+					return target, nil
+				}
+				return mgmt, nil
+			},
+		}
+		ctx := t.Context()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: crclient.ObjectKeyFromObject(migration)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := mgmt.Get(ctx, crclient.ObjectKeyFromObject(migration), migration); err != nil {
+			t.Fatalf("failed to get ClusterDeploymentMigration: %v", err)
+		}
+		if migration.Status.Phase != kcmv1.ClusterDeploymentMigrationPhaseFailed {
+			t.Errorf("phase = %s, want %s", migration.Status.Phase, kcmv1.ClusterDeploymentMigrationPhaseFailed)
+		}
+		if len(migration.Finalizers) != 0 {
+			t.Errorf("finalizers = %v, want none once rolled back", migration.Finalizers)
+		}
+
+		expectRolledBack(t, mgmt, target)
+		machine := new(clusterapiv1.Machine)
+		if err := mgmt.Get(ctx, crclient.ObjectKey{Namespace: namespace, Name: "machine"}, machine); err != nil {
+			t.Fatalf("expected the Machine to be moved back to the source cluster, got %v", err)
+		}
+		if refs := machine.OwnerReferences; len(refs) != 1 || refs[0].UID != "cluster-uid" {
+			t.Errorf("owner references of the moved back Machine = %v, want the source Cluster", refs)
+		}
+	})
+
+	t.Run("unfinished migration deleted", func(t *testing.T) {
+		t.Parallel()
+
+		migration := newMigration(kcmv1.ClusterDeploymentMigrationPhaseMoving)
+		migration.Spec.Timeout = nil
+
+		mgmt := newMgmtClient(append(newClusterObjects("cluster-uid"), cd.DeepCopy(), hr.DeepCopy(), migration)...)
+		target := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newClusterObjects("new-cluster")...).Build()
+		ctx := t.Context()
+		if err := mgmt.Delete(ctx, migration); err != nil {
+			t.Fatalf("failed to delete ClusterDeploymentMigration: %v", err)
+		}
+
+		r := &ClusterDeploymentMigrationReconciler{
+			MgmtClient: mgmt,
+			timeFunc:   func() time.Time { return now },
+			getRegionalClientFunc: func(_ context.Context, region string) (crclient.Client, error) {
+				if region == "region" {
+					return target, nil
+				}
+				return mgmt, nil
+			},
+		}
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: crclient.ObjectKeyFromObject(migration)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := mgmt.Get(ctx, crclient.ObjectKeyFromObject(migration), migration); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the ClusterDeploymentMigration to be deleted once rolled back, got %v", err)
+		}
+		expectRolledBack(t, mgmt, target)
+	})
+}
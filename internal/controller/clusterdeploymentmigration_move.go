@@ -0,0 +1,293 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+const (
+	// helmStorageOwnerLabel and helmStorageNameLabel are the labels of the secrets
+	// holding the revisions of the Helm releases.
+	helmStorageOwnerLabel = "owner"
+	helmStorageNameLabel  = "name"
+)
+
+var crdListGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinitionList"}
+
+// clusterObjectsMover moves the objects of a single CAPI cluster between the management
+// and the regional clusters, similar to clusterctl move.
+//
+// The moved objects are the namespaced objects of the kinds served by the CAPI providers
+// together with the secrets and the config maps which either belong to the CAPI cluster
+// or have been rendered by the Helm release of the [kcmv1.ClusterDeployment], and the secrets
+// holding the revisions of the Helm release itself.
+type clusterObjectsMover struct {
+	source, target client.Client
+
+	namespace   string
+	clusterName string
+	releaseName string
+
+	// knownUIDs maps the UIDs of the source objects to the UIDs of the objects
+	// already present in the target cluster, used to rewrite the owner references.
+	knownUIDs map[types.UID]types.UID
+}
+
+// discover returns the objects to move ordered so that the owners precede their dependents.
+func (m *clusterObjectsMover) discover(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	gvks, err := m.providerKinds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gvks = append(gvks, corev1.SchemeGroupVersion.WithKind("Secret"), corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+
+	selectors := []client.MatchingLabels{
+		{clusterapiv1.ClusterNameLabel: m.clusterName},
+		{kcmv1.FluxHelmChartNameKey: m.releaseName},
+	}
+
+	var (
+		objs []*unstructured.Unstructured
+		seen = make(map[types.UID]struct{})
+	)
+	collect := func(gvk schema.GroupVersionKind, selector client.MatchingLabels) error {
+		list := new(unstructured.UnstructuredList)
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := m.source.List(ctx, list, client.InNamespace(m.namespace), selector); err != nil {
+			return fmt.Errorf("failed to list %s objects in namespace %s: %w", gvk.Kind, m.namespace, err)
+		}
+		for i := range list.Items {
+			if _, ok := seen[list.Items[i].GetUID()]; ok {
+				continue
+			}
+			seen[list.Items[i].GetUID()] = struct{}{}
+			objs = append(objs, &list.Items[i])
+		}
+		return nil
+	}
+
+	for _, gvk := range gvks {
+		for _, selector := range selectors {
+			if err := collect(gvk, selector); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := collect(corev1.SchemeGroupVersion.WithKind("Secret"), client.MatchingLabels{
+		helmStorageOwnerLabel: "helm",
+		helmStorageNameLabel:  m.releaseName,
+	}); err != nil {
+		return nil, err
+	}
+
+	return orderByOwners(objs), nil
+}
+
+// providerKinds returns the namespaced kinds served by the CAPI providers installed in the source cluster.
+func (m *clusterObjectsMover) providerKinds(ctx context.Context) ([]schema.GroupVersionKind, error) {
+	crds := new(unstructured.UnstructuredList)
+	crds.SetGroupVersionKind(crdListGVK)
+	if err := m.source.List(ctx, crds, client.HasLabels{clusterapiv1.ProviderNameLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list CAPI providers CustomResourceDefinitions: %w", err)
+	}
+
+	gvks := make([]schema.GroupVersionKind, 0, len(crds.Items))
+	for _, crd := range crds.Items {
+		if scope, _, _ := unstructured.NestedString(crd.Object, "spec", "scope"); scope != "Namespaced" {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+		versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+		for _, v := range versions {
+			version, ok := v.(map[string]any)
+			if !ok || version["storage"] != true {
+				continue
+			}
+			name, _ := version["name"].(string)
+			gvks = append(gvks, schema.GroupVersionKind{Group: group, Version: name, Kind: kind})
+		}
+	}
+
+	slices.SortFunc(gvks, func(a, b schema.GroupVersionKind) int {
+		return cmp.Or(cmp.Compare(a.Group, b.Group), cmp.Compare(a.Kind, b.Kind))
+	})
+
+	return gvks, nil
+}
+
+// copyToTarget creates the given objects in the target cluster rewriting their owner references
+// to the UIDs of the created owners. Owner references to the objects which are not moved are dropped
+// to prevent the garbage collection of the created objects. The objects already existing in the target
+// cluster are left intact. Returns the objects present in the target cluster along with their UIDs in both clusters.
+func (m *clusterObjectsMover) copyToTarget(ctx context.Context, objs []*unstructured.Unstructured) ([]kcmv1.ClusterDeploymentMigrationObject, error) {
+	uids := make(map[types.UID]types.UID, len(objs)+len(m.knownUIDs))
+	maps.Copy(uids, m.knownUIDs)
+	copied := make([]kcmv1.ClusterDeploymentMigrationObject, 0, len(objs))
+	for _, obj := range objs {
+		newObj := obj.DeepCopy()
+		for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds", "managedFields", "selfLink"} {
+			unstructured.RemoveNestedField(newObj.Object, "metadata", field)
+		}
+		unstructured.RemoveNestedField(newObj.Object, "status")
+
+		var owners []metav1.OwnerReference
+		for _, ref := range obj.GetOwnerReferences() {
+			if uid, ok := uids[ref.UID]; ok {
+				ref.UID = uid
+				owners = append(owners, ref)
+			}
+		}
+		newObj.SetOwnerReferences(owners)
+
+		err := m.target.Create(ctx, newObj)
+		switch {
+		case apierrors.IsAlreadyExists(err):
+			if err := m.target.Get(ctx, client.ObjectKeyFromObject(obj), newObj); err != nil {
+				return nil, fmt.Errorf("failed to get %s %s in the target cluster: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+			}
+		case err != nil:
+			return nil, fmt.Errorf("failed to create %s %s in the target cluster: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+		default:
+			// the status is restored on a best-effort basis, the providers rebuild it anyway
+			if status, ok := obj.Object["status"]; ok {
+				newObj.Object["status"] = status
+				if err := m.target.Status().Update(ctx, newObj); client.IgnoreNotFound(err) != nil && !apierrors.IsMethodNotSupported(err) {
+					return nil, fmt.Errorf("failed to restore status of %s %s in the target cluster: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+				}
+			}
+		}
+
+		uids[obj.GetUID()] = newObj.GetUID()
+		copied = append(copied, kcmv1.ClusterDeploymentMigrationObject{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+			SourceUID:  obj.GetUID(),
+			TargetUID:  newObj.GetUID(),
+		})
+	}
+
+	return copied, nil
+}
+
+// deleteFromSource removes the finalizers of the given copied objects and deletes them from the source cluster.
+// The objects which are already gone or have been recreated under the same name are skipped, hence the deletion
+// can be safely retried. The CAPI cluster is expected to be paused, so that the providers do not act upon the deletion.
+func (m *clusterObjectsMover) deleteFromSource(ctx context.Context, copied []kcmv1.ClusterDeploymentMigrationObject) error {
+	var (
+		objs []*unstructured.Unstructured
+		errs error
+	)
+	for _, ref := range copied {
+		obj := new(unstructured.Unstructured)
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		err := m.source.Get(ctx, client.ObjectKey{Namespace: m.namespace, Name: ref.Name}, obj)
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			errs = errors.Join(errs, fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, m.namespace, ref.Name, err))
+			continue
+		case obj.GetUID() != ref.SourceUID:
+			continue
+		}
+		objs = append(objs, obj)
+
+		if len(obj.GetFinalizers()) > 0 {
+			if err := m.source.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`))); client.IgnoreNotFound(err) != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to remove finalizers of %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err))
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	for _, obj := range slices.Backward(objs) {
+		if err := m.source.Delete(ctx, obj, client.Preconditions{UID: new(obj.GetUID())}); client.IgnoreNotFound(err) != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to delete %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err))
+		}
+	}
+
+	return errs
+}
+
+// orderByOwners orders the given objects so that the owners precede their dependents
+// keeping the relative order otherwise. The objects with circular ownership are appended last.
+func orderByOwners(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	placed := make(map[types.UID]bool, len(objs))
+	for _, obj := range objs {
+		placed[obj.GetUID()] = false
+	}
+
+	ordered := make([]*unstructured.Unstructured, 0, len(objs))
+	for len(ordered) < len(objs) {
+		progress := false
+		for _, obj := range objs {
+			if placed[obj.GetUID()] {
+				continue
+			}
+			if slices.ContainsFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+				ownerPlaced, moved := placed[ref.UID]
+				return moved && !ownerPlaced
+			}) {
+				continue
+			}
+
+			ordered = append(ordered, obj)
+			placed[obj.GetUID()] = true
+			progress = true
+		}
+
+		if !progress {
+			for _, obj := range objs {
+				if !placed[obj.GetUID()] {
+					ordered = append(ordered, obj)
+					placed[obj.GetUID()] = true
+				}
+			}
+		}
+	}
+
+	return ordered
+}
+
+// ensureNamespace creates the namespace of the moved objects in the target cluster if it does not exist.
+func (m *clusterObjectsMover) ensureNamespace(ctx context.Context) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: m.namespace}}
+	if err := m.target.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s in the target cluster: %w", m.namespace, err)
+	}
+	return nil
+}
@@ -0,0 +1,412 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	fluxconditions "github.com/fluxcd/pkg/runtime/conditions"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/record"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
+)
+
+// clusterUpgradeState is the state of a ClusterDeployment with regard to the upgrade to its current template.
+type clusterUpgradeState int
+
+const (
+	clusterUpgradeInProgress clusterUpgradeState = iota
+	clusterUpgradeReady
+	clusterUpgradeFailed
+)
+
+// ClusterFleetUpgradeReconciler reconciles a ClusterFleetUpgrade object
+type ClusterFleetUpgradeReconciler struct {
+	Client client.Client
+
+	timeFunc func() time.Time
+
+	defaultRequeueTime time.Duration
+}
+
+func (r *ClusterFleetUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("Reconciling ClusterFleetUpgrade")
+
+	fleetUpgrade := new(kcmv1.ClusterFleetUpgrade)
+	if err := r.Client.Get(ctx, req.NamespacedName, fleetUpgrade); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.Info("ClusterFleetUpgrade not found, ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get ClusterFleetUpgrade %s: %w", req.NamespacedName, err)
+	}
+	if !fleetUpgrade.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	oldStatus := fleetUpgrade.Status.DeepCopy()
+	err := r.reconcileUpgrade(ctx, fleetUpgrade)
+
+	if !equality.Semantic.DeepEqual(*oldStatus, fleetUpgrade.Status) {
+		fleetUpgrade.Status.ObservedGeneration = fleetUpgrade.Generation
+		if updErr := r.Client.Status().Update(ctx, fleetUpgrade); updErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update ClusterFleetUpgrade %s status: %w", req.NamespacedName, updErr))
+		}
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if fleetUpgrade.Status.Phase == kcmv1.ClusterFleetUpgradePhaseCompleted {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
+}
+
+// reconcileUpgrade walks the matching ClusterDeployments along their upgrade paths to the target template
+// one hop at a time, upgrading at most [kcmv1.ClusterFleetUpgradeSpec.MaxConcurrency] clusters at a time.
+func (r *ClusterFleetUpgradeReconciler) reconcileUpgrade(ctx context.Context, fleetUpgrade *kcmv1.ClusterFleetUpgrade) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	selector, err := metav1.LabelSelectorAsSelector(&fleetUpgrade.Spec.ClusterSelector)
+	if err != nil {
+		return fmt.Errorf("failed to convert ClusterSelector to selector: %w", err)
+	}
+
+	cds := new(kcmv1.ClusterDeploymentList)
+	if err := r.Client.List(ctx, cds, client.InNamespace(fleetUpgrade.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+	slices.SortFunc(cds.Items, func(a, b kcmv1.ClusterDeployment) int { return cmp.Compare(a.Name, b.Name) })
+
+	previous := make(map[string]kcmv1.ClusterUpgradeStatus, len(fleetUpgrade.Status.Clusters))
+	for _, c := range fleetUpgrade.Status.Clusters {
+		previous[c.Name] = c
+	}
+
+	var (
+		errs     error
+		clusters = make([]kcmv1.ClusterUpgradeStatus, 0, len(cds.Items))
+		// ready holds whether the cluster is ready to be upgraded to the next template
+		ready = make(map[string]bool, len(cds.Items))
+	)
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		if !cd.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		state, msg, err := r.clusterUpgradeState(ctx, cd)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		c := kcmv1.ClusterUpgradeStatus{Name: cd.Name, CurrentTemplate: cd.Spec.Template, Message: msg}
+		if cd.Spec.Template != fleetUpgrade.Spec.TargetTemplate {
+			if c.RemainingTemplates, err = r.upgradePath(ctx, cd.Namespace, cd.Spec.Template, fleetUpgrade.Spec.TargetTemplate); err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+		}
+		prev := previous[cd.Name]
+		c.StartTime = prev.StartTime
+
+		c.Phase = clusterUpgradePhase(cd.Spec.Template == fleetUpgrade.Spec.TargetTemplate, len(c.RemainingTemplates) > 0, c.StartTime != nil, state)
+		if c.Phase == kcmv1.ClusterUpgradePhaseBlocked {
+			c.Message = fmt.Sprintf("No upgrade path from ClusterTemplate %s to %s", cd.Spec.Template, fleetUpgrade.Spec.TargetTemplate)
+		}
+		ready[cd.Name] = state == clusterUpgradeReady
+
+		c.LastTransitionTime = prev.LastTransitionTime
+		if prev.Phase != c.Phase || c.LastTransitionTime == nil {
+			c.LastTransitionTime = &metav1.Time{Time: r.timeFunc()}
+		}
+		clusters = append(clusters, c)
+	}
+
+	for i, c := range nextClusterUpgradeHops(clusters, ready, int(fleetUpgrade.Spec.MaxConcurrency)) {
+		next := c.RemainingTemplates[0]
+		l.Info("Upgrading ClusterDeployment", "ClusterDeployment", c.Name, "from", c.CurrentTemplate, "to", next)
+
+		cd := &kcmv1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: fleetUpgrade.Namespace, Name: c.Name}}
+		patch := client.RawPatch(client.Merge.Type(), fmt.Appendf(nil, `{"spec":{"template":%q}}`, next))
+		if err := r.Client.Patch(ctx, cd, patch); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to upgrade ClusterDeployment %s/%s to ClusterTemplate %s: %w", cd.Namespace, cd.Name, next, err))
+			continue
+		}
+		record.Eventf(fleetUpgrade, cd, "ClusterUpgradeStarted", "UpgradeCluster",
+			"Upgrading ClusterDeployment %s from ClusterTemplate %s to %s", c.Name, c.CurrentTemplate, next)
+
+		c.CurrentTemplate, c.RemainingTemplates = next, c.RemainingTemplates[1:]
+		c.Phase, c.Message = kcmv1.ClusterUpgradePhaseUpgrading, ""
+		if c.LastTransitionTime == nil || clusters[i].Phase != kcmv1.ClusterUpgradePhaseUpgrading {
+			c.LastTransitionTime = &metav1.Time{Time: r.timeFunc()}
+		}
+		if c.StartTime == nil {
+			c.StartTime = &metav1.Time{Time: r.timeFunc()}
+		}
+		clusters[i] = c
+	}
+
+	fleetUpgrade.Status.Clusters = clusters
+	setClusterFleetUpgradeStatus(fleetUpgrade)
+
+	return errs
+}
+
+// clusterUpgradePhase returns the phase of the upgrade of a single cluster.
+func clusterUpgradePhase(atTarget, hasPath, started bool, state clusterUpgradeState) kcmv1.ClusterUpgradePhase {
+	switch {
+	case state == clusterUpgradeFailed && (started || atTarget):
+		return kcmv1.ClusterUpgradePhaseFailed
+	case atTarget && state == clusterUpgradeReady:
+		return kcmv1.ClusterUpgradePhaseCompleted
+	case atTarget:
+		return kcmv1.ClusterUpgradePhaseUpgrading
+	case !hasPath:
+		return kcmv1.ClusterUpgradePhaseBlocked
+	case started:
+		return kcmv1.ClusterUpgradePhaseUpgrading
+	default:
+		return kcmv1.ClusterUpgradePhasePending
+	}
+}
+
+// nextClusterUpgradeHops returns the indexes of the clusters to be upgraded to the next template of their upgrade paths:
+// the clusters being upgraded which are ready for the next hop go first, then the pending ready clusters, as long as
+// the number of the clusters being upgraded does not exceed the given maximum. No clusters are started if the upgrade
+// of any of the clusters has failed. Only the clusters the upgrade of which has been started by the fleet upgrade
+// are counted, the clusters already deployed with the target template are not.
+func nextClusterUpgradeHops(clusters []kcmv1.ClusterUpgradeStatus, ready map[string]bool, maxConcurrency int) map[int]kcmv1.ClusterUpgradeStatus {
+	var inFlight int
+	halted := false
+	hops := make(map[int]kcmv1.ClusterUpgradeStatus)
+	for i, c := range clusters {
+		if c.StartTime == nil {
+			continue
+		}
+		switch c.Phase {
+		case kcmv1.ClusterUpgradePhaseFailed:
+			halted = true
+		case kcmv1.ClusterUpgradePhaseUpgrading:
+			inFlight++
+			if ready[c.Name] && len(c.RemainingTemplates) > 0 {
+				hops[i] = c
+			}
+		}
+	}
+
+	if halted {
+		return hops
+	}
+
+	for i, c := range clusters {
+		if inFlight >= max(maxConcurrency, 1) {
+			break
+		}
+		if c.Phase == kcmv1.ClusterUpgradePhasePending && ready[c.Name] {
+			hops[i] = c
+			inFlight++
+		}
+	}
+	return hops
+}
+
+// setClusterFleetUpgradeStatus sets the overall phase, counters and the Ready condition of the given [kcmv1.ClusterFleetUpgrade].
+func setClusterFleetUpgradeStatus(fleetUpgrade *kcmv1.ClusterFleetUpgrade) {
+	var upgraded, blocked, failed int32
+	for _, c := range fleetUpgrade.Status.Clusters {
+		switch c.Phase {
+		case kcmv1.ClusterUpgradePhaseCompleted:
+			upgraded++
+		case kcmv1.ClusterUpgradePhaseBlocked:
+			blocked++
+		case kcmv1.ClusterUpgradePhaseFailed:
+			if c.StartTime != nil {
+				failed++
+			}
+		}
+	}
+	total := int32(len(fleetUpgrade.Status.Clusters))
+	fleetUpgrade.Status.UpgradedClusters, fleetUpgrade.Status.TotalClusters = upgraded, total
+
+	c := metav1.Condition{
+		Type:               kcmv1.ReadyCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: fleetUpgrade.Generation,
+		Message:            fmt.Sprintf("%d/%d clusters upgraded", upgraded, total),
+	}
+	switch {
+	case failed > 0:
+		fleetUpgrade.Status.Phase = kcmv1.ClusterFleetUpgradePhaseHalted
+		c.Reason = kcmv1.ClusterFleetUpgradeHaltedReason
+		c.Message += fmt.Sprintf(", %d failed", failed)
+	case upgraded+blocked == total:
+		fleetUpgrade.Status.Phase = kcmv1.ClusterFleetUpgradePhaseCompleted
+		c.Status, c.Reason = metav1.ConditionTrue, kcmv1.SucceededReason
+	default:
+		fleetUpgrade.Status.Phase = kcmv1.ClusterFleetUpgradePhaseProgressing
+		c.Reason = kcmv1.ProgressingReason
+	}
+	if blocked > 0 {
+		c.Message += fmt.Sprintf(", %d without upgrade path", blocked)
+	}
+	apimeta.SetStatusCondition(&fleetUpgrade.Status.Conditions, c)
+}
+
+// clusterUpgradeState returns the state of the given ClusterDeployment with regard to its current template:
+// the cluster is ready once its HelmRelease has been reconciled with the chart of the template and
+// the CAPI cluster is ready, and failed if either the template is invalid or the HelmRelease is stalled.
+func (r *ClusterFleetUpgradeReconciler) clusterUpgradeState(ctx context.Context, cd *kcmv1.ClusterDeployment) (clusterUpgradeState, string, error) {
+	if cd.Status.ObservedGeneration != cd.Generation {
+		return clusterUpgradeInProgress, "Waiting for the ClusterDeployment to be reconciled", nil
+	}
+
+	if cond := apimeta.FindStatusCondition(cd.Status.Conditions, kcmv1.TemplateReadyCondition); cond != nil && cond.Status == metav1.ConditionFalse {
+		return clusterUpgradeFailed, cond.Message, nil
+	}
+	if cond := apimeta.FindStatusCondition(cd.Status.Conditions, kcmv1.MaintenanceWindowPendingCondition); cond != nil {
+		return clusterUpgradeInProgress, cond.Message, nil
+	}
+
+	clusterTpl := new(kcmv1.ClusterTemplate)
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Template}, clusterTpl); err != nil {
+		if apierrors.IsNotFound(err) {
+			return clusterUpgradeFailed, fmt.Sprintf("ClusterTemplate %s is not found", cd.Spec.Template), nil
+		}
+		return clusterUpgradeInProgress, "", fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", cd.Namespace, cd.Spec.Template, err)
+	}
+
+	hr := new(helmcontrollerv2.HelmRelease)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cd), hr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return clusterUpgradeInProgress, "Waiting for the HelmRelease to be created", nil
+		}
+		return clusterUpgradeInProgress, "", fmt.Errorf("failed to get HelmRelease %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+
+	return helmReleaseUpgradeState(cd, clusterTpl, hr)
+}
+
+// helmReleaseUpgradeState returns the upgrade state of the given ClusterDeployment given its HelmRelease.
+func helmReleaseUpgradeState(cd *kcmv1.ClusterDeployment, clusterTpl *kcmv1.ClusterTemplate, hr *helmcontrollerv2.HelmRelease) (clusterUpgradeState, string, error) {
+	if clusterTpl.Status.ChartRef == nil || hr.Spec.ChartRef == nil ||
+		hr.Spec.ChartRef.Name != clusterTpl.Status.ChartRef.Name || hr.Spec.ChartRef.Namespace != clusterTpl.Status.ChartRef.Namespace {
+		return clusterUpgradeInProgress, "Waiting for the HelmRelease to be updated", nil
+	}
+	if fluxconditions.IsStalled(hr) {
+		return clusterUpgradeFailed, fluxconditions.GetMessage(hr, fluxmeta.StalledCondition), nil
+	}
+	if hr.Status.ObservedGeneration != hr.Generation || !fluxconditions.IsReady(hr) {
+		return clusterUpgradeInProgress, "Waiting for the HelmRelease to be ready", nil
+	}
+
+	cond := apimeta.FindStatusCondition(cd.Status.Conditions, kcmv1.CAPIClusterSummaryCondition)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return clusterUpgradeInProgress, "Waiting for the cluster to be ready", nil
+	}
+
+	return clusterUpgradeReady, "", nil
+}
+
+// upgradePath returns the templates to upgrade to one by one from the given template to the target one
+// according to the upgrade paths of the ClusterTemplateChains in the given namespace, the shortest one
+// is chosen. Returns nil if there is no upgrade path.
+func (r *ClusterFleetUpgradeReconciler) upgradePath(ctx context.Context, namespace, from, to string) ([]string, error) {
+	chains := new(kcmv1.ClusterTemplateChainList)
+	if err := r.Client.List(ctx, chains,
+		client.InNamespace(namespace),
+		client.MatchingFields{kcmv1.TemplateChainSupportedTemplatesIndexKey: from},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterTemplateChains: %w", err)
+	}
+
+	return clusterTemplateUpgradePath(chains.Items, from, to), nil
+}
+
+// clusterTemplateUpgradePath returns the shortest sequence of the templates to upgrade to one by one from the
+// given template to the target one according to [kcmv1.TemplateChainSpec.UpgradePaths] of the given chains.
+func clusterTemplateUpgradePath(chains []kcmv1.ClusterTemplateChain, from, to string) []string {
+	var shortest []string
+	for _, chain := range chains {
+		if !chain.Status.Valid {
+			continue
+		}
+
+		paths, err := chain.Spec.UpgradePaths(from)
+		if err != nil {
+			continue
+		}
+
+		for _, path := range paths {
+			idx := slices.IndexFunc(path.Versions, func(u kcmv1.AvailableUpgrade) bool { return u.Name == to })
+			if idx < 0 || (shortest != nil && idx+1 >= len(shortest)) {
+				continue
+			}
+
+			shortest = make([]string, idx+1)
+			for i, u := range path.Versions[:idx+1] {
+				shortest[i] = u.Name
+			}
+		}
+	}
+	return shortest
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterFleetUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Client = mgr.GetClient()
+	if r.timeFunc == nil {
+		r.timeFunc = time.Now
+	}
+	r.defaultRequeueTime = 30 * time.Second
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimitutil.DefaultFastSlow(),
+		}).
+		For(&kcmv1.ClusterFleetUpgrade{}).
+		Watches(&kcmv1.ClusterDeployment{}, kubeutil.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) ([]ctrl.Request, error) {
+			fleetUpgrades := new(kcmv1.ClusterFleetUpgradeList)
+			if err := r.Client.List(ctx, fleetUpgrades, client.InNamespace(o.GetNamespace())); err != nil {
+				return nil, fmt.Errorf("failed to list ClusterFleetUpgrades in namespace %s: %w", o.GetNamespace(), err)
+			}
+
+			requests := make([]ctrl.Request, 0, len(fleetUpgrades.Items))
+			for _, fleetUpgrade := range fleetUpgrades.Items {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&fleetUpgrade)})
+			}
+			return requests, nil
+		})).
+		Complete(r)
+}
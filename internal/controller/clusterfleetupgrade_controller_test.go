@@ -0,0 +1,252 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	testscheme "github.com/K0rdent/kcm/test/scheme"
+)
+
+func Test_clusterTemplateUpgradePath(t *testing.T) {
+	t.Parallel()
+
+	chain := kcmv1.ClusterTemplateChain{
+		Spec: kcmv1.TemplateChainSpec{SupportedTemplates: []kcmv1.SupportedTemplate{
+			{Name: "tpl-1-0-0", AvailableUpgrades: []kcmv1.AvailableUpgrade{{Name: "tpl-1-1-0"}}},
+			{Name: "tpl-1-1-0", AvailableUpgrades: []kcmv1.AvailableUpgrade{{Name: "tpl-1-2-0"}}},
+			{Name: "tpl-1-2-0"},
+		}},
+		Status: kcmv1.TemplateChainStatus{Valid: true},
+	}
+	invalidChain := kcmv1.ClusterTemplateChain{
+		Spec: kcmv1.TemplateChainSpec{SupportedTemplates: []kcmv1.SupportedTemplate{
+			{Name: "tpl-1-0-0", AvailableUpgrades: []kcmv1.AvailableUpgrade{{Name: "tpl-1-2-0"}}},
+		}},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		chains   []kcmv1.ClusterTemplateChain
+		from, to string
+		want     []string
+	}{
+		{name: "single hop", chains: []kcmv1.ClusterTemplateChain{chain}, from: "tpl-1-1-0", to: "tpl-1-2-0", want: []string{"tpl-1-2-0"}},
+		{name: "multiple hops", chains: []kcmv1.ClusterTemplateChain{chain}, from: "tpl-1-0-0", to: "tpl-1-2-0", want: []string{"tpl-1-1-0", "tpl-1-2-0"}},
+		{name: "invalid chains are ignored", chains: []kcmv1.ClusterTemplateChain{invalidChain, chain}, from: "tpl-1-0-0", to: "tpl-1-2-0", want: []string{"tpl-1-1-0", "tpl-1-2-0"}},
+		{name: "no path", chains: []kcmv1.ClusterTemplateChain{chain}, from: "tpl-1-2-0", to: "tpl-1-0-0"},
+		{name: "unknown template", chains: []kcmv1.ClusterTemplateChain{chain}, from: "other", to: "tpl-1-2-0"},
+	} {
+		if got := clusterTemplateUpgradePath(tc.chains, tc.from, tc.to); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: upgrade path = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func Test_nextClusterUpgradeHops(t *testing.T) {
+	t.Parallel()
+
+	newCluster := func(name string, phase kcmv1.ClusterUpgradePhase, remaining ...string) kcmv1.ClusterUpgradeStatus {
+		return kcmv1.ClusterUpgradeStatus{Name: name, Phase: phase, RemainingTemplates: remaining}
+	}
+	started := func(c kcmv1.ClusterUpgradeStatus) kcmv1.ClusterUpgradeStatus {
+		c.StartTime = &metav1.Time{Time: time.Now()}
+		return c
+	}
+
+	for _, tc := range []struct {
+		name     string
+		clusters []kcmv1.ClusterUpgradeStatus
+		ready    map[string]bool
+		want     []int
+	}{
+		{
+			name: "pending clusters are started up to the concurrency",
+			clusters: []kcmv1.ClusterUpgradeStatus{
+				newCluster("a", kcmv1.ClusterUpgradePhasePending, "t2"),
+				newCluster("b", kcmv1.ClusterUpgradePhasePending, "t2"),
+				newCluster("c", kcmv1.ClusterUpgradePhasePending, "t2"),
+			},
+			ready: map[string]bool{"a": true, "b": true, "c": true},
+			want:  []int{0, 1},
+		},
+		{
+			name: "clusters in progress take the slots and proceed to the next hop once ready",
+			clusters: []kcmv1.ClusterUpgradeStatus{
+				started(newCluster("a", kcmv1.ClusterUpgradePhaseUpgrading)),
+				started(newCluster("b", kcmv1.ClusterUpgradePhaseUpgrading, "t3")),
+				newCluster("c", kcmv1.ClusterUpgradePhasePending, "t2", "t3"),
+			},
+			ready: map[string]bool{"b": true, "c": true},
+			want:  []int{1},
+		},
+		{
+			name: "not ready clusters are not started",
+			clusters: []kcmv1.ClusterUpgradeStatus{
+				newCluster("a", kcmv1.ClusterUpgradePhaseCompleted),
+				newCluster("b", kcmv1.ClusterUpgradePhasePending, "t2"),
+				newCluster("c", kcmv1.ClusterUpgradePhasePending, "t2"),
+			},
+			ready: map[string]bool{"a": true, "c": true},
+			want:  []int{2},
+		},
+		{
+			name: "failed cluster halts the upgrade",
+			clusters: []kcmv1.ClusterUpgradeStatus{
+				started(newCluster("a", kcmv1.ClusterUpgradePhaseFailed)),
+				started(newCluster("b", kcmv1.ClusterUpgradePhaseUpgrading, "t3")),
+				newCluster("c", kcmv1.ClusterUpgradePhasePending, "t2"),
+			},
+			ready: map[string]bool{"b": true, "c": true},
+			want:  []int{1},
+		},
+		{
+			name: "clusters not started by the fleet upgrade neither take the slots nor halt the upgrade",
+			clusters: []kcmv1.ClusterUpgradeStatus{
+				newCluster("a", kcmv1.ClusterUpgradePhaseFailed),
+				newCluster("b", kcmv1.ClusterUpgradePhaseUpgrading),
+				newCluster("c", kcmv1.ClusterUpgradePhaseUpgrading),
+				newCluster("d", kcmv1.ClusterUpgradePhasePending, "t2"),
+				newCluster("e", kcmv1.ClusterUpgradePhasePending, "t2"),
+			},
+			ready: map[string]bool{"d": true, "e": true},
+			want:  []int{3, 4},
+		},
+	} {
+		hops := nextClusterUpgradeHops(tc.clusters, tc.ready, 2)
+		got := make([]int, 0, len(hops))
+		for i := range hops {
+			got = append(got, i)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: hops = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func Test_ClusterFleetUpgradeReconciler_reconcileUpgrade(t *testing.T) {
+	t.Parallel()
+
+	const namespace = metav1.NamespaceDefault
+	chain := &kcmv1.ClusterTemplateChain{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "chain"},
+		Spec: kcmv1.TemplateChainSpec{SupportedTemplates: []kcmv1.SupportedTemplate{
+			{Name: "tpl-1-0-0", AvailableUpgrades: []kcmv1.AvailableUpgrade{{Name: "tpl-1-1-0"}}},
+			{Name: "tpl-1-1-0", AvailableUpgrades: []kcmv1.AvailableUpgrade{{Name: "tpl-1-2-0"}}},
+			{Name: "tpl-1-2-0"},
+		}},
+		Status: kcmv1.TemplateChainStatus{Valid: true},
+	}
+	newTemplate := func(name string) *kcmv1.ClusterTemplate {
+		return &kcmv1.ClusterTemplate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status: kcmv1.ClusterTemplateStatus{TemplateStatusCommon: kcmv1.TemplateStatusCommon{
+				ChartRef: &helmcontrollerv2.CrossNamespaceSourceReference{Kind: "HelmChart", Namespace: namespace, Name: name},
+			}},
+		}
+	}
+	newCD := func(name, template string) (*kcmv1.ClusterDeployment, *helmcontrollerv2.HelmRelease) {
+		cd := &kcmv1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"fleet": "a"}},
+			Spec:       kcmv1.ClusterDeploymentSpec{Template: template},
+			Status: kcmv1.ClusterDeploymentStatus{Conditions: []metav1.Condition{{
+				Type: kcmv1.CAPIClusterSummaryCondition, Status: metav1.ConditionTrue, Reason: kcmv1.SucceededReason,
+			}}},
+		}
+		hr := &helmcontrollerv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: helmcontrollerv2.HelmReleaseSpec{
+				ChartRef: &helmcontrollerv2.CrossNamespaceSourceReference{Kind: "HelmChart", Namespace: namespace, Name: template},
+			},
+			Status: helmcontrollerv2.HelmReleaseStatus{Conditions: []metav1.Condition{{
+				Type: fluxmeta.ReadyCondition, Status: metav1.ConditionTrue, Reason: fluxmeta.SucceededReason,
+			}}},
+		}
+		return cd, hr
+	}
+	cd1, hr1 := newCD("cd-1", "tpl-1-0-0")
+	cd2, hr2 := newCD("cd-2", "tpl-1-2-0")
+	cd3, hr3 := newCD("cd-3", "tpl-1-0-0")
+
+	c := fake.NewClientBuilder().
+		WithScheme(testscheme.Scheme).
+		WithObjects(chain, newTemplate("tpl-1-0-0"), newTemplate("tpl-1-1-0"), newTemplate("tpl-1-2-0"),
+			cd1, hr1, cd2, hr2, cd3, hr3).
+		WithIndex(&kcmv1.ClusterTemplateChain{}, kcmv1.TemplateChainSupportedTemplatesIndexKey, func(o crclient.Object) []string {
+			var names []string
+			for _, t := range o.(*kcmv1.ClusterTemplateChain).Spec.SupportedTemplates {
+				names = append(names, t.Name)
+			}
+			return names
+		}).
+		Build()
+
+	r := &ClusterFleetUpgradeReconciler{Client: c, timeFunc: time.Now}
+	fleetUpgrade := &kcmv1.ClusterFleetUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "upgrade"},
+		Spec: kcmv1.ClusterFleetUpgradeSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"fleet": "a"}},
+			TargetTemplate:  "tpl-1-2-0",
+			MaxConcurrency:  1,
+		},
+	}
+
+	if err := r.reconcileUpgrade(t.Context(), fleetUpgrade); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantPhases := []kcmv1.ClusterUpgradePhase{kcmv1.ClusterUpgradePhaseUpgrading, kcmv1.ClusterUpgradePhaseCompleted, kcmv1.ClusterUpgradePhasePending}
+	for i, cluster := range fleetUpgrade.Status.Clusters {
+		if cluster.Phase != wantPhases[i] {
+			t.Errorf("cluster %s phase = %s, want %s", cluster.Name, cluster.Phase, wantPhases[i])
+		}
+	}
+	if got := fleetUpgrade.Status.Clusters[0].RemainingTemplates; !slices.Equal(got, []string{"tpl-1-2-0"}) {
+		t.Errorf("cluster cd-1 remaining templates = %v, want [tpl-1-2-0]", got)
+	}
+	if fleetUpgrade.Status.Clusters[0].StartTime == nil || fleetUpgrade.Status.Clusters[1].StartTime != nil {
+		t.Errorf("only the upgrade of cluster cd-1 must be started, got start times %v and %v",
+			fleetUpgrade.Status.Clusters[0].StartTime, fleetUpgrade.Status.Clusters[1].StartTime)
+	}
+	if fleetUpgrade.Status.Phase != kcmv1.ClusterFleetUpgradePhaseProgressing || fleetUpgrade.Status.UpgradedClusters != 1 {
+		t.Errorf("phase %s upgraded %d, want Progressing and 1", fleetUpgrade.Status.Phase, fleetUpgrade.Status.UpgradedClusters)
+	}
+
+	upgraded := new(kcmv1.ClusterDeployment)
+	if err := c.Get(t.Context(), crclient.ObjectKeyFromObject(cd1), upgraded); err != nil {
+		t.Fatalf("failed to get ClusterDeployment: %v", err)
+	}
+	if upgraded.Spec.Template != "tpl-1-1-0" {
+		t.Errorf("cluster cd-1 template = %s, want the first hop tpl-1-1-0", upgraded.Spec.Template)
+	}
+
+	// the first hop has not been reconciled yet, so neither the next hop nor the next cluster are started
+	if err := r.reconcileUpgrade(t.Context(), fleetUpgrade); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fleetUpgrade.Status.Clusters[0].Phase != kcmv1.ClusterUpgradePhaseUpgrading || fleetUpgrade.Status.Clusters[2].Phase != kcmv1.ClusterUpgradePhasePending {
+		t.Errorf("unexpected phases %s and %s", fleetUpgrade.Status.Clusters[0].Phase, fleetUpgrade.Status.Clusters[2].Phase)
+	}
+}
@@ -35,7 +35,7 @@ import (
 func CopyClusterIdentities(ctx context.Context, mgmtClient, rgnClient client.Client, cred *kcmv1.Credential, systemNamespace string) error {
 	// Copy Cluster Identities only for regional Credential objects or Credentials created by the Access Management system
 	// (with `k0rdent.mirantis.com/managed: true` label).
-	if cred.Labels[kcmv1.KCMManagedLabelKey] != kcmv1.KCMManagedLabelValue && cred.Spec.Region == "" {
+	if !isDistributed(cred) {
 		return nil
 	}
 	cis, err := collectClusterIdentities(ctx, mgmtClient, rgnClient, cred, systemNamespace)
@@ -56,7 +56,7 @@ func CopyClusterIdentities(ctx context.Context, mgmtClient, rgnClient client.Cli
 func ReleaseClusterIdentities(ctx context.Context, rgnClient client.Client, cred *kcmv1.Credential) error {
 	// Release Cluster Identities only for regional Credential objects or Credentials created by the Access Management system
 	// (with `k0rdent.mirantis.com/managed: true` label).
-	if cred.Labels[kcmv1.KCMManagedLabelKey] != kcmv1.KCMManagedLabelValue && cred.Spec.Region == "" {
+	if !isDistributed(cred) {
 		return nil
 	}
 
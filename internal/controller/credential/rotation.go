@@ -0,0 +1,178 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/providerinterface"
+)
+
+// versionLength is the length of the hashes identifying the versions of the credentials.
+const versionLength = 16
+
+// RotationResult describes the rotation of the data of the Secret behind a ClusterIdentity.
+type RotationResult struct {
+	// Version is the hash of the data the Secret holds after the rotation.
+	Version string
+	// PreviousSecretName is the name of the snapshot Secret holding the data replaced by the rotation,
+	// empty if the data has not been replaced.
+	PreviousSecretName string
+	// PreviousVersion is the hash of the data replaced by the rotation, empty if the data has not been replaced.
+	PreviousVersion string
+}
+
+// IdentitySecretKey returns the key of the Secret in the management cluster holding the data of the ClusterIdentity
+// referenced by the given [kcmv1.Credential]: the identity itself if it is a Secret, otherwise the first Secret
+// referenced by the identity as defined by the ProviderInterface objects.
+func IdentitySecretKey(ctx context.Context, mgmtClient client.Client, cred *kcmv1.Credential, systemNamespace string) (client.ObjectKey, error) {
+	identityRef := cred.Spec.IdentityRef
+	if identityRef == nil {
+		return client.ObjectKey{}, errors.New("identityRef is not set")
+	}
+
+	identityKey := client.ObjectKey{Namespace: identityRef.Namespace, Name: identityRef.Name}
+	if identityRef.Namespace != "" && isDistributed(cred) {
+		// the original identity is expected to exist in the system namespace
+		identityKey.Namespace = systemNamespace
+	}
+
+	if identityRef.Kind == "Secret" && (identityRef.APIVersion == "v1" || identityRef.APIVersion == "") {
+		return identityKey, nil
+	}
+
+	ci, err := providerinterface.FindClusterIdentity(ctx, mgmtClient, identityRef)
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("failed to get Cluster Identity definition for %s: %w", identityRef.String(), err)
+	}
+
+	idx := slices.IndexFunc(ci.References, func(ref kcmv1.ClusterIdentityReference) bool {
+		return ref.Group == "" && ref.Kind == "Secret"
+	})
+	if idx < 0 {
+		return client.ObjectKey{}, fmt.Errorf("ClusterIdentity of Kind=%s does not reference a Secret", identityRef.Kind)
+	}
+	reference := ci.References[idx]
+
+	clIdty := &unstructured.Unstructured{}
+	clIdty.SetAPIVersion(identityRef.APIVersion)
+	clIdty.SetKind(identityRef.Kind)
+	if err := mgmtClient.Get(ctx, identityKey, clIdty); err != nil {
+		return client.ObjectKey{}, fmt.Errorf("failed to get ClusterIdentity object of Kind=%s %s: %w", identityRef.Kind, identityKey, err)
+	}
+
+	name, _, err := unstructured.NestedString(clIdty.Object, strings.Split(reference.NameFieldPath, ".")...)
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("failed to get name reference from ClusterIdentity %s %s by path %s: %w",
+			identityRef.Kind, identityKey, reference.NameFieldPath, err)
+	}
+	if name == "" {
+		return client.ObjectKey{}, fmt.Errorf("name reference from ClusterIdentity %s %s by path %s is not found",
+			identityRef.Kind, identityKey, reference.NameFieldPath)
+	}
+
+	namespace := systemNamespace
+	if reference.NamespaceFieldPath != "" {
+		if namespace, _, err = unstructured.NestedString(clIdty.Object, strings.Split(reference.NamespaceFieldPath, ".")...); err != nil {
+			return client.ObjectKey{}, fmt.Errorf("failed to get namespace reference from ClusterIdentity %s %s by path %s: %w",
+				identityRef.Kind, identityKey, reference.NamespaceFieldPath, err)
+		}
+	}
+
+	return client.ObjectKey{Namespace: namespace, Name: name}, nil
+}
+
+// RotateSecret replaces the data of the given Secret with the data of the Secret with the given name
+// from the same namespace, preserving the replaced data in a snapshot Secret.
+// The data is not replaced if it is already the same.
+func RotateSecret(ctx context.Context, c client.Client, key client.ObjectKey, newSecretName string) (*RotationResult, error) {
+	newSecret := new(corev1.Secret)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: newSecretName}, newSecret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s with the new version of the credentials: %w", key.Namespace, newSecretName, err)
+	}
+
+	secret := new(corev1.Secret)
+	if err := c.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s: %w", key, err)
+	}
+
+	result := &RotationResult{Version: SecretVersion(newSecret.Data)}
+	currentVersion := SecretVersion(secret.Data)
+	if currentVersion == result.Version {
+		return result, nil
+	}
+
+	snapshot := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SnapshotSecretName(key.Name, currentVersion),
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				kcmv1.KCMManagedLabelKey:        kcmv1.KCMManagedLabelValue,
+				kcmv1.GenericComponentNameLabel: kcmv1.GenericComponentLabelValueKCM,
+			},
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+	if err := c.Create(ctx, snapshot); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create snapshot Secret %s: %w", client.ObjectKeyFromObject(snapshot), err)
+	}
+
+	secret.Data = newSecret.Data
+	if err := c.Update(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to update Secret %s: %w", key, err)
+	}
+
+	result.PreviousSecretName = snapshot.Name
+	result.PreviousVersion = currentVersion
+	return result, nil
+}
+
+// SecretVersion returns the hash identifying the version of the given Secret data.
+func SecretVersion(data map[string][]byte) string {
+	h := sha256.New()
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(data[k])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:versionLength]
+}
+
+// SnapshotSecretName returns the name of the Secret preserving the given version of the data of the given Secret.
+func SnapshotSecretName(secretName, version string) string {
+	return secretName + "-" + version
+}
+
+// isDistributed returns true if the ClusterIdentity objects of the given [kcmv1.Credential]
+// are copied from the system namespace by [CopyClusterIdentities].
+func isDistributed(cred *kcmv1.Credential) bool {
+	return cred.Labels[kcmv1.KCMManagedLabelKey] == kcmv1.KCMManagedLabelValue || cred.Spec.Region != ""
+}
@@ -0,0 +1,152 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credential
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/test/objects/credential"
+)
+
+func Test_IdentitySecretKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		existingObjs []runtime.Object
+		cred         *kcmv1.Credential
+		expected     client.ObjectKey
+		err          string
+	}{
+		{
+			name: "Secret identity of the management Credential",
+			cred: credential.NewCredential(
+				credential.WithNamespace(testNamespace),
+				credential.WithIdentityRef(&corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "secret", Namespace: testNamespace}),
+			),
+			expected: client.ObjectKey{Namespace: testNamespace, Name: "secret"},
+		},
+		{
+			name: "Secret identity of the regional Credential is taken from the system namespace",
+			cred: credential.NewCredential(
+				credential.WithNamespace(testNamespace),
+				credential.WithRegion("rgn"),
+				credential.WithIdentityRef(&corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "secret", Namespace: testNamespace}),
+			),
+			expected: client.ObjectKey{Namespace: systemNamespace, Name: "secret"},
+		},
+		{
+			name:         "cluster-scoped identity referencing the Secret in the system namespace",
+			existingObjs: []runtime.Object{clusterScopedIdentityWithSecretRef},
+			cred: credential.NewCredential(
+				credential.WithIdentityRef(&corev1.ObjectReference{APIVersion: infraAPIVersion, Kind: clusterScopedClusterIdentityKind, Name: clusterIdentityName}),
+			),
+			expected: client.ObjectKey{Namespace: systemNamespace, Name: clusterIdentitySecretRefName},
+		},
+		{
+			name:         "namespace-scoped identity referencing the Secret in a custom namespace",
+			existingObjs: []runtime.Object{namespaceScopedIdentity},
+			cred: credential.NewCredential(
+				credential.WithNamespace(testNamespace),
+				credential.WithIdentityRef(&corev1.ObjectReference{APIVersion: infraAPIVersion, Kind: namespaceScopedClusterIdentityKind, Name: clusterIdentityName, Namespace: testNamespace}),
+			),
+			expected: client.ObjectKey{Namespace: testNamespace, Name: clusterIdentitySecretRefName},
+		},
+		{
+			name: "unknown identity kind",
+			cred: credential.NewCredential(
+				credential.WithIdentityRef(&corev1.ObjectReference{APIVersion: infraAPIVersion, Kind: "UnknownKind", Name: clusterIdentityName}),
+			),
+			err: "failed to get Cluster Identity definition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mgmtClient, _ := setupClients(tt.existingObjs, nil, "")
+			key, err := IdentitySecretKey(t.Context(), mgmtClient, tt.cred, systemNamespace)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(key).To(Equal(tt.expected))
+		})
+	}
+}
+
+func Test_RotateSecret(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	newSecret := func(name string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: systemNamespace}, Data: data}
+	}
+	oldData := map[string][]byte{"key": []byte("old")}
+	newData := map[string][]byte{"key": []byte("new")}
+
+	c, _ := setupClients([]runtime.Object{newSecret("live", oldData), newSecret("v2", newData)}, nil, "")
+	liveKey := client.ObjectKey{Namespace: systemNamespace, Name: "live"}
+
+	// rotating to the new version preserves the current data in the snapshot
+	result, err := RotateSecret(ctx, c, liveKey, "v2")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Version).To(Equal(SecretVersion(newData)))
+	g.Expect(result.PreviousVersion).To(Equal(SecretVersion(oldData)))
+	g.Expect(result.PreviousSecretName).To(Equal(SnapshotSecretName("live", SecretVersion(oldData))))
+
+	live := new(corev1.Secret)
+	g.Expect(c.Get(ctx, liveKey, live)).To(Succeed())
+	g.Expect(live.Data).To(Equal(newData))
+
+	snapshot := new(corev1.Secret)
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: result.PreviousSecretName}, snapshot)).To(Succeed())
+	g.Expect(snapshot.Data).To(Equal(oldData))
+	g.Expect(snapshot.Labels).To(HaveKeyWithValue(kcmv1.KCMManagedLabelKey, kcmv1.KCMManagedLabelValue))
+
+	// rotating to the same version is a no-op
+	result, err = RotateSecret(ctx, c, liveKey, "v2")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.PreviousSecretName).To(BeEmpty())
+
+	// rolling back to the snapshot restores the previous data
+	rollback, err := RotateSecret(ctx, c, liveKey, SnapshotSecretName("live", SecretVersion(oldData)))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rollback.Version).To(Equal(SecretVersion(oldData)))
+	g.Expect(rollback.PreviousSecretName).To(Equal(SnapshotSecretName("live", SecretVersion(newData))))
+	g.Expect(c.Get(ctx, liveKey, live)).To(Succeed())
+	g.Expect(live.Data).To(Equal(oldData))
+
+	// rotating to the missing Secret fails
+	_, err = RotateSecret(ctx, c, liveKey, "missing")
+	g.Expect(err).To(MatchError(ContainSubstring("failed to get Secret")))
+}
+
+func Test_SecretVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(SecretVersion(map[string][]byte{"a": []byte("1"), "b": []byte("2")})).
+		To(Equal(SecretVersion(map[string][]byte{"b": []byte("2"), "a": []byte("1")})))
+	g.Expect(SecretVersion(map[string][]byte{"a": []byte("12")})).
+		NotTo(Equal(SecretVersion(map[string][]byte{"a1": []byte("2")})))
+	g.Expect(SecretVersion(nil)).To(HaveLen(versionLength))
+}
@@ -0,0 +1,162 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	awsClusterStaticIdentityKind = "AWSClusterStaticIdentity"
+
+	awsDefaultSTSEndpoint = "https://sts.amazonaws.com"
+	awsDefaultRegion      = "us-east-1"
+	awsSTSService         = "sts"
+	awsSignatureAlgorithm = "AWS4-HMAC-SHA256"
+)
+
+type awsGetCallerIdentityResponse struct {
+	Result struct {
+		Arn     string `xml:"Arn"`
+		Account string `xml:"Account"`
+	} `xml:"GetCallerIdentityResult"`
+}
+
+// VerifyAWS verifies the credentials of an AWSClusterStaticIdentity by calling the STS GetCallerIdentity action.
+// The Secret referenced by the identity is expected in the system namespace.
+func VerifyAWS(ctx context.Context, in *Input) (*Result, error) {
+	secretName, _, _ := unstructured.NestedString(in.Identity.Object, "spec", "secretRef")
+	if secretName == "" {
+		return nil, errors.New("secretRef of the AWSClusterStaticIdentity is not set")
+	}
+
+	data, err := getSecretData(ctx, in.Client, in.SystemNamespace, secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	accessKeyID, secretAccessKey := string(data["AccessKeyID"]), string(data["SecretAccessKey"])
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("%w: Secret %s/%s has no AccessKeyID or SecretAccessKey", ErrRejected, in.SystemNamespace, secretName)
+	}
+
+	endpoint := endpointOr(in.Endpoint, awsDefaultSTSEndpoint)
+	body := url.Values{"Action": {"GetCallerIdentity"}, "Version": {"2011-06-15"}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build STS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if token := string(data["SessionToken"]); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signAWSRequest(req, []byte(body), accessKeyID, secretAccessKey, awsRegionFromEndpoint(req.URL.Host), time.Now().UTC())
+
+	respBody, err := do(in.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(awsGetCallerIdentityResponse)
+	if err := xml.Unmarshal(respBody, resp); err != nil {
+		return nil, fmt.Errorf("failed to decode STS GetCallerIdentity response: %w", err)
+	}
+
+	return &Result{Provider: "aws", Identity: resp.Result.Arn}, nil
+}
+
+// awsRegionFromEndpoint returns the region of the given regional STS endpoint host,
+// e.g. sts.eu-west-1.amazonaws.com, otherwise the default one.
+func awsRegionFromEndpoint(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) >= 4 && parts[0] == awsSTSService && parts[2] == "amazonaws" {
+		return parts[1]
+	}
+	return awsDefaultRegion
+}
+
+// signAWSRequest signs the given request with the AWS Signature Version 4.
+func signAWSRequest(req *http.Request, body []byte, accessKeyID, secretAccessKey, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+	if req.Header.Get("X-Amz-Security-Token") != "" {
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, h := range signedHeaders {
+		v := req.Header.Get(h)
+		if h == "host" {
+			v = req.URL.Host
+		}
+		canonicalHeaders.WriteString(h + ":" + strings.TrimSpace(v) + "\n")
+	}
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, awsSTSService, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{awsSignatureAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, awsSTSService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSignatureAlgorithm, accessKeyID, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
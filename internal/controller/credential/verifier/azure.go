@@ -0,0 +1,86 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	azureClusterIdentityKind = "AzureClusterIdentity"
+
+	azureDefaultAuthorityHost = "https://login.microsoftonline.com"
+	azureManagementScope      = "https://management.azure.com/.default"
+	azureClientSecretKey      = "clientSecret"
+)
+
+// VerifyAzure verifies the credentials of an AzureClusterIdentity of the ServicePrincipal or
+// ManualServicePrincipal type by requesting a token with the client credentials grant.
+func VerifyAzure(ctx context.Context, in *Input) (*Result, error) {
+	spec, _, _ := unstructured.NestedMap(in.Identity.Object, "spec")
+	identityType, _, _ := unstructured.NestedString(spec, "type")
+	switch identityType {
+	case "ServicePrincipal", "ManualServicePrincipal":
+	default:
+		return nil, fmt.Errorf("%w for AzureClusterIdentity of type %q", ErrUnsupported, identityType)
+	}
+
+	clientID, _, _ := unstructured.NestedString(spec, "clientID")
+	tenantID, _, _ := unstructured.NestedString(spec, "tenantID")
+	secretName, _, _ := unstructured.NestedString(spec, "clientSecret", "name")
+	secretNamespace, _, _ := unstructured.NestedString(spec, "clientSecret", "namespace")
+	if clientID == "" || tenantID == "" || secretName == "" {
+		return nil, errors.New("clientID, tenantID or clientSecret of the AzureClusterIdentity is not set")
+	}
+	if secretNamespace == "" {
+		secretNamespace = in.Identity.GetNamespace()
+	}
+
+	data, err := getSecretData(ctx, in.Client, secretNamespace, secretName)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret := string(data[azureClientSecretKey])
+	if clientSecret == "" {
+		return nil, fmt.Errorf("%w: Secret %s/%s has no %s", ErrRejected, secretNamespace, secretName, azureClientSecretKey)
+	}
+
+	endpoint := endpointOr(in.Endpoint, azureDefaultAuthorityHost)
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {azureManagementScope},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/"+url.PathEscape(tenantID)+"/oauth2/v2.0/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if _, err := do(in.HTTPClient, req); err != nil {
+		return nil, err
+	}
+
+	return &Result{Provider: "azure", Identity: clientID}, nil
+}
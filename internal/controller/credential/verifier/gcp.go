@@ -0,0 +1,136 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	gcpCredentialsKey      = "credentials"
+	gcpDefaultTokenURI     = "https://oauth2.googleapis.com/token"
+	gcpCloudPlatformScope  = "https://www.googleapis.com/auth/cloud-platform"
+	gcpJWTBearerGrantType  = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	gcpServiceAccountType  = "service_account"
+	gcpAssertionExpiration = 10 * time.Minute
+)
+
+type gcpServiceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// verifyGCP verifies the service account key stored in the given Secret data
+// by exchanging a signed JWT assertion for an access token.
+func verifyGCP(ctx context.Context, in *Input, data map[string][]byte) (*Result, error) {
+	key := new(gcpServiceAccountKey)
+	if err := json.Unmarshal(data[gcpCredentialsKey], key); err != nil {
+		return nil, fmt.Errorf("failed to decode GCP credentials: %w", err)
+	}
+	if key.Type != gcpServiceAccountType {
+		return nil, fmt.Errorf("%w for GCP credentials of type %q", ErrUnsupported, key.Type)
+	}
+
+	privateKey, err := parseRSAPrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid private key of the GCP service account %s: %w", ErrRejected, key.ClientEmail, err)
+	}
+
+	tokenURI := endpointOr(in.Endpoint, endpointOr(key.TokenURI, gcpDefaultTokenURI))
+	assertion, err := signJWT(privateKey, key.PrivateKeyID, map[string]any{
+		"iss":   key.ClientEmail,
+		"scope": gcpCloudPlatformScope,
+		"aud":   tokenURI,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(gcpAssertionExpiration).Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign JWT assertion: %w", err)
+	}
+
+	form := url.Values{"grant_type": {gcpJWTBearerGrantType}, "assertion": {assertion}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if _, err := do(in.HTTPClient, req); err != nil {
+		return nil, err
+	}
+
+	return &Result{Provider: "gcp", Identity: key.ClientEmail}, nil
+}
+
+func parseRSAPrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+// signJWT returns the JWT with the given claims signed with RS256.
+func signJWT(key *rsa.PrivateKey, keyID string, claims map[string]any) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
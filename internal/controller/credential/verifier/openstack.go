@@ -0,0 +1,226 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	openstackCloudsKey       = "clouds.yaml"
+	openstackDefaultCloud    = "openstack"
+	openstackSubjectTokenKey = "X-Subject-Token"
+)
+
+type openstackClouds struct {
+	Clouds map[string]openstackCloud `json:"clouds"`
+}
+
+type openstackCloud struct {
+	AuthType string        `json:"auth_type"`
+	Auth     openstackAuth `json:"auth"`
+}
+
+type openstackAuth struct {
+	AuthURL                     string `json:"auth_url"`
+	ApplicationCredentialID     string `json:"application_credential_id"`
+	ApplicationCredentialName   string `json:"application_credential_name"`
+	ApplicationCredentialSecret string `json:"application_credential_secret"`
+	Username                    string `json:"username"`
+	UserID                      string `json:"user_id"`
+	Password                    string `json:"password"`
+	UserDomainName              string `json:"user_domain_name"`
+	UserDomainID                string `json:"user_domain_id"`
+	DomainName                  string `json:"domain_name"`
+}
+
+type openstackTokenResponse struct {
+	Token struct {
+		User struct {
+			PasswordExpiresAt *time.Time `json:"password_expires_at"`
+			ID                string     `json:"id"`
+			Name              string     `json:"name"`
+		} `json:"user"`
+		ApplicationCredential *struct {
+			ID string `json:"id"`
+		} `json:"application_credential"`
+	} `json:"token"`
+}
+
+type openstackApplicationCredentialResponse struct {
+	ApplicationCredential struct {
+		ExpiresAt *openstackTime `json:"expires_at"`
+	} `json:"application_credential"`
+}
+
+// openstackTime is the time as formatted by Keystone, with or without the time zone.
+type openstackTime struct {
+	time.Time
+}
+
+func (t *openstackTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999"} {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected time format %q", s)
+}
+
+// verifyOpenStack verifies the credentials of the cloud in the clouds.yaml stored in the given Secret data
+// by issuing an unscoped Keystone token. The expiration of the application credential or of the password
+// of the user is reported if known.
+func verifyOpenStack(ctx context.Context, in *Input, data map[string][]byte) (*Result, error) {
+	clouds := new(openstackClouds)
+	if err := yaml.Unmarshal(data[openstackCloudsKey], clouds); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", openstackCloudsKey, err)
+	}
+
+	cloud, ok := clouds.Clouds[openstackDefaultCloud]
+	if !ok && len(clouds.Clouds) == 1 {
+		for _, c := range clouds.Clouds {
+			cloud, ok = c, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("no %q cloud found in %s", openstackDefaultCloud, openstackCloudsKey)
+	}
+
+	identity, err := openstackIdentity(cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	authURL := endpointOr(in.Endpoint, strings.TrimSuffix(cloud.Auth.AuthURL, "/"))
+	if authURL == "" {
+		return nil, fmt.Errorf("auth_url is not set in %s", openstackCloudsKey)
+	}
+	if !strings.HasSuffix(authURL, "/v3") {
+		authURL += "/v3"
+	}
+
+	body, err := json.Marshal(map[string]any{"auth": map[string]any{"identity": identity}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Keystone auth request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL+"/auth/tokens", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Keystone auth request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	respBody, header, err := doWithHeader(in.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenResp := new(openstackTokenResponse)
+	if err := json.Unmarshal(respBody, tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to decode Keystone token: %w", err)
+	}
+
+	user := tokenResp.Token.User
+	result := &Result{Provider: "openstack", Identity: cmp.Or(user.Name, user.ID), ExpirationTime: user.PasswordExpiresAt}
+	if appCred := tokenResp.Token.ApplicationCredential; appCred != nil && user.ID != "" {
+		// the expiration of the application credential is a hint, the failure to get it is not an error
+		if expiresAt, err := openstackApplicationCredentialExpiration(ctx, in.HTTPClient, authURL, header.Get(openstackSubjectTokenKey), user.ID, appCred.ID); err == nil {
+			result.ExpirationTime = expiresAt
+		}
+	}
+
+	return result, nil
+}
+
+func openstackApplicationCredentialExpiration(ctx context.Context, c *http.Client, authURL, token, userID, appCredID string) (*time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		authURL+"/users/"+url.PathEscape(userID)+"/application_credentials/"+url.PathEscape(appCredID), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build application credential request: %w", err)
+	}
+	req.Header.Set("X-Auth-Token", token)
+
+	body, err := do(c, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(openstackApplicationCredentialResponse)
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("failed to decode application credential: %w", err)
+	}
+	if resp.ApplicationCredential.ExpiresAt == nil {
+		return nil, nil //nolint:nilnil // the application credential never expires
+	}
+
+	return &resp.ApplicationCredential.ExpiresAt.Time, nil
+}
+
+// openstackIdentity returns the identity part of the Keystone auth request for the given cloud.
+func openstackIdentity(cloud openstackCloud) (map[string]any, error) {
+	auth := cloud.Auth
+	authType := cloud.AuthType
+	if authType == "" {
+		authType = "password"
+		if auth.ApplicationCredentialID != "" || auth.ApplicationCredentialName != "" {
+			authType = "v3applicationcredential"
+		}
+	}
+
+	user := map[string]any{}
+	if auth.UserID != "" {
+		user["id"] = auth.UserID
+	} else {
+		user["name"] = auth.Username
+		switch {
+		case auth.UserDomainID != "":
+			user["domain"] = map[string]any{"id": auth.UserDomainID}
+		default:
+			user["domain"] = map[string]any{"name": cmp.Or(auth.UserDomainName, auth.DomainName, "Default")}
+		}
+	}
+
+	switch authType {
+	case "v3applicationcredential":
+		appCred := map[string]any{"secret": auth.ApplicationCredentialSecret}
+		if auth.ApplicationCredentialID != "" {
+			appCred["id"] = auth.ApplicationCredentialID
+		} else {
+			appCred["name"] = auth.ApplicationCredentialName
+			appCred["user"] = user
+		}
+		return map[string]any{"methods": []string{"application_credential"}, "application_credential": appCred}, nil
+	case "password", "v3password":
+		user["password"] = auth.Password
+		return map[string]any{"methods": []string{"password"}, "password": map[string]any{"user": user}}, nil
+	default:
+		return nil, fmt.Errorf("%w for OpenStack auth type %q", ErrUnsupported, authType)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"fmt"
+)
+
+const secretKind = "Secret"
+
+// VerifySecret verifies the credentials stored in a Secret used as a ClusterIdentity
+// dispatching by the layout of the data: the GCP service account key is expected
+// in the "credentials" key, the OpenStack clouds.yaml in the "clouds.yaml" one.
+func VerifySecret(ctx context.Context, in *Input) (*Result, error) {
+	data, err := getSecretData(ctx, in.Client, in.Identity.GetNamespace(), in.Identity.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(data[gcpCredentialsKey]) > 0:
+		return verifyGCP(ctx, in, data)
+	case len(data[openstackCloudsKey]) > 0:
+		return verifyOpenStack(ctx, in, data)
+	default:
+		return nil, fmt.Errorf("%w for Secret %s/%s with unknown layout of the credentials", ErrUnsupported, in.Identity.GetNamespace(), in.Identity.GetName())
+	}
+}
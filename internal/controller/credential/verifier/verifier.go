@@ -0,0 +1,208 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verifier verifies the credentials of the ClusterIdentity objects
+// referenced by [github.com/K0rdent/kcm/api/v1beta1.Credential] objects
+// by performing a cheap authenticated call to the respective cloud provider.
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultTimeout is the timeout of a single verification used when no [WithTimeout] option is provided.
+const DefaultTimeout = 30 * time.Second
+
+var (
+	// ErrRejected is wrapped by the errors returned if the cloud provider has rejected the credentials.
+	ErrRejected = errors.New("credentials are rejected")
+	// ErrUnsupported is wrapped by the errors returned if the credentials cannot be verified,
+	// e.g. the kind of the ClusterIdentity or the authentication method is not supported.
+	ErrUnsupported = errors.New("verification is not supported")
+)
+
+// Input holds the data required to verify the credentials of a ClusterIdentity.
+type Input struct {
+	// Identity is the ClusterIdentity object.
+	Identity *unstructured.Unstructured
+	// Client reads the objects referenced by the ClusterIdentity, e.g. Secrets.
+	Client client.Reader
+	// HTTPClient performs the calls to the cloud provider.
+	HTTPClient *http.Client
+	// SystemNamespace is the namespace the Secrets referenced by cluster-scoped identities reside in.
+	SystemNamespace string
+	// Endpoint overrides the default endpoint of the cloud provider.
+	Endpoint string
+}
+
+// Result is the result of a successful verification.
+type Result struct {
+	// ExpirationTime is the time the credentials expire at, nil if unknown or the credentials never expire.
+	ExpirationTime *time.Time
+	// Provider is the name of the cloud provider the credentials have been verified against.
+	Provider string
+	// Identity is the identity the cloud provider has authenticated with the credentials.
+	Identity string
+}
+
+// VerifyFunc verifies the credentials of a ClusterIdentity.
+type VerifyFunc func(ctx context.Context, in *Input) (*Result, error)
+
+// Registry holds the [VerifyFunc] functions keyed by the kind of the ClusterIdentity.
+type Registry struct {
+	verifiers  map[string]VerifyFunc
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// RegistryOpt configures the [Registry].
+type RegistryOpt func(*Registry)
+
+// WithHTTPClient sets the HTTP client used to perform the calls to the cloud providers.
+func WithHTTPClient(c *http.Client) RegistryOpt {
+	return func(r *Registry) {
+		if c != nil {
+			r.httpClient = c
+		}
+	}
+}
+
+// WithTimeout sets the timeout of a single verification.
+func WithTimeout(d time.Duration) RegistryOpt {
+	return func(r *Registry) {
+		if d > 0 {
+			r.timeout = d
+		}
+	}
+}
+
+// NewRegistry constructs a new empty [Registry].
+func NewRegistry(opts ...RegistryOpt) *Registry {
+	r := &Registry{
+		verifiers:  make(map[string]VerifyFunc),
+		httpClient: http.DefaultClient,
+		timeout:    DefaultTimeout,
+	}
+
+	for _, o := range opts {
+		o(r)
+	}
+
+	return r
+}
+
+// NewDefaultRegistry constructs a new [Registry] with the verifiers of all the supported cloud providers.
+func NewDefaultRegistry(opts ...RegistryOpt) *Registry {
+	r := NewRegistry(opts...)
+	r.Register(awsClusterStaticIdentityKind, VerifyAWS)
+	r.Register(azureClusterIdentityKind, VerifyAzure)
+	r.Register(vsphereClusterIdentityKind, VerifyVSphere)
+	r.Register(secretKind, VerifySecret)
+	return r
+}
+
+// Register registers the given [VerifyFunc] for the given kind of the ClusterIdentity,
+// replacing the one already registered, if any.
+func (r *Registry) Register(kind string, f VerifyFunc) {
+	r.verifiers[kind] = f
+}
+
+// Verify verifies the credentials of the given ClusterIdentity with the [VerifyFunc] registered for its kind.
+// Returns an error wrapping [ErrUnsupported] if there is no such function
+// and an error wrapping [ErrRejected] if the cloud provider has rejected the credentials.
+func (r *Registry) Verify(ctx context.Context, in *Input) (*Result, error) {
+	kind := in.Identity.GetKind()
+	f, ok := r.verifiers[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w for ClusterIdentity of Kind=%s", ErrUnsupported, kind)
+	}
+
+	in = new(*in)
+	if in.HTTPClient == nil {
+		in.HTTPClient = r.httpClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	return f(ctx, in)
+}
+
+// getSecretData returns the data of the given Secret.
+func getSecretData(ctx context.Context, c client.Reader, namespace, name string) (map[string][]byte, error) {
+	secret := new(corev1.Secret)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret %s/%s: %w", namespace, name, err)
+	}
+	return secret.Data, nil
+}
+
+// do performs the given request and returns the body of the response, the error wraps
+// [ErrRejected] if the response status denotes that the credentials have been rejected.
+func do(c *http.Client, req *http.Request) ([]byte, error) {
+	body, _, err := doWithHeader(c, req)
+	return body, err
+}
+
+// doWithHeader is the same as [do] but also returns the header of the response.
+func doWithHeader(c *http.Client, req *http.Request) ([]byte, http.Header, error) {
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to call %s: %w", req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response of %s: %w", req.URL.Redacted(), err)
+	}
+
+	switch {
+	case resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices:
+		return body, resp.Header, nil
+	case resp.StatusCode == http.StatusBadRequest, resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return nil, nil, fmt.Errorf("%w by %s: %s: %s", ErrRejected, req.URL.Host, resp.Status, summarize(body))
+	default:
+		return nil, nil, fmt.Errorf("unexpected response from %s: %s: %s", req.URL.Host, resp.Status, summarize(body))
+	}
+}
+
+// summarize shortens the given response body to be used in error messages.
+func summarize(body []byte) string {
+	const maxLen = 256
+
+	s := strings.Join(strings.Fields(string(body)), " ")
+	if len(s) > maxLen {
+		s = s[:maxLen] + "..."
+	}
+	return s
+}
+
+// endpointOr returns the given endpoint without the trailing slash, the given default if empty.
+func endpointOr(endpoint, def string) string {
+	if endpoint == "" {
+		return def
+	}
+	return strings.TrimSuffix(endpoint, "/")
+}
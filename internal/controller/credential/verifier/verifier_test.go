@@ -0,0 +1,376 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+const testNamespace = "kcm-system"
+
+func newIdentity(kind, namespace, name string, spec map[string]any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func newSecret(name string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       make(map[string][]byte, len(data)),
+	}
+	for k, v := range data {
+		secret.Data[k] = []byte(v)
+	}
+	return secret
+}
+
+func newInput(identity *unstructured.Unstructured, endpoint string, objs ...client.Object) *Input {
+	return &Input{
+		Identity:        identity,
+		Client:          fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build(),
+		SystemNamespace: testNamespace,
+		Endpoint:        endpoint,
+	}
+}
+
+func checkResult(t *testing.T, result *Result, err, wantErr error, wantIdentity string) {
+	t.Helper()
+
+	if wantErr != nil {
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("expected error %v, got %v", wantErr, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Identity != wantIdentity {
+		t.Errorf("expected identity %q, got %q", wantIdentity, result.Identity)
+	}
+}
+
+func TestRegistry_Verify(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	if _, err := r.Verify(t.Context(), &Input{Identity: newIdentity("Unknown", "", "foo", nil)}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected unsupported error, got %v", err)
+	}
+
+	var gotClient *http.Client
+	r.Register("Fake", func(ctx context.Context, in *Input) (*Result, error) {
+		gotClient = in.HTTPClient
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("expected the verification to have a deadline")
+		}
+		return &Result{Identity: in.Identity.GetName()}, nil
+	})
+
+	result, err := r.Verify(t.Context(), &Input{Identity: newIdentity("Fake", "", "foo", nil)})
+	checkResult(t, result, err, nil, "foo")
+	if gotClient != http.DefaultClient {
+		t.Error("expected the default HTTP client to be used")
+	}
+}
+
+func TestVerifyAWS(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, awsSignatureAlgorithm+" Credential=AKIDVALID/") || !strings.Contains(auth, "/us-east-1/sts/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<ErrorResponse><Error><Code>InvalidClientTokenId</Code></Error></ErrorResponse>`))
+			return
+		}
+		if r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`<GetCallerIdentityResponse><GetCallerIdentityResult><Arn>arn:aws:iam::123456789012:user/kcm</Arn><Account>123456789012</Account></GetCallerIdentityResult></GetCallerIdentityResponse>`))
+	}))
+	t.Cleanup(srv.Close)
+
+	identity := newIdentity(awsClusterStaticIdentityKind, "", "aws", map[string]any{"secretRef": "aws-secret"})
+
+	for _, tc := range []struct {
+		name         string
+		data         map[string]string
+		wantErr      error
+		wantIdentity string
+	}{
+		{name: "valid", data: map[string]string{"AccessKeyID": "AKIDVALID", "SecretAccessKey": "secret"}, wantIdentity: "arn:aws:iam::123456789012:user/kcm"},
+		{name: "rejected", data: map[string]string{"AccessKeyID": "AKIDWRONG", "SecretAccessKey": "secret"}, wantErr: ErrRejected},
+		{name: "empty", data: map[string]string{}, wantErr: ErrRejected},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			in := newInput(identity, srv.URL, newSecret("aws-secret", tc.data))
+			result, err := NewDefaultRegistry().Verify(t.Context(), in)
+			checkResult(t, result, err, tc.wantErr, tc.wantIdentity)
+		})
+	}
+}
+
+func Test_awsRegionFromEndpoint(t *testing.T) {
+	t.Parallel()
+
+	for host, want := range map[string]string{
+		"sts.amazonaws.com":           "us-east-1",
+		"sts.eu-west-1.amazonaws.com": "eu-west-1",
+		"127.0.0.1:8080":              "us-east-1",
+	} {
+		if got := awsRegionFromEndpoint(host); got != want {
+			t.Errorf("awsRegionFromEndpoint(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestVerifyAzure(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.FormValue("client_id") != "client" || r.FormValue("client_secret") != "valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3599}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, tc := range []struct {
+		name         string
+		identityType string
+		secret       string
+		wantErr      error
+		wantIdentity string
+	}{
+		{name: "valid", identityType: "ServicePrincipal", secret: "valid", wantIdentity: "client"},
+		{name: "rejected", identityType: "ManualServicePrincipal", secret: "expired", wantErr: ErrRejected},
+		{name: "unsupported type", identityType: "WorkloadIdentity", secret: "valid", wantErr: ErrUnsupported},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			identity := newIdentity(azureClusterIdentityKind, testNamespace, "azure", map[string]any{
+				"type":         tc.identityType,
+				"clientID":     "client",
+				"tenantID":     "tenant",
+				"clientSecret": map[string]any{"name": "azure-secret", "namespace": testNamespace},
+			})
+			in := newInput(identity, srv.URL, newSecret("azure-secret", map[string]string{azureClientSecretKey: tc.secret}))
+			result, err := NewDefaultRegistry().Verify(t.Context(), in)
+			checkResult(t, result, err, tc.wantErr, tc.wantIdentity)
+		})
+	}
+}
+
+func TestVerifyVSphere(t *testing.T) {
+	t.Parallel()
+
+	deleted := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			if username, password, _ := r.BasicAuth(); username != "admin" || password != "valid" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`"session-id"`))
+		case http.MethodDelete:
+			deleted <- r.Header.Get(vsphereSessionHeader)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	identity := newIdentity(vsphereClusterIdentityKind, "", "vsphere", map[string]any{"secretName": "vsphere-secret"})
+
+	in := newInput(identity, srv.URL, newSecret("vsphere-secret", map[string]string{"username": "admin", "password": "valid"}))
+	result, err := NewDefaultRegistry().Verify(t.Context(), in)
+	checkResult(t, result, err, nil, "admin")
+	if got := <-deleted; got != "session-id" {
+		t.Errorf("expected the session to be deleted, got %q", got)
+	}
+
+	in = newInput(identity, srv.URL, newSecret("vsphere-secret", map[string]string{"username": "admin", "password": "wrong"}))
+	_, err = NewDefaultRegistry().Verify(t.Context(), in)
+	checkResult(t, nil, err, ErrRejected, "")
+
+	in = newInput(identity, "", newSecret("vsphere-secret", map[string]string{"username": "admin", "password": "valid"}))
+	if _, err = NewDefaultRegistry().Verify(t.Context(), in); err == nil || errors.Is(err, ErrRejected) {
+		t.Errorf("expected the missing endpoint error, got %v", err)
+	}
+}
+
+func TestVerifySecret_GCP(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	revokedKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedKeyDER, err := x509.MarshalPKCS8PrivateKey(revokedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: revokedKeyDER}))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.FormValue("assertion"), ".")
+		if r.FormValue("grant_type") != gcpJWTBearerGrantType || len(parts) != 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_request"}`))
+			return
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid JWT Signature."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3599}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	credentials := func(keyType, privateKey string) string {
+		b, _ := json.Marshal(gcpServiceAccountKey{
+			Type:         keyType,
+			ClientEmail:  "kcm@project.iam.gserviceaccount.com",
+			PrivateKeyID: "kid",
+			PrivateKey:   privateKey,
+			TokenURI:     srv.URL + "/token",
+		})
+		return string(b)
+	}
+
+	for _, tc := range []struct {
+		name         string
+		credentials  string
+		wantErr      error
+		wantIdentity string
+	}{
+		{name: "valid", credentials: credentials(gcpServiceAccountType, keyPEM), wantIdentity: "kcm@project.iam.gserviceaccount.com"},
+		{name: "revoked key", credentials: credentials(gcpServiceAccountType, revokedKeyPEM), wantErr: ErrRejected},
+		{name: "invalid private key", credentials: credentials(gcpServiceAccountType, "garbage"), wantErr: ErrRejected},
+		{name: "unsupported type", credentials: credentials("external_account", ""), wantErr: ErrUnsupported},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			identity := newIdentity(secretKind, testNamespace, "gcp", nil)
+			in := newInput(identity, "", newSecret("gcp", map[string]string{gcpCredentialsKey: tc.credentials}))
+			result, err := NewDefaultRegistry().Verify(t.Context(), in)
+			checkResult(t, result, err, tc.wantErr, tc.wantIdentity)
+		})
+	}
+}
+
+func TestVerifySecret_OpenStack(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/auth/tokens":
+			req := struct {
+				Auth struct {
+					Identity struct {
+						ApplicationCredential struct {
+							ID     string `json:"id"`
+							Secret string `json:"secret"`
+						} `json:"application_credential"`
+					} `json:"identity"`
+				} `json:"auth"`
+			}{}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if appCred := req.Auth.Identity.ApplicationCredential; appCred.ID != "app" || appCred.Secret != "valid" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":{"code":401,"message":"The request you have made requires authentication."}}`))
+				return
+			}
+			w.Header().Set(openstackSubjectTokenKey, "token")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"token":{"user":{"id":"uid","name":"kcm"},"application_credential":{"id":"app"}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/users/uid/application_credentials/app" && r.Header.Get("X-Auth-Token") == "token":
+			_, _ = w.Write([]byte(`{"application_credential":{"expires_at":"2030-01-02T03:04:05.000000"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	clouds := func(secret string) string {
+		return "clouds:\n  openstack:\n    auth:\n      auth_url: " + srv.URL + "\n" +
+			"      application_credential_id: app\n      application_credential_secret: " + secret + "\n" +
+			"    auth_type: v3applicationcredential\n"
+	}
+	identity := newIdentity(secretKind, testNamespace, "openstack", nil)
+
+	in := newInput(identity, "", newSecret("openstack", map[string]string{openstackCloudsKey: clouds("valid")}))
+	result, err := NewDefaultRegistry().Verify(t.Context(), in)
+	checkResult(t, result, err, nil, "kcm")
+	if result.ExpirationTime == nil || !result.ExpirationTime.Equal(expiresAt) {
+		t.Errorf("expected expiration time %s, got %v", expiresAt, result.ExpirationTime)
+	}
+
+	in = newInput(identity, "", newSecret("openstack", map[string]string{openstackCloudsKey: clouds("expired")}))
+	_, err = NewDefaultRegistry().Verify(t.Context(), in)
+	checkResult(t, nil, err, ErrRejected, "")
+
+	in = newInput(identity, "", newSecret("openstack", map[string]string{"unknown": "data"}))
+	_, err = NewDefaultRegistry().Verify(t.Context(), in)
+	checkResult(t, nil, err, ErrUnsupported, "")
+}
@@ -0,0 +1,80 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	vsphereClusterIdentityKind = "VSphereClusterIdentity"
+
+	vsphereSessionHeader = "vmware-api-session-id"
+)
+
+// VerifyVSphere verifies the credentials of a VSphereClusterIdentity by creating and deleting
+// a session of the vCenter server the endpoint of which must be provided.
+// The Secret referenced by the identity is expected in the system namespace.
+func VerifyVSphere(ctx context.Context, in *Input) (*Result, error) {
+	if in.Endpoint == "" {
+		return nil, errors.New("the endpoint of the vCenter server is required to verify the VSphereClusterIdentity")
+	}
+
+	secretName, _, _ := unstructured.NestedString(in.Identity.Object, "spec", "secretName")
+	if secretName == "" {
+		return nil, errors.New("secretName of the VSphereClusterIdentity is not set")
+	}
+
+	data, err := getSecretData(ctx, in.Client, in.SystemNamespace, secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	username, password := string(data["username"]), string(data["password"])
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("%w: Secret %s/%s has no username or password", ErrRejected, in.SystemNamespace, secretName)
+	}
+
+	sessionURL := endpointOr(in.Endpoint, "") + "/api/session"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sessionURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build session request: %w", err)
+	}
+	req.SetBasicAuth(username, password)
+
+	body, err := do(in.HTTPClient, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionID string
+	if err := json.Unmarshal(body, &sessionID); err != nil {
+		return nil, fmt.Errorf("failed to decode vCenter session: %w", err)
+	}
+
+	// the session is only required to check the credentials, the failure to delete it is not an error
+	if req, err := http.NewRequestWithContext(ctx, http.MethodDelete, sessionURL, http.NoBody); err == nil {
+		req.Header.Set(vsphereSessionHeader, sessionID)
+		_, _ = do(in.HTTPClient, req)
+	}
+
+	return &Result{Provider: "vsphere", Identity: username}, nil
+}
@@ -34,6 +34,7 @@ import (
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
 	"github.com/K0rdent/kcm/internal/controller/credential"
+	"github.com/K0rdent/kcm/internal/controller/credential/verifier"
 	"github.com/K0rdent/kcm/internal/record"
 	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
 	labelsutil "github.com/K0rdent/kcm/internal/util/labels"
@@ -41,9 +42,15 @@ import (
 	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
 )
 
+// credentialExpirationWarningPeriod is the period before the expiration of the credentials
+// during which the warnings about the upcoming expiration are emitted.
+const credentialExpirationWarningPeriod = 7 * 24 * time.Hour
+
 // CredentialReconciler reconciles a Credential object
 type CredentialReconciler struct {
 	MgmtClient      client.Client
+	verifiers       *verifier.Registry
+	timeFunc        func() time.Time
 	SystemNamespace string
 	syncPeriod      time.Duration
 }
@@ -111,12 +118,17 @@ func (r *CredentialReconciler) update(ctx context.Context, cred *kcmv1.Credentia
 			return ctrl.Result{}, err
 		}
 
-		rgnClient, _, err = kubeutil.GetRegionalClient(ctx, r.MgmtClient, r.SystemNamespace, rgn, schemeutil.GetRegionalScheme)
+		rgnClient, _, err = kubeutil.GetRegionalClient(ctx, r.MgmtClient, r.SystemNamespace, rgn, schemeutil.Regional)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
+	rotating, err := r.rotate(ctx, cred)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err = credential.CopyClusterIdentities(ctx, r.MgmtClient, rgnClient, cred, r.SystemNamespace); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -145,15 +157,123 @@ func (r *CredentialReconciler) update(ctx context.Context, cred *kcmv1.Credentia
 		return ctrl.Result{}, err
 	}
 
+	requeueAfter, err := r.verify(ctx, rgnClient, cred, clIdty)
+	if rotating && (requeueAfter == 0 || requeueAfter > credentialRotationRequeueTime) {
+		requeueAfter = credentialRotationRequeueTime
+	}
+	if err != nil {
+		if r.setReadyCondition(cred, err) {
+			record.Warnf(cred, cred.Spec.IdentityRef, "CredentialRejected", "VerifyCredential", err.Error())
+		}
+		return ctrl.Result{RequeueAfter: r.nextSync(requeueAfter)}, nil
+	}
+
 	r.setReadyCondition(cred, nil)
 
-	return ctrl.Result{RequeueAfter: r.syncPeriod}, nil
+	return ctrl.Result{RequeueAfter: r.nextSync(requeueAfter)}, nil
+}
+
+// nextSync returns the earlier of the sync period and the given duration until the next verification or rotation check.
+func (r *CredentialReconciler) nextSync(untilNextCheck time.Duration) time.Duration {
+	if untilNextCheck == 0 || (r.syncPeriod > 0 && r.syncPeriod < untilNextCheck) {
+		return r.syncPeriod
+	}
+	return untilNextCheck
+}
+
+// verify verifies the credentials of the given ClusterIdentity against the cloud provider unless
+// the verification is not enabled or the last one of the same generation is not older than the interval,
+// and updates the "CredentialVerified" condition and the verification status accordingly.
+// Returns the duration until the next verification, if any, and an error only if the cloud provider
+// has rejected the credentials; the credentials which cannot be verified are not considered invalid.
+func (r *CredentialReconciler) verify(ctx context.Context, rgnClient client.Client, cred *kcmv1.Credential, clIdty *unstructured.Unstructured) (time.Duration, error) {
+	if !cred.Spec.Verification.IsEnabled() {
+		apimeta.RemoveStatusCondition(&cred.Status.Conditions, kcmv1.CredentialVerifiedCondition)
+		cred.Status.Verification = nil
+		return 0, nil
+	}
+
+	if r.timeFunc == nil {
+		r.timeFunc = time.Now
+	}
+	if r.verifiers == nil {
+		r.verifiers = verifier.NewDefaultRegistry()
+	}
+
+	now := r.timeFunc()
+	interval := cred.Spec.Verification.GetInterval()
+
+	cond := apimeta.FindStatusCondition(cred.Status.Conditions, kcmv1.CredentialVerifiedCondition)
+	if cond != nil && cond.ObservedGeneration == cred.Generation && cred.Status.Verification != nil {
+		if next := cred.Status.Verification.LastVerificationTime.Add(interval); now.Before(next) {
+			if cond.Reason == kcmv1.CredentialRejectedReason {
+				return next.Sub(now), errors.New(cond.Message)
+			}
+			return next.Sub(now), nil
+		}
+	}
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Verifying credentials", "kind", clIdty.GetKind(), "name", clIdty.GetName())
+	result, err := r.verifiers.Verify(ctx, &verifier.Input{
+		Identity:        clIdty,
+		Client:          rgnClient,
+		SystemNamespace: r.SystemNamespace,
+		Endpoint:        cred.Spec.Verification.GetEndpoint(),
+	})
+
+	verifiedCond := metav1.Condition{
+		Type:               kcmv1.CredentialVerifiedCondition,
+		ObservedGeneration: cred.Generation,
+	}
+	cred.Status.Verification = &kcmv1.CredentialVerificationStatus{LastVerificationTime: metav1.NewTime(now)}
+
+	switch {
+	case errors.Is(err, verifier.ErrUnsupported):
+		verifiedCond.Status = metav1.ConditionUnknown
+		verifiedCond.Reason = kcmv1.CredentialVerificationUnsupportedReason
+		verifiedCond.Message = err.Error()
+	case errors.Is(err, verifier.ErrRejected):
+		verifiedCond.Status = metav1.ConditionFalse
+		verifiedCond.Reason = kcmv1.CredentialRejectedReason
+		verifiedCond.Message = err.Error()
+	case err != nil:
+		verifiedCond.Status = metav1.ConditionUnknown
+		verifiedCond.Reason = kcmv1.FailedReason
+		verifiedCond.Message = fmt.Sprintf("failed to verify credentials: %s", err)
+	default:
+		verifiedCond.Status = metav1.ConditionTrue
+		verifiedCond.Reason = kcmv1.SucceededReason
+		verifiedCond.Message = fmt.Sprintf("Credentials are accepted by %s", result.Provider)
+		if result.Identity != "" {
+			verifiedCond.Message += " as " + result.Identity
+		}
+
+		cred.Status.Verification.Provider = result.Provider
+		cred.Status.Verification.Identity = result.Identity
+		if result.ExpirationTime != nil {
+			cred.Status.Verification.ExpirationTime = &metav1.Time{Time: *result.ExpirationTime}
+			if result.ExpirationTime.Sub(now) < credentialExpirationWarningPeriod {
+				record.Warnf(cred, cred.Spec.IdentityRef, "CredentialExpiringSoon", "VerifyCredential",
+					"Credentials expire at %s", result.ExpirationTime.UTC().Format(time.RFC3339))
+			}
+		}
+	}
+
+	if apimeta.SetStatusCondition(&cred.Status.Conditions, verifiedCond) && verifiedCond.Status == metav1.ConditionTrue {
+		record.Eventf(cred, cred.Spec.IdentityRef, "CredentialVerified", "VerifyCredential", verifiedCond.Message)
+	}
+
+	if verifiedCond.Reason == kcmv1.CredentialRejectedReason {
+		return interval, errors.New(verifiedCond.Message)
+	}
+
+	return interval, nil
 }
 
 func (r *CredentialReconciler) delete(ctx context.Context, cred *kcmv1.Credential) (ctrl.Result, error) {
 	l := ctrl.LoggerFrom(ctx)
 
-	rgnClient, err := kubeutil.GetRegionalClientByRegionName(ctx, r.MgmtClient, r.SystemNamespace, cred.Spec.Region, schemeutil.GetRegionalScheme)
+	rgnClient, err := kubeutil.GetRegionalClientByRegionName(ctx, r.MgmtClient, r.SystemNamespace, cred.Spec.Region, schemeutil.Regional)
 	if err == nil {
 		if err := credential.ReleaseClusterIdentities(ctx, rgnClient, cred); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to release all Cluster Identities for Credential %s: %w", client.ObjectKeyFromObject(cred), err)
@@ -207,6 +327,8 @@ func (r *CredentialReconciler) updateStatus(ctx context.Context, cred *kcmv1.Cre
 // SetupWithManager sets up the controller with the Manager.
 func (r *CredentialReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.syncPeriod = 15 * time.Minute
+	r.timeFunc = time.Now
+	r.verifiers = verifier.NewDefaultRegistry()
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.TypedOptions[ctrl.Request]{
@@ -15,8 +15,12 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -24,11 +28,18 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/controller/credential"
+	"github.com/K0rdent/kcm/internal/controller/credential/verifier"
+	testscheme "github.com/K0rdent/kcm/test/scheme"
 )
 
 const systemNamespace = "kcm-system"
@@ -309,3 +320,373 @@ func validateManagedClusterIdentityExists(cred *kcmv1.Credential, namespace, nam
 		strings.Join([]string{kcmv1.CredentialLabelKeyPrefix, cred.Namespace, cred.Name}, "."): "true",
 	}))
 }
+
+func Test_CredentialReconciler_verify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(72 * time.Hour)
+
+	newCredential := func(verification *kcmv1.CredentialVerification) *kcmv1.Credential {
+		return &kcmv1.Credential{
+			ObjectMeta: metav1.ObjectMeta{Name: "cred", Namespace: "default", Generation: 1},
+			Spec: kcmv1.CredentialSpec{
+				IdentityRef:  &corev1.ObjectReference{Kind: "Fake", Name: "identity"},
+				Verification: verification,
+			},
+		}
+	}
+
+	enabled := &kcmv1.CredentialVerification{Enabled: true}
+
+	for _, tc := range []struct {
+		cred         *kcmv1.Credential
+		verifyErr    error
+		name         string
+		wantStatus   metav1.ConditionStatus
+		wantReason   string
+		wantRequeue  time.Duration
+		wantCalls    int
+		wantErr      bool
+		wantNoStatus bool
+	}{
+		{
+			name:        "verified",
+			cred:        newCredential(enabled),
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  kcmv1.SucceededReason,
+			wantRequeue: kcmv1.DefaultCredentialVerificationInterval,
+			wantCalls:   1,
+		},
+		{
+			name:        "rejected",
+			cred:        newCredential(&kcmv1.CredentialVerification{Enabled: true, Interval: &metav1.Duration{Duration: time.Minute}}),
+			verifyErr:   fmt.Errorf("%w: invalid key", verifier.ErrRejected),
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  kcmv1.CredentialRejectedReason,
+			wantRequeue: time.Minute,
+			wantCalls:   1,
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported",
+			cred:        newCredential(enabled),
+			verifyErr:   verifier.ErrUnsupported,
+			wantStatus:  metav1.ConditionUnknown,
+			wantReason:  kcmv1.CredentialVerificationUnsupportedReason,
+			wantRequeue: kcmv1.DefaultCredentialVerificationInterval,
+			wantCalls:   1,
+		},
+		{
+			name:        "transient failure",
+			cred:        newCredential(enabled),
+			verifyErr:   errors.New("connection refused"),
+			wantStatus:  metav1.ConditionUnknown,
+			wantReason:  kcmv1.FailedReason,
+			wantRequeue: kcmv1.DefaultCredentialVerificationInterval,
+			wantCalls:   1,
+		},
+		{
+			name: "recently rejected",
+			cred: func() *kcmv1.Credential {
+				cred := newCredential(enabled)
+				cred.Status.Verification = &kcmv1.CredentialVerificationStatus{LastVerificationTime: metav1.NewTime(now.Add(-10 * time.Minute))}
+				cred.Status.Conditions = []metav1.Condition{{
+					Type: kcmv1.CredentialVerifiedCondition, Status: metav1.ConditionFalse,
+					Reason: kcmv1.CredentialRejectedReason, Message: "rejected", ObservedGeneration: 1,
+				}}
+				return cred
+			}(),
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  kcmv1.CredentialRejectedReason,
+			wantRequeue: 50 * time.Minute,
+			wantErr:     true,
+		},
+		{
+			name: "verified for previous generation",
+			cred: func() *kcmv1.Credential {
+				cred := newCredential(enabled)
+				cred.Status.Verification = &kcmv1.CredentialVerificationStatus{LastVerificationTime: metav1.NewTime(now.Add(-10 * time.Minute))}
+				cred.Status.Conditions = []metav1.Condition{{
+					Type: kcmv1.CredentialVerifiedCondition, Status: metav1.ConditionFalse,
+					Reason: kcmv1.CredentialRejectedReason, Message: "rejected",
+				}}
+				return cred
+			}(),
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  kcmv1.SucceededReason,
+			wantRequeue: kcmv1.DefaultCredentialVerificationInterval,
+			wantCalls:   1,
+		},
+		{
+			name: "not enabled",
+			cred: func() *kcmv1.Credential {
+				cred := newCredential(nil)
+				cred.Status.Verification = &kcmv1.CredentialVerificationStatus{LastVerificationTime: metav1.NewTime(now)}
+				cred.Status.Conditions = []metav1.Condition{{Type: kcmv1.CredentialVerifiedCondition, Status: metav1.ConditionTrue}}
+				return cred
+			}(),
+			wantNoStatus: true,
+		},
+		{
+			name:         "configured but not enabled",
+			cred:         newCredential(&kcmv1.CredentialVerification{Interval: &metav1.Duration{Duration: time.Minute}}),
+			wantNoStatus: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			registry := verifier.NewRegistry()
+			registry.Register("Fake", func(context.Context, *verifier.Input) (*verifier.Result, error) {
+				calls++
+				if tc.verifyErr != nil {
+					return nil, tc.verifyErr
+				}
+				return &verifier.Result{Provider: "fake", Identity: "user", ExpirationTime: &expiresAt}, nil
+			})
+
+			r := &CredentialReconciler{
+				MgmtClient: fake.NewClientBuilder().WithScheme(testscheme.Scheme).Build(),
+				verifiers:  registry,
+				timeFunc:   func() time.Time { return now },
+			}
+
+			identity := &unstructured.Unstructured{}
+			identity.SetKind("Fake")
+			identity.SetName("identity")
+
+			requeue, err := r.verify(t.Context(), r.MgmtClient, tc.cred, identity)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requeue != tc.wantRequeue {
+				t.Errorf("expected requeue after %s, got %s", tc.wantRequeue, requeue)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("expected %d verifications, got %d", tc.wantCalls, calls)
+			}
+
+			cond := apimeta.FindStatusCondition(tc.cred.Status.Conditions, kcmv1.CredentialVerifiedCondition)
+			if tc.wantNoStatus {
+				if cond != nil || tc.cred.Status.Verification != nil {
+					t.Errorf("expected no verification status, got %v, %v", cond, tc.cred.Status.Verification)
+				}
+				return
+			}
+			if cond == nil || cond.Status != tc.wantStatus || cond.Reason != tc.wantReason {
+				t.Fatalf("expected condition %s/%s, got %v", tc.wantStatus, tc.wantReason, cond)
+			}
+			if tc.wantStatus == metav1.ConditionTrue {
+				status := tc.cred.Status.Verification
+				if status.Identity != "user" || status.Provider != "fake" || status.ExpirationTime == nil || !status.ExpirationTime.Equal(&metav1.Time{Time: expiresAt}) {
+					t.Errorf("unexpected verification status %+v", status)
+				}
+			}
+		})
+	}
+}
+
+func Test_CredentialReconciler_rotate(t *testing.T) {
+	t.Parallel()
+
+	const namespace = "default"
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldData := map[string][]byte{"key": []byte("old")}
+	newData := map[string][]byte{"key": []byte("new")}
+
+	infraGVK := schema.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "FakeCluster"}
+	mapper := apimeta.NewDefaultRESTMapper([]schema.GroupVersion{infraGVK.GroupVersion()})
+	mapper.Add(infraGVK, apimeta.RESTScopeNamespace)
+
+	infra := &unstructured.Unstructured{}
+	infra.SetGroupVersionKind(infraGVK)
+	infra.SetNamespace(namespace)
+	infra.SetName("provisioned")
+
+	cred := &kcmv1.Credential{
+		ObjectMeta: metav1.ObjectMeta{Name: "cred", Namespace: namespace, UID: "cred-uid"},
+		Spec: kcmv1.CredentialSpec{
+			IdentityRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "identity", Namespace: namespace},
+			Rotation:    &kcmv1.CredentialRotation{SecretName: "identity-v2"},
+		},
+	}
+
+	mgmtClient := fake.NewClientBuilder().
+		WithScheme(testscheme.Scheme).
+		WithRESTMapper(mapper).
+		WithIndex(&kcmv1.ClusterDeployment{}, kcmv1.ClusterDeploymentCredentialIndexKey, kcmv1.ExtractCredentialNameFromClusterDeployment).
+		WithObjects(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "identity", Namespace: namespace}, Data: oldData},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "identity-v2", Namespace: namespace}, Data: newData},
+			&kcmv1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "provisioned", Namespace: namespace},
+				Spec:       kcmv1.ClusterDeploymentSpec{Credential: cred.Name},
+			},
+			&kcmv1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "provisioning", Namespace: namespace},
+				Spec:       kcmv1.ClusterDeploymentSpec{Credential: cred.Name},
+			},
+			&clusterapiv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "provisioned", Namespace: namespace, Labels: map[string]string{kcmv1.FluxHelmChartNameKey: "provisioned"}},
+				Spec: clusterapiv1.ClusterSpec{InfrastructureRef: clusterapiv1.ContractVersionedObjectReference{
+					APIGroup: infraGVK.Group, Kind: infraGVK.Kind, Name: "provisioned",
+				}},
+				Status: clusterapiv1.ClusterStatus{Conditions: []metav1.Condition{{
+					Type: clusterapiv1.ClusterInfrastructureReadyCondition, Status: metav1.ConditionTrue,
+				}}},
+			},
+			infra,
+		).
+		Build()
+
+	r := &CredentialReconciler{
+		MgmtClient: mgmtClient,
+		timeFunc:   func() time.Time { return now },
+	}
+
+	clusterStates := func() map[string]kcmv1.CredentialRotationClusterState {
+		states := make(map[string]kcmv1.CredentialRotationClusterState)
+		for _, c := range cred.Status.Rotation.Clusters {
+			states[c.Name] = c.State
+		}
+		return states
+	}
+	checkLiveData := func(want map[string][]byte) {
+		t.Helper()
+		live := new(corev1.Secret)
+		if err := mgmtClient.Get(t.Context(), crclient.ObjectKey{Namespace: namespace, Name: "identity"}, live); err != nil {
+			t.Fatalf("failed to get Secret: %v", err)
+		}
+		if string(live.Data["key"]) != string(want["key"]) {
+			t.Errorf("expected the Secret to hold %q, got %q", want["key"], live.Data["key"])
+		}
+	}
+
+	// the rotation replaces the data and records the previous version
+	rotating, err := r.rotate(t.Context(), cred)
+	if err != nil || !rotating {
+		t.Fatalf("expected the rotation to start, got %t, %v", rotating, err)
+	}
+	checkLiveData(newData)
+	if cred.Status.Rotation.PreviousVersion != credential.SecretVersion(oldData) || cred.Status.Rotation.PreviousSecretName == "" {
+		t.Errorf("unexpected previous version %+v", cred.Status.Rotation)
+	}
+
+	// the provisioned cluster is refreshed, the one without the infrastructure yet is considered updated
+	if rotating, err = r.rotate(t.Context(), cred); err != nil || !rotating {
+		t.Fatalf("expected the rotation to progress, got %t, %v", rotating, err)
+	}
+	if states := clusterStates(); states["provisioned"] != kcmv1.CredentialRotationClusterRefreshed || states["provisioning"] != kcmv1.CredentialRotationClusterUpdated {
+		t.Errorf("unexpected cluster states %v", states)
+	}
+	if err := mgmtClient.Get(t.Context(), crclient.ObjectKeyFromObject(infra), infra); err != nil {
+		t.Fatalf("failed to get infrastructure: %v", err)
+	}
+	if got := infra.GetAnnotations()[kcmv1.CredentialVersionAnnotation]; got != cred.Status.Rotation.Version {
+		t.Errorf("expected the infrastructure to be annotated with version %s, got %q", cred.Status.Rotation.Version, got)
+	}
+
+	// the refreshed cluster is updated once its infrastructure stays ready for the settle period
+	if rotating, _ = r.rotate(t.Context(), cred); !rotating {
+		t.Fatal("expected the rotation to wait for the settle period")
+	}
+	now = now.Add(credentialRotationSettlePeriod)
+	if rotating, err = r.rotate(t.Context(), cred); err != nil || rotating {
+		t.Fatalf("expected the rotation to complete, got %t, %v", rotating, err)
+	}
+	if cred.Status.Rotation.CompletionTime == nil || cred.Status.Rotation.UpdatedClusters != 2 || cred.Status.Rotation.TotalClusters != 2 {
+		t.Errorf("unexpected rotation status %+v", cred.Status.Rotation)
+	}
+	if !apimeta.IsStatusConditionTrue(cred.Status.Conditions, kcmv1.CredentialRotatedCondition) {
+		t.Errorf("expected the %s condition to be true", kcmv1.CredentialRotatedCondition)
+	}
+
+	// rolling back to the previous version restores the data
+	cred.Spec.Rotation.SecretName = cred.Status.Rotation.PreviousSecretName
+	if rotating, err = r.rotate(t.Context(), cred); err != nil || !rotating {
+		t.Fatalf("expected the rollback to start, got %t, %v", rotating, err)
+	}
+	checkLiveData(oldData)
+	if cred.Status.Rotation.PreviousVersion != credential.SecretVersion(newData) {
+		t.Errorf("expected the previous version to be %s, got %s", credential.SecretVersion(newData), cred.Status.Rotation.PreviousVersion)
+	}
+
+	// the missing Secret fails the rotation
+	cred.Spec.Rotation.SecretName = "missing"
+	if _, err = r.rotate(t.Context(), cred); err == nil {
+		t.Fatal("expected the rotation to the missing Secret to fail")
+	}
+	if cond := apimeta.FindStatusCondition(cred.Status.Conditions, kcmv1.CredentialRotatedCondition); cond == nil || cond.Reason != kcmv1.FailedReason {
+		t.Errorf("expected the %s condition to be failed, got %v", kcmv1.CredentialRotatedCondition, cond)
+	}
+}
+
+func Test_CredentialReconciler_clusterServicesReady(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace = "default"
+		version   = "v2"
+	)
+
+	cd := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cd", Namespace: namespace},
+		Spec:       kcmv1.ClusterDeploymentSpec{PropagateCredentials: new(true)},
+	}
+	newServiceSet := func(credentialVersion string, generation, observedGeneration int64) *kcmv1.ServiceSet {
+		return &kcmv1.ServiceSet{
+			ObjectMeta: metav1.ObjectMeta{Name: cd.Name, Namespace: namespace, Generation: generation},
+			Spec:       kcmv1.ServiceSetSpec{Cluster: cd.Name, CredentialVersion: credentialVersion},
+			Status: kcmv1.ServiceSetStatus{
+				Deployed: true,
+				Conditions: []metav1.Condition{{
+					Type: kcmv1.ServicesInReadyStateCondition, Status: metav1.ConditionTrue, ObservedGeneration: observedGeneration,
+				}},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		serviceSet *kcmv1.ServiceSet
+		wantReady  bool
+	}{
+		"no ServiceSet": {
+			wantReady: true,
+		},
+		"rotated credentials are not passed to the ServiceSet yet": {
+			serviceSet: newServiceSet("v1", 1, 1),
+		},
+		"ServiceSet with the rotated credentials is not observed yet": {
+			serviceSet: newServiceSet(version, 2, 1),
+		},
+		"ServiceSet with the rotated credentials is ready": {
+			serviceSet: newServiceSet(version, 2, 2),
+			wantReady:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := fake.NewClientBuilder().
+				WithScheme(testscheme.Scheme).
+				WithIndex(&kcmv1.ServiceSet{}, kcmv1.ServiceSetClusterIndexKey, kcmv1.ExtractServiceSetCluster)
+			if tt.serviceSet != nil {
+				builder = builder.WithObjects(tt.serviceSet)
+			}
+			r := &CredentialReconciler{MgmtClient: builder.Build()}
+
+			ready, err := r.clusterServicesReady(t.Context(), cd, version)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ready != tt.wantReady {
+				t.Errorf("ready = %t, want %t", ready, tt.wantReady)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/controller/credential"
+	"github.com/K0rdent/kcm/internal/record"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
+)
+
+const (
+	// credentialRotationSettlePeriod is the period the infrastructure of a refreshed cluster
+	// should stay ready for before the cluster is considered to have picked up the rotated credentials.
+	credentialRotationSettlePeriod = 2 * time.Minute
+	// credentialRotationRequeueTime is the requeue time while the rotation is in progress.
+	credentialRotationRequeueTime = 30 * time.Second
+)
+
+// rotate rotates the data of the Secret behind the ClusterIdentity of the given Credential to the version
+// pointed by its spec, propagates it to the other Credentials sharing the identity and tracks which
+// of the ClusterDeployments using the Credential have picked it up.
+// Returns true if the rotation is in progress.
+func (r *CredentialReconciler) rotate(ctx context.Context, cred *kcmv1.Credential) (bool, error) {
+	if cred.Spec.Rotation == nil {
+		apimeta.RemoveStatusCondition(&cred.Status.Conditions, kcmv1.CredentialRotatedCondition)
+		return false, nil
+	}
+
+	if r.timeFunc == nil {
+		r.timeFunc = time.Now
+	}
+
+	rotation := cred.Status.Rotation
+	if rotation == nil || rotation.SecretName != cred.Spec.Rotation.SecretName {
+		if err := r.startRotation(ctx, cred); err != nil {
+			r.setRotatedCondition(cred, metav1.ConditionFalse, kcmv1.FailedReason, err.Error())
+			record.Warnf(cred, nil, "CredentialRotationFailed", "RotateCredential", err.Error())
+			return false, err
+		}
+
+		// the clusters are refreshed once the rotated credentials are copied to the region
+		r.setRotatedCondition(cred, metav1.ConditionFalse, kcmv1.ProgressingReason, "Rotating the credentials")
+		return true, nil
+	}
+
+	if rotation.CompletionTime != nil {
+		return false, nil
+	}
+
+	if err := r.trackRotation(ctx, cred); err != nil {
+		// the errors are reported per cluster, the other clusters are still tracked
+		ctrl.LoggerFrom(ctx).Error(err, "failed to track the rotation of the credentials")
+	}
+
+	if rotation.UpdatedClusters < rotation.TotalClusters {
+		r.setRotatedCondition(cred, metav1.ConditionFalse, kcmv1.ProgressingReason,
+			fmt.Sprintf("%d/%d clusters have picked up the rotated credentials", rotation.UpdatedClusters, rotation.TotalClusters))
+		return true, nil
+	}
+
+	rotation.CompletionTime = &metav1.Time{Time: r.timeFunc()}
+	r.setRotatedCondition(cred, metav1.ConditionTrue, kcmv1.SucceededReason, "All clusters have picked up the rotated credentials")
+	record.Eventf(cred, nil, "CredentialRotationCompleted", "RotateCredential",
+		"Rotation of the credentials to the version %s is complete", rotation.Version)
+
+	return false, nil
+}
+
+// startRotation replaces the data of the Secret behind the ClusterIdentity of the given Credential,
+// copies it to the regions of the Credentials sharing the identity and resets the rotation status.
+func (r *CredentialReconciler) startRotation(ctx context.Context, cred *kcmv1.Credential) error {
+	secretKey, err := credential.IdentitySecretKey(ctx, r.MgmtClient, cred, r.SystemNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to find the Secret behind the ClusterIdentity: %w", err)
+	}
+
+	result, err := credential.RotateSecret(ctx, r.MgmtClient, secretKey, cred.Spec.Rotation.SecretName)
+	if err != nil {
+		return err
+	}
+
+	rotation := &kcmv1.CredentialRotationStatus{
+		StartTime:          &metav1.Time{Time: r.timeFunc()},
+		SecretName:         cred.Spec.Rotation.SecretName,
+		Version:            result.Version,
+		PreviousSecretName: result.PreviousSecretName,
+		PreviousVersion:    result.PreviousVersion,
+	}
+	if result.PreviousVersion == "" && cred.Status.Rotation != nil && cred.Status.Rotation.Version != result.Version {
+		// the data has already been replaced, e.g. by another Credential sharing the identity
+		rotation.PreviousSecretName = cred.Status.Rotation.SecretName
+		rotation.PreviousVersion = cred.Status.Rotation.Version
+	}
+	cred.Status.Rotation = rotation
+	// the new credentials are to be verified as soon as possible
+	cred.Status.Verification = nil
+
+	ctrl.LoggerFrom(ctx).Info("Rotated credentials", "secret", secretKey, "version", result.Version, "previousVersion", rotation.PreviousVersion)
+	record.Eventf(cred, nil, "CredentialRotationStarted", "RotateCredential",
+		"Rotating the credentials in Secret %s to the version %s from Secret %s", secretKey, result.Version, rotation.SecretName)
+
+	return r.propagateRotation(ctx, cred, secretKey)
+}
+
+// propagateRotation copies the ClusterIdentities to the regions of all the Credentials sharing the given
+// Secret behind the ClusterIdentity of the given Credential, the region of the given one is handled by the reconciliation.
+func (r *CredentialReconciler) propagateRotation(ctx context.Context, cred *kcmv1.Credential, secretKey client.ObjectKey) error {
+	creds := new(kcmv1.CredentialList)
+	if err := r.MgmtClient.List(ctx, creds); err != nil {
+		return fmt.Errorf("failed to list Credentials: %w", err)
+	}
+
+	var errs error
+	for i := range creds.Items {
+		other := &creds.Items[i]
+		if other.UID == cred.UID || !other.DeletionTimestamp.IsZero() || other.Spec.IdentityRef == nil {
+			continue
+		}
+		if otherKey, err := credential.IdentitySecretKey(ctx, r.MgmtClient, other, r.SystemNamespace); err != nil || otherKey != secretKey {
+			continue
+		}
+
+		rgnClient, err := kubeutil.GetRegionalClientByRegionName(ctx, r.MgmtClient, r.SystemNamespace, other.Spec.Region, schemeutil.Regional)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get client for the region of Credential %s: %w", client.ObjectKeyFromObject(other), err))
+			continue
+		}
+		if err := credential.CopyClusterIdentities(ctx, r.MgmtClient, rgnClient, other, r.SystemNamespace); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// trackRotation triggers the re-reconciliation of the infrastructure of the ClusterDeployments
+// using the given Credential and updates the rotation status with the clusters which have picked up
+// the rotated credentials.
+func (r *CredentialReconciler) trackRotation(ctx context.Context, cred *kcmv1.Credential) error {
+	rotation := cred.Status.Rotation
+
+	cds := new(kcmv1.ClusterDeploymentList)
+	if err := r.MgmtClient.List(ctx, cds, client.InNamespace(cred.Namespace), client.MatchingFields{kcmv1.ClusterDeploymentCredentialIndexKey: cred.Name}); err != nil {
+		return fmt.Errorf("failed to list ClusterDeployments using Credential %s: %w", client.ObjectKeyFromObject(cred), err)
+	}
+	slices.SortFunc(cds.Items, func(a, b kcmv1.ClusterDeployment) int { return strings.Compare(a.Name, b.Name) })
+
+	previous := make(map[string]kcmv1.CredentialRotationClusterStatus, len(rotation.Clusters))
+	for _, c := range rotation.Clusters {
+		previous[c.Name] = c
+	}
+
+	var errs error
+	clusters := make([]kcmv1.CredentialRotationClusterStatus, 0, len(cds.Items))
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		status, ok := previous[cd.Name]
+		if !ok {
+			status = kcmv1.CredentialRotationClusterStatus{Name: cd.Name, State: kcmv1.CredentialRotationClusterPending}
+		}
+
+		if status.State != kcmv1.CredentialRotationClusterUpdated {
+			if err := r.trackClusterRotation(ctx, rotation.Version, cd, &status); err != nil {
+				status.Message = err.Error()
+				errs = errors.Join(errs, err)
+			}
+		}
+		clusters = append(clusters, status)
+	}
+
+	rotation.Clusters = clusters
+	rotation.TotalClusters = int32(len(clusters))
+	rotation.UpdatedClusters = 0
+	for _, c := range clusters {
+		if c.State == kcmv1.CredentialRotationClusterUpdated {
+			rotation.UpdatedClusters++
+		}
+	}
+
+	return errs
+}
+
+// trackClusterRotation advances the state of the rotation for the given ClusterDeployment.
+func (r *CredentialReconciler) trackClusterRotation(ctx context.Context, version string, cd *kcmv1.ClusterDeployment, status *kcmv1.CredentialRotationClusterStatus) error {
+	rgnClient, err := kubeutil.GetRegionalClientByRegionName(ctx, r.MgmtClient, r.SystemNamespace, cd.Status.Region, schemeutil.Regional)
+	if err != nil {
+		return fmt.Errorf("failed to get client for the region of ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+
+	cluster, err := getCAPICluster(ctx, rgnClient, cd)
+	if err != nil {
+		return err
+	}
+	if cluster == nil || !cluster.Spec.InfrastructureRef.IsDefined() {
+		status.State = kcmv1.CredentialRotationClusterUpdated
+		status.Message = "The infrastructure of the cluster has not been created yet"
+		return nil
+	}
+
+	if status.State == kcmv1.CredentialRotationClusterPending {
+		if err := refreshInfrastructure(ctx, rgnClient, cluster, version); err != nil {
+			return err
+		}
+		status.State = kcmv1.CredentialRotationClusterRefreshed
+		status.RefreshTime = &metav1.Time{Time: r.timeFunc()}
+		status.Message = "Triggered the re-reconciliation of the infrastructure"
+		return nil
+	}
+
+	if !apimeta.IsStatusConditionTrue(cluster.Status.Conditions, clusterapiv1.ClusterInfrastructureReadyCondition) {
+		status.Message = "Waiting for the infrastructure of the cluster to become ready"
+		return nil
+	}
+	if status.RefreshTime != nil && r.timeFunc().Sub(status.RefreshTime.Time) < credentialRotationSettlePeriod {
+		status.Message = "Waiting for the infrastructure of the cluster to settle"
+		return nil
+	}
+
+	if cd.Spec.PropagateCredentials != nil && *cd.Spec.PropagateCredentials {
+		ready, err := r.clusterServicesReady(ctx, cd, version)
+		if err != nil || !ready {
+			status.Message = "Waiting for the propagated credentials to be refreshed in the cluster"
+			return err
+		}
+	}
+
+	status.State = kcmv1.CredentialRotationClusterUpdated
+	status.Message = ""
+	return nil
+}
+
+// clusterServicesReady returns true if the services deploying the propagated credentials
+// to the given ClusterDeployment are ready since the given version of the credentials has been
+// passed to the ServiceSet of the ClusterDeployment.
+func (r *CredentialReconciler) clusterServicesReady(ctx context.Context, cd *kcmv1.ClusterDeployment, version string) (bool, error) {
+	serviceSets := new(kcmv1.ServiceSetList)
+	if err := r.MgmtClient.List(ctx, serviceSets, client.InNamespace(cd.Namespace), client.MatchingFields{kcmv1.ServiceSetClusterIndexKey: cd.Name}); err != nil {
+		return false, fmt.Errorf("failed to list ServiceSets of ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+
+	for _, serviceSet := range serviceSets.Items {
+		// only the ServiceSet of the ClusterDeployment itself propagates the credentials
+		if serviceSet.Spec.MultiClusterService == "" && serviceSet.Spec.CredentialVersion != version {
+			return false, nil
+		}
+		if serviceSetRolloutState(&serviceSet) != rolloutTargetReady {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// refreshInfrastructure annotates the infrastructure object of the given CAPI Cluster
+// with the given version of the credentials to trigger its re-reconciliation by the provider.
+func refreshInfrastructure(ctx context.Context, cl client.Client, cluster *clusterapiv1.Cluster, version string) error {
+	ref := cluster.Spec.InfrastructureRef
+	mapping, err := cl.RESTMapper().RESTMapping(schema.GroupKind{Group: ref.APIGroup, Kind: ref.Kind})
+	if err != nil {
+		return fmt.Errorf("failed to get REST mapping of %s: %w", ref.GroupKind(), err)
+	}
+
+	infra := new(unstructured.Unstructured)
+	infra.SetGroupVersionKind(mapping.GroupVersionKind)
+	infra.SetNamespace(cluster.Namespace)
+	infra.SetName(ref.Name)
+
+	patch := fmt.Appendf(nil, `{"metadata":{"annotations":{%q:%q}}}`, kcmv1.CredentialVersionAnnotation, version)
+	if err := cl.Patch(ctx, infra, client.RawPatch(client.Merge.Type(), patch)); err != nil {
+		return fmt.Errorf("failed to annotate %s %s: %w", ref.Kind, client.ObjectKeyFromObject(infra), err)
+	}
+
+	return nil
+}
+
+func (*CredentialReconciler) setRotatedCondition(cred *kcmv1.Credential, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&cred.Status.Conditions, metav1.Condition{
+		Type:               kcmv1.CredentialRotatedCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cred.Generation,
+	})
+}
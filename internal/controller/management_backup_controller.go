@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -27,6 +28,7 @@ import (
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
 	"github.com/K0rdent/kcm/internal/controller/backup"
+	"github.com/K0rdent/kcm/internal/metrics"
 	pollerutil "github.com/K0rdent/kcm/internal/util/poller"
 	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
 )
@@ -43,6 +45,21 @@ type ManagementBackupReconciler struct {
 func (r *ManagementBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	l := ctrl.LoggerFrom(ctx)
 
+	mgmtBackup := new(kcmv1.ManagementBackup)
+	if err := r.Get(ctx, req.NamespacedName, mgmtBackup); err != nil {
+		if apierrors.IsNotFound(err) {
+			l.V(1).Info("ManagementBackup not found, deleting its metrics")
+			metrics.DeleteMetricsManagementBackup(req.Name)
+			return ctrl.Result{}, nil
+		}
+		l.Error(err, "unable to fetch ManagementBackup")
+		return ctrl.Result{}, err
+	}
+	if !mgmtBackup.DeletionTimestamp.IsZero() {
+		l.Info("ManagementBackup is being deleted, removing its scope labels")
+		return ctrl.Result{}, r.internal.Finalize(ctx, mgmtBackup)
+	}
+
 	management := new(kcmv1.Management)
 	if err := r.Get(ctx, client.ObjectKey{Name: kcmv1.ManagementName}, management); err != nil {
 		l.Error(err, "unable to fetch Management")
@@ -53,12 +70,6 @@ func (r *ManagementBackupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 
-	mgmtBackup := new(kcmv1.ManagementBackup)
-	if err := r.Get(ctx, req.NamespacedName, mgmtBackup); err != nil {
-		l.Error(err, "unable to fetch ManagementBackup")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
-	}
-
 	res, err := r.internal.ReconcileBackup(ctx, mgmtBackup)
 	if err != nil {
 		l.Error(err, "failed to reconcile managementbackups")
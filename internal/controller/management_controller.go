@@ -145,6 +145,18 @@ func (r *ManagementReconciler) update(ctx context.Context, management *kcmv1.Man
 		}
 	}
 
+	proceed, err := r.ensureReleaseUpgrade(ctx, management, release)
+	if err != nil {
+		r.warnf(management, "ReleaseUpgradePreflightError", "failed to run pre-flight checks of the Release upgrade: %v", err)
+		l.Error(err, "failed to run pre-flight checks of the Release upgrade")
+		return ctrl.Result{}, err
+	}
+	if !proceed {
+		const requeueAfter = 1 * time.Minute
+		l.Info("Pre-flight checks of the Release upgrade have failed, will retry", "current_release", management.Status.Release, "new_release", management.Spec.Release, "requeue_after", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	// Cleanup only management components (without `k0rdent.mirantis.com/region` label)
 	labelSelector := &metav1.LabelSelector{
 		MatchExpressions: []metav1.LabelSelectorRequirement{
@@ -198,6 +210,15 @@ func (r *ManagementReconciler) update(ctx context.Context, management *kcmv1.Man
 	}
 	management.Status.ObservedGeneration = management.Generation
 
+	upgradeRequeue, upgradeErr := r.rolloutReleaseUpgrade(ctx, management)
+	if upgradeErr != nil {
+		l.Error(upgradeErr, "failed to roll out the Release upgrade to the regions")
+		errs = errors.Join(errs, upgradeErr)
+	}
+	if upgradeRequeue {
+		requeue = true
+	}
+
 	driftRequeue, driftErr := r.ensureDriftDetectionManager(ctx, management)
 	if driftErr != nil {
 		l.Error(driftErr, "failed to ensure drift-detection-manager is deployed")
@@ -0,0 +1,460 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	fluxconditions "github.com/fluxcd/pkg/runtime/conditions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	validationutil "github.com/K0rdent/kcm/internal/util/validation"
+)
+
+// ensureReleaseUpgrade starts the upgrade to the new Release once it is requested and runs its pre-flight checks.
+// The unfinished upgrade is dropped once the Release is reverted to the current one.
+// Returns false if the components must not be upgraded yet.
+func (r *ManagementReconciler) ensureReleaseUpgrade(ctx context.Context, mgmt *kcmv1.Management, release *kcmv1.Release) (proceed bool, _ error) {
+	if mgmt.Status.Release == "" {
+		return true, nil
+	}
+
+	if mgmt.Spec.Release == mgmt.Status.Release {
+		if upgrade := mgmt.Status.Upgrade; upgrade != nil && upgrade.To != mgmt.Spec.Release {
+			mgmt.Status.Upgrade = nil
+			r.eventf(mgmt, "ReleaseUpgradeCancelled", "Cancelled the upgrade from the Release %s to %s since the Release has been reverted", upgrade.From, upgrade.To)
+		}
+		return true, nil
+	}
+
+	upgrade := mgmt.Status.Upgrade
+	if upgrade == nil || upgrade.To != mgmt.Spec.Release {
+		now := metav1.Now()
+		upgrade = &kcmv1.ReleaseUpgradeStatus{
+			StartTime: &now,
+			From:      mgmt.Status.Release,
+			To:        mgmt.Spec.Release,
+			Phase:     kcmv1.ReleaseUpgradePhasePreflight,
+		}
+		mgmt.Status.Upgrade = upgrade
+		r.eventf(mgmt, "ReleaseUpgradeStarted", "Started the upgrade from the Release %s to %s", upgrade.From, upgrade.To)
+	}
+
+	if upgrade.Phase != kcmv1.ReleaseUpgradePhasePreflight && upgrade.Phase != kcmv1.ReleaseUpgradePhasePreflightFailed {
+		return true, nil
+	}
+
+	failures, impact, err := releaseUpgradePreflight(ctx, r.Client, mgmt, release)
+	if err != nil {
+		return false, fmt.Errorf("failed to run pre-flight checks of the upgrade to the Release %s: %w", upgrade.To, err)
+	}
+
+	if len(failures) > 0 {
+		msg := strings.Join(failures, "; ")
+		if upgrade.Phase != kcmv1.ReleaseUpgradePhasePreflightFailed || upgrade.Message != msg {
+			r.warnf(mgmt, "ReleaseUpgradePreflightFailed", "Pre-flight checks of the upgrade to the Release %s have failed: %s", upgrade.To, msg)
+		}
+		upgrade.Phase = kcmv1.ReleaseUpgradePhasePreflightFailed
+		upgrade.Message = msg
+		meta.SetStatusCondition(&mgmt.Status.Conditions, metav1.Condition{
+			Type:               kcmv1.ReadyCondition,
+			ObservedGeneration: mgmt.Generation,
+			Status:             metav1.ConditionFalse,
+			Reason:             kcmv1.ReleaseUpgradePreflightFailedReason,
+			Message:            msg,
+		})
+		return false, r.updateStatus(ctx, mgmt)
+	}
+
+	upgrade.Phase = kcmv1.ReleaseUpgradePhaseUpgradingManagement
+	upgrade.Message = ""
+	upgrade.Impact = impact
+	r.eventf(mgmt, "ReleaseUpgradePreflightSucceeded", "Pre-flight checks of the upgrade to the Release %s have succeeded, upgrading the management", upgrade.To)
+
+	return true, r.updateStatus(ctx, mgmt)
+}
+
+// releaseUpgradePreflight checks that the components of the management and of each of the regions
+// can be upgraded to the given Release and estimates the impact of the upgrade.
+// Returns the list of the failed checks, the impact is returned only if all of the checks have passed.
+func releaseUpgradePreflight(ctx context.Context, cl client.Client, mgmt *kcmv1.Management, release *kcmv1.Release) (failures []string, impact []kcmv1.ReleaseUpgradeImpact, _ error) {
+	if !release.Status.Ready {
+		return []string{fmt.Sprintf("Release %s is not ready", release.Name)}, nil, nil
+	}
+
+	// the previous Release might have already been removed, then all of the components are considered upgraded
+	oldRelease := new(kcmv1.Release)
+	if err := cl.Get(ctx, client.ObjectKey{Name: mgmt.Status.Upgrade.From}, oldRelease); client.IgnoreNotFound(err) != nil {
+		return nil, nil, fmt.Errorf("failed to get Release %s: %w", mgmt.Status.Upgrade.From, err)
+	}
+
+	regions := new(kcmv1.RegionList)
+	if err := cl.List(ctx, regions); err != nil {
+		return nil, nil, fmt.Errorf("failed to list Regions: %w", err)
+	}
+
+	mgmt.SetGroupVersionKind(kcmv1.GroupVersion.WithKind(kcmv1.ManagementKind))
+	managers := []validationutil.ComponentsManager{mgmt}
+	for i := range regions.Items {
+		if !regions.Items[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		regions.Items[i].SetGroupVersionKind(kcmv1.GroupVersion.WithKind(kcmv1.RegionKind))
+		managers = append(managers, &regions.Items[i])
+	}
+
+	for _, obj := range managers {
+		objFailures, err := checkUpgradeTemplates(ctx, cl, release, obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, failure := range objFailures {
+			failures = append(failures, fmt.Sprintf("%s %s: %s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), failure))
+		}
+	}
+	if len(failures) > 0 {
+		return failures, nil, nil
+	}
+
+	cds := new(kcmv1.ClusterDeploymentList)
+	if err := cl.List(ctx, cds); err != nil {
+		return nil, nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	for _, obj := range managers {
+		objImpact, err := estimateUpgradeImpact(ctx, cl, oldRelease, release, obj, cds.Items)
+		if err != nil {
+			return nil, nil, err
+		}
+		impact = append(impact, objImpact)
+	}
+
+	return nil, impact, nil
+}
+
+// checkUpgradeTemplates checks that all of the ProviderTemplates the components of the given object
+// are to be deployed with exist and are valid, and that their CAPI contracts are compatible with
+// the existing ClusterDeployments. Returns the list of the failed checks.
+func checkUpgradeTemplates(ctx context.Context, cl client.Client, release *kcmv1.Release, obj validationutil.ComponentsManager) ([]string, error) {
+	templates := validationutil.ComponentsTemplates(release, obj)
+	components := make([]string, 0, len(templates))
+	for component := range templates {
+		components = append(components, component)
+	}
+	slices.Sort(components)
+
+	var failures []string
+	for _, component := range components {
+		name := templates[component]
+		if name == "" {
+			failures = append(failures, fmt.Sprintf("no ProviderTemplate for the component %s in the Release %s", component, release.Name))
+			continue
+		}
+
+		template := new(kcmv1.ProviderTemplate)
+		if err := cl.Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+			if apierrors.IsNotFound(err) {
+				failures = append(failures, fmt.Sprintf("ProviderTemplate %s of the component %s is not found", name, component))
+				continue
+			}
+			return nil, fmt.Errorf("failed to get ProviderTemplate %s: %w", name, err)
+		}
+
+		if !template.Status.Valid {
+			msg := fmt.Sprintf("ProviderTemplate %s of the component %s is not valid", name, component)
+			if template.Status.ValidationError != "" {
+				msg += ": " + template.Status.ValidationError
+			}
+			failures = append(failures, msg)
+		}
+	}
+	if len(failures) > 0 {
+		return failures, nil
+	}
+
+	incompatibleContracts, err := validationutil.ValidateProviderContracts(ctx, cl, release, obj)
+	if err != nil {
+		if errors.Is(err, validationutil.ErrProviderIsNotReady) || apierrors.IsNotFound(err) {
+			return []string{err.Error()}, nil
+		}
+		return nil, fmt.Errorf("failed to get incompatible contracts: %w", err)
+	}
+	if incompatibleContracts != "" {
+		failures = append(failures, incompatibleContracts)
+	}
+
+	return failures, nil
+}
+
+// estimateUpgradeImpact returns the providers of the given object restarted by the upgrade from
+// the old to the new Release and the ClusterDeployments managed by them.
+func estimateUpgradeImpact(ctx context.Context, cl client.Client, oldRelease, newRelease *kcmv1.Release, obj validationutil.ComponentsManager, cds []kcmv1.ClusterDeployment) (kcmv1.ReleaseUpgradeImpact, error) {
+	var impact kcmv1.ReleaseUpgradeImpact
+	if obj.GetObjectKind().GroupVersionKind().Kind == kcmv1.RegionKind {
+		impact.Region = obj.GetName()
+	}
+
+	oldTemplates := validationutil.ComponentsTemplates(oldRelease, obj)
+	newTemplates := validationutil.ComponentsTemplates(newRelease, obj)
+
+	var capiUpgraded bool
+	for component, name := range newTemplates {
+		if oldTemplates[component] == name {
+			continue
+		}
+		if component == kcmv1.CoreCAPIName {
+			capiUpgraded = true
+		}
+
+		for _, tplName := range []string{oldTemplates[component], name} {
+			if tplName == "" {
+				continue
+			}
+			template := new(kcmv1.ProviderTemplate)
+			if err := cl.Get(ctx, client.ObjectKey{Name: tplName}, template); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return impact, fmt.Errorf("failed to get ProviderTemplate %s: %w", tplName, err)
+			}
+			impact.Providers = append(impact.Providers, template.Status.Providers...)
+		}
+	}
+	slices.Sort(impact.Providers)
+	impact.Providers = slices.Compact(impact.Providers)
+
+	if !capiUpgraded && len(impact.Providers) == 0 {
+		return impact, nil
+	}
+
+	clusterTemplates := make(map[client.ObjectKey]*kcmv1.ClusterTemplate)
+	for _, cd := range cds {
+		if cd.Status.Region != impact.Region {
+			continue
+		}
+
+		affected := capiUpgraded
+		if !affected {
+			key := client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Template}
+			template, ok := clusterTemplates[key]
+			if !ok {
+				template = new(kcmv1.ClusterTemplate)
+				if err := cl.Get(ctx, key, template); client.IgnoreNotFound(err) != nil {
+					return impact, fmt.Errorf("failed to get ClusterTemplate %s: %w", key, err)
+				}
+				clusterTemplates[key] = template
+			}
+			affected = slices.ContainsFunc(template.Status.Providers, func(provider string) bool {
+				_, found := slices.BinarySearch(impact.Providers, provider)
+				return found
+			})
+		}
+
+		if affected {
+			impact.ClusterDeployments = append(impact.ClusterDeployments, client.ObjectKeyFromObject(&cd).String())
+		}
+	}
+	slices.Sort(impact.ClusterDeployments)
+
+	return impact, nil
+}
+
+// rolloutReleaseUpgrade upgrades the regions one at a time once the management has been upgraded
+// and halts the upgrade if any of the regions fails to be upgraded.
+// Returns true if the upgrade is still in progress.
+func (r *ManagementReconciler) rolloutReleaseUpgrade(ctx context.Context, mgmt *kcmv1.Management) (requeue bool, _ error) {
+	upgrade := mgmt.Status.Upgrade
+	if upgrade == nil || upgrade.To != mgmt.Spec.Release {
+		return false, nil
+	}
+
+	switch upgrade.Phase {
+	case kcmv1.ReleaseUpgradePhasePreflight, kcmv1.ReleaseUpgradePhasePreflightFailed, kcmv1.ReleaseUpgradePhaseCompleted:
+		return false, nil
+	case kcmv1.ReleaseUpgradePhaseUpgradingManagement:
+		if mgmt.Status.Release != upgrade.To || !allComponentsHealthy(mgmt.Status.Components) {
+			return true, nil
+		}
+
+		regions := new(kcmv1.RegionList)
+		if err := r.Client.List(ctx, regions); err != nil {
+			return true, fmt.Errorf("failed to list Regions: %w", err)
+		}
+		slices.SortFunc(regions.Items, func(a, b kcmv1.Region) int { return strings.Compare(a.Name, b.Name) })
+
+		now := metav1.Now()
+		upgrade.Regions = make([]kcmv1.RegionUpgradeStatus, 0, len(regions.Items))
+		for _, rgn := range regions.Items {
+			upgrade.Regions = append(upgrade.Regions, kcmv1.RegionUpgradeStatus{
+				LastTransitionTime: &now,
+				Name:               rgn.Name,
+				Phase:              kcmv1.RegionUpgradePhasePending,
+			})
+		}
+		upgrade.Phase = kcmv1.ReleaseUpgradePhaseUpgradingRegions
+		r.eventf(mgmt, "ManagementUpgraded", "Management has been upgraded to the Release %s, upgrading %d regions", upgrade.To, len(upgrade.Regions))
+	}
+
+	for i := range upgrade.Regions {
+		rgn := &upgrade.Regions[i]
+		if rgn.Phase == kcmv1.RegionUpgradePhaseCompleted {
+			continue
+		}
+
+		if rgn.Phase == kcmv1.RegionUpgradePhasePending {
+			setRegionUpgradePhase(rgn, kcmv1.RegionUpgradePhaseUpgrading, "")
+			r.eventf(mgmt, "RegionUpgradeStarted", "Upgrading the Region %s to the Release %s", rgn.Name, upgrade.To)
+			return true, nil
+		}
+
+		phase, msg, err := r.regionUpgradeState(ctx, rgn.Name, upgrade.To)
+		if err != nil {
+			return true, err
+		}
+
+		switch phase {
+		case kcmv1.RegionUpgradePhaseFailed:
+			if rgn.Phase != kcmv1.RegionUpgradePhaseFailed {
+				r.warnf(mgmt, "RegionUpgradeFailed", "Upgrade of the Region %s to the Release %s has failed, halting the upgrade: %s", rgn.Name, upgrade.To, msg)
+			}
+			setRegionUpgradePhase(rgn, phase, msg)
+			upgrade.Phase = kcmv1.ReleaseUpgradePhaseHalted
+			return true, nil
+		case kcmv1.RegionUpgradePhaseCompleted:
+			setRegionUpgradePhase(rgn, phase, msg)
+			upgrade.Phase = kcmv1.ReleaseUpgradePhaseUpgradingRegions
+			r.eventf(mgmt, "RegionUpgraded", "Region %s has been upgraded to the Release %s", rgn.Name, upgrade.To)
+		default:
+			setRegionUpgradePhase(rgn, phase, msg)
+			upgrade.Phase = kcmv1.ReleaseUpgradePhaseUpgradingRegions
+			return true, nil
+		}
+	}
+
+	now := metav1.Now()
+	upgrade.CompletionTime = &now
+	upgrade.Phase = kcmv1.ReleaseUpgradePhaseCompleted
+	r.eventf(mgmt, "ReleaseUpgradeCompleted", "Management and all of the regions have been upgraded to the Release %s", upgrade.To)
+
+	return false, nil
+}
+
+// regionUpgradeState returns the phase of the upgrade of the given Region to the given Release.
+// The upgrade is completed once the regional components are deployed with the Release and ready,
+// and failed if either the regional cluster is unreachable or any of the regional HelmReleases
+// is stalled after it has observed the chart of the Release.
+func (r *ManagementReconciler) regionUpgradeState(ctx context.Context, name, release string) (kcmv1.RegionUpgradePhase, string, error) {
+	region := new(kcmv1.Region)
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, region); err != nil {
+		if apierrors.IsNotFound(err) {
+			return kcmv1.RegionUpgradePhaseCompleted, "Region has been removed", nil
+		}
+		return kcmv1.RegionUpgradePhaseUpgrading, "", fmt.Errorf("failed to get Region %s: %w", name, err)
+	}
+
+	if region.IsAutoPaused() {
+		return kcmv1.RegionUpgradePhaseFailed, "Region is paused since the regional cluster is unreachable", nil
+	}
+
+	hrs := new(helmcontrollerv2.HelmReleaseList)
+	if err := r.Client.List(ctx, hrs, client.InNamespace(r.SystemNamespace), client.MatchingLabels{kcmv1.KCMRegionLabelKey: name}); err != nil {
+		return kcmv1.RegionUpgradePhaseUpgrading, "", fmt.Errorf("failed to list HelmReleases of the Region %s: %w", name, err)
+	}
+	var charts map[client.ObjectKey]struct{}
+	for _, hr := range hrs.Items {
+		if hr.Status.ObservedGeneration != hr.Generation || !fluxconditions.IsStalled(&hr) || hr.Spec.ChartRef == nil {
+			continue
+		}
+
+		if charts == nil {
+			var err error
+			if charts, err = r.releaseCharts(ctx, release); err != nil {
+				return kcmv1.RegionUpgradePhaseUpgrading, "", err
+			}
+		}
+		// the HelmRelease stalled before the upgrade has not observed the Release yet
+		if _, ok := charts[client.ObjectKey{Namespace: hr.Spec.ChartRef.Namespace, Name: hr.Spec.ChartRef.Name}]; !ok {
+			continue
+		}
+
+		return kcmv1.RegionUpgradePhaseFailed, fmt.Sprintf("HelmRelease %s is stalled: %s", hr.Name, fluxconditions.GetMessage(&hr, fluxmeta.StalledCondition)), nil
+	}
+
+	if region.Status.Release != release || region.Status.ObservedGeneration != region.Generation {
+		return kcmv1.RegionUpgradePhaseUpgrading, "Waiting for the regional components to be upgraded", nil
+	}
+
+	if cond := meta.FindStatusCondition(region.Status.Conditions, kcmv1.ReadyCondition); cond == nil || cond.Status != metav1.ConditionTrue {
+		msg := "Waiting for the regional components to be ready"
+		if cond != nil && cond.Message != "" {
+			msg = cond.Message
+		}
+		return kcmv1.RegionUpgradePhaseUpgrading, msg, nil
+	}
+
+	return kcmv1.RegionUpgradePhaseCompleted, "", nil
+}
+
+// releaseCharts returns the charts of the templates of the given Release.
+func (r *ManagementReconciler) releaseCharts(ctx context.Context, name string) (map[client.ObjectKey]struct{}, error) {
+	release := new(kcmv1.Release)
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, release); err != nil {
+		return nil, fmt.Errorf("failed to get Release %s: %w", name, err)
+	}
+
+	charts := make(map[client.ObjectKey]struct{})
+	for _, name := range release.Templates() {
+		template := new(kcmv1.ProviderTemplate)
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get ProviderTemplate %s: %w", name, err)
+		}
+		if ref := template.Status.ChartRef; ref != nil {
+			charts[client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}] = struct{}{}
+		}
+	}
+
+	return charts, nil
+}
+
+func setRegionUpgradePhase(rgn *kcmv1.RegionUpgradeStatus, phase kcmv1.RegionUpgradePhase, msg string) {
+	if rgn.Phase != phase {
+		now := metav1.Now()
+		rgn.LastTransitionTime = &now
+	}
+	rgn.Phase = phase
+	rgn.Message = msg
+}
+
+func allComponentsHealthy(components map[string]kcmv1.ComponentStatus) bool {
+	for _, comp := range components {
+		if !comp.Success {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	fluxconditions "github.com/fluxcd/pkg/runtime/conditions"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/test/objects/release"
+	"github.com/K0rdent/kcm/test/objects/template"
+	testscheme "github.com/K0rdent/kcm/test/scheme"
+)
+
+func Test_releaseUpgradePreflight(t *testing.T) {
+	t.Parallel()
+
+	const namespace = "default"
+
+	valid := kcmv1.TemplateValidationStatus{Valid: true}
+	newCD := func(name, clusterTemplate, region string) *kcmv1.ClusterDeployment {
+		cd := &kcmv1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       kcmv1.ClusterDeploymentSpec{Template: clusterTemplate},
+		}
+		cd.Status.Region = region
+		return cd
+	}
+
+	tests := map[string]struct {
+		releaseNotReady  bool
+		awsValid         bool
+		expectedFailures []string
+		expectedImpact   []kcmv1.ReleaseUpgradeImpact
+	}{
+		"release is not ready": {
+			releaseNotReady:  true,
+			awsValid:         true,
+			expectedFailures: []string{"Release new is not ready"},
+		},
+		"invalid templates": {
+			expectedFailures: []string{
+				"Management kcm: ProviderTemplate aws-2 of the component aws is not valid",
+				"Region rgn: ProviderTemplate aws-2 of the component aws is not valid",
+			},
+		},
+		"impact is estimated": {
+			awsValid: true,
+			expectedImpact: []kcmv1.ReleaseUpgradeImpact{
+				{Providers: []string{"infrastructure-aws"}, ClusterDeployments: []string{"default/aws"}},
+				{Region: "rgn", Providers: []string{"infrastructure-aws"}, ClusterDeployments: []string{"default/regional-aws"}},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			oldRelease := release.New(
+				release.WithName("old"),
+				release.WithKCMTemplateName("kcm-1"),
+				release.WithRegionalTemplateName("kcm-regional-1"),
+				release.WithCAPITemplateName("capi"),
+				release.WithProviders(kcmv1.NamedProviderTemplate{Name: "aws", CoreProviderTemplate: kcmv1.CoreProviderTemplate{Template: "aws-1"}}),
+			)
+			newRelease := release.New(
+				release.WithName("new"),
+				release.WithKCMTemplateName("kcm-2"),
+				release.WithRegionalTemplateName("kcm-regional-2"),
+				release.WithCAPITemplateName("capi"),
+				release.WithProviders(kcmv1.NamedProviderTemplate{Name: "aws", CoreProviderTemplate: kcmv1.CoreProviderTemplate{Template: "aws-2"}}),
+				release.WithReadyStatus(!tc.releaseNotReady),
+			)
+
+			providers := []kcmv1.Provider{{Name: "aws"}}
+			mgmt := &kcmv1.Management{
+				ObjectMeta: metav1.ObjectMeta{Name: kcmv1.ManagementName},
+				Spec: kcmv1.ManagementSpec{
+					Release:              newRelease.Name,
+					ComponentsCommonSpec: kcmv1.ComponentsCommonSpec{Providers: providers},
+				},
+				Status: kcmv1.ManagementStatus{
+					Release: "old",
+					Upgrade: &kcmv1.ReleaseUpgradeStatus{From: oldRelease.Name, To: newRelease.Name, Phase: kcmv1.ReleaseUpgradePhasePreflight},
+				},
+			}
+			region := &kcmv1.Region{
+				ObjectMeta: metav1.ObjectMeta{Name: "rgn"},
+				Spec:       kcmv1.RegionSpec{ComponentsCommonSpec: kcmv1.ComponentsCommonSpec{Providers: providers}},
+			}
+
+			cl := fake.NewClientBuilder().
+				WithScheme(testscheme.Scheme).
+				WithObjects(
+					mgmt, region, oldRelease, newRelease,
+					template.NewProviderTemplate(template.WithName("kcm-1"), template.WithValidationStatus(valid)),
+					template.NewProviderTemplate(template.WithName("kcm-2"), template.WithValidationStatus(valid)),
+					template.NewProviderTemplate(template.WithName("kcm-regional-1"), template.WithValidationStatus(valid)),
+					template.NewProviderTemplate(template.WithName("kcm-regional-2"), template.WithValidationStatus(valid)),
+					template.NewProviderTemplate(template.WithName("capi"), template.WithValidationStatus(valid), template.WithProvidersStatus("cluster-api")),
+					template.NewProviderTemplate(template.WithName("aws-1"), template.WithValidationStatus(valid), template.WithProvidersStatus("infrastructure-aws")),
+					template.NewProviderTemplate(template.WithName("aws-2"),
+						template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: tc.awsValid}), template.WithProvidersStatus("infrastructure-aws")),
+					template.NewClusterTemplate(template.WithName("aws-cluster"), template.WithNamespace(namespace), template.WithProvidersStatus("infrastructure-aws")),
+					template.NewClusterTemplate(template.WithName("azure-cluster"), template.WithNamespace(namespace), template.WithProvidersStatus("infrastructure-azure")),
+					newCD("aws", "aws-cluster", ""), newCD("azure", "azure-cluster", ""), newCD("regional-aws", "aws-cluster", region.Name),
+				).
+				Build()
+
+			failures, impact, err := releaseUpgradePreflight(t.Context(), cl, mgmt, newRelease)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFailures, failures)
+			require.Equal(t, tc.expectedImpact, impact)
+		})
+	}
+}
+
+func Test_rolloutReleaseUpgrade(t *testing.T) {
+	t.Parallel()
+
+	const systemNamespace = "kcm-system"
+
+	mgmt := &kcmv1.Management{
+		ObjectMeta: metav1.ObjectMeta{Name: kcmv1.ManagementName},
+		Spec:       kcmv1.ManagementSpec{Release: "new"},
+		Status: kcmv1.ManagementStatus{
+			Release:                "new",
+			ComponentsCommonStatus: kcmv1.ComponentsCommonStatus{Components: map[string]kcmv1.ComponentStatus{kcmv1.CoreKCMName: {Success: true}}},
+			Upgrade:                &kcmv1.ReleaseUpgradeStatus{From: "old", To: "new", Phase: kcmv1.ReleaseUpgradePhaseUpgradingManagement},
+		},
+	}
+	regionA := &kcmv1.Region{ObjectMeta: metav1.ObjectMeta{Name: "a"}, Status: kcmv1.RegionStatus{Release: "old"}}
+	regionB := &kcmv1.Region{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Status: kcmv1.RegionStatus{Release: "old"}}
+	oldChart := &helmcontrollerv2.CrossNamespaceSourceReference{Kind: sourcev1.HelmChartKind, Namespace: systemNamespace, Name: "aws-1"}
+	newChart := &helmcontrollerv2.CrossNamespaceSourceReference{Kind: sourcev1.HelmChartKind, Namespace: systemNamespace, Name: "aws-2"}
+	hrB := &helmcontrollerv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "b-aws",
+			Namespace: systemNamespace,
+			Labels:    map[string]string{kcmv1.KCMRegionLabelKey: regionB.Name},
+		},
+		Spec: helmcontrollerv2.HelmReleaseSpec{
+			ChartRef: oldChart,
+		},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(testscheme.Scheme).
+		WithObjects(
+			regionB, regionA, hrB,
+			release.New(release.WithName("new"), release.WithProviders(kcmv1.NamedProviderTemplate{Name: "aws", CoreProviderTemplate: kcmv1.CoreProviderTemplate{Template: "aws-2"}})),
+			template.NewProviderTemplate(template.WithName("aws-1"), template.WithStatusChartRef(oldChart)),
+			template.NewProviderTemplate(template.WithName("aws-2"), template.WithStatusChartRef(newChart)),
+		).
+		Build()
+	r := &ManagementReconciler{Client: cl, SystemNamespace: systemNamespace}
+
+	rollout := func(expectedRequeue bool, expectedPhase kcmv1.ReleaseUpgradePhase, expectedRegions ...kcmv1.RegionUpgradePhase) {
+		t.Helper()
+
+		requeue, err := r.rolloutReleaseUpgrade(t.Context(), mgmt)
+		require.NoError(t, err)
+		require.Equal(t, expectedRequeue, requeue)
+		require.Equal(t, expectedPhase, mgmt.Status.Upgrade.Phase)
+
+		phases := make([]kcmv1.RegionUpgradePhase, 0, len(mgmt.Status.Upgrade.Regions))
+		for _, rgn := range mgmt.Status.Upgrade.Regions {
+			phases = append(phases, rgn.Phase)
+		}
+		require.Equal(t, expectedRegions, phases)
+	}
+	upgradeRegion := func(region *kcmv1.Region) {
+		t.Helper()
+
+		require.NoError(t, cl.Get(t.Context(), client.ObjectKeyFromObject(region), region))
+		region.Status.Release = "new"
+		apimeta.SetStatusCondition(&region.Status.Conditions, metav1.Condition{Type: kcmv1.ReadyCondition, Status: metav1.ConditionTrue, Reason: kcmv1.AllComponentsHealthyReason})
+		require.NoError(t, cl.Update(t.Context(), region))
+	}
+
+	// the first region in the order of names is being upgraded once the management is upgraded
+	rollout(true, kcmv1.ReleaseUpgradePhaseUpgradingRegions, kcmv1.RegionUpgradePhaseUpgrading, kcmv1.RegionUpgradePhasePending)
+	require.Equal(t, "a", mgmt.Status.Upgrade.Regions[0].Name)
+	require.Equal(t, "old", mgmt.RegionRelease(regionB))
+
+	// the region is being upgraded until it observes the new release and is ready
+	rollout(true, kcmv1.ReleaseUpgradePhaseUpgradingRegions, kcmv1.RegionUpgradePhaseUpgrading, kcmv1.RegionUpgradePhasePending)
+
+	upgradeRegion(regionA)
+	rollout(true, kcmv1.ReleaseUpgradePhaseUpgradingRegions, kcmv1.RegionUpgradePhaseCompleted, kcmv1.RegionUpgradePhaseUpgrading)
+	require.Equal(t, "new", mgmt.RegionRelease(regionB))
+
+	// the HelmRelease stalled before the upgrade does not fail the region
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKeyFromObject(hrB), hrB))
+	fluxconditions.MarkStalled(hrB, "RetriesExceeded", "install failed")
+	require.NoError(t, cl.Update(t.Context(), hrB))
+	rollout(true, kcmv1.ReleaseUpgradePhaseUpgradingRegions, kcmv1.RegionUpgradePhaseCompleted, kcmv1.RegionUpgradePhaseUpgrading)
+
+	// the upgrade halts on the failure of the region
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKeyFromObject(hrB), hrB))
+	hrB.Spec.ChartRef = newChart
+	fluxconditions.MarkStalled(hrB, "RetriesExceeded", "upgrade failed")
+	require.NoError(t, cl.Update(t.Context(), hrB))
+	rollout(true, kcmv1.ReleaseUpgradePhaseHalted, kcmv1.RegionUpgradePhaseCompleted, kcmv1.RegionUpgradePhaseFailed)
+	require.Contains(t, mgmt.Status.Upgrade.Regions[1].Message, "upgrade failed")
+
+	// and resumes once the region recovers
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKeyFromObject(hrB), hrB))
+	fluxconditions.Delete(hrB, fluxmeta.StalledCondition)
+	require.NoError(t, cl.Update(t.Context(), hrB))
+	upgradeRegion(regionB)
+	rollout(false, kcmv1.ReleaseUpgradePhaseCompleted, kcmv1.RegionUpgradePhaseCompleted, kcmv1.RegionUpgradePhaseCompleted)
+	require.NotNil(t, mgmt.Status.Upgrade.CompletionTime)
+}
+
+func Test_ensureReleaseUpgrade_reverted(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		upgrade         *kcmv1.ReleaseUpgradeStatus
+		expectedUpgrade *kcmv1.ReleaseUpgradeStatus
+	}{
+		"unfinished upgrade is cancelled": {
+			upgrade: &kcmv1.ReleaseUpgradeStatus{From: "old", To: "new", Phase: kcmv1.ReleaseUpgradePhasePreflightFailed},
+		},
+		"completed upgrade is kept": {
+			upgrade:         &kcmv1.ReleaseUpgradeStatus{From: "older", To: "old", Phase: kcmv1.ReleaseUpgradePhaseCompleted},
+			expectedUpgrade: &kcmv1.ReleaseUpgradeStatus{From: "older", To: "old", Phase: kcmv1.ReleaseUpgradePhaseCompleted},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mgmt := &kcmv1.Management{
+				ObjectMeta: metav1.ObjectMeta{Name: kcmv1.ManagementName},
+				Spec:       kcmv1.ManagementSpec{Release: "old"},
+				Status:     kcmv1.ManagementStatus{Release: "old", Upgrade: tc.upgrade},
+			}
+			r := &ManagementReconciler{Client: fake.NewClientBuilder().WithScheme(testscheme.Scheme).Build()}
+
+			proceed, err := r.ensureReleaseUpgrade(t.Context(), mgmt, release.New(release.WithName("old")))
+			require.NoError(t, err)
+			require.True(t, proceed)
+			require.Equal(t, tc.expectedUpgrade, mgmt.Status.Upgrade)
+		})
+	}
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/K0rdent/kcm/internal/record"
 	"github.com/K0rdent/kcm/internal/serviceset"
 	conditionsutil "github.com/K0rdent/kcm/internal/util/conditions"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
 	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
 	labelsutil "github.com/K0rdent/kcm/internal/util/labels"
 	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
@@ -80,7 +81,8 @@ func (r *MultiClusterServiceReconciler) Reconcile(ctx context.Context, req ctrl.
 		// otherwise we'll miss if some ClusterDeployment will be updated
 		// with matching labels.
 		requeue, e := r.updateStatus(ctx, clone, mcs)
-		if requeue {
+		// the sooner requeue requested by the reconciliation, e.g. to proceed with the rollout, is kept
+		if requeue && (result.RequeueAfter == 0 || (r.defaultRequeueTime > 0 && r.defaultRequeueTime < result.RequeueAfter)) {
 			result = ctrl.Result{RequeueAfter: r.defaultRequeueTime}
 		}
 		err = errors.Join(err, e)
@@ -135,6 +137,16 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 	}
 	r.setCondition(mcs, kcmv1.ServicesReferencesValidationCondition, nil)
 
+	templates, err := deprecationutil.Resolve(ctx, r.Client, r.SystemNamespace, "", serviceTemplateNames(mcs.Spec.ServiceSpec.Services))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get deprecation of the service templates: %w", err)
+	}
+	now := r.timeFunc()
+	if cond, changed := setTemplateDeprecatedCondition(&mcs.Status.Conditions, templates, now, mcs.Generation); changed {
+		record.Warnf(mcs, nil, "Template"+cond.Reason, "ValidateServiceTemplates", cond.Message)
+	}
+	trackTemplatesUsage(ctx, kcmv1.MultiClusterServiceKind, mcs.ObjectMeta, templates, now, kcmv1.ServiceTemplateKind)
+
 	l.Info("Validating service dependencies")
 	if err := validationutil.ValidateServiceDependencyOverall(mcs.Spec.ServiceSpec.Services); err != nil {
 		if r.setCondition(mcs, kcmv1.ServicesDependencyValidationCondition, err) {
@@ -168,7 +180,10 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 		return ctrl.Result{}, fmt.Errorf("failed to convert ClusterSelector to selector: %w", err)
 	}
 
-	var errs error
+	var (
+		errs                error
+		rolloutRequeueAfter time.Duration
+	)
 	// totalMatchingClusters tracks how many clusters we expect ServiceSets to be deployed to.
 	// Sourcing the total from the matching ClusterDeployments (plus selfManagement) - rather
 	// than from the existing ServiceSets - ensures that clusters whose ServiceSet failed to be
@@ -176,30 +191,46 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 	// counted in the denominator of the ClusterInReadyState condition.
 	totalMatchingClusters := 0
 
-	// if selfManagement flag is set, then we'll need to create serviceSet which does not refer
-	// any clusterDeployment, but also has selfManagement flag set to true.
-	if mcs.Spec.ServiceSpec.Provider.SelfManagement {
-		l.V(1).Info("Ensuring ServiceSet for the management cluster")
-		errs = r.createOrUpdateServiceSet(ctx, mcs, nil)
-		totalMatchingClusters++
-	}
-
 	clusters := new(kcmv1.ClusterDeploymentList)
 	if !selector.Empty() {
 		if err := r.Client.List(ctx, clusters, client.MatchingLabelsSelector{Selector: selector}); err != nil {
-			return ctrl.Result{}, errors.Join(errs, fmt.Errorf("failed to list ClusterDeployments: %w", err))
+			return ctrl.Result{}, fmt.Errorf("failed to list ClusterDeployments: %w", err)
 		}
 	}
 
 	l.V(1).Info("Matching ClusterDeployments found", "count", len(clusters.Items))
+	matchingClusters := make([]*kcmv1.ClusterDeployment, 0, len(clusters.Items))
 	matchingClusterKeys := make(map[client.ObjectKey]struct{}, len(clusters.Items))
-	for _, cluster := range clusters.Items {
-		if !cluster.DeletionTimestamp.IsZero() {
+	for i := range clusters.Items {
+		if !clusters.Items[i].DeletionTimestamp.IsZero() {
 			continue
 		}
+		matchingClusters = append(matchingClusters, &clusters.Items[i])
+		matchingClusterKeys[client.ObjectKeyFromObject(&clusters.Items[i])] = struct{}{}
+	}
+	totalMatchingClusters = len(matchingClusters)
+	if mcs.Spec.ServiceSpec.Provider.SelfManagement {
 		totalMatchingClusters++
-		matchingClusterKeys[client.ObjectKeyFromObject(&cluster)] = struct{}{}
-		errs = errors.Join(errs, r.createOrUpdateServiceSet(ctx, mcs, &cluster))
+	}
+
+	if mcs.Spec.RolloutStrategy != nil {
+		l.V(1).Info("Rolling out ServiceSets in waves")
+		rolloutRequeueAfter, errs = r.reconcileRollout(ctx, mcs, matchingClusters)
+	} else {
+		mcs.Status.Rollout = nil
+		setRolloutCondition(mcs)
+
+		// if selfManagement flag is set, then we'll need to create serviceSet which does not refer
+		// any clusterDeployment, but also has selfManagement flag set to true.
+		if mcs.Spec.ServiceSpec.Provider.SelfManagement {
+			l.V(1).Info("Ensuring ServiceSet for the management cluster")
+			_, errs = r.createOrUpdateServiceSet(ctx, mcs, nil)
+		}
+
+		for _, cluster := range matchingClusters {
+			_, err := r.createOrUpdateServiceSet(ctx, mcs, cluster)
+			errs = errors.Join(errs, err)
+		}
 	}
 
 	serviceSetList := new(kcmv1.ServiceSetList)
@@ -231,9 +262,15 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 	}
 
 	r.setClustersCondition(ctx, mcs, totalMatchingClusters, currentlyMatchingServiceSets)
-	if errs != nil {
+	requeueAfter, err := r.setMaintenanceWindowPendingCondition(ctx, mcs, matchingClusters, currentlyMatchingServiceSets)
+	if errs = errors.Join(errs, err); errs != nil {
 		return ctrl.Result{}, errs
 	}
+	for _, after := range []time.Duration{requeueAfter, rolloutRequeueAfter} {
+		if after > 0 && (result.RequeueAfter == 0 || after < result.RequeueAfter) {
+			result.RequeueAfter = after
+		}
+	}
 
 	var (
 		upgradePaths []kcmv1.ServiceUpgradePaths
@@ -489,6 +526,24 @@ func (r *MultiClusterServiceReconciler) SetupWithManager(mgr ctrl.Manager) error
 			}),
 		)
 
+	managedController.Watches(&kcmv1.ServiceTemplate{}, kubeutil.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) ([]ctrl.Request, error) {
+		if o.GetNamespace() != r.SystemNamespace {
+			return nil, nil
+		}
+
+		mcss := new(kcmv1.MultiClusterServiceList)
+		if err := mgr.GetClient().List(ctx, mcss, client.MatchingFields{kcmv1.MultiClusterServiceTemplatesIndexKey: o.GetName()}); err != nil {
+			return nil, fmt.Errorf("failed to list MultiClusterServices by ServiceTemplate %s: %w", o.GetName(), err)
+		}
+
+		resp := make([]ctrl.Request, 0, len(mcss.Items))
+		for _, v := range mcss.Items {
+			resp = append(resp, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&v)})
+		}
+
+		return resp, nil
+	}), builder.WithPredicates(templateDeprecationChangedPredicate))
+
 	if r.IsDisabledValidationWH {
 		managedController.Watches(&kcmv1.ServiceTemplate{}, kubeutil.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) ([]ctrl.Request, error) {
 			mcss := new(kcmv1.MultiClusterServiceList)
@@ -523,17 +578,23 @@ func (r *MultiClusterServiceReconciler) SetupWithManager(mgr ctrl.Manager) error
 	return managedController.Complete(r)
 }
 
-// createOrUpdateServiceSet creates or updates the ServiceSet for the given ClusterDeployment.
+// createOrUpdateServiceSet creates or updates the ServiceSet for the given ClusterDeployment
+// and returns the performed operation.
 func (r *MultiClusterServiceReconciler) createOrUpdateServiceSet(
 	ctx context.Context,
 	mcs *kcmv1.MultiClusterService,
 	cd *kcmv1.ClusterDeployment,
-) error {
+) (kcmv1.ServiceSetOperation, error) {
 	// We won't create or update the ServiceSet until all MultiClusterServices
 	// which this one depends on successfully deploy all of their services to
 	// the cluster represented by the provided ClusterDeployment.
 	if err := r.okToReconcileServiceSet(ctx, mcs, cd); err != nil {
-		return err
+		return kcmv1.ServiceSetOperationNone, err
+	}
+
+	window, err := r.maintenanceState(ctx, mcs, cd)
+	if err != nil {
+		return kcmv1.ServiceSetOperationNone, err
 	}
 
 	serviceSetObjectKey := serviceset.ObjectKey(r.SystemNamespace, cd, mcs)
@@ -542,17 +603,18 @@ func (r *MultiClusterServiceReconciler) createOrUpdateServiceSet(
 		MCS:             mcs,
 		CD:              cd,
 		SystemNamespace: r.SystemNamespace,
+		HoldUpgrades:    !window.Open,
 	}
 
 	serviceSet, op, err := serviceset.GetServiceSetWithOperation(ctx, r.Client, opRequisites)
 	if err != nil {
-		return fmt.Errorf("failed to get ServiceSet %s: %w", serviceSetObjectKey.String(), err)
+		return kcmv1.ServiceSetOperationNone, fmt.Errorf("failed to get ServiceSet %s: %w", serviceSetObjectKey.String(), err)
 	}
 	if op == kcmv1.ServiceSetOperationNone {
-		return nil
+		return op, nil
 	}
 
-	return serviceset.NewProcessor(r.Client).CreateOrUpdateServiceSet(ctx, op, serviceSet)
+	return op, serviceset.NewProcessor(r.Client).CreateOrUpdateServiceSet(ctx, op, serviceSet)
 }
 
 func (r *MultiClusterServiceReconciler) cleanupServiceSets(ctx context.Context, mcs *kcmv1.MultiClusterService) error {
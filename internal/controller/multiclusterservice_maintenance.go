@@ -0,0 +1,127 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/record"
+	"github.com/K0rdent/kcm/internal/serviceset"
+	maintenanceutil "github.com/K0rdent/kcm/internal/util/maintenance"
+)
+
+// maintenanceState returns the state of the maintenance window applicable to the services
+// of the given MultiClusterService deployed to the given cluster: the one of the MultiClusterService
+// if set, otherwise the one of the cluster. Nil cd stands for the management cluster.
+func (r *MultiClusterServiceReconciler) maintenanceState(ctx context.Context, mcs *kcmv1.MultiClusterService, cd *kcmv1.ClusterDeployment) (maintenanceutil.State, error) {
+	window := mcs.Spec.MaintenanceWindow
+	if window == nil && cd != nil {
+		var err error
+		window, err = maintenanceutil.ForClusterDeployment(ctx, r.Client, cd)
+		if errors.Is(err, maintenanceutil.ErrInvalidNamespaceWindow) {
+			// reported by the ClusterDeployment controller, the invalid window is ignored
+			ctrl.LoggerFrom(ctx).V(1).Info("Ignoring the maintenance window of the ClusterDeployment", "clusterDeployment", client.ObjectKeyFromObject(cd), "reason", err.Error())
+			window, err = nil, nil
+		}
+		if err != nil {
+			return maintenanceutil.State{}, fmt.Errorf("failed to get maintenance window of ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+		}
+	}
+
+	return maintenanceutil.Evaluate(window, r.timeFunc())
+}
+
+// pendingUpgrades returns the services of the given MultiClusterService whose upgrades
+// on the cluster of the given ServiceSet are held until the maintenance window opens.
+func (r *MultiClusterServiceReconciler) pendingUpgrades(ctx context.Context, mcs *kcmv1.MultiClusterService, cd *kcmv1.ClusterDeployment, serviceSet *kcmv1.ServiceSet) ([]string, maintenanceutil.State, error) {
+	state, err := r.maintenanceState(ctx, mcs, cd)
+	if err != nil || state.Open {
+		return nil, state, err
+	}
+	return serviceset.PendingUpgrades(mcs.Spec.ServiceSpec.Services, serviceSet), state, nil
+}
+
+// setMaintenanceWindowPendingCondition reports the clusters where the upgrades of the services are
+// held until the maintenance window opens, and returns the duration until the earliest of the windows opens.
+func (r *MultiClusterServiceReconciler) setMaintenanceWindowPendingCondition(ctx context.Context, mcs *kcmv1.MultiClusterService, cds []*kcmv1.ClusterDeployment, serviceSets []kcmv1.ServiceSet) (time.Duration, error) {
+	clusters := make(map[client.ObjectKey]*kcmv1.ClusterDeployment, len(cds))
+	for _, cd := range cds {
+		clusters[client.ObjectKeyFromObject(cd)] = cd
+	}
+
+	var (
+		pending     []string
+		nextOpening time.Time
+		errs        error
+	)
+	for i := range serviceSets {
+		serviceSet := &serviceSets[i]
+		if !serviceSet.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		target := "management"
+		var cd *kcmv1.ClusterDeployment
+		if serviceSet.Spec.Cluster != "" {
+			key := client.ObjectKey{Namespace: serviceSet.Namespace, Name: serviceSet.Spec.Cluster}
+			if cd = clusters[key]; cd == nil {
+				continue
+			}
+			target = key.String()
+		}
+
+		services, state, err := r.pendingUpgrades(ctx, mcs, cd, serviceSet)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if len(services) == 0 {
+			continue
+		}
+
+		pending = append(pending, target)
+		if nextOpening.IsZero() || state.NextOpening.Before(nextOpening) {
+			nextOpening = state.NextOpening
+		}
+	}
+
+	if len(pending) == 0 {
+		apimeta.RemoveStatusCondition(&mcs.Status.Conditions, kcmv1.MaintenanceWindowPendingCondition)
+		return 0, errs
+	}
+
+	msg := maintenanceutil.PendingMessage("Upgrade of services on clusters "+strings.Join(pending, ", "), maintenanceutil.State{NextOpening: nextOpening})
+	if apimeta.SetStatusCondition(&mcs.Status.Conditions, metav1.Condition{
+		Type:               kcmv1.MaintenanceWindowPendingCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             kcmv1.PendingMaintenanceWindowReason,
+		Message:            msg,
+		ObservedGeneration: mcs.Generation,
+	}) {
+		record.Eventf(mcs, nil, kcmv1.PendingMaintenanceWindowReason, "HoldServiceUpgrades", msg)
+	}
+
+	return nextOpening.Sub(r.timeFunc()), errs
+}
@@ -0,0 +1,301 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/serviceset"
+)
+
+// rolloutTargetState is the state of the services rolled out to a single cluster.
+type rolloutTargetState int
+
+const (
+	rolloutTargetPending rolloutTargetState = iota
+	rolloutTargetReady
+	rolloutTargetFailed
+)
+
+const (
+	// rolloutNextWaveRequeue is the delay before the ServiceSets of the next wave are created
+	// once the rollout has advanced, which practically means right away.
+	rolloutNextWaveRequeue = time.Millisecond
+	// rolloutProgressRequeue is the interval the rollout progress is checked at while the current wave is being rolled out.
+	rolloutProgressRequeue = 10 * time.Second
+)
+
+// rolloutTarget is a cluster the services are rolled out to.
+// Nil cd stands for the management cluster.
+type rolloutTarget struct {
+	cd    *kcmv1.ClusterDeployment
+	state rolloutTargetState
+}
+
+func (t rolloutTarget) String() string {
+	if t.cd == nil {
+		return "management"
+	}
+	return client.ObjectKeyFromObject(t.cd).String()
+}
+
+// reconcileRollout creates or updates ServiceSets for the given matching ClusterDeployments
+// wave by wave according to the [kcmv1.MultiClusterServiceRolloutStrategy], and reports
+// the progress in the MultiClusterService status. It returns the delay after which the rollout
+// has to be reconciled again to proceed, zero if the rollout is not going to proceed on its own.
+func (r *MultiClusterServiceReconciler) reconcileRollout(ctx context.Context, mcs *kcmv1.MultiClusterService, cds []*kcmv1.ClusterDeployment) (time.Duration, error) {
+	l := ctrl.LoggerFrom(ctx)
+	strategy := mcs.Spec.RolloutStrategy
+
+	targets := orderRolloutTargets(cds, strategy.OrderByLabel, mcs.Spec.ServiceSpec.Provider.SelfManagement)
+	waveSize, err := rolloutWaveSize(strategy.BatchSize, len(targets))
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine rollout wave size: %w", err)
+	}
+
+	rollout := mcs.Status.Rollout
+	if rollout == nil || rollout.Generation != mcs.Generation {
+		l.Info("Starting services rollout", "generation", mcs.Generation)
+		rollout = &kcmv1.MultiClusterServiceRolloutStatus{
+			Generation:  mcs.Generation,
+			Phase:       kcmv1.MultiClusterServiceRolloutPhaseProgressing,
+			CurrentWave: 1,
+		}
+		mcs.Status.Rollout = rollout
+	}
+
+	allowed := len(targets)
+	if rollout.Phase != kcmv1.MultiClusterServiceRolloutPhaseCompleted {
+		allowed = min(int(rollout.CurrentWave)*waveSize, len(targets))
+	}
+
+	var errs error
+	for i := range targets[:allowed] {
+		targets[i].state, err = r.rolloutServiceSet(ctx, mcs, targets[i].cd)
+		errs = errors.Join(errs, err)
+	}
+	l.V(1).Info("Deferring ServiceSets to the next waves", "count", len(targets)-allowed)
+
+	var pause time.Duration
+	if strategy.PauseBetweenWaves != nil {
+		pause = strategy.PauseBetweenWaves.Duration
+	}
+	requeueAfter := advanceRollout(rollout, targets, waveSize, pause, r.timeFunc())
+	setRolloutCondition(mcs)
+
+	return requeueAfter, errs
+}
+
+// rolloutServiceSet creates or updates the ServiceSet for the given ClusterDeployment
+// and returns the state of its services.
+func (r *MultiClusterServiceReconciler) rolloutServiceSet(ctx context.Context, mcs *kcmv1.MultiClusterService, cd *kcmv1.ClusterDeployment) (rolloutTargetState, error) {
+	op, err := r.createOrUpdateServiceSet(ctx, mcs, cd)
+	if err != nil || op != kcmv1.ServiceSetOperationNone {
+		// the ServiceSet has just been changed, its services are yet to be observed
+		return rolloutTargetPending, err
+	}
+
+	serviceSet := new(kcmv1.ServiceSet)
+	key := serviceset.ObjectKey(r.SystemNamespace, cd, mcs)
+	if err := r.Client.Get(ctx, key, serviceSet); err != nil {
+		return rolloutTargetPending, fmt.Errorf("failed to get ServiceSet %s: %w", key, err)
+	}
+
+	// the services held until the maintenance window opens are not updated yet
+	held, _, err := r.pendingUpgrades(ctx, mcs, cd, serviceSet)
+	if err != nil || len(held) > 0 {
+		return rolloutTargetPending, err
+	}
+
+	return serviceSetRolloutState(serviceSet), nil
+}
+
+// serviceSetRolloutState returns the state of the services of the given ServiceSet,
+// only the state observed for the current generation of the ServiceSet is taken into account.
+func serviceSetRolloutState(serviceSet *kcmv1.ServiceSet) rolloutTargetState {
+	cond := apimeta.FindStatusCondition(serviceSet.Status.Conditions, kcmv1.ServicesInReadyStateCondition)
+	if cond == nil || cond.ObservedGeneration != serviceSet.Generation {
+		return rolloutTargetPending
+	}
+
+	for _, svc := range serviceSet.Status.Services {
+		if svc.State == kcmv1.ServiceStateFailed {
+			return rolloutTargetFailed
+		}
+	}
+
+	if cond.Status == metav1.ConditionTrue && serviceSet.Status.Deployed {
+		return rolloutTargetReady
+	}
+	return rolloutTargetPending
+}
+
+// orderRolloutTargets returns the rollout targets in the order of the waves. The management
+// cluster goes first, then the ClusterDeployments ordered by the value of the given label,
+// those without the label go last. Ties are resolved by the namespace and name.
+func orderRolloutTargets(cds []*kcmv1.ClusterDeployment, orderByLabel string, selfManagement bool) []rolloutTarget {
+	sorted := slices.Clone(cds)
+	slices.SortStableFunc(sorted, func(a, b *kcmv1.ClusterDeployment) int {
+		if orderByLabel != "" {
+			av, aok := a.Labels[orderByLabel]
+			bv, bok := b.Labels[orderByLabel]
+			if aok != bok {
+				if aok {
+					return -1
+				}
+				return 1
+			}
+			if n := cmp.Compare(av, bv); n != 0 {
+				return n
+			}
+		}
+		if n := cmp.Compare(a.Namespace, b.Namespace); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	targets := make([]rolloutTarget, 0, len(sorted)+1)
+	if selfManagement {
+		targets = append(targets, rolloutTarget{})
+	}
+	for _, cd := range sorted {
+		targets = append(targets, rolloutTarget{cd: cd})
+	}
+	return targets
+}
+
+// rolloutWaveSize returns the number of the clusters in a single wave, which is at least 1.
+func rolloutWaveSize(batchSize intstr.IntOrString, total int) (int, error) {
+	size, err := intstr.GetScaledValueFromIntOrPercent(&batchSize, total, true)
+	if err != nil {
+		return 0, err
+	}
+	return max(size, 1), nil
+}
+
+// advanceRollout updates the rollout status given the states of the targets: halts the rollout
+// if any of the services has failed, otherwise moves to the next wave once all of the
+// targets of the current and previous waves are ready and the pause between the waves has elapsed.
+// It returns the delay after which the rollout has to be advanced again, zero once it is completed or halted.
+func advanceRollout(rollout *kcmv1.MultiClusterServiceRolloutStatus, targets []rolloutTarget, waveSize int, pause time.Duration, now time.Time) time.Duration {
+	totalWaves := max((len(targets)+waveSize-1)/waveSize, 1)
+	rollout.TotalWaves = int32(totalWaves)
+	rollout.TotalClusters = int32(len(targets))
+
+	var (
+		updated int32
+		failed  []string
+	)
+	for _, t := range targets {
+		switch t.state {
+		case rolloutTargetReady:
+			updated++
+		case rolloutTargetFailed:
+			failed = append(failed, t.String())
+		}
+	}
+	rollout.UpdatedClusters = updated
+
+	switch rollout.Phase {
+	case kcmv1.MultiClusterServiceRolloutPhaseCompleted:
+		return 0
+	case kcmv1.MultiClusterServiceRolloutPhaseHalted:
+		// halted rollout is resumed only on the change of the spec
+		return 0
+	}
+
+	if len(failed) > 0 {
+		rollout.Phase = kcmv1.MultiClusterServiceRolloutPhaseHalted
+		rollout.WaveCompletionTime = nil
+		rollout.Message = "Services failed on clusters: " + strings.Join(failed, ", ")
+		return 0
+	}
+
+	// the targets of the next waves are not rolled out yet, thus only the current and previous waves matter
+	allowed := min(int(rollout.CurrentWave)*waveSize, len(targets))
+	if slices.ContainsFunc(targets[:allowed], func(t rolloutTarget) bool { return t.state == rolloutTargetPending }) {
+		rollout.Phase = kcmv1.MultiClusterServiceRolloutPhaseProgressing
+		rollout.WaveCompletionTime = nil
+		rollout.Message = fmt.Sprintf("Rolling out wave %d/%d", rollout.CurrentWave, totalWaves)
+		return rolloutProgressRequeue
+	}
+
+	if int(rollout.CurrentWave) >= totalWaves {
+		rollout.CurrentWave = int32(totalWaves)
+		rollout.Phase = kcmv1.MultiClusterServiceRolloutPhaseCompleted
+		rollout.WaveCompletionTime = nil
+		rollout.Message = ""
+		return 0
+	}
+
+	if pause > 0 {
+		if rollout.WaveCompletionTime == nil {
+			rollout.WaveCompletionTime = &metav1.Time{Time: now}
+		}
+		if resumeAt := rollout.WaveCompletionTime.Add(pause); now.Before(resumeAt) {
+			rollout.Phase = kcmv1.MultiClusterServiceRolloutPhasePaused
+			rollout.Message = fmt.Sprintf("Wave %d/%d has been rolled out, the next one starts at %s", rollout.CurrentWave, totalWaves, resumeAt.UTC().Format(time.RFC3339))
+			return resumeAt.Sub(now)
+		}
+	}
+
+	rollout.CurrentWave++
+	rollout.Phase = kcmv1.MultiClusterServiceRolloutPhaseProgressing
+	rollout.WaveCompletionTime = nil
+	rollout.Message = fmt.Sprintf("Rolling out wave %d/%d", rollout.CurrentWave, totalWaves)
+	return rolloutNextWaveRequeue
+}
+
+// setRolloutCondition sets the [kcmv1.ServicesRolloutCondition] according to the rollout status.
+func setRolloutCondition(mcs *kcmv1.MultiClusterService) {
+	rollout := mcs.Status.Rollout
+	if rollout == nil {
+		apimeta.RemoveStatusCondition(&mcs.Status.Conditions, kcmv1.ServicesRolloutCondition)
+		return
+	}
+
+	c := metav1.Condition{
+		Type:               kcmv1.ServicesRolloutCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: mcs.Generation,
+		Message:            rollout.Message,
+	}
+	switch rollout.Phase {
+	case kcmv1.MultiClusterServiceRolloutPhaseCompleted:
+		c.Status, c.Reason = metav1.ConditionTrue, kcmv1.SucceededReason
+		c.Message = fmt.Sprintf("%d/%d clusters updated", rollout.UpdatedClusters, rollout.TotalClusters)
+	case kcmv1.MultiClusterServiceRolloutPhasePaused:
+		c.Reason = kcmv1.ServicesRolloutPausedReason
+	case kcmv1.MultiClusterServiceRolloutPhaseHalted:
+		c.Reason = kcmv1.ServicesRolloutHaltedReason
+	default:
+		c.Reason = kcmv1.ServicesRolloutProgressingReason
+	}
+	apimeta.SetStatusCondition(&mcs.Status.Conditions, c)
+}
@@ -0,0 +1,304 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/objects/management"
+	"github.com/K0rdent/kcm/test/objects/multiclusterservice"
+	testscheme "github.com/K0rdent/kcm/test/scheme"
+)
+
+func Test_orderRolloutTargets(t *testing.T) {
+	t.Parallel()
+
+	newCD := func(namespace, name, ring string) *kcmv1.ClusterDeployment {
+		cd := &kcmv1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		if ring != "" {
+			cd.Labels = map[string]string{"ring": ring}
+		}
+		return cd
+	}
+	cds := []*kcmv1.ClusterDeployment{
+		newCD("b", "prod", "2"),
+		newCD("a", "unlabeled", ""),
+		newCD("b", "canary", "0"),
+		newCD("a", "staging", "1"),
+		newCD("a", "prod", "2"),
+	}
+
+	var got []string
+	for _, target := range orderRolloutTargets(cds, "ring", true) {
+		got = append(got, target.String())
+	}
+	want := []string{"management", "b/canary", "a/staging", "a/prod", "b/prod", "a/unlabeled"}
+	if !slices.Equal(got, want) {
+		t.Errorf("targets order = %v, want %v", got, want)
+	}
+}
+
+func Test_rolloutWaveSize(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		batchSize intstr.IntOrString
+		total     int
+		want      int
+	}{
+		{batchSize: intstr.FromInt32(2), total: 5, want: 2},
+		{batchSize: intstr.FromString("25%"), total: 5, want: 2},
+		{batchSize: intstr.FromString("10%"), total: 3, want: 1},
+		{batchSize: intstr.FromString("10%"), total: 0, want: 1},
+	} {
+		got, err := rolloutWaveSize(tc.batchSize, tc.total)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("wave size for %s of %d = %d, want %d", tc.batchSize.String(), tc.total, got, tc.want)
+		}
+	}
+}
+
+func Test_advanceRollout(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	newTargets := func(states ...rolloutTargetState) []rolloutTarget {
+		targets := make([]rolloutTarget, len(states))
+		for i, state := range states {
+			targets[i] = rolloutTarget{
+				cd:    &kcmv1.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: string(rune('a' + i))}},
+				state: state,
+			}
+		}
+		return targets
+	}
+
+	for _, tc := range []struct {
+		name        string
+		rollout     kcmv1.MultiClusterServiceRolloutStatus
+		targets     []rolloutTarget
+		pause       time.Duration
+		wantPhase   kcmv1.MultiClusterServiceRolloutPhase
+		wantWave    int32
+		wantRequeue time.Duration
+	}{
+		{
+			name:        "current wave is pending",
+			rollout:     kcmv1.MultiClusterServiceRolloutStatus{Phase: kcmv1.MultiClusterServiceRolloutPhaseProgressing, CurrentWave: 1},
+			targets:     newTargets(rolloutTargetReady, rolloutTargetPending, rolloutTargetPending),
+			wantPhase:   kcmv1.MultiClusterServiceRolloutPhaseProgressing,
+			wantWave:    1,
+			wantRequeue: rolloutProgressRequeue,
+		},
+		{
+			name:        "current wave is ready",
+			rollout:     kcmv1.MultiClusterServiceRolloutStatus{Phase: kcmv1.MultiClusterServiceRolloutPhaseProgressing, CurrentWave: 1},
+			targets:     newTargets(rolloutTargetReady, rolloutTargetReady, rolloutTargetPending),
+			wantPhase:   kcmv1.MultiClusterServiceRolloutPhaseProgressing,
+			wantWave:    2,
+			wantRequeue: rolloutNextWaveRequeue,
+		},
+		{
+			name:        "pause between waves",
+			rollout:     kcmv1.MultiClusterServiceRolloutStatus{Phase: kcmv1.MultiClusterServiceRolloutPhaseProgressing, CurrentWave: 1},
+			targets:     newTargets(rolloutTargetReady, rolloutTargetReady, rolloutTargetPending),
+			pause:       time.Minute,
+			wantPhase:   kcmv1.MultiClusterServiceRolloutPhasePaused,
+			wantWave:    1,
+			wantRequeue: time.Minute,
+		},
+		{
+			name: "pause has elapsed",
+			rollout: kcmv1.MultiClusterServiceRolloutStatus{
+				Phase: kcmv1.MultiClusterServiceRolloutPhasePaused, CurrentWave: 1,
+				WaveCompletionTime: &metav1.Time{Time: now.Add(-2 * time.Minute)},
+			},
+			targets:     newTargets(rolloutTargetReady, rolloutTargetReady, rolloutTargetPending),
+			pause:       time.Minute,
+			wantPhase:   kcmv1.MultiClusterServiceRolloutPhaseProgressing,
+			wantWave:    2,
+			wantRequeue: rolloutNextWaveRequeue,
+		},
+		{
+			name:      "failed services halt the rollout",
+			rollout:   kcmv1.MultiClusterServiceRolloutStatus{Phase: kcmv1.MultiClusterServiceRolloutPhaseProgressing, CurrentWave: 1},
+			targets:   newTargets(rolloutTargetReady, rolloutTargetFailed, rolloutTargetPending),
+			wantPhase: kcmv1.MultiClusterServiceRolloutPhaseHalted,
+			wantWave:  1,
+		},
+		{
+			name:      "halted rollout does not proceed",
+			rollout:   kcmv1.MultiClusterServiceRolloutStatus{Phase: kcmv1.MultiClusterServiceRolloutPhaseHalted, CurrentWave: 1},
+			targets:   newTargets(rolloutTargetReady, rolloutTargetReady, rolloutTargetPending),
+			wantPhase: kcmv1.MultiClusterServiceRolloutPhaseHalted,
+			wantWave:  1,
+		},
+		{
+			name:      "last wave is ready",
+			rollout:   kcmv1.MultiClusterServiceRolloutStatus{Phase: kcmv1.MultiClusterServiceRolloutPhaseProgressing, CurrentWave: 2},
+			targets:   newTargets(rolloutTargetReady, rolloutTargetReady, rolloutTargetReady),
+			pause:     time.Minute,
+			wantPhase: kcmv1.MultiClusterServiceRolloutPhaseCompleted,
+			wantWave:  2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rollout := tc.rollout.DeepCopy()
+			requeue := advanceRollout(rollout, tc.targets, 2, tc.pause, now)
+
+			if rollout.Phase != tc.wantPhase || rollout.CurrentWave != tc.wantWave {
+				t.Errorf("phase %s wave %d, want phase %s wave %d (message %q)", rollout.Phase, rollout.CurrentWave, tc.wantPhase, tc.wantWave, rollout.Message)
+			}
+			if requeue != tc.wantRequeue {
+				t.Errorf("requeue after %s, want %s", requeue, tc.wantRequeue)
+			}
+			if rollout.TotalWaves != 2 || rollout.TotalClusters != 3 {
+				t.Errorf("total waves %d clusters %d, want 2 and 3", rollout.TotalWaves, rollout.TotalClusters)
+			}
+		})
+	}
+}
+
+func Test_serviceSetRolloutState(t *testing.T) {
+	t.Parallel()
+
+	newServiceSet := func(observedGeneration int64, status metav1.ConditionStatus, state string) *kcmv1.ServiceSet {
+		return &kcmv1.ServiceSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Status: kcmv1.ServiceSetStatus{
+				Conditions: []metav1.Condition{{
+					Type: kcmv1.ServicesInReadyStateCondition, Status: status, ObservedGeneration: observedGeneration,
+				}},
+				Services: []kcmv1.ServiceState{{Name: "svc", State: state}},
+				Deployed: state == kcmv1.ServiceStateDeployed,
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		serviceSet *kcmv1.ServiceSet
+		want       rolloutTargetState
+	}{
+		{name: "ready", serviceSet: newServiceSet(2, metav1.ConditionTrue, kcmv1.ServiceStateDeployed), want: rolloutTargetReady},
+		{name: "outdated", serviceSet: newServiceSet(1, metav1.ConditionTrue, kcmv1.ServiceStateDeployed), want: rolloutTargetPending},
+		{name: "provisioning", serviceSet: newServiceSet(2, metav1.ConditionFalse, kcmv1.ServiceStateProvisioning), want: rolloutTargetPending},
+		{name: "failed", serviceSet: newServiceSet(2, metav1.ConditionFalse, kcmv1.ServiceStateFailed), want: rolloutTargetFailed},
+	} {
+		if got := serviceSetRolloutState(tc.serviceSet); got != tc.want {
+			t.Errorf("%s: state = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestMultiClusterServiceRolloutProceedsOnRequeue(t *testing.T) {
+	t.Parallel()
+
+	const systemNamespace = "kcm-system"
+
+	mcs := multiclusterservice.NewMultiClusterService(multiclusterservice.WithName("rollout"))
+	mcs.Finalizers = []string{kcmv1.MultiClusterServiceFinalizer}
+	mcs.Labels = map[string]string{kcmv1.GenericComponentNameLabel: kcmv1.GenericComponentLabelValueKCM}
+	mcs.Spec.ClusterSelector = metav1.LabelSelector{MatchLabels: map[string]string{"rollout": "true"}}
+	mcs.Spec.RolloutStrategy = &kcmv1.MultiClusterServiceRolloutStrategy{
+		BatchSize:         intstr.FromInt32(1),
+		PauseBetweenWaves: &metav1.Duration{Duration: time.Minute},
+	}
+	objects := []client.Object{management.NewManagement(), mcs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+		&kcmv1.StateManagementProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: kubeutil.DefaultStateManagementProvider},
+			Spec:       kcmv1.StateManagementProviderSpec{Selector: &metav1.LabelSelector{}},
+		},
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		objects = append(objects, clusterdeployment.NewClusterDeployment(
+			clusterdeployment.WithName(name),
+			clusterdeployment.WithNamespace("ns"),
+			func(cd *kcmv1.ClusterDeployment) { cd.Labels = map[string]string{"rollout": "true"} },
+		))
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(testscheme.Scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&kcmv1.MultiClusterService{}, &kcmv1.ServiceSet{}).
+		WithIndex(&kcmv1.ServiceSet{}, kcmv1.ServiceSetMultiClusterServiceIndexKey, kcmv1.ExtractServiceSetMultiClusterService).
+		WithIndex(&kcmv1.ServiceSet{}, kcmv1.ServiceSetClusterIndexKey, kcmv1.ExtractServiceSetCluster).
+		Build()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := &MultiClusterServiceReconciler{Client: cl, SystemNamespace: systemNamespace, timeFunc: func() time.Time { return now }}
+
+	for range 20 {
+		result, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(mcs)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// the services get deployed by the time of the next reconciliation
+		serviceSets := new(kcmv1.ServiceSetList)
+		if err := cl.List(t.Context(), serviceSets); err != nil {
+			t.Fatalf("failed to list ServiceSets: %v", err)
+		}
+		for i := range serviceSets.Items {
+			serviceSet := &serviceSets.Items[i]
+			serviceSet.Status.Deployed = true
+			apimeta.SetStatusCondition(&serviceSet.Status.Conditions, metav1.Condition{
+				Type: kcmv1.ServicesInReadyStateCondition, Status: metav1.ConditionTrue, Reason: kcmv1.SucceededReason, ObservedGeneration: serviceSet.Generation,
+			})
+			if err := cl.Status().Update(t.Context(), serviceSet); err != nil {
+				t.Fatalf("failed to update ServiceSet status: %v", err)
+			}
+		}
+
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(mcs), mcs); err != nil {
+			t.Fatalf("failed to get MultiClusterService: %v", err)
+		}
+		rollout := mcs.Status.Rollout
+		if rollout != nil && int(rollout.CurrentWave) < len(serviceSets.Items) {
+			t.Fatalf("%d ServiceSets exist in wave %d", len(serviceSets.Items), rollout.CurrentWave)
+		}
+		if rollout != nil && rollout.Phase == kcmv1.MultiClusterServiceRolloutPhaseCompleted {
+			if len(serviceSets.Items) != 3 || rollout.UpdatedClusters != 3 {
+				t.Fatalf("rollout completed with %d ServiceSets and %d updated clusters, want 3", len(serviceSets.Items), rollout.UpdatedClusters)
+			}
+			return
+		}
+
+		if result.RequeueAfter <= 0 {
+			t.Fatalf("rollout in %+v is not requeued", rollout)
+		}
+		now = now.Add(result.RequeueAfter)
+	}
+	t.Fatalf("rollout has not been completed: %+v", mcs.Status.Rollout)
+}
@@ -25,6 +25,7 @@ import (
 	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
 	"github.com/K0rdent/kcm/internal/controller/components"
@@ -62,6 +64,10 @@ type Reconciler struct {
 	RegistryCertSecretName string // Name of a Secret with Registry Root CA with ca.crt key; used by RegionReconciler
 	DefaultHelmTimeout     time.Duration
 
+	// ClientPool serves the regional clients and tracks the reachability of the regions,
+	// defaults to [kubeutil.DefaultRegionalClientPool].
+	ClientPool *kubeutil.RegionalClientPool
+
 	defaultRequeueTime time.Duration
 
 	IsDisabledValidationWH bool // is webhook disabled set via the controller flags
@@ -93,19 +99,29 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}
 
-	rgnlClient, restCfg, err := kubeutil.GetRegionalClient(ctx, r.MgmtClient, r.SystemNamespace, region, schemeutil.GetRegionalScheme)
+	rgnlClient, restCfg, err := r.clientPool().Get(ctx, r.MgmtClient, r.SystemNamespace, region, schemeutil.Regional)
 	if err != nil {
 		err := fmt.Errorf("failed to get clients for the %s region: %w", region.Name, err)
 		r.setReadyCondition(region, err)
 		return ctrl.Result{}, errors.Join(err, r.updateStatus(ctx, region))
 	}
 
+	r.setReachableCondition(region)
+
 	if !region.DeletionTimestamp.IsZero() {
 		l.Info("Deleting Region")
 		return r.delete(ctx, rgnlClient, region)
 	}
 
-	return r.update(ctx, rgnlClient, restCfg, region)
+	pauseIn := r.setPausedCondition(region, time.Now())
+
+	result, err := r.update(ctx, rgnlClient, restCfg, region)
+	if err == nil && pauseIn > 0 && (result.RequeueAfter == 0 || result.RequeueAfter > pauseIn) {
+		// recheck once the regional cluster has been unreachable long enough to pause the Region
+		result.RequeueAfter = pauseIn
+	}
+
+	return result, err
 }
 
 func (r *Reconciler) update(ctx context.Context, rgnlClient client.Client, restConfig *rest.Config, region *kcmv1.Region) (result ctrl.Result, err error) {
@@ -137,8 +153,9 @@ func (r *Reconciler) update(ctx context.Context, rgnlClient client.Client, restC
 		return ctrl.Result{}, err
 	}
 
+	// during the upgrade to a new Release, the regions are upgraded one at a time after the management
 	release := &kcmv1.Release{}
-	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Name: mgmt.Spec.Release}, release); err != nil {
+	if err := r.MgmtClient.Get(ctx, client.ObjectKey{Name: mgmt.RegionRelease(region)}, release); err != nil {
 		l.Error(err, "Failed to get Release")
 		return ctrl.Result{}, err
 	}
@@ -199,6 +216,8 @@ func (r *Reconciler) update(ctx context.Context, rgnlClient client.Client, restC
 		r.warnf(region, "RegionComponentsInstallationFailed", "Failed to install KCM components on the regional cluster: %w", err.Error())
 		return ctrl.Result{}, err
 	}
+	// update only if we actually observed the new release
+	region.Status.Release = release.Name
 
 	if requeue {
 		return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
@@ -309,6 +328,93 @@ func (r *Reconciler) setReadyCondition(region *kcmv1.Region, err error) {
 	}
 }
 
+// setReachableCondition updates the Region resource's "RegionReachable" condition based on
+// the result of the last probe of the regional cluster.
+func (r *Reconciler) setReachableCondition(region *kcmv1.Region) {
+	reachableCond := metav1.Condition{
+		Type:               kcmv1.RegionReachableCondition,
+		ObservedGeneration: region.Generation,
+		Status:             metav1.ConditionUnknown,
+		Reason:             kcmv1.RegionProbePendingReason,
+		Message:            "Regional cluster has not been probed yet",
+	}
+
+	if health, ok := r.clientPool().Health(region.Name); ok {
+		reachableCond.Status = metav1.ConditionTrue
+		reachableCond.Reason = kcmv1.RegionReachableReason
+		reachableCond.Message = "Regional cluster is reachable, API server version " + health.ServerVersion
+		if !health.Reachable {
+			reachableCond.Status = metav1.ConditionFalse
+			reachableCond.Reason = kcmv1.RegionUnreachableReason
+			reachableCond.Message = health.Err.Error()
+		}
+	}
+
+	if !meta.SetStatusCondition(&region.Status.Conditions, reachableCond) {
+		return
+	}
+
+	switch reachableCond.Status {
+	case metav1.ConditionTrue:
+		r.eventf(region, "RegionReachable", "Regional cluster is reachable")
+	case metav1.ConditionFalse:
+		r.warnf(region, "RegionUnreachable", "Regional cluster is unreachable: %s", reachableCond.Message)
+	}
+}
+
+// setPausedCondition updates the Region resource's "Paused" condition according to its auto-pause
+// policy and the "RegionReachable" condition. It returns the duration after which the Region is to be
+// paused if the regional cluster remains unreachable, zero otherwise.
+func (r *Reconciler) setPausedCondition(region *kcmv1.Region, now time.Time) time.Duration {
+	if region.Spec.AutoPause == nil {
+		if meta.RemoveStatusCondition(&region.Status.Conditions, kcmv1.PausedCondition) {
+			r.eventf(region, "RegionAutoPauseDisabled", "Automatic pause of the Region has been disabled")
+		}
+		return 0
+	}
+
+	pausedCond := metav1.Condition{
+		Type:               kcmv1.PausedCondition,
+		ObservedGeneration: region.Generation,
+		Status:             metav1.ConditionFalse,
+		Reason:             kcmv1.NotPausedReason,
+		Message:            "Regional cluster is reachable",
+	}
+
+	var pauseIn time.Duration
+	if reachableCond := meta.FindStatusCondition(region.Status.Conditions, kcmv1.RegionReachableCondition); reachableCond != nil && reachableCond.Status == metav1.ConditionFalse {
+		unreachableFor := region.Spec.AutoPause.GetUnreachableFor()
+		pausedCond.Message = fmt.Sprintf("Regional cluster is unreachable, pausing in %s unless it recovers", unreachableFor)
+
+		pauseIn = reachableCond.LastTransitionTime.Add(unreachableFor).Sub(now)
+		if pauseIn <= 0 {
+			pauseIn = 0
+			pausedCond.Status = metav1.ConditionTrue
+			pausedCond.Reason = kcmv1.RegionUnreachableReason
+			pausedCond.Message = fmt.Sprintf("Regional cluster has been unreachable for more than %s: %s", unreachableFor, reachableCond.Message)
+		}
+	}
+
+	wasPaused := region.IsAutoPaused()
+	meta.SetStatusCondition(&region.Status.Conditions, pausedCond)
+
+	switch paused := pausedCond.Status == metav1.ConditionTrue; {
+	case paused && !wasPaused:
+		r.warnf(region, "RegionAutoPaused", "%s", pausedCond.Message)
+	case !paused && wasPaused:
+		r.eventf(region, "RegionAutoUnpaused", "Regional cluster is reachable again, Region has been unpaused")
+	}
+
+	return pauseIn
+}
+
+func (r *Reconciler) clientPool() *kubeutil.RegionalClientPool {
+	if r.ClientPool == nil {
+		return kubeutil.DefaultRegionalClientPool
+	}
+	return r.ClientPool
+}
+
 func (r *Reconciler) delete(ctx context.Context, rgnClient client.Client, region *kcmv1.Region) (ctrl.Result, error) {
 	l := ctrl.LoggerFrom(ctx)
 
@@ -342,8 +448,11 @@ func (r *Reconciler) delete(ctx context.Context, rgnClient client.Client, region
 	r.eventf(region, "RemovedRegion", "Region has been removed")
 	l.Info("Removing Region finalizer")
 	if controllerutil.RemoveFinalizer(region, kcmv1.RegionFinalizer) {
-		return ctrl.Result{}, r.MgmtClient.Update(ctx, region)
+		if err = r.MgmtClient.Update(ctx, region); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
+	r.clientPool().Forget(region.Name)
 	return ctrl.Result{}, nil
 }
 
@@ -429,8 +538,17 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			RateLimiter: ratelimitutil.DefaultFastSlow(),
 		}).
 		For(&kcmv1.Region{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Watches(&kcmv1.Management{}, handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []ctrl.Request {
-			return []ctrl.Request{{NamespacedName: client.ObjectKey{Name: kcmv1.ManagementName}}}
+		Watches(&kcmv1.Management{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []ctrl.Request {
+			regions := new(kcmv1.RegionList)
+			if err := r.MgmtClient.List(ctx, regions); err != nil {
+				return nil
+			}
+
+			requests := make([]ctrl.Request, 0, len(regions.Items))
+			for _, rgn := range regions.Items {
+				requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKey{Name: rgn.Name}})
+			}
+			return requests
 		}), builder.WithPredicates(predicate.Funcs{
 			GenericFunc: func(event.TypedGenericEvent[client.Object]) bool { return false },
 			DeleteFunc:  func(event.TypedDeleteEvent[client.Object]) bool { return false },
@@ -444,9 +562,11 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 				if !ok {
 					return false
 				}
-				return oldO.Spec.Release != newO.Spec.Release
+				// the regions are upgraded to the new Release as the upgrade of the Management progresses
+				return oldO.Spec.Release != newO.Spec.Release || !equality.Semantic.DeepEqual(oldO.Status.Upgrade, newO.Status.Upgrade)
 			},
 		})).
+		WatchesRawSource(source.TypedChannel(r.clientPool().HealthEvents(), &handler.TypedEnqueueRequestForObject[*kcmv1.Region]{})).
 		Complete(r)
 }
 
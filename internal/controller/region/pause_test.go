@@ -0,0 +1,135 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package region
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func Test_setPausedCondition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	reachable := func(status metav1.ConditionStatus, since time.Duration) metav1.Condition {
+		return metav1.Condition{
+			Type:               kcmv1.RegionReachableCondition,
+			Status:             status,
+			Reason:             kcmv1.RegionUnreachableReason,
+			Message:            "connection refused",
+			LastTransitionTime: metav1.NewTime(now.Add(-since)),
+		}
+	}
+	paused := func(status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{Type: kcmv1.PausedCondition, Status: status, Reason: kcmv1.RegionUnreachableReason}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		autoPause  *kcmv1.RegionAutoPause
+		conditions []metav1.Condition
+
+		expectedStatus  metav1.ConditionStatus // empty if the condition must be absent
+		expectedReason  string
+		expectedPauseIn time.Duration
+	}{
+		{
+			name:       "no policy, condition removed",
+			conditions: []metav1.Condition{reachable(metav1.ConditionFalse, time.Hour), paused(metav1.ConditionTrue)},
+		},
+		{
+			name:           "reachable",
+			autoPause:      &kcmv1.RegionAutoPause{},
+			conditions:     []metav1.Condition{reachable(metav1.ConditionTrue, time.Hour)},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: kcmv1.NotPausedReason,
+		},
+		{
+			name:           "not probed yet",
+			autoPause:      &kcmv1.RegionAutoPause{},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: kcmv1.NotPausedReason,
+		},
+		{
+			name:            "unreachable within default threshold",
+			autoPause:       &kcmv1.RegionAutoPause{},
+			conditions:      []metav1.Condition{reachable(metav1.ConditionFalse, time.Minute)},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  kcmv1.NotPausedReason,
+			expectedPauseIn: kcmv1.DefaultRegionAutoPauseUnreachableFor - time.Minute,
+		},
+		{
+			name:           "unreachable beyond default threshold",
+			autoPause:      &kcmv1.RegionAutoPause{},
+			conditions:     []metav1.Condition{reachable(metav1.ConditionFalse, kcmv1.DefaultRegionAutoPauseUnreachableFor)},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: kcmv1.RegionUnreachableReason,
+		},
+		{
+			name:           "unreachable beyond custom threshold",
+			autoPause:      &kcmv1.RegionAutoPause{UnreachableFor: &metav1.Duration{Duration: 30 * time.Second}},
+			conditions:     []metav1.Condition{reachable(metav1.ConditionFalse, time.Minute)},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: kcmv1.RegionUnreachableReason,
+		},
+		{
+			name:           "recovered",
+			autoPause:      &kcmv1.RegionAutoPause{},
+			conditions:     []metav1.Condition{reachable(metav1.ConditionTrue, time.Second), paused(metav1.ConditionTrue)},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: kcmv1.NotPausedReason,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			region := &kcmv1.Region{
+				ObjectMeta: metav1.ObjectMeta{Name: "region"},
+				Spec:       kcmv1.RegionSpec{AutoPause: tc.autoPause},
+				Status:     kcmv1.RegionStatus{Conditions: tc.conditions},
+			}
+
+			pauseIn := (&Reconciler{}).setPausedCondition(region, now)
+			if pauseIn != tc.expectedPauseIn {
+				t.Errorf("expected to pause in %s, got %s", tc.expectedPauseIn, pauseIn)
+			}
+
+			cond := meta.FindStatusCondition(region.Status.Conditions, kcmv1.PausedCondition)
+			if tc.expectedStatus == "" {
+				if cond != nil {
+					t.Fatalf("expected no %s condition, got %+v", kcmv1.PausedCondition, cond)
+				}
+				return
+			}
+
+			if cond == nil {
+				t.Fatalf("expected %s condition to be set", kcmv1.PausedCondition)
+			}
+			if cond.Status != tc.expectedStatus || cond.Reason != tc.expectedReason {
+				t.Errorf("expected %s condition with status %s and reason %s, got %s and %s",
+					kcmv1.PausedCondition, tc.expectedStatus, tc.expectedReason, cond.Status, cond.Reason)
+			}
+			if region.IsAutoPaused() != (tc.expectedStatus == metav1.ConditionTrue) {
+				t.Errorf("unexpected IsAutoPaused result %t", region.IsAutoPaused())
+			}
+		})
+	}
+}
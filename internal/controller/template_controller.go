@@ -44,17 +44,19 @@ import (
 	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
 	labelsutil "github.com/K0rdent/kcm/internal/util/labels"
 	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
-)
-
-const (
-	schemaConfigMapKey = "schema"
+	valuesschemautil "github.com/K0rdent/kcm/internal/util/valuesschema"
 )
 
 // TemplateReconciler reconciles a *Template object
 type TemplateReconciler struct {
 	client.Client
 
-	downloadHelmChartFunc func(ctx context.Context, chartURL, digest string) (*chart.Chart, error)
+	downloadHelmChartFunc     func(ctx context.Context, chartURL, digest string) (*chart.Chart, error)
+	verifyChartProvenanceFunc func(ctx context.Context, cl client.Client, hc *sourcev1.HelmChart, keyringData map[string][]byte) (string, error)
+
+	// ChartVerification is the system-wide verification policy applied to all
+	// of the templates in addition to the ones the templates define themselves.
+	ChartVerification *kcmv1.ChartVerification
 
 	SystemNamespace       string
 	DefaultRegistryConfig helm.DefaultRegistryConfig
@@ -298,6 +300,14 @@ func (r *TemplateReconciler) ReconcileTemplate(ctx context.Context, template tem
 		return ctrl.Result{}, err
 	}
 
+	l.Info("Verifying Helm chart")
+	status.ChartVerification, err = r.verifyChart(ctx, template, hcChart)
+	if err != nil {
+		l.Error(err, "Helm chart verification failed")
+		_ = r.updateStatus(ctx, template, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	if err := fillStatusFromChart(ctx, template, helmChart); err != nil {
 		_ = r.updateStatus(ctx, template, err.Error())
 		return ctrl.Result{}, err
@@ -348,7 +358,7 @@ func (r *TemplateReconciler) ensureSchemaConfigmap(ctx context.Context, template
 		if schemaConfigMap.Data == nil {
 			schemaConfigMap.Data = make(map[string]string)
 		}
-		schemaConfigMap.Data[schemaConfigMapKey] = string(helmChart.Schema)
+		schemaConfigMap.Data[valuesschemautil.ConfigMapKey] = string(helmChart.Schema)
 		return nil
 	})
 	if err != nil {
@@ -438,7 +448,12 @@ func (r *TemplateReconciler) reconcileHelmChart(ctx context.Context, template te
 		},
 	}
 
+	if err := r.ensureChartVerificationSecret(ctx, template, namespace); err != nil {
+		return nil, err
+	}
+
 	helmSpec := template.GetHelmSpec()
+	policy := cosignChartVerificationPolicy(r.chartVerificationPolicies(template))
 	_, err := ctrl.CreateOrUpdate(ctx, r.Client, helmChart, func() error {
 		if helmChart.Labels == nil {
 			helmChart.Labels = make(map[string]string)
@@ -448,6 +463,9 @@ func (r *TemplateReconciler) reconcileHelmChart(ctx context.Context, template te
 		kubeutil.AddOwnerReference(helmChart, template)
 
 		helmChart.Spec = *helmSpec.ChartSpec
+		if policy != nil {
+			helmChart.Spec.Verify = cosignChartVerification(policy.ChartVerification)
+		}
 		return nil
 	})
 
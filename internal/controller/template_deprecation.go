@@ -0,0 +1,128 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/metrics"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
+)
+
+// setTemplateDeprecatedCondition reports the deprecated templates among the given ones
+// in the [kcmv1.TemplateDeprecatedCondition], removing the condition if none is deprecated.
+// Returns the condition and whether it has been set or changed.
+func setTemplateDeprecatedCondition(conditions *[]metav1.Condition, templates []deprecationutil.Template, now time.Time, generation int64) (metav1.Condition, bool) {
+	cond, deprecated := deprecationutil.Condition(templates, now, generation)
+	if !deprecated {
+		apimeta.RemoveStatusCondition(conditions, kcmv1.TemplateDeprecatedCondition)
+		return cond, false
+	}
+
+	// a generation change alone does not change the deprecation
+	prev := apimeta.FindStatusCondition(*conditions, kcmv1.TemplateDeprecatedCondition)
+	changed := prev == nil || prev.Reason != cond.Reason || prev.Message != cond.Message
+	apimeta.SetStatusCondition(conditions, cond)
+	return cond, changed
+}
+
+// trackTemplatesUsage reports the templates of the given kinds used by the given parent object
+// alongside with their lifecycle stages.
+func trackTemplatesUsage(ctx context.Context, parentKind string, parent metav1.ObjectMeta, templates []deprecationutil.Template, now time.Time, templateKinds ...string) {
+	byKind := make(map[string]map[string]string, len(templateKinds))
+	for _, kind := range templateKinds {
+		byKind[kind] = make(map[string]string)
+	}
+	for _, t := range templates {
+		if _, ok := byKind[t.Kind]; ok {
+			byKind[t.Kind][t.Name] = t.Deprecation.Lifecycle(now)
+		}
+	}
+
+	for kind, usage := range byKind {
+		metrics.TrackMetricTemplatesInUse(ctx, kind, parentKind, parent, usage)
+	}
+}
+
+// serviceTemplateNames returns the names of the templates of the given services.
+func serviceTemplateNames(services []kcmv1.Service) []string {
+	names := make([]string, 0, len(services))
+	for _, svc := range services {
+		names = append(names, svc.Template)
+	}
+	return names
+}
+
+// templateDeprecationChangedPredicate passes only the update events changing the deprecation annotations of a template.
+var templateDeprecationChangedPredicate = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return false
+		}
+		oldAnnotations, newAnnotations := e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations()
+		for _, k := range templateDeprecationAnnotations {
+			oldValue, oldOK := oldAnnotations[k]
+			newValue, newOK := newAnnotations[k]
+			if oldOK != newOK || oldValue != newValue {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// syncTemplateDeprecation sets the deprecation annotations of the template managed by the given chain.
+// The deprecation of the chain itself, if any, takes precedence over the deprecation of the source system template.
+func syncTemplateDeprecation(target, source, chain client.Object) {
+	from := source.GetAnnotations()
+	for _, k := range templateDeprecationAnnotations {
+		if _, ok := chain.GetAnnotations()[k]; ok {
+			from = chain.GetAnnotations()
+			break
+		}
+	}
+
+	annotations := target.GetAnnotations()
+	for _, k := range templateDeprecationAnnotations {
+		v, ok := from[k]
+		if !ok {
+			delete(annotations, k)
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string, len(templateDeprecationAnnotations))
+		}
+		annotations[k] = v
+	}
+	target.SetAnnotations(annotations)
+}
+
+// templateDeprecationAnnotations are the annotations defining the deprecation of a template.
+var templateDeprecationAnnotations = []string{
+	kcmv1.TemplateDeprecatedAnnotation,
+	kcmv1.TemplateEndOfLifeAnnotation,
+	kcmv1.TemplateReplacementAnnotation,
+}
@@ -0,0 +1,100 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
+)
+
+func Test_syncTemplateDeprecation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		target   map[string]string
+		source   map[string]string
+		chain    map[string]string
+		expected map[string]string
+	}{
+		"nothing deprecated": {
+			target:   map[string]string{"foo": "bar"},
+			expected: map[string]string{"foo": "bar"},
+		},
+		"source deprecated": {
+			source: map[string]string{kcmv1.TemplateDeprecatedAnnotation: "true", kcmv1.TemplateReplacementAnnotation: "new", "foo": "bar"},
+			expected: map[string]string{
+				kcmv1.TemplateDeprecatedAnnotation:  "true",
+				kcmv1.TemplateReplacementAnnotation: "new",
+			},
+		},
+		"chain takes precedence": {
+			source:   map[string]string{kcmv1.TemplateDeprecatedAnnotation: "true", kcmv1.TemplateReplacementAnnotation: "new"},
+			chain:    map[string]string{kcmv1.TemplateEndOfLifeAnnotation: "2026-12-31"},
+			expected: map[string]string{kcmv1.TemplateEndOfLifeAnnotation: "2026-12-31"},
+		},
+		"deprecation removed": {
+			target:   map[string]string{kcmv1.TemplateDeprecatedAnnotation: "true", "foo": "bar"},
+			expected: map[string]string{"foo": "bar"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			target := &kcmv1.ServiceTemplate{ObjectMeta: metav1.ObjectMeta{Annotations: tc.target}}
+			source := &kcmv1.ServiceTemplate{ObjectMeta: metav1.ObjectMeta{Annotations: tc.source}}
+			chain := &kcmv1.ServiceTemplateChain{ObjectMeta: metav1.ObjectMeta{Annotations: tc.chain}}
+
+			syncTemplateDeprecation(target, source, chain)
+			if len(tc.expected) == 0 {
+				require.Empty(t, target.Annotations)
+				return
+			}
+			require.Equal(t, tc.expected, target.Annotations)
+		})
+	}
+}
+
+func Test_setTemplateDeprecatedCondition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	templates := []deprecationutil.Template{
+		{Kind: kcmv1.ClusterTemplateKind, Name: "ct", Deprecation: &deprecationutil.Deprecation{EndOfLife: now.AddDate(0, 0, 1)}},
+	}
+
+	var conditions []metav1.Condition
+	_, changed := setTemplateDeprecatedCondition(&conditions, templates, now, 1)
+	require.True(t, changed)
+
+	_, changed = setTemplateDeprecatedCondition(&conditions, templates, now, 2)
+	require.False(t, changed, "a generation change alone must not change the condition")
+
+	cond, changed := setTemplateDeprecatedCondition(&conditions, templates, now.AddDate(0, 0, 1), 2)
+	require.True(t, changed)
+	require.Equal(t, kcmv1.TemplateEndOfLifeReason, cond.Reason)
+
+	_, changed = setTemplateDeprecatedCondition(&conditions, templates[:0], now, 2)
+	require.False(t, changed)
+	require.Nil(t, apimeta.FindStatusCondition(conditions, kcmv1.TemplateDeprecatedCondition))
+}
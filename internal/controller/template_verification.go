@@ -0,0 +1,198 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/helm"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+)
+
+// chartVerificationPolicy is a verification policy alongside with the namespace
+// the Secret referenced by the policy is located in.
+type chartVerificationPolicy struct {
+	*kcmv1.ChartVerification
+
+	secretNamespace string
+}
+
+// chartVerificationPolicies returns the verification policies applicable to the given template.
+// The system-wide policy always applies, the template's own policy may only add checks on top of it.
+func (r *TemplateReconciler) chartVerificationPolicies(template templateCommon) []chartVerificationPolicy {
+	var policies []chartVerificationPolicy
+	if r.ChartVerification != nil {
+		policies = append(policies, chartVerificationPolicy{ChartVerification: r.ChartVerification, secretNamespace: r.SystemNamespace})
+	}
+
+	if verify := template.GetHelmSpec().Verify; verify != nil {
+		namespace := template.GetNamespace()
+		if namespace == "" {
+			namespace = r.SystemNamespace
+		}
+		policy := chartVerificationPolicy{ChartVerification: verify, secretNamespace: namespace}
+		if !slices.ContainsFunc(policies, func(p chartVerificationPolicy) bool {
+			return p.secretNamespace == policy.secretNamespace && equality.Semantic.DeepEqual(p.ChartVerification, policy.ChartVerification)
+		}) {
+			policies = append(policies, policy)
+		}
+	}
+
+	return policies
+}
+
+// cosignChartVerificationPolicy returns the first of the given policies relying on the source-controller,
+// nil if there is none. The [sourcev1.HelmChart] enforces a single cosign verification, hence the system-wide
+// policy, being the first one, takes precedence.
+func cosignChartVerificationPolicy(policies []chartVerificationPolicy) *chartVerificationPolicy {
+	for i := range policies {
+		if cosignChartVerification(policies[i].ChartVerification) != nil {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+// cosignChartVerification returns the expected verification of the [sourcev1.HelmChart]
+// for the given policy, nil if the policy does not rely on the source-controller.
+func cosignChartVerification(policy *kcmv1.ChartVerification) *sourcev1.HelmChartVerification {
+	if policy == nil || policy.Provider != kcmv1.ChartVerificationProviderCosign {
+		return nil
+	}
+
+	return &sourcev1.HelmChartVerification{
+		Provider:          policy.Provider,
+		SecretRef:         policy.SecretRef,
+		MatchOIDCIdentity: policy.MatchOIDCIdentity,
+	}
+}
+
+// ensureChartVerificationSecret copies the Secret of the cosign policy enforced by the
+// [sourcev1.HelmChart] to the namespace of the chart since the source-controller
+// looks up the Secret in the namespace of the chart.
+func (r *TemplateReconciler) ensureChartVerificationSecret(ctx context.Context, template templateCommon, chartNamespace string) error {
+	policy := cosignChartVerificationPolicy(r.chartVerificationPolicies(template))
+	if policy == nil || policy.SecretRef == nil || policy.secretNamespace == chartNamespace {
+		return nil
+	}
+
+	if err := kubeutil.CopySecret(ctx, r.Client, r.Client, client.ObjectKey{Namespace: policy.secretNamespace, Name: policy.SecretRef.Name}, chartNamespace, "", nil, nil); err != nil {
+		return fmt.Errorf("failed to copy chart verification Secret %s to the %s namespace: %w", policy.SecretRef.Name, chartNamespace, err)
+	}
+
+	return nil
+}
+
+// verifyChart verifies the chart produced by the given [sourcev1.HelmChart]
+// according to all of the verification policies applicable to the template.
+// Returns nil status if no policy applies.
+func (r *TemplateReconciler) verifyChart(ctx context.Context, template templateCommon, hcChart *sourcev1.HelmChart) (*kcmv1.ChartVerificationStatus, error) {
+	policies := r.chartVerificationPolicies(template)
+	if len(policies) == 0 {
+		return nil, nil //nolint:nilnil // no policy applies
+	}
+
+	enforced := cosignChartVerificationPolicy(policies)
+	var providers, signers []string
+	for _, policy := range policies {
+		var (
+			signer string
+			err    error
+		)
+		switch policy.Provider {
+		case kcmv1.ChartVerificationProviderCosign:
+			if (policy.SecretRef != nil && policy.secretNamespace != enforced.secretNamespace) ||
+				!equality.Semantic.DeepEqual(cosignChartVerification(policy.ChartVerification), cosignChartVerification(enforced.ChartVerification)) {
+				err = errors.New("the cosign verification of the template conflicts with the system-wide one enforced by the HelmChart")
+				break
+			}
+			signer, err = verifyCosignChart(hcChart, cosignChartVerification(policy.ChartVerification))
+		case kcmv1.ChartVerificationProviderHelmProvenance:
+			signer, err = r.verifyChartProvenance(ctx, hcChart, policy.ChartVerification, policy.secretNamespace)
+		default:
+			err = fmt.Errorf("unsupported chart verification provider %q", policy.Provider)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify chart: %w", err)
+		}
+
+		if !slices.Contains(providers, policy.Provider) {
+			providers = append(providers, policy.Provider)
+		}
+		if signer != "" && !slices.Contains(signers, signer) {
+			signers = append(signers, signer)
+		}
+	}
+
+	verification := &kcmv1.ChartVerificationStatus{
+		VerificationTime: metav1.Now(),
+		Provider:         strings.Join(providers, ","),
+		Signer:           strings.Join(signers, "; "),
+		Digest:           hcChart.Status.Artifact.Digest,
+	}
+	if prev := template.GetCommonStatus().ChartVerification; prev != nil &&
+		prev.Provider == verification.Provider && prev.Signer == verification.Signer && prev.Digest == verification.Digest {
+		verification.VerificationTime = prev.VerificationTime
+	}
+
+	return verification, nil
+}
+
+// verifyCosignChart ensures the source-controller has verified the chart with the expected cosign
+// verification and returns the verification message reported by the source-controller.
+func verifyCosignChart(hcChart *sourcev1.HelmChart, expected *sourcev1.HelmChartVerification) (string, error) {
+	if !equality.Semantic.DeepEqual(hcChart.Spec.Verify, expected) {
+		return "", fmt.Errorf("HelmChart %s does not enforce the required cosign verification", client.ObjectKeyFromObject(hcChart))
+	}
+
+	cond := apimeta.FindStatusCondition(hcChart.Status.Conditions, sourcev1.SourceVerifiedCondition)
+	if cond == nil || cond.ObservedGeneration != hcChart.Generation {
+		return "", fmt.Errorf("HelmChart %s signature has not been verified yet", client.ObjectKeyFromObject(hcChart))
+	}
+	if cond.Status != metav1.ConditionTrue {
+		return "", fmt.Errorf("HelmChart %s signature verification failed: %s", client.ObjectKeyFromObject(hcChart), cond.Message)
+	}
+
+	return cond.Message, nil
+}
+
+func (r *TemplateReconciler) verifyChartProvenance(ctx context.Context, hcChart *sourcev1.HelmChart, policy *kcmv1.ChartVerification, secretNamespace string) (string, error) {
+	if policy.SecretRef == nil {
+		return "", errors.New("helm-provenance verification requires the secretRef with the PGP keyring")
+	}
+
+	secret := new(corev1.Secret)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: secretNamespace, Name: policy.SecretRef.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get PGP keyring Secret %s/%s: %w", secretNamespace, policy.SecretRef.Name, err)
+	}
+
+	if r.verifyChartProvenanceFunc == nil {
+		r.verifyChartProvenanceFunc = helm.VerifyChartProvenance
+	}
+
+	return r.verifyChartProvenanceFunc(ctx, r.Client, hcChart, secret.Data)
+}
@@ -0,0 +1,348 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	testscheme "github.com/K0rdent/kcm/test/scheme"
+)
+
+func Test_chartVerificationPolicies(t *testing.T) {
+	t.Parallel()
+
+	const systemNamespace = "kcm-system"
+
+	systemPolicy := &kcmv1.ChartVerification{
+		Provider:  kcmv1.ChartVerificationProviderCosign,
+		SecretRef: &fluxmeta.LocalObjectReference{Name: "system-keys"},
+	}
+	templatePolicy := &kcmv1.ChartVerification{
+		Provider:  kcmv1.ChartVerificationProviderHelmProvenance,
+		SecretRef: &fluxmeta.LocalObjectReference{Name: "template-keys"},
+	}
+
+	tests := map[string]struct {
+		systemPolicy     *kcmv1.ChartVerification
+		template         templateCommon
+		expectedPolicies []chartVerificationPolicy
+	}{
+		"no policy": {
+			template: &kcmv1.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}},
+		},
+		"system policy": {
+			systemPolicy:     systemPolicy,
+			template:         &kcmv1.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}},
+			expectedPolicies: []chartVerificationPolicy{{ChartVerification: systemPolicy, secretNamespace: systemNamespace}},
+		},
+		"template policy": {
+			template: &kcmv1.ServiceTemplate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+				Spec:       kcmv1.ServiceTemplateSpec{Helm: &kcmv1.HelmSpec{Verify: templatePolicy}},
+			},
+			expectedPolicies: []chartVerificationPolicy{{ChartVerification: templatePolicy, secretNamespace: "ns"}},
+		},
+		"template policy adds to the system policy": {
+			systemPolicy: systemPolicy,
+			template: &kcmv1.ServiceTemplate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+				Spec:       kcmv1.ServiceTemplateSpec{Helm: &kcmv1.HelmSpec{Verify: templatePolicy}},
+			},
+			expectedPolicies: []chartVerificationPolicy{
+				{ChartVerification: systemPolicy, secretNamespace: systemNamespace},
+				{ChartVerification: templatePolicy, secretNamespace: "ns"},
+			},
+		},
+		"template policy same as the system policy": {
+			systemPolicy: systemPolicy,
+			template: &kcmv1.ProviderTemplate{
+				Spec: kcmv1.ProviderTemplateSpec{Helm: kcmv1.HelmSpec{Verify: systemPolicy.DeepCopy()}},
+			},
+			expectedPolicies: []chartVerificationPolicy{{ChartVerification: systemPolicy, secretNamespace: systemNamespace}},
+		},
+		"cluster-scoped template policy": {
+			template: &kcmv1.ProviderTemplate{
+				Spec: kcmv1.ProviderTemplateSpec{Helm: kcmv1.HelmSpec{Verify: templatePolicy}},
+			},
+			expectedPolicies: []chartVerificationPolicy{{ChartVerification: templatePolicy, secretNamespace: systemNamespace}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &TemplateReconciler{SystemNamespace: systemNamespace, ChartVerification: tt.systemPolicy}
+			require.Equal(t, tt.expectedPolicies, r.chartVerificationPolicies(tt.template))
+		})
+	}
+}
+
+func Test_verifyCosignChart(t *testing.T) {
+	t.Parallel()
+
+	expected := cosignChartVerification(&kcmv1.ChartVerification{
+		Provider: kcmv1.ChartVerificationProviderCosign,
+		MatchOIDCIdentity: []sourcev1.OIDCIdentityMatch{{
+			Issuer:  "^https://token.actions.githubusercontent.com$",
+			Subject: "^https://github.com/k0rdent/.*$",
+		}},
+	})
+
+	newChart := func(verify *sourcev1.HelmChartVerification, conditions ...metav1.Condition) *sourcev1.HelmChart {
+		return &sourcev1.HelmChart{
+			ObjectMeta: metav1.ObjectMeta{Name: "chart", Namespace: "ns", Generation: 2},
+			Spec:       sourcev1.HelmChartSpec{Verify: verify},
+			Status:     sourcev1.HelmChartStatus{Conditions: conditions},
+		}
+	}
+
+	tests := map[string]struct {
+		chart          *sourcev1.HelmChart
+		expectedSigner string
+		expectedErr    string
+	}{
+		"verification is not enforced": {
+			chart:       newChart(nil),
+			expectedErr: "does not enforce the required cosign verification",
+		},
+		"different verification": {
+			chart:       newChart(&sourcev1.HelmChartVerification{Provider: kcmv1.ChartVerificationProviderCosign}),
+			expectedErr: "does not enforce the required cosign verification",
+		},
+		"not verified yet": {
+			chart:       newChart(expected),
+			expectedErr: "has not been verified yet",
+		},
+		"stale verification": {
+			chart: newChart(expected, metav1.Condition{
+				Type: sourcev1.SourceVerifiedCondition, Status: metav1.ConditionTrue, ObservedGeneration: 1,
+			}),
+			expectedErr: "has not been verified yet",
+		},
+		"verification failed": {
+			chart: newChart(expected, metav1.Condition{
+				Type: sourcev1.SourceVerifiedCondition, Status: metav1.ConditionFalse, ObservedGeneration: 2, Message: "no matching signatures",
+			}),
+			expectedErr: "signature verification failed: no matching signatures",
+		},
+		"verified": {
+			chart: newChart(expected, metav1.Condition{
+				Type: sourcev1.SourceVerifiedCondition, Status: metav1.ConditionTrue, ObservedGeneration: 2, Message: "verified signature of revision 1.0.0",
+			}),
+			expectedSigner: "verified signature of revision 1.0.0",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			signer, err := verifyCosignChart(tt.chart, expected)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSigner, signer)
+		})
+	}
+}
+
+func Test_verifyChart_helmProvenance(t *testing.T) {
+	t.Parallel()
+
+	const (
+		namespace  = "ns"
+		secretName = "pgp-keys"
+		digest     = "sha256:abc"
+	)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: namespace},
+		Data:       map[string][]byte{"pubring.asc": []byte("keyring")},
+	}
+	template := &kcmv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: namespace},
+		Spec: kcmv1.ClusterTemplateSpec{Helm: kcmv1.HelmSpec{Verify: &kcmv1.ChartVerification{
+			Provider:  kcmv1.ChartVerificationProviderHelmProvenance,
+			SecretRef: &fluxmeta.LocalObjectReference{Name: secretName},
+		}}},
+	}
+	hcChart := &sourcev1.HelmChart{
+		ObjectMeta: metav1.ObjectMeta{Name: "chart", Namespace: namespace},
+		Status:     sourcev1.HelmChartStatus{Artifact: &fluxmeta.Artifact{Digest: digest}},
+	}
+
+	r := &TemplateReconciler{
+		Client:          fake.NewClientBuilder().WithScheme(testscheme.Scheme).WithObjects(secret).Build(),
+		SystemNamespace: "kcm-system",
+		verifyChartProvenanceFunc: func(_ context.Context, _ client.Client, hc *sourcev1.HelmChart, keyringData map[string][]byte) (string, error) {
+			require.Equal(t, hcChart, hc)
+			require.Equal(t, secret.Data, keyringData)
+			return "Chart Signer (FINGERPRINT)", nil
+		},
+	}
+
+	verification, err := r.verifyChart(t.Context(), template, hcChart)
+	require.NoError(t, err)
+	require.Equal(t, kcmv1.ChartVerificationProviderHelmProvenance, verification.Provider)
+	require.Equal(t, "Chart Signer (FINGERPRINT)", verification.Signer)
+	require.Equal(t, digest, verification.Digest)
+
+	// the verification time is preserved while the verified chart stays the same
+	verification.VerificationTime = metav1.NewTime(verification.VerificationTime.AddDate(0, 0, -1))
+	template.Status.ChartVerification = verification
+	again, err := r.verifyChart(t.Context(), template, hcChart)
+	require.NoError(t, err)
+	require.Equal(t, verification.VerificationTime, again.VerificationTime)
+
+	template.Spec.Helm.Verify.SecretRef.Name = "missing"
+	_, err = r.verifyChart(t.Context(), template, hcChart)
+	require.ErrorContains(t, err, "failed to get PGP keyring Secret")
+}
+
+func Test_verifyChart_systemPolicyApplies(t *testing.T) {
+	t.Parallel()
+
+	systemPolicy := &kcmv1.ChartVerification{
+		Provider:  kcmv1.ChartVerificationProviderCosign,
+		SecretRef: &fluxmeta.LocalObjectReference{Name: "system-keys"},
+	}
+	verified := metav1.Condition{Type: sourcev1.SourceVerifiedCondition, Status: metav1.ConditionTrue, Message: "verified signature"}
+	hcChart := &sourcev1.HelmChart{
+		ObjectMeta: metav1.ObjectMeta{Name: "chart", Namespace: "ns"},
+		Spec:       sourcev1.HelmChartSpec{Verify: cosignChartVerification(systemPolicy)},
+		Status: sourcev1.HelmChartStatus{
+			Artifact:   &fluxmeta.Artifact{Digest: "sha256:abc"},
+			Conditions: []metav1.Condition{verified},
+		},
+	}
+	newTemplate := func(verify *kcmv1.ChartVerification) *kcmv1.ServiceTemplate {
+		return &kcmv1.ServiceTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: "ns"},
+			Spec:       kcmv1.ServiceTemplateSpec{Helm: &kcmv1.HelmSpec{Verify: verify}},
+		}
+	}
+
+	r := &TemplateReconciler{
+		Client:            fake.NewClientBuilder().WithScheme(testscheme.Scheme).Build(),
+		SystemNamespace:   "kcm-system",
+		ChartVerification: systemPolicy,
+	}
+
+	verification, err := r.verifyChart(t.Context(), newTemplate(nil), hcChart)
+	require.NoError(t, err)
+	require.Equal(t, kcmv1.ChartVerificationProviderCosign, verification.Provider)
+	require.Equal(t, "verified signature", verification.Signer)
+
+	// a template cannot replace the system-wide policy with its own
+	_, err = r.verifyChart(t.Context(), newTemplate(&kcmv1.ChartVerification{
+		Provider:  kcmv1.ChartVerificationProviderCosign,
+		SecretRef: &fluxmeta.LocalObjectReference{Name: "template-keys"},
+	}), hcChart)
+	require.ErrorContains(t, err, "conflicts with the system-wide one")
+
+	// the checks of the template apply on top of the system-wide policy
+	_, err = r.verifyChart(t.Context(), newTemplate(&kcmv1.ChartVerification{
+		Provider:  kcmv1.ChartVerificationProviderHelmProvenance,
+		SecretRef: &fluxmeta.LocalObjectReference{Name: "missing"},
+	}), hcChart)
+	require.ErrorContains(t, err, "failed to get PGP keyring Secret")
+}
+
+func Test_verifyChart_sameCosignPolicy(t *testing.T) {
+	t.Parallel()
+
+	const systemNamespace = "kcm-system"
+
+	keyless := &kcmv1.ChartVerification{
+		Provider: kcmv1.ChartVerificationProviderCosign,
+		MatchOIDCIdentity: []sourcev1.OIDCIdentityMatch{{
+			Issuer:  "^https://token.actions.githubusercontent.com$",
+			Subject: "^https://github.com/k0rdent/.*$",
+		}},
+	}
+	withKeys := &kcmv1.ChartVerification{
+		Provider:  kcmv1.ChartVerificationProviderCosign,
+		SecretRef: &fluxmeta.LocalObjectReference{Name: "cosign-keys"},
+	}
+	newChart := func(policy *kcmv1.ChartVerification) *sourcev1.HelmChart {
+		return &sourcev1.HelmChart{
+			ObjectMeta: metav1.ObjectMeta{Name: "chart", Namespace: "ns"},
+			Spec:       sourcev1.HelmChartSpec{Verify: cosignChartVerification(policy)},
+			Status: sourcev1.HelmChartStatus{
+				Artifact:   &fluxmeta.Artifact{Digest: "sha256:abc"},
+				Conditions: []metav1.Condition{{Type: sourcev1.SourceVerifiedCondition, Status: metav1.ConditionTrue, Message: "verified signature"}},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		systemPolicy      *kcmv1.ChartVerification
+		templateNamespace string
+		expectedErr       string
+	}{
+		"namespaced template with the same keyless policy": {
+			systemPolicy:      keyless,
+			templateNamespace: "ns",
+		},
+		"template in the system namespace with the same keys": {
+			systemPolicy:      withKeys,
+			templateNamespace: systemNamespace,
+		},
+		"namespaced template with the keys of the same name": {
+			systemPolicy:      withKeys,
+			templateNamespace: "ns",
+			expectedErr:       "conflicts with the system-wide one",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &TemplateReconciler{
+				Client:            fake.NewClientBuilder().WithScheme(testscheme.Scheme).Build(),
+				SystemNamespace:   systemNamespace,
+				ChartVerification: tt.systemPolicy,
+			}
+			// the template defines its own copy of the system-wide policy
+			template := &kcmv1.ServiceTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: tt.templateNamespace},
+				Spec:       kcmv1.ServiceTemplateSpec{Helm: &kcmv1.HelmSpec{Verify: tt.systemPolicy.DeepCopy()}},
+			}
+
+			verification, err := r.verifyChart(t.Context(), template, newChart(tt.systemPolicy))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, kcmv1.ChartVerificationProviderCosign, verification.Provider)
+			require.Equal(t, "verified signature", verification.Signer)
+		})
+	}
+}
@@ -21,8 +21,11 @@ import (
 	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -232,6 +235,7 @@ func (r *TemplateChainReconciler) reconcileObj(ctx context.Context, tplChain tem
 
 		operation, err := ctrl.CreateOrUpdate(ctx, r.Client, target, func() error {
 			kubeutil.AddOwnerReference(target, tplChain)
+			syncTemplateDeprecation(target, source, tplChain)
 			return nil
 		})
 		if err != nil {
@@ -245,7 +249,7 @@ func (r *TemplateChainReconciler) reconcileObj(ctx context.Context, tplChain tem
 			l.Info(r.templateKind+" was successfully created", "template namespace", tplChain.GetNamespace(), "template name", supportedTemplate.Name)
 		}
 		if operation == controllerutil.OperationResultUpdated {
-			l.Info("Successfully updated OwnerReference on "+r.templateKind, "template namespace", tplChain.GetNamespace(), "template name", supportedTemplate.Name)
+			l.Info("Successfully updated "+r.templateKind, "template namespace", tplChain.GetNamespace(), "template name", supportedTemplate.Name)
 		}
 	}
 
@@ -299,6 +303,38 @@ func getTemplateNamesManagedByChain(chain templateChain) []string {
 	return result
 }
 
+// mapSystemTemplateToChains returns a map function enqueueing the template chains supporting the given system template,
+// so the deprecation of the source template is propagated to the templates managed by the chains.
+func (r *TemplateChainReconciler) mapSystemTemplateToChains(cl client.Client, chains client.ObjectList) func(context.Context, client.Object) ([]ctrl.Request, error) {
+	return func(ctx context.Context, o client.Object) ([]ctrl.Request, error) {
+		if o.GetNamespace() != r.SystemNamespace {
+			return nil, nil
+		}
+
+		list, ok := chains.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %T of the template chains list", chains)
+		}
+		if err := cl.List(ctx, list, client.MatchingFields{kcmv1.TemplateChainSupportedTemplatesIndexKey: o.GetName()}); err != nil {
+			return nil, fmt.Errorf("failed to list template chains by template %s: %w", o.GetName(), err)
+		}
+
+		var resp []ctrl.Request
+		if err := apimeta.EachListItem(list, func(obj runtime.Object) error {
+			chain, ok := obj.(client.Object)
+			if !ok {
+				return fmt.Errorf("unexpected type %T of the template chain", obj)
+			}
+			resp = append(resp, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(chain)})
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		return resp, nil
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterTemplateChainReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.templateKind = kcmv1.ClusterTemplateKind
@@ -308,6 +344,8 @@ func (r *ClusterTemplateChainReconciler) SetupWithManager(mgr ctrl.Manager) erro
 			RateLimiter: ratelimitutil.DefaultFastSlow(),
 		}).
 		For(&kcmv1.ClusterTemplateChain{}).
+		Watches(&kcmv1.ClusterTemplate{}, kubeutil.EnqueueRequestsFromMapFunc(r.mapSystemTemplateToChains(mgr.GetClient(), &kcmv1.ClusterTemplateChainList{})),
+			builder.WithPredicates(templateDeprecationChangedPredicate)).
 		Complete(r)
 }
 
@@ -320,5 +358,7 @@ func (r *ServiceTemplateChainReconciler) SetupWithManager(mgr ctrl.Manager) erro
 			RateLimiter: ratelimitutil.DefaultFastSlow(),
 		}).
 		For(&kcmv1.ServiceTemplateChain{}).
+		Watches(&kcmv1.ServiceTemplate{}, kubeutil.EnqueueRequestsFromMapFunc(r.mapSystemTemplateToChains(mgr.GetClient(), &kcmv1.ServiceTemplateChainList{})),
+			builder.WithPredicates(templateDeprecationChangedPredicate)).
 		Complete(r)
 }
@@ -0,0 +1,453 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"helm.sh/helm/v3/pkg/chart"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/yaml"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/helm"
+	"github.com/K0rdent/kcm/internal/metrics"
+	"github.com/K0rdent/kcm/internal/record"
+	ratelimitutil "github.com/K0rdent/kcm/internal/util/ratelimit"
+	releaseutil "github.com/K0rdent/kcm/internal/util/release"
+)
+
+// TemplateGCReconciler periodically removes the templates which are not referenced by any object
+// keeping the given number of the most recent versions of each template.
+type TemplateGCReconciler struct {
+	client.Client
+
+	SystemNamespace string
+	// Interval is the interval between the garbage collections.
+	Interval time.Duration
+	// KeepVersions is the number of the most recent versions of each template kept regardless of their usage.
+	KeepVersions int
+
+	downloadHelmChartFunc func(ctx context.Context, chartURL, digest string) (*chart.Chart, error)
+}
+
+// kcmTemplatesChartTemplatesDir is the directory of the kcm-templates chart holding the templates shipped with a Release.
+const kcmTemplatesChartTemplatesDir = "files/templates/"
+
+// templateKey identifies a template by its kind, namespace and name.
+type templateKey struct {
+	kind, namespace, name string
+}
+
+func (r *TemplateGCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("Collecting unused templates")
+
+	mgmt := new(kcmv1.Management)
+	if err := r.Get(ctx, req.NamespacedName, mgmt); err != nil {
+		l.Error(err, "unable to fetch Management")
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !mgmt.DeletionTimestamp.IsZero() {
+		l.Info("Management is being deleted, skipping templates garbage collection")
+		return ctrl.Result{}, nil
+	}
+
+	referenced, err := r.referencedTemplates(ctx, mgmt)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to collect referenced templates: %w", err)
+	}
+
+	templates, err := r.listTemplates(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var errs error
+	for _, template := range collectableTemplates(templates, referenced, r.KeepVersions) {
+		kind := template.GetObjectKind().GroupVersionKind().Kind
+		if err := r.deleteTemplate(ctx, template); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		l.Info("Removed unused template", "kind", kind, "namespace", template.GetNamespace(), "name", template.GetName())
+		record.Eventf(mgmt, template, "TemplateGarbageCollected", "DeleteTemplate", "Removed unused %s %s", kind, client.ObjectKeyFromObject(template))
+		metrics.TrackMetricTemplateGarbageCollected(kind, template.GetNamespace())
+	}
+
+	if errs != nil {
+		return ctrl.Result{}, errs
+	}
+
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// referencedTemplates returns the templates referenced by the objects of the current state of the cluster.
+func (r *TemplateGCReconciler) referencedTemplates(ctx context.Context, mgmt *kcmv1.Management) (map[templateKey]struct{}, error) {
+	referenced := make(map[templateKey]struct{})
+	add := func(kind, namespace string, names ...string) {
+		for _, name := range names {
+			if name != "" {
+				referenced[templateKey{kind: kind, namespace: namespace, name: name}] = struct{}{}
+			}
+		}
+	}
+	addService := func(namespace, name string) {
+		add(kcmv1.ServiceTemplateKind, namespace, name)
+		add(kcmv1.ServiceTemplateKind, r.SystemNamespace, name)
+	}
+
+	add(kcmv1.ProviderTemplateKind, "", componentsTemplates(mgmt.Spec.ComponentsCommonSpec, mgmt.Status.ComponentsCommonStatus)...)
+	regions := new(kcmv1.RegionList)
+	if err := r.List(ctx, regions); err != nil {
+		return nil, fmt.Errorf("failed to list Regions: %w", err)
+	}
+	for _, rgn := range regions.Items {
+		add(kcmv1.ProviderTemplateKind, "", componentsTemplates(rgn.Spec.ComponentsCommonSpec, rgn.Status.ComponentsCommonStatus)...)
+	}
+
+	// ProviderTemplates are bound to the Releases the management and the regions are on or are being upgraded to
+	releasesInUse := []string{mgmt.Spec.Release, mgmt.Status.Release}
+	for i := range regions.Items {
+		releasesInUse = append(releasesInUse, regions.Items[i].Status.Release, mgmt.RegionRelease(&regions.Items[i]))
+	}
+	slices.Sort(releasesInUse)
+	for _, name := range slices.Compact(releasesInUse) {
+		if name == "" {
+			continue
+		}
+		release := new(kcmv1.Release)
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, release); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Release %s: %w", name, err)
+		}
+		add(kcmv1.ProviderTemplateKind, "", release.Templates()...)
+	}
+
+	// the Cluster and Service templates shipped with the current Release are kept for the new clusters
+	for _, name := range slices.Compact([]string{mgmt.Spec.Release, mgmt.Status.Release}) {
+		if name == "" {
+			continue
+		}
+		if err := r.addReleaseShippedTemplates(ctx, name, add); err != nil {
+			return nil, err
+		}
+	}
+
+	cds := new(kcmv1.ClusterDeploymentList)
+	if err := r.List(ctx, cds); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+	for _, cd := range cds.Items {
+		add(kcmv1.ClusterTemplateKind, cd.Namespace, cd.Spec.Template)
+		add(kcmv1.ClusterTemplateKind, cd.Namespace, cd.Status.AvailableUpgrades...)
+		for _, svc := range cd.Spec.ServiceSpec.Services {
+			add(kcmv1.ServiceTemplateKind, cd.Namespace, svc.Template)
+		}
+	}
+
+	mcss := new(kcmv1.MultiClusterServiceList)
+	if err := r.List(ctx, mcss); err != nil {
+		return nil, fmt.Errorf("failed to list MultiClusterServices: %w", err)
+	}
+	for _, mcs := range mcss.Items {
+		for _, svc := range mcs.Spec.ServiceSpec.Services {
+			add(kcmv1.ServiceTemplateKind, r.SystemNamespace, svc.Template)
+		}
+	}
+
+	serviceSets := new(kcmv1.ServiceSetList)
+	if err := r.List(ctx, serviceSets); err != nil {
+		return nil, fmt.Errorf("failed to list ServiceSets: %w", err)
+	}
+	for _, serviceSet := range serviceSets.Items {
+		for _, svc := range serviceSet.Spec.Services {
+			addService(serviceSet.Namespace, svc.Template)
+		}
+	}
+
+	fleetUpgrades := new(kcmv1.ClusterFleetUpgradeList)
+	if err := r.List(ctx, fleetUpgrades); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterFleetUpgrades: %w", err)
+	}
+	for _, upgrade := range fleetUpgrades.Items {
+		add(kcmv1.ClusterTemplateKind, upgrade.Namespace, upgrade.Spec.TargetTemplate)
+		for _, cluster := range upgrade.Status.Clusters {
+			add(kcmv1.ClusterTemplateKind, upgrade.Namespace, cluster.CurrentTemplate)
+			add(kcmv1.ClusterTemplateKind, upgrade.Namespace, cluster.RemainingTemplates...)
+		}
+	}
+
+	// the templates supported by a chain are copied from the system namespace into the chain namespace
+	clusterChains := new(kcmv1.ClusterTemplateChainList)
+	if err := r.List(ctx, clusterChains); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterTemplateChains: %w", err)
+	}
+	for _, chain := range clusterChains.Items {
+		for _, name := range getTemplateNamesManagedByChain(&chain) {
+			add(kcmv1.ClusterTemplateKind, chain.Namespace, name)
+			add(kcmv1.ClusterTemplateKind, r.SystemNamespace, name)
+		}
+	}
+
+	serviceChains := new(kcmv1.ServiceTemplateChainList)
+	if err := r.List(ctx, serviceChains); err != nil {
+		return nil, fmt.Errorf("failed to list ServiceTemplateChains: %w", err)
+	}
+	for _, chain := range serviceChains.Items {
+		for _, name := range getTemplateNamesManagedByChain(&chain) {
+			addService(chain.Namespace, name)
+		}
+	}
+
+	return referenced, nil
+}
+
+// addReleaseShippedTemplates adds the templates shipped with the given Release by the kcm-templates chart:
+// the ones installed by the HelmRelease of the chart and the ones declared in the chart itself,
+// since the chart does not install the templates which already exist, e.g. shipped with another Release.
+func (r *TemplateGCReconciler) addReleaseShippedTemplates(ctx context.Context, releaseName string, add func(kind, namespace string, names ...string)) error {
+	chartName := releaseutil.TemplatesChartFromReleaseName(releaseName)
+	installedBy := client.MatchingLabels{kcmv1.FluxHelmChartNameKey: chartName, kcmv1.FluxHelmChartNamespaceKey: r.SystemNamespace}
+
+	clusterTemplates := new(kcmv1.ClusterTemplateList)
+	if err := r.List(ctx, clusterTemplates, client.InNamespace(r.SystemNamespace), installedBy); err != nil {
+		return fmt.Errorf("failed to list ClusterTemplates of Release %s: %w", releaseName, err)
+	}
+	for _, template := range clusterTemplates.Items {
+		add(kcmv1.ClusterTemplateKind, r.SystemNamespace, template.Name)
+	}
+
+	serviceTemplates := new(kcmv1.ServiceTemplateList)
+	if err := r.List(ctx, serviceTemplates, client.InNamespace(r.SystemNamespace), installedBy); err != nil {
+		return fmt.Errorf("failed to list ServiceTemplates of Release %s: %w", releaseName, err)
+	}
+	for _, template := range serviceTemplates.Items {
+		add(kcmv1.ServiceTemplateKind, r.SystemNamespace, template.Name)
+	}
+
+	hcChart := new(sourcev1.HelmChart)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.SystemNamespace, Name: chartName}, hcChart); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get HelmChart %s/%s: %w", r.SystemNamespace, chartName, err)
+	}
+	if hcChart.Status.Artifact == nil {
+		return fmt.Errorf("HelmChart %s/%s of Release %s has no artifact yet", r.SystemNamespace, chartName, releaseName)
+	}
+
+	if r.downloadHelmChartFunc == nil {
+		r.downloadHelmChartFunc = helm.DownloadChart
+	}
+	templatesChart, err := r.downloadHelmChartFunc(ctx, hcChart.Status.Artifact.URL, hcChart.Status.Artifact.Digest)
+	if err != nil {
+		return fmt.Errorf("failed to download templates chart of Release %s: %w", releaseName, err)
+	}
+	for _, file := range templatesChart.Files {
+		if !strings.HasPrefix(file.Name, kcmTemplatesChartTemplatesDir) {
+			continue
+		}
+		obj := new(metav1.PartialObjectMetadata)
+		if err := yaml.Unmarshal(file.Data, obj); err != nil {
+			return fmt.Errorf("failed to parse %s of the templates chart of Release %s: %w", file.Name, releaseName, err)
+		}
+		if obj.Kind == kcmv1.ClusterTemplateKind || obj.Kind == kcmv1.ServiceTemplateKind {
+			add(obj.Kind, r.SystemNamespace, obj.Name)
+		}
+	}
+
+	return nil
+}
+
+// componentsTemplates returns the ProviderTemplates defined in the given components spec and deployed according to the given status.
+func componentsTemplates(spec kcmv1.ComponentsCommonSpec, status kcmv1.ComponentsCommonStatus) []string {
+	var templates []string
+	if spec.Core != nil {
+		templates = append(templates, spec.Core.KCM.Template, spec.Core.CAPI.Template)
+	}
+	for _, p := range spec.Providers {
+		templates = append(templates, p.Template)
+	}
+	for _, component := range status.Components {
+		templates = append(templates, component.Template)
+	}
+	return templates
+}
+
+// listTemplates returns all of the ProviderTemplates, ClusterTemplates and ServiceTemplates.
+func (r *TemplateGCReconciler) listTemplates(ctx context.Context) ([]templateCommon, error) {
+	var templates []templateCommon
+
+	providerTemplates := new(kcmv1.ProviderTemplateList)
+	if err := r.List(ctx, providerTemplates); err != nil {
+		return nil, fmt.Errorf("failed to list ProviderTemplates: %w", err)
+	}
+	for i := range providerTemplates.Items {
+		providerTemplates.Items[i].SetGroupVersionKind(kcmv1.GroupVersion.WithKind(kcmv1.ProviderTemplateKind))
+		templates = append(templates, &providerTemplates.Items[i])
+	}
+
+	clusterTemplates := new(kcmv1.ClusterTemplateList)
+	if err := r.List(ctx, clusterTemplates); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterTemplates: %w", err)
+	}
+	for i := range clusterTemplates.Items {
+		clusterTemplates.Items[i].SetGroupVersionKind(kcmv1.GroupVersion.WithKind(kcmv1.ClusterTemplateKind))
+		templates = append(templates, &clusterTemplates.Items[i])
+	}
+
+	serviceTemplates := new(kcmv1.ServiceTemplateList)
+	if err := r.List(ctx, serviceTemplates); err != nil {
+		return nil, fmt.Errorf("failed to list ServiceTemplates: %w", err)
+	}
+	for i := range serviceTemplates.Items {
+		serviceTemplates.Items[i].SetGroupVersionKind(kcmv1.GroupVersion.WithKind(kcmv1.ServiceTemplateKind))
+		templates = append(templates, &serviceTemplates.Items[i])
+	}
+
+	return templates, nil
+}
+
+// templateVersionSuffix matches the version suffix of the template names, e.g. "-1-0-2".
+var templateVersionSuffix = regexp.MustCompile(`(-\d+)+$`)
+
+// collectableTemplates returns the templates which are neither referenced, managed by a chain, being deleted,
+// nor among the given number of the most recent versions of the same template.
+// The versions of a template are the templates of the same kind and namespace with the same chart,
+// or the same name without the version suffix if the chart is unknown.
+func collectableTemplates(templates []templateCommon, referenced map[templateKey]struct{}, keepVersions int) []templateCommon {
+	groups := make(map[templateKey][]templateCommon)
+	for _, template := range templates {
+		group := templateKey{
+			kind:      template.GetObjectKind().GroupVersionKind().Kind,
+			namespace: template.GetNamespace(),
+			name:      templateVersionSuffix.ReplaceAllString(template.GetName(), ""),
+		}
+		if spec := template.GetHelmSpec(); spec != nil && spec.ChartSpec != nil && spec.ChartSpec.Chart != "" {
+			group.name = spec.ChartSpec.Chart
+		}
+		groups[group] = append(groups[group], template)
+	}
+
+	var result []templateCommon
+	for _, versions := range groups {
+		slices.SortStableFunc(versions, compareTemplateVersionsDesc)
+		for i, template := range versions {
+			key := templateKey{kind: template.GetObjectKind().GroupVersionKind().Kind, namespace: template.GetNamespace(), name: template.GetName()}
+			if _, ok := referenced[key]; ok || i < keepVersions || !template.GetDeletionTimestamp().IsZero() || managedByChain(template) {
+				continue
+			}
+			result = append(result, template)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b templateCommon) int {
+		return strings.Compare(a.GetNamespace()+"/"+a.GetName(), b.GetNamespace()+"/"+b.GetName())
+	})
+	return result
+}
+
+// compareTemplateVersionsDesc orders the templates from the most recent chart version,
+// falling back to the creation time if any of the versions is unknown.
+func compareTemplateVersionsDesc(a, b templateCommon) int {
+	av, aErr := semver.NewVersion(a.GetCommonStatus().ChartVersion)
+	bv, bErr := semver.NewVersion(b.GetCommonStatus().ChartVersion)
+	if aErr == nil && bErr == nil {
+		if c := bv.Compare(av); c != 0 {
+			return c
+		}
+	}
+	return b.GetCreationTimestamp().Compare(a.GetCreationTimestamp().Time)
+}
+
+func managedByChain(template client.Object) bool {
+	return slices.ContainsFunc(template.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+		return ref.Kind == kcmv1.ClusterTemplateChainKind || ref.Kind == kcmv1.ServiceTemplateChainKind
+	})
+}
+
+// deleteTemplate deletes the given template alongside with the HelmChart and the schema ConfigMap created for it.
+func (r *TemplateGCReconciler) deleteTemplate(ctx context.Context, template templateCommon) error {
+	kind := template.GetObjectKind().GroupVersionKind().Kind
+	if err := r.Delete(ctx, template); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", kind, client.ObjectKeyFromObject(template), err)
+	}
+
+	namespace := template.GetNamespace()
+	if namespace == "" {
+		namespace = r.SystemNamespace
+	}
+
+	var errs error
+	if spec := template.GetHelmSpec(); spec != nil && spec.ChartSpec != nil {
+		helmChart := &sourcev1.HelmChart{ObjectMeta: metav1.ObjectMeta{Name: template.GetName(), Namespace: namespace}}
+		if err := r.deleteOwnedBy(ctx, sourcev1.HelmChartKind, helmChart, template); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if name := template.GetCommonStatus().SchemaConfigMapName; name != "" {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		if err := r.deleteOwnedBy(ctx, "ConfigMap", cm, template); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// deleteOwnedBy deletes the given object if it is owned by the given owner.
+func (r *TemplateGCReconciler) deleteOwnedBy(ctx context.Context, kind string, obj, owner client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !slices.ContainsFunc(obj.GetOwnerReferences(), func(ref metav1.OwnerReference) bool { return ref.UID == owner.GetUID() }) {
+		return nil
+	}
+	if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", kind, client.ObjectKeyFromObject(obj), err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemplateGCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimitutil.DefaultFastSlow(),
+		}).
+		Named("templategc_controller").
+		For(&kcmv1.Management{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
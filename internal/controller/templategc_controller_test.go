@@ -0,0 +1,245 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/test/objects/release"
+	"github.com/K0rdent/kcm/test/objects/template"
+	testscheme "github.com/K0rdent/kcm/test/scheme"
+)
+
+const templateGCTestNamespace = "default"
+
+func templateNames(templates []templateCommon) []string {
+	names := make([]string, 0, len(templates))
+	for _, t := range templates {
+		names = append(names, t.GetName())
+	}
+	return names
+}
+
+func Test_collectableTemplates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	key := func(name string) templateKey {
+		return templateKey{kind: kcmv1.ClusterTemplateKind, namespace: templateGCTestNamespace, name: name}
+	}
+
+	tests := map[string]struct {
+		templates    func() []templateCommon
+		referenced   map[templateKey]struct{}
+		keepVersions int
+		expected     []string
+	}{
+		"keeps the most recent versions": {
+			templates: func() []templateCommon {
+				return []templateCommon{
+					template.NewClusterTemplate(template.WithName("aws-1-0-0"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.0"}}), template.WithChartVersionStatus("1.0.0"), template.WithCreationTimestamp(now)),
+					template.NewClusterTemplate(template.WithName("aws-1-0-10"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.10"}}), template.WithChartVersionStatus("1.0.10"), template.WithCreationTimestamp(now.Add(-time.Hour))),
+					template.NewClusterTemplate(template.WithName("aws-1-0-2"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.2"}}), template.WithChartVersionStatus("1.0.2"), template.WithCreationTimestamp(now)),
+				}
+			},
+			keepVersions: 2,
+			expected:     []string{"aws-1-0-0"},
+		},
+		"groups by the chart name": {
+			templates: func() []templateCommon {
+				return []templateCommon{
+					template.NewClusterTemplate(template.WithName("aws-1-0-0"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.0"}}), template.WithChartVersionStatus("1.0.0"), template.WithCreationTimestamp(now)),
+					template.NewClusterTemplate(template.WithName("azure-1-0-0"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "azure", Version: "1.0.0"}}), template.WithChartVersionStatus("1.0.0"), template.WithCreationTimestamp(now)),
+					template.NewClusterTemplate(template.WithName("azure-1-0-1"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "azure", Version: "1.0.1"}}), template.WithChartVersionStatus("1.0.1"), template.WithCreationTimestamp(now)),
+				}
+			},
+			keepVersions: 1,
+			expected:     []string{"azure-1-0-0"},
+		},
+		"groups by the name without version suffix when no chart spec": {
+			templates: func() []templateCommon {
+				return []templateCommon{
+					template.NewClusterTemplate(template.WithName("custom-1-0-0"), template.WithCreationTimestamp(now.Add(-time.Hour))),
+					template.NewClusterTemplate(template.WithName("custom-1-0-1"), template.WithCreationTimestamp(now)),
+				}
+			},
+			keepVersions: 1,
+			expected:     []string{"custom-1-0-0"},
+		},
+		"referenced templates are never collected": {
+			templates: func() []templateCommon {
+				return []templateCommon{
+					template.NewClusterTemplate(template.WithName("aws-1-0-0"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.0"}}), template.WithChartVersionStatus("1.0.0"), template.WithCreationTimestamp(now)),
+					template.NewClusterTemplate(template.WithName("aws-1-0-1"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.1"}}), template.WithChartVersionStatus("1.0.1"), template.WithCreationTimestamp(now)),
+				}
+			},
+			referenced: map[templateKey]struct{}{key("aws-1-0-0"): {}, key("aws-1-0-1"): {}},
+			expected:   []string{},
+		},
+		"templates managed by chains and being deleted are skipped": {
+			templates: func() []templateCommon {
+				chained := template.NewClusterTemplate(template.WithName("aws-1-0-0"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.0"}}), template.WithChartVersionStatus("1.0.0"), template.WithCreationTimestamp(now))
+				chained.OwnerReferences = []metav1.OwnerReference{{Kind: kcmv1.ClusterTemplateChainKind, Name: "chain"}}
+				deleted := template.NewClusterTemplate(template.WithName("aws-1-0-1"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.1"}}), template.WithChartVersionStatus("1.0.1"), template.WithCreationTimestamp(now))
+				deleted.DeletionTimestamp = &metav1.Time{Time: now}
+				return []templateCommon{chained, deleted, template.NewClusterTemplate(template.WithName("aws-1-0-2"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.2"}}), template.WithChartVersionStatus("1.0.2"), template.WithCreationTimestamp(now))}
+			},
+			expected: []string{"aws-1-0-2"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := collectableTemplates(tc.templates(), tc.referenced, tc.keepVersions)
+			require.Equal(t, tc.expected, templateNames(got))
+		})
+	}
+}
+
+func TestTemplateGCReconcile(t *testing.T) {
+	t.Parallel()
+
+	const systemNamespace = "kcm-system"
+	now := time.Now()
+
+	mgmt := &kcmv1.Management{ObjectMeta: metav1.ObjectMeta{Name: kcmv1.ManagementName}}
+	used := template.NewClusterTemplate(template.WithName("aws-1-0-0"), template.WithUID("aws-1-0-0"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.0"}}), template.WithChartVersionStatus("1.0.0"), template.WithCreationTimestamp(now))
+	unused := template.NewClusterTemplate(template.WithName("aws-1-0-1"), template.WithUID("aws-1-0-1"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.1"}}), template.WithChartVersionStatus("1.0.1"), template.WithCreationTimestamp(now))
+	unused.Status.SchemaConfigMapName = "aws-1-0-1-schema"
+	latest := template.NewClusterTemplate(template.WithName("aws-1-0-2"), template.WithUID("aws-1-0-2"), template.WithHelmSpec(kcmv1.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "aws", Version: "1.0.2"}}), template.WithChartVersionStatus("1.0.2"), template.WithCreationTimestamp(now))
+
+	cd := &kcmv1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cd", Namespace: templateGCTestNamespace},
+		Spec:       kcmv1.ClusterDeploymentSpec{Template: used.Name},
+	}
+	ownedBy := func(template *kcmv1.ClusterTemplate) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: kcmv1.GroupVersion.String(), Kind: kcmv1.ClusterTemplateKind, Name: template.Name, UID: template.UID}}
+	}
+	helmChart := &sourcev1.HelmChart{ObjectMeta: metav1.ObjectMeta{Name: unused.Name, Namespace: templateGCTestNamespace, OwnerReferences: ownedBy(unused)}}
+	schema := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: unused.Status.SchemaConfigMapName, Namespace: templateGCTestNamespace, OwnerReferences: ownedBy(unused)}}
+	foreignChart := &sourcev1.HelmChart{ObjectMeta: metav1.ObjectMeta{Name: used.Name, Namespace: templateGCTestNamespace}}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(testscheme.Scheme).
+		WithObjects(mgmt, used, unused, latest, cd, helmChart, schema, foreignChart).
+		Build()
+
+	r := &TemplateGCReconciler{Client: cl, SystemNamespace: systemNamespace, Interval: time.Hour, KeepVersions: 1}
+	result, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Name: mgmt.Name}})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, result.RequeueAfter)
+
+	for _, obj := range []client.Object{used, latest, foreignChart} {
+		require.NoError(t, cl.Get(t.Context(), client.ObjectKeyFromObject(obj), obj), "%s must be kept", obj.GetName())
+	}
+	for _, obj := range []client.Object{unused, helmChart, schema} {
+		err := cl.Get(t.Context(), client.ObjectKeyFromObject(obj), obj)
+		require.True(t, apierrors.IsNotFound(err), "%s must be removed, got %v", obj.GetName(), err)
+	}
+}
+
+func TestTemplateGCReconcile_releases(t *testing.T) {
+	t.Parallel()
+
+	const systemNamespace = "kcm-system"
+
+	newRelease := func(name string) *kcmv1.Release {
+		return release.New(
+			release.WithName(name),
+			release.WithKCMTemplateName("kcm-"+name),
+			release.WithCAPITemplateName("cluster-api-"+name),
+			release.WithRegionalTemplateName("kcm-regional-"+name),
+		)
+	}
+	templatesChartLabels := func(releaseName string) template.Opt {
+		return template.WithLabels(map[string]string{
+			kcmv1.FluxHelmChartNameKey:      releaseName + "-tpl",
+			kcmv1.FluxHelmChartNamespaceKey: systemNamespace,
+		})
+	}
+
+	mgmt := &kcmv1.Management{
+		ObjectMeta: metav1.ObjectMeta{Name: kcmv1.ManagementName},
+		Spec:       kcmv1.ManagementSpec{Release: "1-1-0"},
+		Status:     kcmv1.ManagementStatus{Release: "1-0-0"},
+	}
+	region := &kcmv1.Region{ObjectMeta: metav1.ObjectMeta{Name: "region"}, Status: kcmv1.RegionStatus{Release: "0-9-0"}}
+
+	inUse := []client.Object{
+		template.NewProviderTemplate(template.WithName("kcm-1-1-0")), template.NewProviderTemplate(template.WithName("kcm-1-0-0")), template.NewProviderTemplate(template.WithName("cluster-api-0-9-0")),
+		// installed by the templates chart of the current Release
+		template.NewClusterTemplate(template.WithName("installed-1-1-0"), template.WithNamespace(systemNamespace), templatesChartLabels("1-1-0")),
+		template.NewClusterTemplate(template.WithName("installed-1-0-0"), template.WithNamespace(systemNamespace), templatesChartLabels("1-0-0")),
+		// shipped with the current Release but installed by a previous one
+		template.NewClusterTemplate(template.WithName("shipped-0-8-0"), template.WithNamespace(systemNamespace), templatesChartLabels("0-8-0")),
+	}
+	unused := []client.Object{
+		template.NewProviderTemplate(template.WithName("kcm-0-8-0")), template.NewProviderTemplate(template.WithName("cluster-api-0-8-0")),
+		template.NewClusterTemplate(template.WithName("installed-0-8-0"), template.WithNamespace(systemNamespace), templatesChartLabels("0-8-0")),
+	}
+	templatesChart := &sourcev1.HelmChart{
+		ObjectMeta: metav1.ObjectMeta{Name: "1-1-0-tpl", Namespace: systemNamespace},
+		Status:     sourcev1.HelmChartStatus{Artifact: &fluxmeta.Artifact{URL: "http://source-controller/1-1-0-tpl.tgz", Digest: "sha256:1-1-0"}},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(testscheme.Scheme).
+		WithObjects(mgmt, region, newRelease("1-1-0"), newRelease("1-0-0"), newRelease("0-9-0"), newRelease("0-8-0"), templatesChart).
+		WithStatusSubresource(mgmt, region).
+		WithObjects(inUse...).
+		WithObjects(unused...).
+		Build()
+
+	r := &TemplateGCReconciler{
+		Client:          cl,
+		SystemNamespace: systemNamespace,
+		Interval:        time.Hour,
+		downloadHelmChartFunc: func(_ context.Context, chartURL, digest string) (*chart.Chart, error) {
+			require.Equal(t, templatesChart.Status.Artifact.URL, chartURL)
+			require.Equal(t, templatesChart.Status.Artifact.Digest, digest)
+			return &chart.Chart{Files: []*chart.File{
+				{Name: "files/templates/shipped-0-8-0.yaml", Data: []byte("apiVersion: k0rdent.mirantis.com/v1beta1\nkind: ClusterTemplate\nmetadata:\n  name: shipped-0-8-0\n")},
+				{Name: "files/templates/installed-1-1-0.yaml", Data: []byte("apiVersion: k0rdent.mirantis.com/v1beta1\nkind: ClusterTemplate\nmetadata:\n  name: installed-1-1-0\n")},
+			}}, nil
+		},
+	}
+	_, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: types.NamespacedName{Name: mgmt.Name}})
+	require.NoError(t, err)
+
+	for _, obj := range inUse {
+		require.NoError(t, cl.Get(t.Context(), client.ObjectKeyFromObject(obj), obj), "%s must be kept", obj.GetName())
+	}
+	for _, obj := range unused {
+		err := cl.Get(t.Context(), client.ObjectKeyFromObject(obj), obj)
+		require.True(t, apierrors.IsNotFound(err), "%s must be removed, got %v", obj.GetName(), err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/rest"
 
@@ -64,7 +65,7 @@ func (*Actor) EnsureReleaseWithValues(
 	actionConfig *action.Configuration,
 	hcChart *chart.Chart,
 	clusterDeployment *kcmv1.ClusterDeployment,
-) error {
+) (*release.Release, error) {
 	install := action.NewInstall(actionConfig)
 	install.DryRun = true
 	install.ReleaseName = clusterDeployment.Name
@@ -73,9 +74,8 @@ func (*Actor) EnsureReleaseWithValues(
 
 	vals, err := clusterDeployment.HelmValues()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = install.RunWithContext(ctx, hcChart, vals)
-	return err
+	return install.RunWithContext(ctx, hcChart, vals)
 }
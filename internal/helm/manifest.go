@@ -0,0 +1,199 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// releaseSecretDataKey is the key of the Helm storage Secret holding the encoded release.
+const releaseSecretDataKey = "release"
+
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// GetDeployedManifest returns the manifest and the revision of the latest release
+// of the given [helmcontrollerv2.HelmRelease] by reading the Helm storage Secret.
+// The given client must point to the cluster the HelmRelease is deployed to.
+// An empty manifest and zero revision are returned if there is no release in the history yet.
+func GetDeployedManifest(ctx context.Context, cl client.Client, hr *helmcontrollerv2.HelmRelease) (manifest string, revision int, _ error) {
+	latest := hr.Status.History.Latest()
+	if latest == nil {
+		return "", 0, nil
+	}
+
+	storageNamespace := hr.Status.StorageNamespace
+	if storageNamespace == "" {
+		storageNamespace = hr.GetStorageNamespace()
+	}
+
+	key := client.ObjectKey{Namespace: storageNamespace, Name: "sh.helm.release.v1." + latest.Name + ".v" + strconv.Itoa(latest.Version)}
+	secret := new(corev1.Secret)
+	if err := cl.Get(ctx, key, secret); err != nil {
+		return "", 0, fmt.Errorf("failed to get Helm release storage Secret %s: %w", key, err)
+	}
+
+	rel, err := decodeRelease(secret.Data[releaseSecretDataKey])
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to decode Helm release from Secret %s: %w", key, err)
+	}
+
+	return rel.Manifest, rel.Version, nil
+}
+
+// decodeRelease decodes the base64 encoded and optionally gzipped Helm release
+// in the same manner as the Helm Secret storage driver does.
+func decodeRelease(data []byte) (*release.Release, error) {
+	b, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(b, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		if b, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+
+	rel := new(release.Release)
+	if err := json.Unmarshal(b, rel); err != nil {
+		return nil, err
+	}
+
+	return rel, nil
+}
+
+// ManifestDiff is the object-level difference between two rendered manifests.
+// Each object is identified as Kind[.group]/[namespace/]name.
+type ManifestDiff struct {
+	Added   []string
+	Changed []string
+	Removed []string
+	// Objects is the total number of objects in the new manifest.
+	Objects int
+}
+
+// String returns a human-readable representation of the diff with
+// one object per line prefixed with "+", "~" or "-".
+func (d *ManifestDiff) String() string {
+	var sb strings.Builder
+	for _, v := range []struct {
+		prefix string
+		ids    []string
+	}{
+		{"+", d.Added},
+		{"~", d.Changed},
+		{"-", d.Removed},
+	} {
+		for _, id := range v.ids {
+			sb.WriteString(v.prefix + " " + id + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// DiffManifests compares two multi-document YAML manifests and returns
+// objects that are added, changed or removed in the new manifest compared to the old one.
+func DiffManifests(oldManifest, newManifest string) (*ManifestDiff, error) {
+	oldObjects, err := parseManifest(oldManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the old manifest: %w", err)
+	}
+	newObjects, err := parseManifest(newManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the new manifest: %w", err)
+	}
+
+	diff := &ManifestDiff{Objects: len(newObjects)}
+	for id, obj := range newObjects {
+		oldObj, ok := oldObjects[id]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, id)
+		case !equality.Semantic.DeepEqual(oldObj, obj):
+			diff.Changed = append(diff.Changed, id)
+		}
+	}
+	for id := range oldObjects {
+		if _, ok := newObjects[id]; !ok {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+
+	slices.Sort(diff.Added)
+	slices.Sort(diff.Changed)
+	slices.Sort(diff.Removed)
+
+	return diff, nil
+}
+
+func parseManifest(manifest string) (map[string]map[string]any, error) {
+	objects := make(map[string]map[string]any)
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		obj := make(map[string]any)
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, err
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		objects[objectID(obj)] = obj
+	}
+
+	return objects, nil
+}
+
+func objectID(obj map[string]any) string {
+	str := func(m map[string]any, key string) string {
+		v, _ := m[key].(string)
+		return v
+	}
+
+	kind := str(obj, "kind")
+	if group, _, found := strings.Cut(str(obj, "apiVersion"), "/"); found {
+		kind += "." + group
+	}
+
+	metadata, _ := obj["metadata"].(map[string]any)
+	name := str(metadata, "name")
+	if ns := str(metadata, "namespace"); ns != "" {
+		name = ns + "/" + name
+	}
+
+	return kind + "/" + name
+}
@@ -0,0 +1,242 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"golang.org/x/crypto/openpgp"           //nolint:staticcheck // helm signs provenance files with the same package
+	"golang.org/x/crypto/openpgp/clearsign" //nolint:staticcheck // helm signs provenance files with the same package
+	"helm.sh/helm/v3/pkg/provenance"
+	helmrepo "helm.sh/helm/v3/pkg/repo"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// provenanceFileSuffix is appended to the chart archive URL to get the
+// location of its provenance file.
+const provenanceFileSuffix = ".prov"
+
+// maxProvenanceDownloadSize limits the size of the downloaded repository
+// index and provenance file.
+const maxProvenanceDownloadSize = 64 << 20
+
+// VerifyChartProvenance verifies the Helm provenance (.prov) file of the chart
+// produced by the given [sourcev1.HelmChart] against the PGP keyring
+// assembled from the keyring data. Every value of the keyring data is expected
+// to contain either an armored or a binary PGP public keyring.
+//
+// The chart must be sourced from a HTTP(S) [sourcev1.HelmRepository]:
+// OCI repositories do not serve provenance files.
+//
+// On success the signer's identity in the "Name <email> (FINGERPRINT)" form is returned.
+func VerifyChartProvenance(ctx context.Context, cl client.Client, hc *sourcev1.HelmChart, keyringData map[string][]byte) (string, error) {
+	if hc.Status.Artifact == nil {
+		return "", fmt.Errorf("HelmChart %s has no artifact", client.ObjectKeyFromObject(hc))
+	}
+	if hc.Spec.SourceRef.Kind != sourcev1.HelmRepositoryKind {
+		return "", fmt.Errorf("provenance verification is supported only for charts sourced from a %s, got %s", sourcev1.HelmRepositoryKind, hc.Spec.SourceRef.Kind)
+	}
+
+	keyring, err := parseKeyring(keyringData)
+	if err != nil {
+		return "", err
+	}
+
+	repo := new(sourcev1.HelmRepository)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: hc.Spec.SourceRef.Name}, repo); err != nil {
+		return "", fmt.Errorf("failed to get HelmRepository %s/%s: %w", hc.Namespace, hc.Spec.SourceRef.Name, err)
+	}
+	if repo.Spec.Type == sourcev1.HelmRepositoryTypeOCI {
+		return "", fmt.Errorf("provenance verification is not supported for the OCI HelmRepository %s", client.ObjectKeyFromObject(repo))
+	}
+	if repo.Status.Artifact == nil {
+		return "", fmt.Errorf("HelmRepository %s has no index artifact", client.ObjectKeyFromObject(repo))
+	}
+
+	indexData, err := download(ctx, http.DefaultClient, repo.Status.Artifact.URL, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to download index of the HelmRepository %s: %w", client.ObjectKeyFromObject(repo), err)
+	}
+	index := new(helmrepo.IndexFile)
+	if err := yaml.Unmarshal(indexData, index); err != nil {
+		return "", fmt.Errorf("failed to parse index of the HelmRepository %s: %w", client.ObjectKeyFromObject(repo), err)
+	}
+
+	version, err := index.Get(hc.Spec.Chart, hc.Status.Artifact.Revision)
+	if err != nil {
+		return "", fmt.Errorf("failed to find chart %s version %s in the HelmRepository %s: %w", hc.Spec.Chart, hc.Status.Artifact.Revision, client.ObjectKeyFromObject(repo), err)
+	}
+	if len(version.URLs) == 0 {
+		return "", fmt.Errorf("chart %s version %s has no URLs in the HelmRepository %s", hc.Spec.Chart, version.Version, client.ObjectKeyFromObject(repo))
+	}
+	chartURL, err := helmrepo.ResolveReferenceURL(repo.Spec.URL, version.URLs[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve URL of the chart %s version %s: %w", hc.Spec.Chart, version.Version, err)
+	}
+
+	httpClient, auth, err := repositoryHTTPClient(ctx, cl, repo)
+	if err != nil {
+		return "", err
+	}
+	provData, err := download(ctx, httpClient, chartURL+provenanceFileSuffix, auth)
+	if err != nil {
+		return "", fmt.Errorf("failed to download provenance file of the chart %s version %s: %w", hc.Spec.Chart, version.Version, err)
+	}
+
+	parsedURL, err := url.Parse(chartURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse chart URL %s: %w", chartURL, err)
+	}
+
+	return verifyProvenance(provData, keyring, path.Base(parsedURL.Path), hc.Status.Artifact.Digest)
+}
+
+// verifyProvenance checks the clearsigned provenance data against the keyring
+// and ensures the provenance records the expected digest for the chart archive.
+func verifyProvenance(provData []byte, keyring openpgp.EntityList, archiveName, digest string) (string, error) {
+	block, _ := clearsign.Decode(provData)
+	if block == nil {
+		return "", errors.New("signature block not found in the provenance file")
+	}
+
+	signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(block.Bytes), block.ArmoredSignature.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify provenance signature: %w", err)
+	}
+
+	// the message block consists of the chart metadata and the sums separated
+	// by the YAML document end marker, see helm.sh/helm/v3/pkg/provenance
+	parts := bytes.Split(block.Plaintext, []byte("\n...\n"))
+	if len(parts) < 2 {
+		return "", errors.New("provenance message block must have at least two parts")
+	}
+	sums := new(provenance.SumCollection)
+	if err := yaml.Unmarshal(parts[1], sums); err != nil {
+		return "", fmt.Errorf("failed to parse provenance checksums: %w", err)
+	}
+
+	sum, ok := sums.Files[archiveName]
+	if !ok {
+		return "", fmt.Errorf("provenance file has no checksum for the chart archive %s", archiveName)
+	}
+	if sum != digest {
+		return "", fmt.Errorf("chart archive %s digest %s does not match the provenance checksum %s", archiveName, digest, sum)
+	}
+
+	return signerIdentity(signer), nil
+}
+
+func signerIdentity(entity *openpgp.Entity) string {
+	fingerprint := strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint[:]))
+	names := slices.Sorted(maps.Keys(entity.Identities))
+	if len(names) == 0 {
+		return fingerprint
+	}
+	return names[0] + " (" + fingerprint + ")"
+}
+
+func parseKeyring(keyringData map[string][]byte) (openpgp.EntityList, error) {
+	var keyring openpgp.EntityList
+	for k, data := range keyringData {
+		entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+		if err != nil {
+			if entities, err = openpgp.ReadKeyRing(bytes.NewReader(data)); err != nil {
+				return nil, fmt.Errorf("failed to read PGP keyring from the %s key: %w", k, err)
+			}
+		}
+		keyring = append(keyring, entities...)
+	}
+	if len(keyring) == 0 {
+		return nil, errors.New("PGP keyring is empty")
+	}
+	return keyring, nil
+}
+
+// repositoryHTTPClient returns the HTTP client and the basic authentication
+// header value to access the given [sourcev1.HelmRepository].
+func repositoryHTTPClient(ctx context.Context, cl client.Client, repo *sourcev1.HelmRepository) (*http.Client, string, error) {
+	var auth string
+	if ref := repo.Spec.SecretRef; ref != nil {
+		secret := new(corev1.Secret)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: repo.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, "", fmt.Errorf("failed to get auth Secret %s of the HelmRepository %s: %w", ref.Name, client.ObjectKeyFromObject(repo), err)
+		}
+		if username, password := secret.Data["username"], secret.Data["password"]; len(username) > 0 || len(password) > 0 {
+			req := &http.Request{Header: make(http.Header)}
+			req.SetBasicAuth(string(username), string(password))
+			auth = req.Header.Get("Authorization")
+		}
+	}
+
+	ref := repo.Spec.CertSecretRef
+	if ref == nil {
+		return http.DefaultClient, auth, nil
+	}
+
+	secret := new(corev1.Secret)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: repo.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, "", fmt.Errorf("failed to get cert Secret %s of the HelmRepository %s: %w", ref.Name, client.ObjectKeyFromObject(repo), err)
+	}
+	caPool, err := x509.SystemCertPool()
+	if err != nil {
+		caPool = x509.NewCertPool()
+	}
+	if caCert := secret.Data["ca.crt"]; len(caCert) > 0 {
+		caPool.AppendCertsFromPEM(caCert)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: caPool, MinVersion: tls.VersionTLS12}
+	return &http.Client{Transport: transport, Timeout: time.Minute}, auth, nil
+}
+
+func download(ctx context.Context, httpClient *http.Client, url, auth string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct request: %w", err)
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download request failed: %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxProvenanceDownloadSize))
+}
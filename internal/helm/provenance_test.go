@@ -0,0 +1,207 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"           //nolint:staticcheck // helm signs provenance files with the same package
+	"golang.org/x/crypto/openpgp/armor"     //nolint:staticcheck // helm signs provenance files with the same package
+	"golang.org/x/crypto/openpgp/clearsign" //nolint:staticcheck // helm signs provenance files with the same package
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testChartName    = "test-chart"
+	testChartVersion = "1.0.0"
+	testChartArchive = testChartName + "-" + testChartVersion + ".tgz"
+	testChartDigest  = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+)
+
+func newTestSigner(t *testing.T, name string) (*openpgp.Entity, []byte) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	return entity, buf.Bytes()
+}
+
+func signProvenance(t *testing.T, signer *openpgp.Entity, archiveName, digest string) []byte {
+	t.Helper()
+
+	message := "apiVersion: v2\nname: " + testChartName + "\nversion: " + testChartVersion + "\n" +
+		"\n...\n" +
+		"files:\n  " + archiveName + ": " + digest + "\n"
+
+	var buf bytes.Buffer
+	w, err := clearsign.Encode(&buf, signer.PrivateKey, nil)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(message))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestVerifyProvenance(t *testing.T) {
+	signer, publicKey := newTestSigner(t, "Chart Signer")
+	_, otherKey := newTestSigner(t, "Someone Else")
+
+	keyring, err := parseKeyring(map[string][]byte{"pubring.asc": publicKey})
+	require.NoError(t, err)
+	otherKeyring, err := parseKeyring(map[string][]byte{"pubring.asc": otherKey})
+	require.NoError(t, err)
+
+	prov := signProvenance(t, signer, testChartArchive, testChartDigest)
+
+	t.Run("valid", func(t *testing.T) {
+		identity, err := verifyProvenance(prov, keyring, testChartArchive, testChartDigest)
+		require.NoError(t, err)
+		require.Contains(t, identity, "Chart Signer <Chart Signer@example.com>")
+	})
+
+	t.Run("unknown signer", func(t *testing.T) {
+		_, err := verifyProvenance(prov, otherKeyring, testChartArchive, testChartDigest)
+		require.ErrorContains(t, err, "failed to verify provenance signature")
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		_, err := verifyProvenance(prov, keyring, testChartArchive, "sha256:ffff")
+		require.ErrorContains(t, err, "does not match the provenance checksum")
+	})
+
+	t.Run("no archive checksum", func(t *testing.T) {
+		_, err := verifyProvenance(prov, keyring, "other-1.0.0.tgz", testChartDigest)
+		require.ErrorContains(t, err, "has no checksum for the chart archive")
+	})
+
+	t.Run("not signed", func(t *testing.T) {
+		_, err := verifyProvenance([]byte("files: {}"), keyring, testChartArchive, testChartDigest)
+		require.ErrorContains(t, err, "signature block not found")
+	})
+}
+
+func TestParseKeyring(t *testing.T) {
+	_, err := parseKeyring(nil)
+	require.ErrorContains(t, err, "PGP keyring is empty")
+
+	_, err = parseKeyring(map[string][]byte{"broken": []byte("not a key")})
+	require.ErrorContains(t, err, "failed to read PGP keyring from the broken key")
+}
+
+func TestVerifyChartProvenance(t *testing.T) {
+	signer, publicKey := newTestSigner(t, "Chart Signer")
+	prov := signProvenance(t, signer, testChartArchive, testChartDigest)
+
+	const (
+		namespace  = "kcm-system"
+		repoName   = "test-repo"
+		authSecret = "repo-auth"
+		username   = "user"
+		password   = "pass"
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("apiVersion: v1\nentries:\n  " + testChartName + ":\n  - name: " + testChartName +
+			"\n    version: " + testChartVersion + "\n    urls:\n    - charts/" + testChartArchive + "\n"))
+	})
+	mux.HandleFunc("/charts/"+testChartArchive+".prov", func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(prov)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, sourcev1.AddToScheme(scheme))
+
+	repo := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: repoName, Namespace: namespace},
+		Spec: sourcev1.HelmRepositorySpec{
+			URL:       srv.URL,
+			SecretRef: &fluxmeta.LocalObjectReference{Name: authSecret},
+		},
+		Status: sourcev1.HelmRepositoryStatus{
+			Artifact: &fluxmeta.Artifact{URL: srv.URL + "/index.yaml"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: authSecret, Namespace: namespace},
+		Data:       map[string][]byte{"username": []byte(username), "password": []byte(password)},
+	}
+
+	newChart := func(revision, digest string) *sourcev1.HelmChart {
+		return &sourcev1.HelmChart{
+			ObjectMeta: metav1.ObjectMeta{Name: testChartName, Namespace: namespace},
+			Spec: sourcev1.HelmChartSpec{
+				Chart:     testChartName,
+				SourceRef: sourcev1.LocalHelmChartSourceReference{Kind: sourcev1.HelmRepositoryKind, Name: repoName},
+			},
+			Status: sourcev1.HelmChartStatus{
+				Artifact: &fluxmeta.Artifact{Revision: revision, Digest: digest},
+			},
+		}
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(repo, secret).WithStatusSubresource(repo).Build()
+	keyringData := map[string][]byte{"pubring.asc": publicKey}
+
+	t.Run("valid", func(t *testing.T) {
+		identity, err := VerifyChartProvenance(t.Context(), cl, newChart(testChartVersion, testChartDigest), keyringData)
+		require.NoError(t, err)
+		require.Contains(t, identity, "Chart Signer")
+	})
+
+	t.Run("tampered chart", func(t *testing.T) {
+		_, err := VerifyChartProvenance(t.Context(), cl, newChart(testChartVersion, "sha256:ffff"), keyringData)
+		require.ErrorContains(t, err, "does not match the provenance checksum")
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := VerifyChartProvenance(t.Context(), cl, newChart("2.0.0", testChartDigest), keyringData)
+		require.ErrorContains(t, err, "failed to find chart")
+	})
+
+	t.Run("OCI repository", func(t *testing.T) {
+		ociRepo := repo.DeepCopy()
+		ociRepo.ResourceVersion = ""
+		ociRepo.Spec.Type = sourcev1.HelmRepositoryTypeOCI
+		ociCl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ociRepo).Build()
+		_, err := VerifyChartProvenance(t.Context(), ociCl, newChart(testChartVersion, testChartDigest), keyringData)
+		require.ErrorContains(t, err, "not supported for the OCI HelmRepository")
+	})
+}
@@ -16,19 +16,23 @@ package metrics //nolint:revive // it is okay for now
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
 )
 
 const (
 	metricLabelTemplateKind      = "template_kind"
 	metricLabelTemplateNamespace = "template_namespace"
 	metricLabelTemplateName      = "template_name"
+	metricLabelTemplateLifecycle = "template_lifecycle"
 	metricLabelParentKind        = "parent_kind"
 	metricLabelParentNamespace   = "parent_namespace"
 	metricLabelParentName        = "parent_name"
@@ -36,11 +40,14 @@ const (
 	metricLabelIPAMKind      = "ipam_kind"
 	metricLabelIPAMNamespace = "ipam_namespace"
 	metricLabelIPAMName      = "ipam_name"
+
+	metricLabelManagementBackupName = "management_backup"
+	metricLabelRegion               = "region"
 )
 
 var (
 	metricTemplateUsage = newGaugeVec("template_usage", "Number of templates currently in use",
-		metricLabelTemplateKind, metricLabelTemplateName, metricLabelParentKind, metricLabelParentNamespace, metricLabelParentName)
+		metricLabelTemplateKind, metricLabelTemplateName, metricLabelTemplateLifecycle, metricLabelParentKind, metricLabelParentNamespace, metricLabelParentName)
 
 	metricTemplateInvalidity = newGaugeVec("template_invalidity", "Number of invalid templates",
 		metricLabelTemplateKind, metricLabelTemplateNamespace, metricLabelTemplateName)
@@ -50,6 +57,31 @@ var (
 
 	metricIPAMClaimsBound = newGaugeVec("ipam_claims_bound", "Number of IPAM claims which are bound",
 		metricLabelIPAMKind, metricLabelIPAMNamespace, metricLabelIPAMName)
+
+	metricManagementBackupLastSuccess = newGaugeVec("management_backup_last_success_timestamp_seconds",
+		"Completion time of the last successfully completed backup in seconds since the Unix epoch",
+		metricLabelManagementBackupName, metricLabelRegion)
+
+	metricManagementBackupDuration = newGaugeVec("management_backup_duration_seconds", "Duration of the last finished backup",
+		metricLabelManagementBackupName, metricLabelRegion)
+
+	metricManagementBackupErrors = newGaugeVec("management_backup_errors", "Number of errors of the last finished backup",
+		metricLabelManagementBackupName, metricLabelRegion)
+
+	metricManagementBackupWarnings = newGaugeVec("management_backup_warnings", "Number of warnings of the last finished backup",
+		metricLabelManagementBackupName, metricLabelRegion)
+
+	metricManagementBackupMissingItems = newGaugeVec("management_backup_missing_items", "Number of expected items absent in the last verified backup",
+		metricLabelManagementBackupName, metricLabelRegion)
+
+	metricRegionReachable = newGaugeVec("region_reachable", "Whether the API server of the regional cluster is reachable",
+		metricLabelRegion)
+
+	metricRegionProbeLatency = newGaugeVec("region_probe_latency_seconds", "Latency of the last successful probe of the API server of the regional cluster",
+		metricLabelRegion)
+
+	metricTemplatesGarbageCollected = newCounterVec("templates_garbage_collected_total", "Number of unused templates removed by the garbage collector",
+		metricLabelTemplateKind, metricLabelTemplateNamespace)
 )
 
 func init() {
@@ -58,6 +90,14 @@ func init() {
 		metricTemplateInvalidity,
 		metricIPAMClaimUse,
 		metricIPAMClaimsBound,
+		metricManagementBackupLastSuccess,
+		metricManagementBackupDuration,
+		metricManagementBackupErrors,
+		metricManagementBackupWarnings,
+		metricManagementBackupMissingItems,
+		metricRegionReachable,
+		metricRegionProbeLatency,
+		metricTemplatesGarbageCollected,
 	)
 }
 
@@ -72,6 +112,17 @@ func newGaugeVec(name, help string, labels ...string) *prometheus.GaugeVec {
 	)
 }
 
+func newCounterVec(name, help string, labels ...string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: kcmv1.CoreKCMName,
+			Name:      name,
+			Help:      help,
+		},
+		labels,
+	)
+}
+
 func setGaugeAndLog(ctx context.Context, gauge *prometheus.GaugeVec, labels prometheus.Labels, active bool, logMsg string) { //nolint:revive
 	value := 0.0
 	if active {
@@ -109,14 +160,60 @@ func TrackMetricIPAMClaimsBound(ctx context.Context, ipamKind, ipamName, ipamNam
 	}, bound, "Tracking cluster IPAM bound metric")
 }
 
+// TrackMetricTemplateUsage tracks whether the template is used by the given parent object.
+// The used template is reported as supported, use [TrackMetricTemplatesInUse] to report
+// the actual lifecycle stages. The series of the unused template are removed
+// regardless of the lifecycle stage they have been reported with.
 func TrackMetricTemplateUsage(ctx context.Context, templateKind, templateName, parentKind string, parent metav1.ObjectMeta, inUse bool) {
-	setGaugeAndLog(ctx, metricTemplateUsage, prometheus.Labels{
+	labels := prometheus.Labels{
 		metricLabelTemplateKind:    templateKind,
 		metricLabelTemplateName:    templateName,
 		metricLabelParentKind:      parentKind,
 		metricLabelParentNamespace: parent.Namespace,
 		metricLabelParentName:      parent.Name,
-	}, inUse, "Tracking template usage metric")
+	}
+
+	if !inUse {
+		metricTemplateUsage.DeletePartialMatch(labels)
+		if l := ctrl.LoggerFrom(ctx); l.V(1).Enabled() {
+			l.V(1).Info("Removing template usage metric", labelMapToSlice(labels)...)
+		}
+		return
+	}
+
+	labels[metricLabelTemplateLifecycle] = deprecationutil.LifecycleSupported
+	setGaugeAndLog(ctx, metricTemplateUsage, labels, inUse, "Tracking template usage metric")
+}
+
+// TrackMetricTemplatesInUse tracks the templates of the given kind currently used by the given parent object,
+// the templates are given as a map of their names to their lifecycle stages.
+// The templates of the kind which are no longer used by the parent object are reported as unused.
+func TrackMetricTemplatesInUse(ctx context.Context, templateKind, parentKind string, parent metav1.ObjectMeta, templates map[string]string) {
+	metricTemplateUsage.DeletePartialMatch(prometheus.Labels{
+		metricLabelTemplateKind:    templateKind,
+		metricLabelParentKind:      parentKind,
+		metricLabelParentNamespace: parent.Namespace,
+		metricLabelParentName:      parent.Name,
+	})
+
+	for name, lifecycle := range templates {
+		setGaugeAndLog(ctx, metricTemplateUsage, prometheus.Labels{
+			metricLabelTemplateKind:      templateKind,
+			metricLabelTemplateName:      name,
+			metricLabelTemplateLifecycle: lifecycle,
+			metricLabelParentKind:        parentKind,
+			metricLabelParentNamespace:   parent.Namespace,
+			metricLabelParentName:        parent.Name,
+		}, true, "Tracking template usage metric")
+	}
+}
+
+// TrackMetricTemplateGarbageCollected counts the template removed by the garbage collector.
+func TrackMetricTemplateGarbageCollected(templateKind, templateNamespace string) {
+	metricTemplatesGarbageCollected.With(prometheus.Labels{
+		metricLabelTemplateKind:      templateKind,
+		metricLabelTemplateNamespace: templateNamespace,
+	}).Inc()
 }
 
 func TrackMetricTemplateInvalidity(ctx context.Context, templateKind, templateNamespace, templateName string, valid bool) {
@@ -126,3 +223,68 @@ func TrackMetricTemplateInvalidity(ctx context.Context, templateKind, templateNa
 		metricLabelTemplateName:      templateName,
 	}, !valid, "Tracking template invalidity metric")
 }
+
+func TrackMetricManagementBackup(ctx context.Context, mgmtBackupName, region string, status *velerov1.BackupStatus) {
+	if status == nil || status.StartTimestamp.IsZero() || status.CompletionTimestamp.IsZero() {
+		return
+	}
+
+	labels := prometheus.Labels{
+		metricLabelManagementBackupName: mgmtBackupName,
+		metricLabelRegion:               region,
+	}
+
+	duration := status.CompletionTimestamp.Sub(status.StartTimestamp.Time).Seconds()
+	metricManagementBackupDuration.With(labels).Set(duration)
+	metricManagementBackupErrors.With(labels).Set(float64(status.Errors))
+	metricManagementBackupWarnings.With(labels).Set(float64(status.Warnings))
+	if status.Phase == velerov1.BackupPhaseCompleted {
+		metricManagementBackupLastSuccess.With(labels).Set(float64(status.CompletionTimestamp.Unix()))
+	}
+
+	l := ctrl.LoggerFrom(ctx)
+	if l.V(1).Enabled() {
+		l.V(1).Info("Tracking management backup metrics", append(labelMapToSlice(labels),
+			"phase", status.Phase, "duration", duration, "errors", status.Errors, "warnings", status.Warnings)...)
+	}
+}
+
+func TrackMetricManagementBackupMissingItems(ctx context.Context, mgmtBackupName, region string, missingItems int) {
+	labels := prometheus.Labels{
+		metricLabelManagementBackupName: mgmtBackupName,
+		metricLabelRegion:               region,
+	}
+
+	metricManagementBackupMissingItems.With(labels).Set(float64(missingItems))
+
+	l := ctrl.LoggerFrom(ctx)
+	if l.V(1).Enabled() {
+		l.V(1).Info("Tracking management backup missing items metric", append(labelMapToSlice(labels), "value", missingItems)...)
+	}
+}
+
+func DeleteMetricsManagementBackup(mgmtBackupName string) {
+	labels := prometheus.Labels{metricLabelManagementBackupName: mgmtBackupName}
+
+	metricManagementBackupLastSuccess.DeletePartialMatch(labels)
+	metricManagementBackupDuration.DeletePartialMatch(labels)
+	metricManagementBackupErrors.DeletePartialMatch(labels)
+	metricManagementBackupWarnings.DeletePartialMatch(labels)
+	metricManagementBackupMissingItems.DeletePartialMatch(labels)
+}
+
+func TrackMetricRegionConnectivity(ctx context.Context, region string, reachable bool, latency time.Duration) {
+	labels := prometheus.Labels{metricLabelRegion: region}
+
+	if reachable {
+		metricRegionProbeLatency.With(labels).Set(latency.Seconds())
+	}
+	setGaugeAndLog(ctx, metricRegionReachable, labels, reachable, "Tracking region connectivity metric")
+}
+
+func DeleteMetricRegionConnectivity(region string) {
+	labels := prometheus.Labels{metricLabelRegion: region}
+
+	metricRegionReachable.Delete(labels)
+	metricRegionProbeLatency.Delete(labels)
+}
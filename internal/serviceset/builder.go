@@ -43,6 +43,9 @@ type Builder struct {
 
 	// ServicesToDeploy is the list of services to deploy
 	ServicesToDeploy []kcmv1.ServiceWithValues
+
+	// CredentialVersion is the version of the credentials propagated to the cluster
+	CredentialVersion string
 }
 
 // NewBuilder returns a new Builder with mandatory parameters set.
@@ -66,6 +69,12 @@ func (b *Builder) WithServicesToDeploy(servicesToDeploy []kcmv1.ServiceWithValue
 	return b
 }
 
+// WithCredentialVersion sets the version of the credentials propagated to the cluster.
+func (b *Builder) WithCredentialVersion(version string) *Builder {
+	b.CredentialVersion = version
+	return b
+}
+
 // Build constructs and returns a ServiceSet object based on the builder's parameters or returns an error if invalid.
 func (b *Builder) Build() (*kcmv1.ServiceSet, error) {
 	var ownerReference *metav1.OwnerReference
@@ -91,6 +100,7 @@ func (b *Builder) Build() (*kcmv1.ServiceSet, error) {
 	b.ServiceSet.Spec = kcmv1.ServiceSetSpec{Services: b.ServicesToDeploy}
 	if b.ClusterDeployment != nil {
 		b.ServiceSet.Spec.Cluster = b.ClusterDeployment.Name
+		b.ServiceSet.Spec.CredentialVersion = b.CredentialVersion
 		providerConfig, err = StateManagementProviderConfigFromServiceSpec(b.ClusterDeployment.Spec.ServiceSpec)
 	}
 	if b.MultiClusterService != nil {
@@ -0,0 +1,78 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceset
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// holdServiceUpgrades keeps the already stored services which are about to be
+// switched to another template as is, so the upgrades are postponed. The rollbacks
+// recorded in the given states are not held back.
+func holdServiceUpgrades(stored, services []kcmv1.ServiceWithValues, states []kcmv1.ServiceState) []kcmv1.ServiceWithValues {
+	storedServices := make(map[client.ObjectKey]kcmv1.ServiceWithValues, len(stored))
+	for _, svc := range stored {
+		storedServices[ServiceKey(svc.Namespace, svc.Name)] = svc
+	}
+	rollbacks := make(map[client.ObjectKey]*kcmv1.ServiceRollbackStatus)
+	for _, state := range states {
+		if state.Rollback != nil {
+			rollbacks[ServiceKey(state.Namespace, state.Name)] = state.Rollback
+		}
+	}
+
+	for i, svc := range services {
+		key := ServiceKey(svc.Namespace, svc.Name)
+		current, ok := storedServices[key]
+		if !ok || current.Template == svc.Template {
+			continue
+		}
+		if rollback, ok := rollbacks[key]; ok && serviceRevisionsEqual(serviceRevision(svc), rollback.RevertedTo) {
+			continue
+		}
+		services[i] = current
+	}
+	return services
+}
+
+// PendingUpgrades returns the names of the desired services which are deployed
+// by the given ServiceSet with a template other than the desired one, excluding
+// the services which have been rolled back.
+func PendingUpgrades(desired []kcmv1.Service, serviceSet *kcmv1.ServiceSet) []string {
+	rolledBack := make(map[client.ObjectKey]struct{})
+	for _, state := range serviceSet.Status.Services {
+		if state.Rollback != nil {
+			rolledBack[ServiceKey(state.Namespace, state.Name)] = struct{}{}
+		}
+	}
+	templates := make(map[client.ObjectKey]string, len(serviceSet.Spec.Services))
+	for _, svc := range serviceSet.Spec.Services {
+		templates[ServiceKey(svc.Namespace, svc.Name)] = svc.Template
+	}
+
+	var pending []string
+	for _, svc := range desired {
+		key := ServiceKey(effectiveNamespace(svc.Namespace), svc.Name)
+		if _, ok := rolledBack[key]; ok {
+			continue
+		}
+		if template, ok := templates[key]; ok && template != svc.Template {
+			pending = append(pending, key.String())
+		}
+	}
+	return pending
+}
@@ -0,0 +1,77 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func Test_holdServiceUpgrades(t *testing.T) {
+	t.Parallel()
+
+	stored := []kcmv1.ServiceWithValues{
+		{Name: "a", Namespace: metav1.NamespaceDefault, Template: "a-1-0-0", Version: new("1.0.0")},
+		{Name: "b", Namespace: metav1.NamespaceDefault, Template: "b-1-0-0", Version: new("1.0.0")},
+		{Name: "c", Namespace: metav1.NamespaceDefault, Template: "c-2-0-0", Version: new("2.0.0")},
+	}
+	services := []kcmv1.ServiceWithValues{
+		{Name: "a", Namespace: metav1.NamespaceDefault, Template: "a-2-0-0", Version: new("2.0.0")},
+		{Name: "b", Namespace: metav1.NamespaceDefault, Template: "b-1-0-0", Version: new("1.0.0"), Values: "replicas: 2"},
+		{Name: "c", Namespace: metav1.NamespaceDefault, Template: "c-1-0-0", Version: new("1.0.0")},
+		{Name: "d", Namespace: metav1.NamespaceDefault, Template: "d-1-0-0", Version: new("1.0.0")},
+	}
+	states := []kcmv1.ServiceState{{
+		Name: "c", Namespace: metav1.NamespaceDefault,
+		Rollback: &kcmv1.ServiceRollbackStatus{
+			Time:       metav1.NewTime(time.Now()),
+			RevertedTo: kcmv1.ServiceRevision{Template: "c-1-0-0", Version: "1.0.0"},
+		},
+	}}
+
+	result := holdServiceUpgrades(stored, services, states)
+	assert.Equal(t, "a-1-0-0", result[0].Template, "the upgrade must be held")
+	assert.Equal(t, "replicas: 2", result[1].Values, "the changes other than upgrades must not be held")
+	assert.Equal(t, "c-1-0-0", result[2].Template, "the rollback must not be held")
+	assert.Equal(t, "d-1-0-0", result[3].Template, "the new services must not be held")
+}
+
+func Test_PendingUpgrades(t *testing.T) {
+	t.Parallel()
+
+	serviceSet := &kcmv1.ServiceSet{
+		Spec: kcmv1.ServiceSetSpec{Services: []kcmv1.ServiceWithValues{
+			{Name: "a", Namespace: metav1.NamespaceDefault, Template: "a-1-0-0"},
+			{Name: "b", Namespace: metav1.NamespaceDefault, Template: "b-1-0-0"},
+			{Name: "c", Namespace: metav1.NamespaceDefault, Template: "c-1-0-0"},
+		}},
+		Status: kcmv1.ServiceSetStatus{Services: []kcmv1.ServiceState{{
+			Name: "c", Namespace: metav1.NamespaceDefault, Rollback: &kcmv1.ServiceRollbackStatus{},
+		}}},
+	}
+	desired := []kcmv1.Service{
+		{Name: "a", Template: "a-2-0-0"},
+		{Name: "b", Template: "b-1-0-0"},
+		{Name: "c", Template: "c-2-0-0"},
+		{Name: "d", Template: "d-1-0-0"},
+	}
+
+	assert.Equal(t, []string{"default/a"}, PendingUpgrades(desired, serviceSet))
+}
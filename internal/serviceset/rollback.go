@@ -0,0 +1,164 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceset
+
+import (
+	"bytes"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// TrackServiceRevisions records the last successfully deployed revision of each of
+// the services in the given ServiceSet status and rolls back the services which have
+// been failing for longer than the failure window of their rollback policy.
+//
+// A changed revision is recorded as deployed only if the provider reports the Deployed
+// state along with a deployment hash other than the one of the previously recorded revision.
+//
+// The rollback is recorded in the [kcmv1.ServiceState] only, the ServiceSet spec is
+// reverted to the recorded revision by [GetServiceSetWithOperation]. The rollback record
+// is dropped once the desired revision of the service changes.
+//
+// Returns the states of the services which have just been rolled back and the duration
+// after which the next of the failing services is due to be rolled back, zero if none.
+func TrackServiceRevisions(serviceSet *kcmv1.ServiceSet, now time.Time) (rolledBack []kcmv1.ServiceState, requeueAfter time.Duration) {
+	specServices := make(map[client.ObjectKey]kcmv1.ServiceWithValues, len(serviceSet.Spec.Services))
+	for _, svc := range serviceSet.Spec.Services {
+		specServices[ServiceKey(svc.Namespace, svc.Name)] = svc
+	}
+
+	for i := range serviceSet.Status.Services {
+		state := &serviceSet.Status.Services[i]
+		svc, ok := specServices[ServiceKey(state.Namespace, state.Name)]
+		if !ok {
+			continue
+		}
+
+		revision := serviceRevision(svc)
+		if state.Rollback != nil &&
+			!serviceRevisionsEqual(revision, state.Rollback.FailedRevision) &&
+			!serviceRevisionsEqual(revision, state.Rollback.RevertedTo) {
+			// the desired revision has been changed since the rollback
+			state.Rollback = nil
+		}
+
+		switch state.State {
+		case kcmv1.ServiceStateDeployed:
+			// the provider keeps reporting the state of the previous revision until it picks up the change,
+			// hence the new revision is recorded only once the deployment hash has changed
+			if state.LastDeployed != nil && !serviceRevisionsEqual(revision, *state.LastDeployed) &&
+				len(state.DeploymentHash) > 0 && bytes.Equal(state.DeploymentHash, state.LastDeployedHash) {
+				continue
+			}
+
+			state.LastDeployed = &revision
+			state.LastDeployedHash = state.DeploymentHash
+		case kcmv1.ServiceStateFailed:
+			if svc.RollbackPolicy == nil || state.Rollback != nil ||
+				state.LastDeployed == nil || serviceRevisionsEqual(revision, *state.LastDeployed) {
+				continue
+			}
+
+			failedSince := now
+			if state.LastStateTransitionTime != nil {
+				failedSince = state.LastStateTransitionTime.Time
+			}
+			if wait := failedSince.Add(svc.RollbackPolicy.FailureWindow.Duration).Sub(now); wait > 0 {
+				if requeueAfter == 0 || wait < requeueAfter {
+					requeueAfter = wait
+				}
+				continue
+			}
+
+			state.Rollback = &kcmv1.ServiceRollbackStatus{
+				Time:           metav1.NewTime(now),
+				FailedRevision: revision,
+				RevertedTo:     *state.LastDeployed.DeepCopy(),
+				Message:        state.FailureMessage,
+			}
+			rolledBack = append(rolledBack, *state)
+		}
+	}
+
+	return rolledBack, requeueAfter
+}
+
+// applyServiceRollbacks replaces the services which are about to be deployed with
+// the revision which has failed and been rolled back with the revision they were rolled back to.
+func applyServiceRollbacks(services []kcmv1.ServiceWithValues, states []kcmv1.ServiceState) []kcmv1.ServiceWithValues {
+	rollbacks := make(map[client.ObjectKey]*kcmv1.ServiceRollbackStatus)
+	for _, state := range states {
+		if state.Rollback != nil {
+			rollbacks[ServiceKey(state.Namespace, state.Name)] = state.Rollback
+		}
+	}
+	if len(rollbacks) == 0 {
+		return services
+	}
+
+	for i, svc := range services {
+		rollback, ok := rollbacks[ServiceKey(svc.Namespace, svc.Name)]
+		if !ok || !serviceRevisionsEqual(serviceRevision(svc), rollback.FailedRevision) {
+			continue
+		}
+
+		services[i].Template = rollback.RevertedTo.Template
+		services[i].Version = new(rollback.RevertedTo.Version)
+		services[i].Values = rollback.RevertedTo.Values
+		services[i].ValuesFrom = slices.Clone(rollback.RevertedTo.ValuesFrom)
+	}
+	return services
+}
+
+// servicesWithRollbackPolicy returns a copy of the given services with
+// the given default rollback policy set for the services lacking one.
+func servicesWithRollbackPolicy(services []kcmv1.Service, policy *kcmv1.ServiceRollbackPolicy) []kcmv1.Service {
+	if policy == nil {
+		return services
+	}
+
+	result := make([]kcmv1.Service, len(services))
+	for i, svc := range services {
+		if svc.RollbackPolicy == nil {
+			svc.RollbackPolicy = policy.DeepCopy()
+		}
+		result[i] = svc
+	}
+	return result
+}
+
+func serviceRevision(svc kcmv1.ServiceWithValues) kcmv1.ServiceRevision {
+	revision := kcmv1.ServiceRevision{
+		Template:   svc.Template,
+		Values:     svc.Values,
+		ValuesFrom: slices.Clone(svc.ValuesFrom),
+	}
+	if svc.Version != nil {
+		revision.Version = *svc.Version
+	}
+	return revision
+}
+
+func serviceRevisionsEqual(a, b kcmv1.ServiceRevision) bool {
+	return a.Template == b.Template &&
+		a.Version == b.Version &&
+		a.Values == b.Values &&
+		slices.Equal(a.ValuesFrom, b.ValuesFrom)
+}
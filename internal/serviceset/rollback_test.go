@@ -0,0 +1,135 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func Test_ServiceRollback(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	policy := &kcmv1.ServiceRollbackPolicy{FailureWindow: metav1.Duration{Duration: 5 * time.Minute}}
+	desired := kcmv1.Service{
+		Name:           "service1",
+		Namespace:      metav1.NamespaceDefault,
+		Template:       "template1-2-0-0",
+		Version:        "1.2.0.0",
+		Values:         "replicas: 2",
+		RollbackPolicy: policy,
+	}
+
+	// the previous revision has been deployed
+	serviceSet := &kcmv1.ServiceSet{
+		Spec: kcmv1.ServiceSetSpec{Services: []kcmv1.ServiceWithValues{{
+			Name: "service1", Namespace: metav1.NamespaceDefault, Template: "template1-1-0-0",
+			Version: new("1.1.0.0"), Values: "replicas: 1", RollbackPolicy: policy,
+		}}},
+		Status: kcmv1.ServiceSetStatus{Services: []kcmv1.ServiceState{{
+			Name: "service1", Namespace: metav1.NamespaceDefault, Version: new("1.1.0.0"), State: kcmv1.ServiceStateDeployed,
+			DeploymentHash: []byte("hash1"),
+		}}},
+	}
+	rolledBack, requeueAfter := TrackServiceRevisions(serviceSet, now)
+	require.Empty(t, rolledBack)
+	require.Zero(t, requeueAfter)
+	previous := kcmv1.ServiceRevision{Template: "template1-1-0-0", Version: "1.1.0.0", Values: "replicas: 1"}
+	require.Equal(t, &previous, serviceSet.Status.Services[0].LastDeployed)
+	require.Equal(t, []byte("hash1"), serviceSet.Status.Services[0].LastDeployedHash)
+
+	// the upgrade has just been requested, the provider still reports the previous revision as deployed
+	serviceSet.Spec.Services = ServicesToDeploy(nil, []kcmv1.Service{desired}, serviceSet)
+	serviceSet.Status.Services[0].Version = serviceSet.Spec.Services[0].Version
+
+	rolledBack, requeueAfter = TrackServiceRevisions(serviceSet, now)
+	require.Empty(t, rolledBack)
+	require.Zero(t, requeueAfter)
+	require.Equal(t, &previous, serviceSet.Status.Services[0].LastDeployed, "stale deployed state must not be recorded")
+
+	// the upgrade has just failed
+	serviceSet.Status.Services[0].DeploymentHash = []byte("hash2")
+	serviceSet.Status.Services[0].State = kcmv1.ServiceStateFailed
+	serviceSet.Status.Services[0].FailureMessage = "upgrade failed"
+	serviceSet.Status.Services[0].LastStateTransitionTime = &metav1.Time{Time: now.Add(-time.Minute)}
+
+	rolledBack, requeueAfter = TrackServiceRevisions(serviceSet, now)
+	require.Empty(t, rolledBack)
+	assert.Equal(t, 4*time.Minute, requeueAfter)
+
+	// the failure window has elapsed
+	rolledBack, requeueAfter = TrackServiceRevisions(serviceSet, now.Add(5*time.Minute))
+	require.Len(t, rolledBack, 1)
+	require.Zero(t, requeueAfter)
+	failed := kcmv1.ServiceRevision{Template: "template1-2-0-0", Version: "1.2.0.0", Values: "replicas: 2"}
+	rollback := serviceSet.Status.Services[0].Rollback
+	require.NotNil(t, rollback)
+	assert.Equal(t, failed, rollback.FailedRevision)
+	assert.Equal(t, previous, rollback.RevertedTo)
+	assert.Equal(t, "upgrade failed", rollback.Message)
+
+	// the ServiceSet is reverted to the previous revision and stays so
+	for range 2 {
+		serviceSet.Spec.Services = ServicesToDeploy(nil, []kcmv1.Service{desired}, serviceSet)
+		require.Len(t, serviceSet.Spec.Services, 1)
+		assert.Equal(t, previous, serviceRevision(serviceSet.Spec.Services[0]))
+
+		serviceSet.Status.Services[0].Version = serviceSet.Spec.Services[0].Version
+		serviceSet.Status.Services[0].DeploymentHash = []byte("hash3")
+		serviceSet.Status.Services[0].State = kcmv1.ServiceStateDeployed
+		rolledBack, _ = TrackServiceRevisions(serviceSet, now.Add(10*time.Minute))
+		require.Empty(t, rolledBack)
+		require.NotNil(t, serviceSet.Status.Services[0].Rollback)
+		assert.Equal(t, &previous, serviceSet.Status.Services[0].LastDeployed)
+		assert.Equal(t, []byte("hash3"), serviceSet.Status.Services[0].LastDeployedHash)
+	}
+
+	// the desired revision has been changed
+	desired.Values = "replicas: 3"
+	serviceSet.Spec.Services = ServicesToDeploy(nil, []kcmv1.Service{desired}, serviceSet)
+	require.Len(t, serviceSet.Spec.Services, 1)
+	assert.Equal(t, "replicas: 3", serviceSet.Spec.Services[0].Values)
+	assert.Equal(t, "1.2.0.0", *serviceSet.Spec.Services[0].Version)
+
+	serviceSet.Status.Services[0].State = kcmv1.ServiceStateProvisioning
+	TrackServiceRevisions(serviceSet, now.Add(10*time.Minute))
+	assert.Nil(t, serviceSet.Status.Services[0].Rollback)
+
+	// the new revision has been deployed
+	serviceSet.Status.Services[0].DeploymentHash = []byte("hash4")
+	serviceSet.Status.Services[0].State = kcmv1.ServiceStateDeployed
+	TrackServiceRevisions(serviceSet, now.Add(10*time.Minute))
+	assert.Equal(t, &kcmv1.ServiceRevision{Template: "template1-2-0-0", Version: "1.2.0.0", Values: "replicas: 3"}, serviceSet.Status.Services[0].LastDeployed)
+}
+
+func Test_servicesWithRollbackPolicy(t *testing.T) {
+	t.Parallel()
+
+	defaultPolicy := &kcmv1.ServiceRollbackPolicy{FailureWindow: metav1.Duration{Duration: time.Minute}}
+	servicePolicy := &kcmv1.ServiceRollbackPolicy{FailureWindow: metav1.Duration{Duration: time.Hour}}
+	services := []kcmv1.Service{{Name: "a"}, {Name: "b", RollbackPolicy: servicePolicy}}
+
+	result := servicesWithRollbackPolicy(services, defaultPolicy)
+	assert.Equal(t, defaultPolicy, result[0].RollbackPolicy)
+	assert.Equal(t, servicePolicy, result[1].RollbackPolicy)
+	assert.Nil(t, services[0].RollbackPolicy, "the original services must not be modified")
+}
@@ -495,13 +495,14 @@ func makeService(s kcmv1.Service, version, template string) kcmv1.ServiceWithVal
 		// in clusterDeployment's/multiClusterService's service definition can be empty.
 		// This will lead to persistent discrepancy between service definitions and
 		// lead to continuous serviceSet updates.
-		Namespace:   effectiveNamespace(s.Namespace),
-		Version:     new(version),
-		Template:    template,
-		Values:      s.Values,
-		ValuesFrom:  s.ValuesFrom,
-		HelmOptions: s.HelmOptions,
-		HelmAction:  s.HelmAction,
+		Namespace:      effectiveNamespace(s.Namespace),
+		Version:        new(version),
+		Template:       template,
+		Values:         s.Values,
+		ValuesFrom:     s.ValuesFrom,
+		HelmOptions:    s.HelmOptions,
+		HelmAction:     s.HelmAction,
+		RollbackPolicy: s.RollbackPolicy,
 	}
 }
 
@@ -634,6 +635,7 @@ func ServicesToDeploy(
 				svc.ValuesFrom = ds.ValuesFrom
 				svc.HelmOptions = ds.HelmOptions
 				svc.HelmAction = ds.HelmAction
+				svc.RollbackPolicy = ds.RollbackPolicy
 				break
 			}
 		}
@@ -687,7 +689,7 @@ func ServicesToDeploy(
 		services = appendIfNotPresent(services, s, minimumUpgrade)
 	}
 
-	return services
+	return applyServiceRollbacks(services, serviceSet.Status.Services)
 }
 
 // BuildServicesList produces the final list of services for the ServiceSet spec by:
@@ -805,6 +807,11 @@ type OperationRequisites struct {
 	MCS             *kcmv1.MultiClusterService
 	CD              *kcmv1.ClusterDeployment
 	SystemNamespace string
+	// HoldUpgrades keeps the already deployed services at their current templates,
+	// e.g. while the maintenance window is closed.
+	HoldUpgrades bool
+	// CredentialVersion is the version of the credentials propagated to the cluster, if any.
+	CredentialVersion string
 }
 
 // GetServiceSetWithOperation fetches or initialises the ServiceSet identified by
@@ -861,6 +868,10 @@ type OperationRequisites struct {
 // desired spec) and is not advanced to the next upgrade step until the in-flight
 // version is fully deployed.
 //
+// Held upgrades — if [OperationRequisites.HoldUpgrades] is set, the stored services
+// which would be switched to another template are carried over verbatim, so the
+// upgrades are applied only once the holding is lifted. Rollbacks are not held.
+//
 // No-op — if the resolved spec is identical to the existing ServiceSet spec,
 // [kcmv1.ServiceSetOperationNone] is returned and no write is performed.
 //
@@ -877,12 +888,11 @@ func GetServiceSetWithOperation(
 	var desiredServices []kcmv1.Service
 	var serviceSpec kcmv1.ServiceSpec
 	if operationReq.MCS != nil {
-		desiredServices = operationReq.MCS.Spec.ServiceSpec.Services
 		serviceSpec = operationReq.MCS.Spec.ServiceSpec
 	} else {
-		desiredServices = operationReq.CD.Spec.ServiceSpec.Services
 		serviceSpec = operationReq.CD.Spec.ServiceSpec
 	}
+	desiredServices = servicesWithRollbackPolicy(serviceSpec.Services, serviceSpec.RollbackPolicy)
 
 	// Resolve the provider that backs this ServiceSet.
 	providerSpec, err := StateManagementProviderConfigFromServiceSpec(serviceSpec)
@@ -916,6 +926,9 @@ func GetServiceSetWithOperation(
 	l.V(1).Info("Resolved services to apply", "services", filteredServices)
 
 	resultingServices := BuildServicesList(serviceSet.Spec.Services, filteredServices, desiredServices)
+	if operationReq.HoldUpgrades {
+		resultingServices = holdServiceUpgrades(serviceSet.Spec.Services, resultingServices, serviceSet.Status.Services)
+	}
 	l.V(1).Info("Services to deploy", "services", resultingServices)
 
 	// Save current spec before Build() overwrites it in place
@@ -924,7 +937,8 @@ func GetServiceSetWithOperation(
 
 	candidate, err := NewBuilder(operationReq.CD, serviceSet, provider.Spec.Selector).
 		WithMultiClusterService(operationReq.MCS).
-		WithServicesToDeploy(resultingServices).Build()
+		WithServicesToDeploy(resultingServices).
+		WithCredentialVersion(operationReq.CredentialVersion).Build()
 	if err != nil {
 		return nil, kcmv1.ServiceSetOperationNone, fmt.Errorf("failed to build ServiceSet: %w", err)
 	}
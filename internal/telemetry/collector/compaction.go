@@ -0,0 +1,170 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Retention configures the compaction of the local telemetry files.
+type Retention struct {
+	// Days is the number of days the daily files are kept before being
+	// compacted into the monthly summaries, 0 disables the compaction.
+	Days int
+	// Months is the number of months the monthly summaries are kept, 0 keeps them forever.
+	Months int
+}
+
+// summaryState is the content of a monthly summary file.
+type summaryState struct {
+	// Days lists the days compacted into the summary.
+	Days     []string       `json:"days,omitempty"`
+	Clusters []clusterEntry `json:"clusters,omitempty"`
+}
+
+// compact merges the daily files older than the retention into the monthly
+// summaries, removing the compacted daily files and the expired summaries.
+func (f *file) compact(retention Retention, now time.Time) error {
+	if retention.Days <= 0 {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	files, err := f.listLocked()
+	if err != nil {
+		return err
+	}
+
+	cutoff := dateOnlyTime(now).AddDate(0, 0, -retention.Days)
+
+	expiry := time.Time{} // summaries for the months before are removed
+	if retention.Months > 0 {
+		today := dateOnlyTime(now)
+		expiry = today.AddDate(0, -retention.Months, 1-today.Day())
+	}
+
+	byMonth := make(map[time.Time][]time.Time)
+	for day := range files.daily {
+		if day.Before(cutoff) {
+			month := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+			byMonth[month] = append(byMonth[month], day)
+		}
+	}
+
+	for _, month := range slices.SortedFunc(maps.Keys(byMonth), compareTime) {
+		if month.Before(expiry) { // the summary would have been expired anyway
+			for _, day := range byMonth[month] {
+				if err := removeIfExists(files.daily[day]); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := f.compactMonthLocked(month, byMonth[month], files); err != nil {
+			return err
+		}
+	}
+
+	for month, path := range files.monthly {
+		if !month.Before(expiry) {
+			continue
+		}
+
+		f.logger.Info("removing expired monthly summary", "fname", path)
+		if err := removeIfExists(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *file) compactMonthLocked(month time.Time, days []time.Time, files *localFiles) error {
+	path := filepath.Join(f.baseDir, month.Format(monthOnly)+jsonFileSuffix)
+
+	summary, err := readSummaryFile(path)
+	if err != nil {
+		return err
+	}
+
+	merged := stateToMap(fileState{Clusters: summary.Clusters})
+	for _, day := range days {
+		d := day.Format(time.DateOnly)
+		if slices.Contains(summary.Days, d) { // already compacted, removal has not happened
+			continue
+		}
+
+		st, err := readStateFile(files.daily[day])
+		if err != nil {
+			return err
+		}
+
+		f.mergeMaps(merged, stateToMap(st))
+		summary.Days = append(summary.Days, d)
+	}
+
+	slices.Sort(summary.Days)
+	summary.Clusters = mapToState(merged).Clusters
+
+	b, err := json.MarshalIndent(summary, "", " ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	// write-then-rename so the summary is never observed partially written
+	tmpPath := path + ".compacting"
+	if err := os.WriteFile(tmpPath, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename file %s to %s: %w", tmpPath, path, err)
+	}
+
+	f.logger.Info("compacted daily files into monthly summary", "fname", path, "days", len(days))
+
+	for _, day := range days {
+		if err := removeIfExists(files.daily[day]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+func readSummaryFile(path string) (summaryState, error) {
+	var st summaryState
+	if err := readJSONFile(path, &st); err != nil {
+		return summaryState{}, err
+	}
+	return st, nil
+}
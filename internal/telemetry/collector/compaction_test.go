@@ -0,0 +1,92 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_file_compact(t *testing.T) {
+	t.Parallel()
+
+	reqs := require.New(t)
+
+	f := mustNewFile(t)
+	dir := f.baseDir
+
+	now := mustDate(t, "2026-10-17")
+
+	for day, scrapes := range map[string]uint64{
+		"2026-08-15": 1,
+		"2026-09-29": 2,
+		"2026-09-30": 3,
+		"2026-10-07": 4, // within the retention
+	} {
+		writeJSONFile(t, filepath.Join(dir, day+".json"), fileState{Clusters: []clusterEntry{
+			newTestEntry("ns/a", "tpl", map[string]uint64{"scrapes": scrapes}),
+		}})
+	}
+	writeJSONFile(t, filepath.Join(dir, "2026-09.json"), summaryState{
+		Days: []string{"2026-09-01", "2026-09-30"}, // 30th is left over after an interrupted compaction
+		Clusters: []clusterEntry{
+			newTestEntry("ns/a", "tpl", map[string]uint64{"scrapes": 10}),
+		},
+	})
+	writeJSONFile(t, filepath.Join(dir, "2026-05.json"), summaryState{Days: []string{"2026-05-01"}})
+
+	reqs.NoError(f.compact(Retention{}, now), "disabled compaction must be no-op")
+	_, err := os.Stat(filepath.Join(dir, "2026-08-15.json"))
+	reqs.NoError(err)
+
+	reqs.NoError(f.compact(Retention{Days: 10, Months: 2}, now))
+
+	for _, name := range []string{"2026-08-15.json", "2026-09-29.json", "2026-09-30.json", "2026-05.json"} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		reqs.ErrorIs(err, fs.ErrNotExist, "file %s must have been removed", name)
+	}
+	_, err = os.Stat(filepath.Join(dir, "2026-10-07.json"))
+	reqs.NoError(err, "file within the retention must be kept")
+
+	aug, err := readSummaryFile(filepath.Join(dir, "2026-08.json"))
+	reqs.NoError(err)
+	reqs.Equal([]string{"2026-08-15"}, aug.Days)
+	reqs.Len(aug.Clusters, 1)
+	reqs.Equal(uint64(1), aug.Clusters[0].Counters["scrapes"])
+
+	sep, err := readSummaryFile(filepath.Join(dir, "2026-09.json"))
+	reqs.NoError(err)
+	reqs.Equal([]string{"2026-09-01", "2026-09-29", "2026-09-30"}, sep.Days)
+	reqs.Len(sep.Clusters, 1)
+	reqs.Equal(uint64(12), sep.Clusters[0].Counters["scrapes"])
+	reqs.Equal(uint64(2), sep.Clusters[0].Counters["template:tpl"])
+
+	// compacting twice is idempotent
+	reqs.NoError(f.compact(Retention{Days: 10, Months: 2}, now))
+	sep, err = readSummaryFile(filepath.Join(dir, "2026-09.json"))
+	reqs.NoError(err)
+	reqs.Equal(uint64(12), sep.Clusters[0].Counters["scrapes"])
+
+	// whole months before the monthly retention are removed
+	reqs.NoError(f.compact(Retention{Days: 10, Months: 1}, now))
+	_, err = os.Stat(filepath.Join(dir, "2026-08.json"))
+	reqs.ErrorIs(err, fs.ErrNotExist)
+	_, err = os.Stat(filepath.Join(dir, "2026-09.json"))
+	reqs.NoError(err)
+}
@@ -38,6 +38,7 @@ type (
 		parentClient client.Client
 		childScheme  *runtime.Scheme
 		childFactory func([]byte, *runtime.Scheme) (client.Client, error) // for test mocks
+		retention    Retention
 		concurrency  int
 	}
 
@@ -49,10 +50,15 @@ type (
 	}
 )
 
-const labelCluster = "cluster" // clusterdeployment namespaced name, used for cluster search
+const (
+	labelCluster   = "cluster"             // clusterdeployment namespaced name, used for cluster search
+	labelCldID     = "clusterDeploymentID" // clusterdeployment UID, only on the management cluster
+	labelClusterID = "clusterID"           // k0s cluster ID if any
+)
 
-// NewLocalCollector creates a new instance of the [LocalCollector].
-func NewLocalCollector(parentClient client.Client, baseDir string, concurrency int) (*LocalCollector, error) {
+// NewLocalCollector creates a new instance of the [LocalCollector]
+// compacting the flushed files according to the given retention.
+func NewLocalCollector(parentClient client.Client, baseDir string, concurrency int, retention Retention) (*LocalCollector, error) {
 	childScheme, err := getChildScheme()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create child client scheme: %w", err)
@@ -67,6 +73,7 @@ func NewLocalCollector(parentClient client.Client, baseDir string, concurrency i
 		file:         f,
 		parentClient: parentClient,
 		childScheme:  childScheme,
+		retention:    retention,
 		concurrency:  concurrency,
 		childFactory: kubeutil.DefaultClientFactory,
 	}, nil
@@ -74,7 +81,7 @@ func NewLocalCollector(parentClient client.Client, baseDir string, concurrency i
 
 // Collect fetches all of the required data from mgmt and child clusters, counting
 // and flushing data on a local disk (persistent volume).
-// Manages data rotations and failures automatically.
+// Manages data rotations, compaction and failures automatically.
 func (l *LocalCollector) Collect(ctx context.Context) error {
 	// NOTE: we do not ensure PV/PVC because of arbitrary names
 	// either way, absence of either only provides us with extra verbosity
@@ -88,19 +95,47 @@ func (l *LocalCollector) Collect(ctx context.Context) error {
 		return fmt.Errorf("failed to ensure temp file for local data: %w", err)
 	}
 
+	entries, err := collectClusterEntries(ctx, l.parentClient, l.childScheme, l.childFactory, l.concurrency)
+	if err != nil {
+		return err
+	}
+
+	logger.V(1).Info("starting flushing collected data")
+	if err := l.file.flush(entries); err != nil {
+		return fmt.Errorf("failed to flush collected data: %w", err)
+	}
+
+	if err := l.file.compact(l.retention, time.Now()); err != nil {
+		return fmt.Errorf("failed to compact collected data: %w", err)
+	}
+
+	return nil
+}
+
+// collectClusterEntries fetches all of the required data from the parent and child clusters
+// and returns the per-cluster bucketed counters keyed by the cluster namespaced name.
+func collectClusterEntries(
+	ctx context.Context,
+	parentClient client.Client,
+	childScheme *runtime.Scheme,
+	childFactory func([]byte, *runtime.Scheme) (client.Client, error),
+	concurrency int,
+) (map[string]clusterEntry, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
 	parentData := newParentDataFetcher()
-	dataScope, err := parentData.getScope(ctx, l.parentClient, scopeLocal)
+	dataScope, err := parentData.getScope(ctx, parentClient, scopeLocal)
 	if err != nil {
-		return fmt.Errorf("failed to get current scope: %w", err)
+		return nil, fmt.Errorf("failed to get current scope: %w", err)
 	}
 
-	if err := parentData.fetch(ctx, l.parentClient, dataScope); err != nil {
-		return fmt.Errorf("failed to fetch data from parent cluster: %w", err)
+	if err := parentData.fetch(ctx, parentClient, dataScope); err != nil {
+		return nil, fmt.Errorf("failed to fetch data from parent cluster: %w", err)
 	}
 
 	var (
 		wg  sync.WaitGroup
-		sem = make(chan struct{}, l.concurrency)
+		sem = make(chan struct{}, concurrency)
 
 		entriesLock sync.Mutex
 		entries     = make(map[string]clusterEntry, len(parentData.clusters))
@@ -109,9 +144,6 @@ func (l *LocalCollector) Collect(ctx context.Context) error {
 	const (
 		counterScrapes  = "scrapes"
 		counterFailures = "failures"
-
-		labelCldID     = "clusterDeploymentID"
-		labelClusterID = "clusterID"
 	)
 
 	start := time.Time{}
@@ -135,7 +167,7 @@ func (l *LocalCollector) Collect(ctx context.Context) error {
 			clusterKey := client.ObjectKeyFromObject(cluster)
 
 			secretRef := kubeutil.GetKubeconfigSecretKey(clusterKey)
-			childCl, err := kubeutil.GetChildClient(ctx, l.parentClient, secretRef, "value", l.childScheme, l.childFactory)
+			childCl, err := kubeutil.GetChildClient(ctx, parentClient, secretRef, "value", childScheme, childFactory)
 			if err != nil {
 				ll.Error(err, "failed to get child kubeconfig")
 				return
@@ -178,12 +210,7 @@ func (l *LocalCollector) Collect(ctx context.Context) error {
 		logger.V(1).Info("finished collecting telemetry", "finished_in", time.Since(start))
 	}
 
-	logger.V(1).Info("starting flushing collected data")
-	if err := l.file.flush(entries); err != nil {
-		return fmt.Errorf("failed to flush collected data: %w", err)
-	}
-
-	return nil
+	return entries, nil
 }
 
 func (ce *clusterEntry) collectChildProperties(ctx context.Context, childCl client.Client) error {
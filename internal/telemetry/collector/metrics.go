@@ -0,0 +1,89 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Names of the labels (attributes) attached to the per-cluster counters
+// exposed by the [PrometheusCollector] and the [OTLPCollector].
+const (
+	metricLabelCluster             = "cluster"
+	metricLabelClusterID           = "cluster_id"
+	metricLabelClusterDeploymentID = "cluster_deployment_id"
+	metricLabelCounter             = "counter"
+	metricLabelBucket              = "bucket"
+)
+
+// counterSample is a single per-cluster counter split into the counter name
+// and its bucket, e.g. "node.count:1-2" becomes "node.count" and "1-2".
+type counterSample struct {
+	cluster             string
+	clusterID           string
+	clusterDeploymentID string
+	counter             string
+	bucket              string
+	value               uint64
+}
+
+// samples is a concurrent-safe holder of the samples from the latest collection round.
+type samples struct {
+	mu    sync.RWMutex
+	items []counterSample
+}
+
+func (s *samples) set(items []counterSample) {
+	s.mu.Lock()
+	s.items = items
+	s.mu.Unlock()
+}
+
+func (s *samples) get() []counterSample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items
+}
+
+// toSamples flattens the given cluster entries into samples
+// ordered by cluster and counter names.
+func toSamples(entries map[string]clusterEntry) []counterSample {
+	var result []counterSample
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		entry := entries[key]
+
+		cluster := entry.Labels[labelCluster]
+		if cluster == "" {
+			cluster = key
+		}
+
+		for _, counterKey := range slices.Sorted(maps.Keys(entry.Counters)) {
+			counter, bucket, _ := strings.Cut(counterKey, ":")
+			result = append(result, counterSample{
+				cluster:             cluster,
+				clusterID:           entry.Labels[labelClusterID],
+				clusterDeploymentID: entry.Labels[labelCldID],
+				counter:             counter,
+				bucket:              bucket,
+				value:               entry.Counters[counterKey],
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,168 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/K0rdent/kcm/internal/build"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+)
+
+// OTLPCollector pushes the same per-cluster counters as the [LocalCollector]
+// as gauges to an OpenTelemetry collector via OTLP/HTTP.
+type OTLPCollector struct {
+	exporter     sdkmetric.Exporter
+	reader       *sdkmetric.ManualReader
+	provider     *sdkmetric.MeterProvider
+	samples      samples
+	parentClient client.Client
+	childScheme  *runtime.Scheme
+	childFactory func([]byte, *runtime.Scheme) (client.Client, error) // for test mocks
+	concurrency  int
+}
+
+const (
+	otlpClusterCounterMetric = "kcm.telemetry.cluster.counter"
+	otlpDefaultURLPath       = "/v1/metrics"
+	otlpServiceName          = "kcm-telemetry"
+)
+
+// NewOTLPCollector creates a new instance of the [OTLPCollector] pushing metrics
+// to the given OTLP/HTTP endpoint, e.g. http://otel-collector:4318.
+// The default /v1/metrics path is used if the endpoint does not have one.
+func NewOTLPCollector(ctx context.Context, parentClient client.Client, endpoint string, concurrency int, opts ...otlpmetrichttp.Option) (*OTLPCollector, error) {
+	endpointURL, err := OTLPEndpointURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	childScheme, err := getChildScheme()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create child client scheme: %w", err)
+	}
+
+	exporter, err := otlpmetrichttp.New(ctx, append([]otlpmetrichttp.Option{otlpmetrichttp.WithEndpointURL(endpointURL)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metrics exporter: %w", err)
+	}
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader),
+		sdkmetric.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", otlpServiceName),
+			attribute.String("service.version", build.Version),
+		)),
+	)
+
+	o := &OTLPCollector{
+		exporter:     exporter,
+		reader:       reader,
+		provider:     provider,
+		parentClient: parentClient,
+		childScheme:  childScheme,
+		childFactory: kubeutil.DefaultClientFactory,
+		concurrency:  concurrency,
+	}
+
+	if _, err := provider.Meter(otlpServiceName).Int64ObservableGauge(otlpClusterCounterMetric,
+		metric.WithDescription("Per-cluster telemetry counters, the counter attribute holds the name of the counter and the bucket attribute holds its bucketed value."),
+		metric.WithInt64Callback(o.observe),
+	); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create OTLP gauge: %w", err), provider.Shutdown(ctx), exporter.Shutdown(ctx))
+	}
+
+	return o, nil
+}
+
+// OTLPEndpointURL validates the given OTLP/HTTP endpoint and returns it
+// with the default metrics path if the endpoint does not have one.
+func OTLPEndpointURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse OTLP endpoint %q: %w", endpoint, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("OTLP endpoint %q must be an absolute http(s) URL", endpoint)
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpDefaultURLPath
+	}
+
+	return u.String(), nil
+}
+
+// Collect fetches all of the required data from the parent and child clusters
+// and pushes the per-cluster counters to the OTLP endpoint.
+func (o *OTLPCollector) Collect(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("otlp-collector")
+	ctx = ctrl.LoggerInto(ctx, logger)
+
+	entries, err := collectClusterEntries(ctx, o.parentClient, o.childScheme, o.childFactory, o.concurrency)
+	if err != nil {
+		return err
+	}
+
+	o.samples.set(toSamples(entries))
+
+	var rm metricdata.ResourceMetrics
+	if err := o.reader.Collect(ctx, &rm); err != nil {
+		return fmt.Errorf("failed to collect telemetry metrics: %w", err)
+	}
+
+	logger.V(1).Info("pushing telemetry metrics", "clusters", len(entries))
+	if err := o.exporter.Export(ctx, &rm); err != nil {
+		return fmt.Errorf("failed to export telemetry metrics: %w", err)
+	}
+
+	return nil
+}
+
+// Close shuts down the meter provider and the exporter.
+func (o *OTLPCollector) Close(ctx context.Context) error {
+	ctrl.LoggerFrom(ctx).Info("closing otlp collector")
+	return errors.Join(o.provider.Shutdown(ctx), o.exporter.Shutdown(ctx))
+}
+
+func (o *OTLPCollector) observe(_ context.Context, observer metric.Int64Observer) error {
+	for _, s := range o.samples.get() {
+		observer.Observe(int64(min(s.value, math.MaxInt64)), metric.WithAttributes( //nolint:gosec // capped above
+			attribute.String(metricLabelCluster, s.cluster),
+			attribute.String(metricLabelClusterID, s.clusterID),
+			attribute.String(metricLabelClusterDeploymentID, s.clusterDeploymentID),
+			attribute.String(metricLabelCounter, s.counter),
+			attribute.String(metricLabelBucket, s.bucket),
+		))
+	}
+
+	return nil
+}
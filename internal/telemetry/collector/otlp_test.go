@@ -0,0 +1,165 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/proto"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// otlpReceiver is an in-process OTLP/HTTP metrics receiver.
+type otlpReceiver struct {
+	mu       sync.Mutex
+	paths    []string
+	requests []*colmetricpb.ExportMetricsServiceRequest
+}
+
+func (r *otlpReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	msg := new(colmetricpb.ExportMetricsServiceRequest)
+	if err := proto.Unmarshal(body, msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	r.paths = append(r.paths, req.URL.Path)
+	r.requests = append(r.requests, msg)
+	r.mu.Unlock()
+
+	resp, err := proto.Marshal(new(colmetricpb.ExportMetricsServiceResponse))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(resp)
+}
+
+func Test_otlpCollector(t *testing.T) {
+	t.Parallel()
+
+	reqs := require.New(t)
+
+	receiver := new(otlpReceiver)
+	srv := httptest.NewServer(receiver)
+	t.Cleanup(srv.Close)
+
+	parentClient, childFactory := newMetricsTestClients(t)
+
+	ctx := ctrl.LoggerInto(t.Context(), logr.Discard())
+	oc, err := NewOTLPCollector(ctx, parentClient, srv.URL, 1)
+	reqs.NoError(err)
+	oc.childFactory = childFactory
+
+	reqs.NoError(oc.Collect(ctx))
+	reqs.NoError(oc.Close(ctx))
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+
+	reqs.Equal([]string{otlpDefaultURLPath}, receiver.paths)
+	reqs.Len(receiver.requests, 1)
+
+	rms := receiver.requests[0].GetResourceMetrics()
+	reqs.Len(rms, 1)
+
+	serviceName := ""
+	for _, attr := range rms[0].GetResource().GetAttributes() {
+		if attr.GetKey() == "service.name" {
+			serviceName = attr.GetValue().GetStringValue()
+		}
+	}
+	reqs.Equal(otlpServiceName, serviceName)
+
+	sms := rms[0].GetScopeMetrics()
+	reqs.Len(sms, 1)
+	reqs.Len(sms[0].GetMetrics(), 1)
+
+	m := sms[0].GetMetrics()[0]
+	reqs.Equal(otlpClusterCounterMetric, m.GetName())
+	reqs.NotNil(m.GetGauge())
+
+	got := make(map[string]int64)
+	for _, dp := range m.GetGauge().GetDataPoints() {
+		attrs := make(map[string]string)
+		for _, attr := range dp.GetAttributes() {
+			attrs[attr.GetKey()] = attr.GetValue().GetStringValue()
+		}
+
+		reqs.Equal(metricsTestNamespace+"/"+metricsTestCluster, attrs[metricLabelCluster])
+		reqs.Equal("kube-system:abcd-1234", attrs[metricLabelClusterID])
+		reqs.Equal("uuid:"+metricsTestNamespace+"/"+metricsTestCluster, attrs[metricLabelClusterDeploymentID])
+
+		got[attrs[metricLabelCounter]+":"+attrs[metricLabelBucket]] = dp.GetAsInt()
+	}
+
+	reqs.Equal(map[string]int64{
+		"scrapes:":                            1,
+		"node.count:1-2":                      1,
+		"node.cpu.total:3-4":                  1,
+		"node.memory.gibibytes:3-4":           1,
+		"node.info.kubeVersion:1.30":          2,
+		"node.info.arch:amd64":                2,
+		"node.info.os:linux":                  2,
+		"gpu.operator_installed.nvidia:false": 1,
+		"gpu.operator_installed.amd:false":    1,
+		"template:tpl-1":                      1,
+	}, got)
+}
+
+func Test_OTLPEndpointURL(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		{endpoint: "http://otel-collector:4318", want: "http://otel-collector:4318/v1/metrics"},
+		{endpoint: "https://otel-collector:4318/", want: "https://otel-collector:4318/v1/metrics"},
+		{endpoint: "http://otel-collector:4318/custom/metrics", want: "http://otel-collector:4318/custom/metrics"},
+		{endpoint: "otel-collector:4318", wantErr: true},
+		{endpoint: "grpc://otel-collector:4317", wantErr: true},
+		{endpoint: "http://", wantErr: true},
+	} {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := OTLPEndpointURL(tc.endpoint)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
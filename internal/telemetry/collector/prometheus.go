@@ -0,0 +1,117 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+)
+
+// PrometheusCollector exposes the same per-cluster counters as the [LocalCollector]
+// as gauges on the given Prometheus registry.
+type PrometheusCollector struct {
+	registerer   prometheus.Registerer
+	metrics      *clusterCountersMetrics
+	parentClient client.Client
+	childScheme  *runtime.Scheme
+	childFactory func([]byte, *runtime.Scheme) (client.Client, error) // for test mocks
+	concurrency  int
+}
+
+// clusterCountersMetrics implements the [prometheus.Collector] serving
+// the samples from the latest collection round.
+type clusterCountersMetrics struct {
+	desc    *prometheus.Desc
+	samples samples
+}
+
+var _ prometheus.Collector = (*clusterCountersMetrics)(nil)
+
+const prometheusClusterCounterMetric = "kcm_telemetry_cluster_counter"
+
+// NewPrometheusCollector creates a new instance of the [PrometheusCollector]
+// registering its metrics in the given registerer.
+func NewPrometheusCollector(parentClient client.Client, registerer prometheus.Registerer, concurrency int) (*PrometheusCollector, error) {
+	childScheme, err := getChildScheme()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create child client scheme: %w", err)
+	}
+
+	metrics := &clusterCountersMetrics{
+		desc: prometheus.NewDesc(
+			prometheusClusterCounterMetric,
+			"Per-cluster telemetry counters, the counter label holds the name of the counter and the bucket label holds its bucketed value.",
+			[]string{metricLabelCluster, metricLabelClusterID, metricLabelClusterDeploymentID, metricLabelCounter, metricLabelBucket},
+			nil,
+		),
+	}
+
+	if err := registerer.Register(metrics); err != nil {
+		return nil, fmt.Errorf("failed to register telemetry metrics: %w", err)
+	}
+
+	return &PrometheusCollector{
+		registerer:   registerer,
+		metrics:      metrics,
+		parentClient: parentClient,
+		childScheme:  childScheme,
+		childFactory: kubeutil.DefaultClientFactory,
+		concurrency:  concurrency,
+	}, nil
+}
+
+// Collect fetches all of the required data from the parent and child clusters,
+// replacing the previously exposed per-cluster counters.
+func (p *PrometheusCollector) Collect(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("prometheus-collector")
+	ctx = ctrl.LoggerInto(ctx, logger)
+
+	entries, err := collectClusterEntries(ctx, p.parentClient, p.childScheme, p.childFactory, p.concurrency)
+	if err != nil {
+		return err
+	}
+
+	p.metrics.samples.set(toSamples(entries))
+	logger.V(1).Info("updated telemetry metrics", "clusters", len(entries))
+
+	return nil
+}
+
+// Close unregisters the metrics from the registry.
+func (p *PrometheusCollector) Close(ctx context.Context) error {
+	ctrl.LoggerFrom(ctx).Info("closing prometheus collector")
+	p.registerer.Unregister(p.metrics)
+	return nil
+}
+
+// Describe implements the [prometheus.Collector] interface.
+func (m *clusterCountersMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.desc
+}
+
+// Collect implements the [prometheus.Collector] interface.
+func (m *clusterCountersMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range m.samples.get() {
+		ch <- prometheus.MustNewConstMetric(m.desc, prometheus.GaugeValue, float64(s.value),
+			s.cluster, s.clusterID, s.clusterDeploymentID, s.counter, s.bucket)
+	}
+}
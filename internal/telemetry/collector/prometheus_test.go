@@ -0,0 +1,130 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	metricsTestNamespace = "ns1"
+	metricsTestCluster   = "cld1"
+)
+
+// newMetricsTestClients returns the parent client with a single cluster deployment
+// and the child client factory serving its two nodes.
+func newMetricsTestClients(t *testing.T) (client.Client, func([]byte, *runtime.Scheme) (client.Client, error)) {
+	t.Helper()
+
+	mgmtCRD := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "managements.k0rdent.mirantis.com"}}
+	mgmtCRD.SetGroupVersionKind(apiextv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+
+	const tag = "child:" + metricsTestNamespace + "/" + metricsTestCluster
+	parentClient := fake.NewClientBuilder().
+		WithScheme(buildMgmtScheme(t)).
+		WithObjects(
+			mgmtCRD,
+			makeCLD(t, metricsTestNamespace, metricsTestCluster, "tpl-1", 0),
+			makeCAPICluster(t, metricsTestNamespace, metricsTestCluster, "kube-system:abcd-1234"),
+			makeKubeconfigSecret(t, metricsTestNamespace, metricsTestCluster, tag),
+		).
+		Build()
+
+	childFactory := func(kubeconfig []byte, scheme *runtime.Scheme) (client.Client, error) {
+		if string(kubeconfig) != tag {
+			return nil, fmt.Errorf("unrecognized kubeconfig tag %q", kubeconfig)
+		}
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			makeNode(t, "n1", "amd64", "linux", "v1.30.4", 2, 2, 0, 0),
+			makeNode(t, "n2", "amd64", "linux", "v1.30.1", 2, 2, 0, 0),
+		).Build(), nil
+	}
+
+	return parentClient, childFactory
+}
+
+func Test_prometheusCollector(t *testing.T) {
+	t.Parallel()
+
+	reqs := require.New(t)
+
+	parentClient, childFactory := newMetricsTestClients(t)
+	registry := prometheus.NewPedanticRegistry()
+
+	pc, err := NewPrometheusCollector(parentClient, registry, 1)
+	reqs.NoError(err)
+	pc.childFactory = childFactory
+
+	_, err = NewPrometheusCollector(parentClient, registry, 1)
+	reqs.Error(err, "metrics must not be registered twice")
+
+	families, err := registry.Gather()
+	reqs.NoError(err)
+	reqs.Empty(families, "nothing is exposed before the first collection")
+
+	ctx := ctrl.LoggerInto(t.Context(), logr.Discard())
+	reqs.NoError(pc.Collect(ctx))
+
+	families, err = registry.Gather()
+	reqs.NoError(err)
+	reqs.Len(families, 1)
+	reqs.Equal(prometheusClusterCounterMetric, families[0].GetName())
+	reqs.Equal(dto.MetricType_GAUGE, families[0].GetType())
+
+	got := make(map[string]float64)
+	for _, m := range families[0].GetMetric() {
+		labels := make(map[string]string)
+		for _, lp := range m.GetLabel() {
+			labels[lp.GetName()] = lp.GetValue()
+		}
+
+		reqs.Equal(metricsTestNamespace+"/"+metricsTestCluster, labels[metricLabelCluster])
+		reqs.Equal("kube-system:abcd-1234", labels[metricLabelClusterID])
+		reqs.Equal("uuid:"+metricsTestNamespace+"/"+metricsTestCluster, labels[metricLabelClusterDeploymentID])
+
+		got[labels[metricLabelCounter]+":"+labels[metricLabelBucket]] = m.GetGauge().GetValue()
+	}
+
+	reqs.Equal(map[string]float64{
+		"scrapes:":                            1,
+		"node.count:1-2":                      1,
+		"node.cpu.total:3-4":                  1,
+		"node.memory.gibibytes:3-4":           1,
+		"node.info.kubeVersion:1.30":          2,
+		"node.info.arch:amd64":                2,
+		"node.info.os:linux":                  2,
+		"gpu.operator_installed.nvidia:false": 1,
+		"gpu.operator_installed.amd:false":    1,
+		"template:tpl-1":                      1,
+	}, got)
+
+	reqs.NoError(pc.Close(ctx))
+
+	families, err = registry.Gather()
+	reqs.NoError(err)
+	reqs.Empty(families, "metrics must be unregistered on close")
+}
@@ -0,0 +1,272 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Query selects the local telemetry data to aggregate.
+	Query struct {
+		// From is the first day (inclusive) of the range.
+		From time.Time
+		// To is the last day (inclusive) of the range.
+		To time.Time
+		// Clusters limits the result to the clusters with the given namespaced names.
+		Clusters []string
+		// Templates limits the result to the clusters deployed from the given templates.
+		Templates []string
+	}
+
+	// Report is the aggregated local telemetry data over a date range.
+	Report struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		// Sources lists the files the report has been built from. A monthly summary
+		// is used as a whole if the range overlaps its month.
+		Sources  []string        `json:"sources,omitempty"`
+		Clusters []ClusterReport `json:"clusters"`
+	}
+
+	// ClusterReport holds the counters of a single cluster summed over the report range.
+	ClusterReport struct {
+		Cluster  string            `json:"cluster"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Counters map[string]uint64 `json:"counters,omitempty"`
+	}
+)
+
+// Query aggregates the data flushed by the collector over the given range.
+func (l *LocalCollector) Query(q Query) (*Report, error) {
+	return l.file.query(q)
+}
+
+// WriteCSV writes the report as CSV, one row per cluster counter.
+func (r *Report) WriteCSV(w io.Writer) error {
+	entries := make(map[string]clusterEntry, len(r.Clusters))
+	for _, c := range r.Clusters {
+		entries[c.Cluster] = clusterEntry{Counters: c.Counters, Labels: c.Labels}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{metricLabelCluster, metricLabelClusterID, metricLabelClusterDeploymentID, metricLabelCounter, metricLabelBucket, "value"}); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, s := range toSamples(entries) {
+		if err := cw.Write([]string{s.cluster, s.clusterID, s.clusterDeploymentID, s.counter, s.bucket, strconv.FormatUint(s.value, 10)}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (q Query) matches(e clusterEntry) bool {
+	if len(q.Clusters) > 0 && !slices.Contains(q.Clusters, e.Labels[labelCluster]) {
+		return false
+	}
+
+	if len(q.Templates) > 0 && !slices.ContainsFunc(q.Templates, func(tpl string) bool {
+		return e.Counters[bucketTemplate(tpl)] > 0
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// query aggregates the daily, today's temp and the monthly summary files within the range.
+func (f *file) query(q Query) (*Report, error) {
+	from, to := dateOnlyTime(q.From), dateOnlyTime(q.To)
+	if to.Before(from) {
+		return nil, fmt.Errorf("range end %s is before its start %s", to.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	files, err := f.listLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sources []string
+		covered = make(map[time.Time]struct{})
+		result  = make(map[string]clusterEntry)
+	)
+
+	add := func(path string, st fileState) {
+		sources = append(sources, filepath.Base(path))
+		filtered := make(map[string]clusterEntry, len(st.Clusters))
+		for k, e := range stateToMap(st) {
+			if q.matches(e) {
+				filtered[k] = e
+			}
+		}
+		f.mergeMaps(result, filtered)
+	}
+
+	for _, month := range slices.SortedFunc(maps.Keys(files.monthly), compareTime) {
+		if month.After(to) || !month.AddDate(0, 1, 0).After(from) {
+			continue
+		}
+
+		path := files.monthly[month]
+		summary, err := readSummaryFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range summary.Days {
+			if day, err := time.Parse(time.DateOnly, d); err == nil {
+				covered[day] = struct{}{}
+			}
+		}
+		add(path, fileState{Clusters: summary.Clusters})
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if _, ok := covered[day]; ok {
+			continue
+		}
+
+		// the temp file holds everything flushed for the day so far including the permanent one if any
+		if path, ok := files.temp[day]; ok {
+			if st, err := readStateFile(path); err == nil && len(st.Clusters) > 0 {
+				add(path, st)
+				continue
+			}
+		}
+
+		if path, ok := files.daily[day]; ok {
+			st, err := readStateFile(path)
+			if err != nil {
+				return nil, err
+			}
+			add(path, st)
+		}
+	}
+
+	report := &Report{
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		Sources:  sources,
+		Clusters: make([]ClusterReport, 0, len(result)),
+	}
+	for _, e := range mapToState(result).Clusters {
+		report.Clusters = append(report.Clusters, ClusterReport{Cluster: e.Labels[labelCluster], Labels: e.Labels, Counters: e.Counters})
+	}
+
+	return report, nil
+}
+
+// localFiles are the telemetry files found in the base directory keyed by their day or month.
+type localFiles struct {
+	daily   map[time.Time]string
+	temp    map[time.Time]string
+	monthly map[time.Time]string
+}
+
+const (
+	tempFilePrefix = "telemetry-"
+	tempFileSuffix = ".json.tmp"
+	jsonFileSuffix = ".json"
+	monthOnly      = "2006-01"
+)
+
+func (f *file) listLocked() (*localFiles, error) {
+	dirEntries, err := os.ReadDir(f.baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dir %s: %w", f.baseDir, err)
+	}
+
+	files := &localFiles{
+		daily:   make(map[time.Time]string),
+		temp:    make(map[time.Time]string),
+		monthly: make(map[time.Time]string),
+	}
+	for _, de := range dirEntries {
+		if !de.Type().IsRegular() {
+			continue
+		}
+
+		name, path := de.Name(), filepath.Join(f.baseDir, de.Name())
+		switch {
+		case strings.HasPrefix(name, tempFilePrefix) && strings.HasSuffix(name, tempFileSuffix):
+			if day, err := time.Parse(time.DateOnly, strings.TrimSuffix(strings.TrimPrefix(name, tempFilePrefix), tempFileSuffix)); err == nil {
+				files.temp[day] = path
+			}
+		case strings.HasSuffix(name, jsonFileSuffix):
+			base := strings.TrimSuffix(name, jsonFileSuffix)
+			if day, err := time.Parse(time.DateOnly, base); err == nil {
+				files.daily[day] = path
+			} else if month, err := time.Parse(monthOnly, base); err == nil {
+				files.monthly[month] = path
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// readStateFile reads the state of a daily file. Unlike [file.tryReadState]
+// it neither tolerates nor renames corrupted files.
+func readStateFile(path string) (fileState, error) {
+	var st fileState
+	if err := readJSONFile(path, &st); err != nil {
+		return fileState{}, err
+	}
+	return st, nil
+}
+
+func readJSONFile(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if len(strings.TrimSpace(string(b))) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func compareTime(a, b time.Time) int {
+	return a.Compare(b)
+}
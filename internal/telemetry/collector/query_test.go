@@ -0,0 +1,170 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeJSONFile(t *testing.T, path string, v any) {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, b, 0o644))
+}
+
+func newTestEntry(cluster, template string, counters map[string]uint64) clusterEntry {
+	e := newClusterEntry()
+	e.label(labelCluster, cluster)
+	e.label(labelClusterID, "id:"+cluster)
+	for k, v := range counters {
+		e.add(k, v)
+	}
+	e.inc(bucketTemplate(template))
+	return *e
+}
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+
+	d, err := time.Parse(time.DateOnly, s)
+	require.NoError(t, err)
+	return d
+}
+
+func Test_file_query(t *testing.T) {
+	t.Parallel()
+
+	f := mustNewFile(t)
+	dir := f.baseDir
+	require.NoError(t, os.Remove(f.tempPath())) // not relevant for the given dates
+
+	writeJSONFile(t, filepath.Join(dir, "2026-08.json"), summaryState{
+		Days: []string{"2026-08-30", "2026-08-31"},
+		Clusters: []clusterEntry{
+			newTestEntry("ns/a", "tpl-1", map[string]uint64{"scrapes": 10}),
+		},
+	})
+	// left over after an interrupted compaction, must not be counted twice
+	writeJSONFile(t, filepath.Join(dir, "2026-08-31.json"), fileState{Clusters: []clusterEntry{
+		newTestEntry("ns/a", "tpl-1", map[string]uint64{"scrapes": 5}),
+	}})
+	writeJSONFile(t, filepath.Join(dir, "2026-09-01.json"), fileState{Clusters: []clusterEntry{
+		newTestEntry("ns/a", "tpl-1", map[string]uint64{"scrapes": 1, "node.count:1-2": 1}),
+		newTestEntry("ns/b", "tpl-2", map[string]uint64{"scrapes": 1}),
+	}})
+	// permanent file of the day is superseded by the temp one
+	writeJSONFile(t, filepath.Join(dir, "2026-09-02.json"), fileState{Clusters: []clusterEntry{
+		newTestEntry("ns/a", "tpl-1", map[string]uint64{"scrapes": 1}),
+	}})
+	writeJSONFile(t, filepath.Join(dir, "telemetry-2026-09-02.json.tmp"), fileState{Clusters: []clusterEntry{
+		newTestEntry("ns/a", "tpl-1", map[string]uint64{"scrapes": 2}),
+	}})
+	writeJSONFile(t, filepath.Join(dir, "2026-09-10.json"), fileState{Clusters: []clusterEntry{
+		newTestEntry("ns/a", "tpl-1", map[string]uint64{"scrapes": 100}),
+	}})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.json"), []byte("{"), 0o644))
+
+	t.Run("whole range", func(t *testing.T) {
+		t.Parallel()
+
+		reqs := require.New(t)
+
+		report, err := f.query(Query{From: mustDate(t, "2026-08-31"), To: mustDate(t, "2026-09-02")})
+		reqs.NoError(err)
+		reqs.Equal("2026-08-31", report.From)
+		reqs.Equal("2026-09-02", report.To)
+		reqs.Equal([]string{"2026-08.json", "2026-09-01.json", "telemetry-2026-09-02.json.tmp"}, report.Sources)
+		reqs.Len(report.Clusters, 2)
+
+		reqs.Equal("ns/a", report.Clusters[0].Cluster)
+		reqs.Equal(uint64(13), report.Clusters[0].Counters["scrapes"])
+		reqs.Equal(uint64(1), report.Clusters[0].Counters["node.count:1-2"])
+		reqs.Equal(uint64(3), report.Clusters[0].Counters["template:tpl-1"])
+		reqs.Equal("id:ns/a", report.Clusters[0].Labels[labelClusterID])
+
+		reqs.Equal("ns/b", report.Clusters[1].Cluster)
+		reqs.Equal(uint64(1), report.Clusters[1].Counters["scrapes"])
+	})
+
+	t.Run("filter by template", func(t *testing.T) {
+		t.Parallel()
+
+		reqs := require.New(t)
+
+		report, err := f.query(Query{From: mustDate(t, "2026-09-01"), To: mustDate(t, "2026-09-30"), Templates: []string{"tpl-2"}})
+		reqs.NoError(err)
+		reqs.Len(report.Clusters, 1)
+		reqs.Equal("ns/b", report.Clusters[0].Cluster)
+	})
+
+	t.Run("filter by cluster", func(t *testing.T) {
+		t.Parallel()
+
+		reqs := require.New(t)
+
+		report, err := f.query(Query{From: mustDate(t, "2026-09-01"), To: mustDate(t, "2026-09-30"), Clusters: []string{"ns/a"}})
+		reqs.NoError(err)
+		reqs.Len(report.Clusters, 1)
+		reqs.Equal(uint64(103), report.Clusters[0].Counters["scrapes"])
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		t.Parallel()
+
+		reqs := require.New(t)
+
+		report, err := f.query(Query{From: mustDate(t, "2026-07-01"), To: mustDate(t, "2026-07-31")})
+		reqs.NoError(err)
+		reqs.Empty(report.Clusters)
+		reqs.Empty(report.Sources)
+	})
+
+	t.Run("inverted range", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := f.query(Query{From: mustDate(t, "2026-09-02"), To: mustDate(t, "2026-09-01")})
+		require.ErrorContains(t, err, "before its start")
+	})
+}
+
+func Test_Report_WriteCSV(t *testing.T) {
+	t.Parallel()
+
+	reqs := require.New(t)
+
+	report := &Report{Clusters: []ClusterReport{
+		{
+			Cluster:  "ns/a",
+			Labels:   map[string]string{labelCluster: "ns/a", labelClusterID: "id", labelCldID: "uid"},
+			Counters: map[string]uint64{"scrapes": 2, "node.count:1-2": 2},
+		},
+	}}
+
+	var buf bytes.Buffer
+	reqs.NoError(report.WriteCSV(&buf))
+	reqs.Equal(`cluster,cluster_id,cluster_deployment_id,counter,bucket,value
+ns/a,id,uid,node.count,1-2,2
+ns/a,id,uid,scrapes,,2
+`, buf.String())
+}
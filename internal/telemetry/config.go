@@ -22,6 +22,7 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/K0rdent/kcm/internal/telemetry/collector"
 	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
 )
 
@@ -36,6 +37,12 @@ type Config struct {
 	Mode            Mode
 	SystemNamespace string
 	LocalBaseDir    string
+	// LocalRetention configures the compaction of the daily local telemetry files.
+	// Applicable only for the local mode.
+	LocalRetention collector.Retention
+	// OTLPEndpoint is the OTLP/HTTP endpoint of an OpenTelemetry collector to push metrics to.
+	// Applicable only for the otlp mode.
+	OTLPEndpoint string
 	// ExtraResource is the name of the resource that will be collected and to which the [ExpressionCEL] will be applied to extract extra properties.
 	// Applicable only for the online collector.
 	ExtraResource string
@@ -51,9 +58,11 @@ type Config struct {
 type Mode string //nolint:recvcheck // invalid in this case
 
 const (
-	ModeDisabled Mode = "disabled"
-	ModeLocal    Mode = "local"
-	ModeOnline   Mode = "online"
+	ModeDisabled   Mode = "disabled"
+	ModeLocal      Mode = "local"
+	ModeOnline     Mode = "online"
+	ModePrometheus Mode = "prometheus"
+	ModeOTLP       Mode = "otlp"
 )
 
 var _ flag.Value = (*Mode)(nil)
@@ -64,20 +73,23 @@ func (m Mode) String() string {
 
 func (m *Mode) Set(flagValue string) error {
 	switch v := Mode(flagValue); v {
-	case ModeDisabled, ModeOnline, ModeLocal:
+	case ModeDisabled, ModeOnline, ModeLocal, ModePrometheus, ModeOTLP:
 		*m = v
 		return nil
 	default:
-		return fmt.Errorf("unknown mode %q, must be one of 'online', 'local' or 'disabled'", flagValue)
+		return fmt.Errorf("unknown mode %q, must be one of 'online', 'local', 'prometheus', 'otlp' or 'disabled'", flagValue)
 	}
 }
 
 func (c *Config) BindFlags(fs *flag.FlagSet) {
-	fs.Var(&c.Mode, "telemetry-mode", "Telemetry collection mode (one of 'online', 'local' or 'disabled')")
+	fs.Var(&c.Mode, "telemetry-mode", "Telemetry collection mode (one of 'online', 'local', 'prometheus', 'otlp' or 'disabled')")
 	fs.IntVar(&c.Concurrency, "telemetry-concurrency", 5, "Number of clusters for which data is collected concurrently")
 	fs.DurationVar(&c.Interval, "telemetry-interval", 24*time.Hour, "How frequently to collect data")
 	fs.UintVar(&c.JitterPercentage, "telemetry-jitter", 10, "Jitter of telemetry collection interval given in percentage (0, 100)")
 	fs.StringVar(&c.LocalBaseDir, "telemetry-base-dir", "/var/lib/telemetry", "Base directory where to put local telemetry data")
+	fs.IntVar(&c.LocalRetention.Days, "telemetry-retention-days", 0, "Number of days to keep the daily local telemetry files before compacting them into monthly summaries, 0 disables the compaction")
+	fs.IntVar(&c.LocalRetention.Months, "telemetry-retention-months", 0, "Number of months to keep the monthly local telemetry summaries, 0 keeps them forever")
+	fs.StringVar(&c.OTLPEndpoint, "telemetry-otlp-endpoint", "", "OTLP/HTTP endpoint of an OpenTelemetry collector to push metrics to (e.g. 'http://otel-collector:4318'), applicable only for the otlp mode")
 	fs.StringVar(&c.ExtraResource, "telemetry-resource", "", "List objects of this resource to apply the CEL expression on it (e.g. 'licenses'). Adds extra properties to the data collected, applicable only for the online mode")
 	fs.StringVar(&c.ExpressionCEL, "telemetry-cel", "", "CEL expression to be executed if the --telemetry-resource flag has been provided")
 }
@@ -103,8 +115,21 @@ func (c *Config) validate() error {
 		return errors.New("base directory must be set for the local telemetry mode")
 	}
 
+	if c.LocalRetention.Days < 0 || c.LocalRetention.Months < 0 {
+		return errors.New("retention must not be negative")
+	}
+
+	if c.Mode == ModeOTLP {
+		if c.OTLPEndpoint == "" {
+			return errors.New("OTLP endpoint must be set for the otlp telemetry mode")
+		}
+		if _, err := collector.OTLPEndpointURL(c.OTLPEndpoint); err != nil {
+			return err
+		}
+	}
+
 	switch c.Mode {
-	case ModeDisabled, ModeOnline, ModeLocal:
+	case ModeDisabled, ModeOnline, ModeLocal, ModePrometheus, ModeOTLP:
 	default:
 		return fmt.Errorf("unknown mode %q", c.Mode)
 	}
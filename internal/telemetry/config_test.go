@@ -22,6 +22,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/K0rdent/kcm/internal/telemetry/collector"
 )
 
 func TestConfig_validate(t *testing.T) {
@@ -94,6 +96,56 @@ func TestConfig_validate(t *testing.T) {
 		require.ErrorContains(t, err, "base directory")
 	})
 
+	t.Run("negative retention", func(t *testing.T) {
+		cfg := &Config{
+			ParentClient:     fake.NewClientBuilder().Build(),
+			Concurrency:      1,
+			Mode:             ModeLocal,
+			LocalBaseDir:     "some",
+			LocalRetention:   collector.Retention{Days: -1},
+			Interval:         10 * time.Minute,
+			JitterPercentage: 10,
+		}
+		err := cfg.validate()
+		require.ErrorContains(t, err, "retention")
+	})
+
+	t.Run("empty endpoint in otlp mode", func(t *testing.T) {
+		cfg := &Config{
+			ParentClient:     fake.NewClientBuilder().Build(),
+			Concurrency:      1,
+			Mode:             ModeOTLP,
+			Interval:         10 * time.Minute,
+			JitterPercentage: 10,
+		}
+		err := cfg.validate()
+		require.ErrorContains(t, err, "OTLP endpoint must be set")
+	})
+
+	t.Run("invalid endpoint in otlp mode", func(t *testing.T) {
+		cfg := &Config{
+			ParentClient:     fake.NewClientBuilder().Build(),
+			Concurrency:      1,
+			Mode:             ModeOTLP,
+			OTLPEndpoint:     "otel-collector:4318",
+			Interval:         10 * time.Minute,
+			JitterPercentage: 10,
+		}
+		err := cfg.validate()
+		require.ErrorContains(t, err, "must be an absolute http(s) URL")
+	})
+
+	t.Run("valid prometheus mode", func(t *testing.T) {
+		cfg := &Config{
+			ParentClient:     fake.NewClientBuilder().Build(),
+			Concurrency:      1,
+			Mode:             ModePrometheus,
+			Interval:         10 * time.Minute,
+			JitterPercentage: 10,
+		}
+		require.NoError(t, cfg.validate())
+	})
+
 	t.Run("unknown mode", func(t *testing.T) {
 		cfg := &Config{
 			ParentClient:     fake.NewClientBuilder().Build(),
@@ -138,6 +190,8 @@ func TestMode_Set(t *testing.T) {
 		{"valid online", "online", ModeOnline, nil},
 		{"valid local", "local", ModeLocal, nil},
 		{"valid disabled", "disabled", ModeDisabled, nil},
+		{"valid prometheus", "prometheus", ModePrometheus, nil},
+		{"valid otlp", "otlp", ModeOTLP, nil},
 		{"empty is invalid", "", "", errors.New("unknown mode")},
 		{"invalid mode", "invalid", "", errors.New("unknown mode")},
 	}
@@ -160,6 +214,8 @@ func TestMode_String(t *testing.T) {
 	require.Equal(t, "online", ModeOnline.String())
 	require.Equal(t, "local", ModeLocal.String())
 	require.Equal(t, "disabled", ModeDisabled.String())
+	require.Equal(t, "prometheus", ModePrometheus.String())
+	require.Equal(t, "otlp", ModeOTLP.String())
 }
 
 func TestConfig_BindFlags(t *testing.T) {
@@ -173,6 +229,7 @@ func TestConfig_BindFlags(t *testing.T) {
 		"-telemetry-concurrency=3",
 		"-telemetry-interval=5m",
 		"-telemetry-jitter=7",
+		"-telemetry-retention-days=30",
 	})
 	require.NoError(t, err)
 
@@ -180,4 +237,5 @@ func TestConfig_BindFlags(t *testing.T) {
 	require.Equal(t, 3, cfg.Concurrency)
 	require.Equal(t, 5*time.Minute, cfg.Interval)
 	require.Equal(t, uint(7), cfg.JitterPercentage)
+	require.Equal(t, collector.Retention{Days: 30}, cfg.LocalRetention)
 }
@@ -0,0 +1,130 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/K0rdent/kcm/internal/telemetry/collector"
+)
+
+// QueryPath is the path the [Runner.QueryHandler] is expected to be served on.
+// The handler does not authenticate the requests on its own, hence it is served
+// only by the secure metrics server which authenticates and authorizes the requests.
+const QueryPath = "/telemetry"
+
+const (
+	queryFormatJSON = "json"
+	queryFormatCSV  = "csv"
+
+	queryDefaultRangeDays = 30
+)
+
+// querier is implemented by the collectors supporting querying the collected data.
+type querier interface {
+	Query(collector.Query) (*collector.Report, error)
+}
+
+// queryHandler serves the aggregated telemetry over a date range.
+//
+// Supported query parameters:
+//   - from, to: the inclusive range in the YYYY-MM-DD format, defaults to the last 30 days;
+//   - cluster: namespaced name of a cluster to filter by, can be repeated;
+//   - template: name of a cluster template to filter by, can be repeated;
+//   - format: either json (default) or csv.
+type queryHandler struct {
+	querier querier
+	now     func() time.Time
+}
+
+func newQueryHandler(q querier, now func() time.Time) http.Handler {
+	return &queryHandler{querier: q, now: now}
+}
+
+func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+
+	q, err := h.parseQuery(params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	format := params.Get("format")
+	if format == "" {
+		format = queryFormatJSON
+	}
+	if format != queryFormatJSON && format != queryFormatCSV {
+		http.Error(w, fmt.Sprintf("unknown format %q, must be one of 'json' or 'csv'", format), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.querier.Query(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == queryFormatCSV {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "telemetry-"+report.From+"-"+report.To+".csv"))
+		_ = report.WriteCSV(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func (h *queryHandler) parseQuery(params url.Values) (collector.Query, error) {
+	q := collector.Query{
+		To:        h.now(),
+		Clusters:  params["cluster"],
+		Templates: params["template"],
+	}
+
+	if v := params.Get("to"); v != "" {
+		to, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return collector.Query{}, fmt.Errorf("invalid 'to' date %q, must be in the YYYY-MM-DD format", v)
+		}
+		q.To = to
+	}
+
+	q.From = q.To.AddDate(0, 0, 1-queryDefaultRangeDays)
+	if v := params.Get("from"); v != "" {
+		from, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return collector.Query{}, fmt.Errorf("invalid 'from' date %q, must be in the YYYY-MM-DD format", v)
+		}
+		q.From = from
+	}
+
+	if q.To.Before(q.From) {
+		return collector.Query{}, fmt.Errorf("'to' date %s is before the 'from' date %s", q.To.Format(time.DateOnly), q.From.Format(time.DateOnly))
+	}
+
+	return q, nil
+}
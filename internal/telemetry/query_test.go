@@ -0,0 +1,153 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/K0rdent/kcm/internal/telemetry/collector"
+)
+
+type fakeQuerier struct {
+	got    collector.Query
+	report *collector.Report
+	err    error
+}
+
+func (f *fakeQuerier) Query(q collector.Query) (*collector.Report, error) {
+	f.got = q
+	return f.report, f.err
+}
+
+func TestQueryHandler(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	report := &collector.Report{
+		From: "2026-10-01",
+		To:   "2026-10-17",
+		Clusters: []collector.ClusterReport{
+			{Cluster: "ns/a", Labels: map[string]string{"cluster": "ns/a"}, Counters: map[string]uint64{"scrapes": 3}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		querierErr  error
+		wantCode    int
+		wantQuery   *collector.Query
+		wantType    string
+		wantBodySub string
+	}{
+		{
+			name:     "defaults",
+			target:   "/telemetry",
+			wantCode: http.StatusOK,
+			wantType: "application/json",
+			wantQuery: &collector.Query{
+				From: now.AddDate(0, 0, -29),
+				To:   now,
+			},
+			wantBodySub: `"cluster":"ns/a"`,
+		},
+		{
+			name:     "filters and csv",
+			target:   "/telemetry?from=2026-10-01&to=2026-10-17&cluster=ns/a&cluster=ns/b&template=tpl&format=csv",
+			wantCode: http.StatusOK,
+			wantType: "text/csv",
+			wantQuery: &collector.Query{
+				From:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+				To:        time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+				Clusters:  []string{"ns/a", "ns/b"},
+				Templates: []string{"tpl"},
+			},
+			wantBodySub: "ns/a,,,scrapes,,3",
+		},
+		{
+			name:        "invalid date",
+			target:      "/telemetry?from=17-10-2026",
+			wantCode:    http.StatusBadRequest,
+			wantBodySub: "invalid 'from' date",
+		},
+		{
+			name:        "inverted range",
+			target:      "/telemetry?from=2026-10-17&to=2026-10-01",
+			wantCode:    http.StatusBadRequest,
+			wantBodySub: "is before the 'from' date",
+		},
+		{
+			name:        "unknown format",
+			target:      "/telemetry?format=xml",
+			wantCode:    http.StatusBadRequest,
+			wantBodySub: "unknown format",
+		},
+		{
+			name:     "wrong method",
+			method:   http.MethodPost,
+			target:   "/telemetry",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "querier error",
+			target:      "/telemetry",
+			querierErr:  errors.New("boom"),
+			wantCode:    http.StatusInternalServerError,
+			wantBodySub: "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &fakeQuerier{report: report, err: tt.querierErr}
+			h := newQueryHandler(q, func() time.Time { return now })
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequestWithContext(t.Context(), method, tt.target, nil))
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			if tt.wantType != "" {
+				require.Equal(t, tt.wantType, rec.Header().Get("Content-Type"))
+			}
+			if tt.wantQuery != nil {
+				require.Equal(t, *tt.wantQuery, q.got)
+			}
+			require.Contains(t, rec.Body.String(), tt.wantBodySub)
+
+			if tt.wantType == "application/json" {
+				var got collector.Report
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+				require.Equal(t, *report, got)
+			}
+		})
+	}
+}
+
+func TestRunner_QueryHandler(t *testing.T) {
+	require.Nil(t, (&Runner{collector: &mockCollector{}}).QueryHandler())
+	require.Nil(t, (&Runner{querier: &fakeQuerier{}, isDisabled: true}).QueryHandler())
+	require.NotNil(t, (&Runner{querier: &fakeQuerier{}}).QueryHandler())
+}
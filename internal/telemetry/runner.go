@@ -32,6 +32,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/K0rdent/kcm/internal/build"
 	"github.com/K0rdent/kcm/internal/telemetry/collector"
@@ -48,6 +49,7 @@ type Collector interface {
 // Runner is a controller-runtime Runnable that periodically invokes the tracker.
 type Runner struct {
 	collector      Collector
+	querier        querier
 	frequency      time.Duration
 	jitterFraction float64
 	isDisabled     bool
@@ -62,7 +64,10 @@ func NewRunner(cfg *Config) (*Runner, error) {
 	}
 	cfg.normalize()
 
-	var tr Collector
+	var (
+		tr      Collector
+		querier querier
+	)
 	if cfg.Mode == ModeOnline && segmentToken != "" {
 		tz := ""
 		if loc := time.Now().Location(); loc != nil {
@@ -129,12 +134,31 @@ func NewRunner(cfg *Config) (*Runner, error) {
 	}
 
 	if cfg.Mode == ModeLocal {
-		localCollector, err := collector.NewLocalCollector(cfg.ParentClient, cfg.LocalBaseDir, cfg.Concurrency)
+		localCollector, err := collector.NewLocalCollector(cfg.ParentClient, cfg.LocalBaseDir, cfg.Concurrency, cfg.LocalRetention)
 		if err != nil {
 			return nil, fmt.Errorf("failed to init local collector: %w", err)
 		}
 
 		tr = localCollector
+		querier = localCollector
+	}
+
+	if cfg.Mode == ModePrometheus {
+		prometheusCollector, err := collector.NewPrometheusCollector(cfg.ParentClient, metrics.Registry, cfg.Concurrency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init prometheus collector: %w", err)
+		}
+
+		tr = prometheusCollector
+	}
+
+	if cfg.Mode == ModeOTLP {
+		otlpCollector, err := collector.NewOTLPCollector(context.Background(), cfg.ParentClient, cfg.OTLPEndpoint, cfg.Concurrency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init otlp collector: %w", err)
+		}
+
+		tr = otlpCollector
 	}
 
 	if cfg.Mode == ModeOnline && segmentToken == "" {
@@ -143,6 +167,7 @@ func NewRunner(cfg *Config) (*Runner, error) {
 
 	return &Runner{
 		collector:      tr,
+		querier:        querier,
 		frequency:      cfg.Interval,
 		jitterFraction: float64(cfg.JitterPercentage) / 100,
 		isDisabled:     cfg.Mode == ModeDisabled,
@@ -196,6 +221,15 @@ func (r *Runner) Start(ctx context.Context) error {
 	}
 }
 
+// QueryHandler returns the HTTP handler serving the collected telemetry,
+// nil if the collector does not support querying.
+func (r *Runner) QueryHandler() http.Handler {
+	if r.isDisabled || r.querier == nil {
+		return nil
+	}
+	return newQueryHandler(r.querier, time.Now)
+}
+
 func (r *Runner) Enabled() bool {
 	return !r.isDisabled
 }
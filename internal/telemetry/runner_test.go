@@ -90,6 +90,26 @@ func TestNewRunner_ValidLocalMode(t *testing.T) {
 	require.True(t, runn.Enabled())
 }
 
+func TestNewRunner_ValidPrometheusMode(t *testing.T) {
+	cfg := &Config{Mode: ModePrometheus, ParentClient: fake.NewClientBuilder().Build()}
+	cfg.normalize()
+
+	runn, err := NewRunner(cfg)
+	require.NoError(t, err)
+	require.True(t, runn.Enabled())
+	require.NoError(t, runn.collector.Close(t.Context()))
+}
+
+func TestNewRunner_ValidOTLPMode(t *testing.T) {
+	cfg := &Config{Mode: ModeOTLP, OTLPEndpoint: "http://127.0.0.1:4318", ParentClient: fake.NewClientBuilder().Build()}
+	cfg.normalize()
+
+	runn, err := NewRunner(cfg)
+	require.NoError(t, err)
+	require.True(t, runn.Enabled())
+	require.NoError(t, runn.collector.Close(t.Context()))
+}
+
 func TestNewRunner_OnlineNoSegmentToken(t *testing.T) {
 	cfg := &Config{Mode: ModeOnline, ParentClient: fake.NewClientBuilder().Build()}
 	cfg.normalize()
@@ -50,8 +50,9 @@ func UpdateReadyCondition(conditions []metav1.Condition, generation int64, handl
 		}
 
 		if cond.Type == kcmv1.PausedCondition {
-			// If True and Paused, the cluster is paused and thus is not ready
-			if cond.Status == metav1.ConditionTrue && cond.Reason == kcmv1.PausedReason {
+			// If True and Paused (manually, due to the unreachable region or the migration), the cluster is paused and thus is not ready
+			if cond.Status == metav1.ConditionTrue &&
+				(cond.Reason == kcmv1.PausedReason || cond.Reason == kcmv1.RegionUnreachableReason || cond.Reason == kcmv1.MigratingReason) {
 				errs = append(errs, cond.Message)
 			}
 			// If False and NotPaused, that's normal operation - no need to include in status
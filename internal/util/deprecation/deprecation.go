@@ -0,0 +1,241 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deprecation provides helpers to evaluate the deprecation and end-of-life
+// metadata of the [kcmv1.ClusterTemplate] and [kcmv1.ServiceTemplate] objects.
+package deprecation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// The lifecycle stages of a template.
+const (
+	LifecycleSupported  = "supported"
+	LifecycleDeprecated = "deprecated"
+	LifecycleEndOfLife  = "end-of-life"
+)
+
+// Deprecation is the deprecation metadata of a template.
+type Deprecation struct {
+	// EndOfLife is the time since which the template is no longer supported, zero if not set.
+	EndOfLife time.Time
+	// Message is the human-readable deprecation message.
+	Message string
+	// Replacement is the name of the template to migrate to.
+	Replacement string
+}
+
+// FromObject returns the deprecation metadata defined by the annotations
+// of the given template, nil if the template is not deprecated.
+func FromObject(obj client.Object) (*Deprecation, error) {
+	annotations := obj.GetAnnotations()
+	message, deprecated := annotations[kcmv1.TemplateDeprecatedAnnotation]
+	eol, hasEOL := annotations[kcmv1.TemplateEndOfLifeAnnotation]
+	if !deprecated && !hasEOL {
+		return nil, nil //nolint:nilnil // template is not deprecated
+	}
+
+	if strings.EqualFold(message, "true") {
+		message = ""
+	}
+	d := &Deprecation{Message: message, Replacement: annotations[kcmv1.TemplateReplacementAnnotation]}
+	if hasEOL {
+		t, err := parseEndOfLife(eol)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation of %s: %w", kcmv1.TemplateEndOfLifeAnnotation, client.ObjectKeyFromObject(obj), err)
+		}
+		d.EndOfLife = t
+	}
+
+	return d, nil
+}
+
+// Validate checks that the deprecation annotations of the given template, if any, are well-formed.
+func Validate(obj client.Object) error {
+	if v, ok := obj.GetAnnotations()[kcmv1.TemplateEndOfLifeAnnotation]; ok {
+		if _, err := parseEndOfLife(v); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", kcmv1.TemplateEndOfLifeAnnotation, err)
+		}
+	}
+	return nil
+}
+
+func parseEndOfLife(v string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a date in the YYYY-MM-DD format nor an RFC 3339 time", v)
+	}
+	return t, nil
+}
+
+// IsEndOfLife returns whether the template is no longer supported at the given time.
+func (d *Deprecation) IsEndOfLife(now time.Time) bool {
+	return d != nil && !d.EndOfLife.IsZero() && !now.Before(d.EndOfLife)
+}
+
+// Lifecycle returns the lifecycle stage of the template at the given time.
+func (d *Deprecation) Lifecycle(now time.Time) string {
+	switch {
+	case d == nil:
+		return LifecycleSupported
+	case d.IsEndOfLife(now):
+		return LifecycleEndOfLife
+	default:
+		return LifecycleDeprecated
+	}
+}
+
+// Describe returns the human-readable description of the deprecation
+// of the template with the given kind and name at the given time.
+func (d *Deprecation) Describe(kind, name string, now time.Time) string {
+	var sb strings.Builder
+	sb.WriteString(kind + " " + name)
+	if d.IsEndOfLife(now) {
+		sb.WriteString(" has reached end of life on " + d.EndOfLife.Format(time.DateOnly))
+	} else {
+		sb.WriteString(" is deprecated")
+		if !d.EndOfLife.IsZero() {
+			sb.WriteString(" and reaches end of life on " + d.EndOfLife.Format(time.DateOnly))
+		}
+	}
+	if d.Message != "" {
+		sb.WriteString(": " + d.Message)
+	}
+	if d.Replacement != "" {
+		sb.WriteString(", use " + d.Replacement + " instead")
+	}
+	return sb.String()
+}
+
+// Template is a template referenced by an object alongside with its deprecation metadata.
+type Template struct {
+	// Deprecation is the deprecation metadata of the template, nil if the template is not deprecated.
+	Deprecation *Deprecation
+	// Kind is the kind of the template.
+	Kind string
+	// Name is the name of the template.
+	Name string
+}
+
+// Describe returns the human-readable description of the template deprecation at the given time.
+func (t Template) Describe(now time.Time) string {
+	return t.Deprecation.Describe(t.Kind, t.Name, now)
+}
+
+// Resolve returns the given [kcmv1.ClusterTemplate], if set, and [kcmv1.ServiceTemplate] objects in the namespace
+// alongside with their deprecation metadata. The missing templates are skipped since their absence
+// is reported by the templates validation.
+func Resolve(ctx context.Context, c client.Reader, namespace, clusterTemplate string, serviceTemplates []string) ([]Template, error) {
+	templates := make([]Template, 0, len(serviceTemplates)+1)
+
+	resolve := func(obj client.Object, kind, name string) error {
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get %s %s/%s: %w", kind, namespace, name, err)
+		}
+
+		d, err := FromObject(obj)
+		if err != nil {
+			return err
+		}
+		templates = append(templates, Template{Deprecation: d, Kind: kind, Name: name})
+		return nil
+	}
+
+	if clusterTemplate != "" {
+		if err := resolve(new(kcmv1.ClusterTemplate), kcmv1.ClusterTemplateKind, clusterTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]struct{}, len(serviceTemplates))
+	for _, name := range serviceTemplates {
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		if err := resolve(new(kcmv1.ServiceTemplate), kcmv1.ServiceTemplateKind, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+// Deprecated returns only the deprecated templates among the given ones.
+func Deprecated(templates []Template) []Template {
+	var result []Template
+	for _, t := range templates {
+		if t.Deprecation != nil {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// Condition returns the [kcmv1.TemplateDeprecatedCondition] reporting the given deprecated templates
+// at the given time. Returns false if none of the templates is deprecated.
+func Condition(templates []Template, now time.Time, generation int64) (metav1.Condition, bool) {
+	deprecated := Deprecated(templates)
+	if len(deprecated) == 0 {
+		return metav1.Condition{}, false
+	}
+
+	reason := kcmv1.TemplateDeprecatedReason
+	messages := make([]string, 0, len(deprecated))
+	for _, t := range deprecated {
+		if t.Deprecation.IsEndOfLife(now) {
+			reason = kcmv1.TemplateEndOfLifeReason
+		}
+		messages = append(messages, t.Describe(now))
+	}
+
+	return metav1.Condition{
+		Type:               kcmv1.TemplateDeprecatedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            strings.Join(messages, "; "),
+		ObservedGeneration: generation,
+	}, true
+}
+
+// NextEndOfLife returns the earliest end of life of the given templates after the given time,
+// zero if none of the templates reaches end of life in the future.
+func NextEndOfLife(templates []Template, now time.Time) time.Time {
+	var next time.Time
+	for _, t := range templates {
+		if t.Deprecation == nil || t.Deprecation.EndOfLife.IsZero() || !t.Deprecation.EndOfLife.After(now) {
+			continue
+		}
+		if next.IsZero() || t.Deprecation.EndOfLife.Before(next) {
+			next = t.Deprecation.EndOfLife
+		}
+	}
+	return next
+}
@@ -0,0 +1,152 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deprecation
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func TestFromObject(t *testing.T) {
+	t.Parallel()
+
+	eol := time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        *Deprecation
+		wantErr     bool
+	}{
+		{name: "not deprecated"},
+		{name: "replacement only", annotations: map[string]string{kcmv1.TemplateReplacementAnnotation: "new"}},
+		{name: "deprecated", annotations: map[string]string{kcmv1.TemplateDeprecatedAnnotation: "true"}, want: &Deprecation{}},
+		{
+			name:        "deprecated with message and replacement",
+			annotations: map[string]string{kcmv1.TemplateDeprecatedAnnotation: "unmaintained", kcmv1.TemplateReplacementAnnotation: "new"},
+			want:        &Deprecation{Message: "unmaintained", Replacement: "new"},
+		},
+		{name: "end of life date", annotations: map[string]string{kcmv1.TemplateEndOfLifeAnnotation: "2026-12-31"}, want: &Deprecation{EndOfLife: eol}},
+		{name: "end of life time", annotations: map[string]string{kcmv1.TemplateEndOfLifeAnnotation: "2026-12-31T00:00:00Z"}, want: &Deprecation{EndOfLife: eol}},
+		{name: "invalid end of life", annotations: map[string]string{kcmv1.TemplateEndOfLifeAnnotation: "31/12/2026"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tpl := &kcmv1.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: "tpl", Namespace: "ns", Annotations: tc.annotations}}
+			got, err := FromObject(tpl)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if Validate(tpl) == nil {
+					t.Error("expected validation error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := Validate(tpl); err != nil {
+				t.Errorf("unexpected validation error: %v", err)
+			}
+			switch {
+			case tc.want == nil && got != nil:
+				t.Errorf("FromObject() = %+v, want nil", got)
+			case tc.want != nil && (got == nil || !got.EndOfLife.Equal(tc.want.EndOfLife) || got.Message != tc.want.Message || got.Replacement != tc.want.Replacement):
+				t.Errorf("FromObject() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeprecationLifecycle(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	future := &Deprecation{EndOfLife: now.AddDate(0, 1, 0), Replacement: "new"}
+	past := &Deprecation{EndOfLife: now.AddDate(0, -1, 0), Message: "unmaintained"}
+
+	for _, tc := range []struct {
+		name      string
+		d         *Deprecation
+		lifecycle string
+		describe  string
+	}{
+		{name: "not deprecated", lifecycle: LifecycleSupported},
+		{name: "deprecated", d: &Deprecation{}, lifecycle: LifecycleDeprecated, describe: "ClusterTemplate tpl is deprecated"},
+		{name: "before end of life", d: future, lifecycle: LifecycleDeprecated, describe: "ClusterTemplate tpl is deprecated and reaches end of life on 2026-11-17, use new instead"},
+		{name: "after end of life", d: past, lifecycle: LifecycleEndOfLife, describe: "ClusterTemplate tpl has reached end of life on 2026-09-17: unmaintained"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.d.Lifecycle(now); got != tc.lifecycle {
+				t.Errorf("Lifecycle() = %q, want %q", got, tc.lifecycle)
+			}
+			if tc.d == nil {
+				return
+			}
+			if got := tc.d.Describe(kcmv1.ClusterTemplateKind, "tpl", now); got != tc.describe {
+				t.Errorf("Describe() = %q, want %q", got, tc.describe)
+			}
+		})
+	}
+}
+
+func TestCondition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	soon, later := now.AddDate(0, 0, 7), now.AddDate(0, 1, 0)
+	templates := []Template{
+		{Kind: kcmv1.ClusterTemplateKind, Name: "ct"},
+		{Kind: kcmv1.ServiceTemplateKind, Name: "st-1", Deprecation: &Deprecation{EndOfLife: later}},
+		{Kind: kcmv1.ServiceTemplateKind, Name: "st-2", Deprecation: &Deprecation{EndOfLife: soon}},
+	}
+
+	if _, ok := Condition(templates[:1], now, 1); ok {
+		t.Error("expected no condition for supported templates")
+	}
+	if got := NextEndOfLife(templates[:1], now); !got.IsZero() {
+		t.Errorf("NextEndOfLife() = %v, want zero", got)
+	}
+
+	cond, ok := Condition(templates, now, 1)
+	if !ok {
+		t.Fatal("expected condition for deprecated templates")
+	}
+	if cond.Status != metav1.ConditionTrue || cond.Reason != kcmv1.TemplateDeprecatedReason || cond.ObservedGeneration != 1 {
+		t.Errorf("unexpected condition %+v", cond)
+	}
+	wantMessage := "ServiceTemplate st-1 is deprecated and reaches end of life on 2026-11-17; ServiceTemplate st-2 is deprecated and reaches end of life on 2026-10-24"
+	if cond.Message != wantMessage {
+		t.Errorf("Condition() message = %q, want %q", cond.Message, wantMessage)
+	}
+	if got := NextEndOfLife(templates, now); !got.Equal(soon) {
+		t.Errorf("NextEndOfLife() = %v, want %v", got, soon)
+	}
+
+	if cond, _ := Condition(templates, soon, 1); cond.Reason != kcmv1.TemplateEndOfLifeReason {
+		t.Errorf("Condition() reason = %q, want %q", cond.Reason, kcmv1.TemplateEndOfLifeReason)
+	}
+	if got := NextEndOfLife(templates, soon); !got.Equal(later) {
+		t.Errorf("NextEndOfLife() = %v, want %v", got, later)
+	}
+}
@@ -15,8 +15,14 @@
 package helm
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
 )
 
 const (
@@ -39,3 +45,44 @@ func DetermineDefaultRepositoryType(templatesRepoURL string) (string, error) {
 		return "", fmt.Errorf("invalid default registry URL scheme: %s must be 'oci://', 'http://', or 'https://'", parsedRegistryURL.Scheme)
 	}
 }
+
+// ChartVerificationFromFlags builds the system-wide chart verification policy
+// from the given flag values. Returns nil if the provider is not set.
+func ChartVerificationFromFlags(provider, secretName, oidcIssuer, oidcSubject string) (*kcmv1.ChartVerification, error) {
+	if provider == "" {
+		if secretName != "" || oidcIssuer != "" || oidcSubject != "" {
+			return nil, errors.New("chart verification provider must be set")
+		}
+		return nil, nil //nolint:nilnil // verification is disabled
+	}
+
+	verification := &kcmv1.ChartVerification{Provider: provider}
+	if secretName != "" {
+		verification.SecretRef = &fluxmeta.LocalObjectReference{Name: secretName}
+	}
+	if oidcIssuer != "" || oidcSubject != "" {
+		if oidcIssuer == "" || oidcSubject == "" {
+			return nil, errors.New("both OIDC issuer and subject must be set for the keyless chart verification")
+		}
+		verification.MatchOIDCIdentity = []sourcev1.OIDCIdentityMatch{{Issuer: oidcIssuer, Subject: oidcSubject}}
+	}
+
+	switch provider {
+	case kcmv1.ChartVerificationProviderCosign:
+		if verification.SecretRef == nil && len(verification.MatchOIDCIdentity) == 0 {
+			return nil, errors.New("either the Secret with public keys or the OIDC identity must be set for the cosign chart verification")
+		}
+	case kcmv1.ChartVerificationProviderHelmProvenance:
+		if verification.SecretRef == nil {
+			return nil, errors.New("the Secret with PGP keyring must be set for the helm-provenance chart verification")
+		}
+		if len(verification.MatchOIDCIdentity) > 0 {
+			return nil, errors.New("OIDC identity is supported only by the cosign chart verification")
+		}
+	default:
+		return nil, fmt.Errorf("invalid chart verification provider %q: must be either %q or %q",
+			provider, kcmv1.ChartVerificationProviderCosign, kcmv1.ChartVerificationProviderHelmProvenance)
+	}
+
+	return verification, nil
+}
@@ -45,3 +45,42 @@ func TestDetermineDefaultRepositoryType(t *testing.T) {
 		})
 	}
 }
+
+func TestChartVerificationFromFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name                              string
+		provider, secret, issuer, subject string
+		expectErr                         bool
+		expectNil                         bool
+	}{
+		{name: "disabled", expectNil: true},
+		{name: "secret without provider", secret: "keys", expectErr: true},
+		{name: "cosign keys", provider: "cosign", secret: "keys"},
+		{name: "cosign keyless", provider: "cosign", issuer: "^https://issuer$", subject: "^subject$"},
+		{name: "cosign partial identity", provider: "cosign", issuer: "^https://issuer$", expectErr: true},
+		{name: "cosign without keys", provider: "cosign", expectErr: true},
+		{name: "helm-provenance", provider: "helm-provenance", secret: "keyring"},
+		{name: "helm-provenance without keyring", provider: "helm-provenance", expectErr: true},
+		{name: "helm-provenance keyless", provider: "helm-provenance", secret: "keyring", issuer: "i", subject: "s", expectErr: true},
+		{name: "unknown provider", provider: "notation", secret: "keys", expectErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ChartVerificationFromFlags(tc.provider, tc.secret, tc.issuer, tc.subject)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectNil != (actual == nil) {
+				t.Errorf("expected nil policy: %t, got %v", tc.expectNil, actual)
+			}
+			if actual != nil && actual.Provider != tc.provider {
+				t.Errorf("expected provider %q, got %q", tc.provider, actual.Provider)
+			}
+		})
+	}
+}
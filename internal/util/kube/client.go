@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
 )
 
 // GetChildClient fetches a child cluster's client.
@@ -130,7 +131,7 @@ func GetRegionalKubeconfigSecretRef(region *kcmv1.Region) (*fluxmeta.SecretKeyRe
 
 // GetRegionalClientByRegionName returns the [sigs.k8s.io/controller-runtime/pkg/client.Client] for the given region name.
 // If region is empty, returns the client of the management cluster.
-func GetRegionalClientByRegionName(ctx context.Context, mgmtClient client.Client, systemNamespace, region string, getSchemeFunc func() (*runtime.Scheme, error)) (client.Client, error) {
+func GetRegionalClientByRegionName(ctx context.Context, mgmtClient client.Client, systemNamespace, region string, scheme schemeutil.RegionalScheme) (client.Client, error) {
 	if region == "" {
 		return mgmtClient, nil
 	}
@@ -140,7 +141,7 @@ func GetRegionalClientByRegionName(ctx context.Context, mgmtClient client.Client
 		return nil, fmt.Errorf("failed to get %s region: %w", region, err)
 	}
 
-	rgnClient, _, err := GetRegionalClient(ctx, mgmtClient, systemNamespace, rgn, getSchemeFunc)
+	rgnClient, _, err := GetRegionalClient(ctx, mgmtClient, systemNamespace, rgn, scheme)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get client for %s region: %w", region, err)
 	}
@@ -149,21 +150,44 @@ func GetRegionalClientByRegionName(ctx context.Context, mgmtClient client.Client
 }
 
 // GetRegionalClient returns the [sigs.k8s.io/controller-runtime/pkg/client.Client] for the given [github.com/k0rdent/kcm/api/v1beta1.Region] object.
+//
+// The client is served from the [DefaultRegionalClientPool].
 func GetRegionalClient(
 	ctx context.Context,
 	mgmtClient client.Client,
 	systemNamespace string,
 	region *kcmv1.Region,
-	getSchemeFunc func() (*runtime.Scheme, error),
+	scheme schemeutil.RegionalScheme,
 ) (client.Client, *rest.Config, error) {
-	scheme, err := getSchemeFunc()
+	return DefaultRegionalClientPool.Get(ctx, mgmtClient, systemNamespace, region, scheme)
+}
+
+// GetRegionalKubeconfig returns the kubeconfig bytes of the cluster of the given [github.com/k0rdent/kcm/api/v1beta1.Region] object.
+func GetRegionalKubeconfig(ctx context.Context, mgmtClient client.Client, systemNamespace string, region *kcmv1.Region) ([]byte, error) {
+	secretRef, secretKey, err := regionalKubeconfigSecretKey(systemNamespace, region)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get regional scheme for the %s region: %w", region.Name, err)
+		return nil, err
+	}
+
+	secret := new(corev1.Secret)
+	if err := mgmtClient.Get(ctx, secretRef, secret); err != nil {
+		return nil, fmt.Errorf("failed to get Secret with kubeconfig: %w", err)
 	}
 
+	kubeconfig, ok := secret.Data[secretKey]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig from Secret %s is empty", secretRef)
+	}
+
+	return kubeconfig, nil
+}
+
+// regionalKubeconfigSecretKey returns the key of the Secret with the kubeconfig
+// of the given [github.com/k0rdent/kcm/api/v1beta1.Region] and the data key of the kubeconfig within it.
+func regionalKubeconfigSecretKey(systemNamespace string, region *kcmv1.Region) (client.ObjectKey, string, error) {
 	kubeConfigSecretRef, err := GetRegionalKubeconfigSecretRef(region)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get kubeconfig secret reference: %w", err)
+		return client.ObjectKey{}, "", fmt.Errorf("failed to get kubeconfig secret reference: %w", err)
 	}
 
 	namespace := systemNamespace
@@ -171,12 +195,5 @@ func GetRegionalClient(
 		namespace = region.Spec.ClusterDeployment.Namespace
 	}
 
-	secretRef := client.ObjectKey{Namespace: namespace, Name: kubeConfigSecretRef.Name}
-	factory, restCfg := DefaultClientFactoryWithRestConfig()
-	rgnlClient, err := GetChildClient(ctx, mgmtClient, secretRef, kubeConfigSecretRef.Key, scheme, factory)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get rest config for the %s region: %w", region.Name, err)
-	}
-
-	return rgnlClient, restCfg, nil
+	return client.ObjectKey{Namespace: namespace, Name: kubeConfigSecretRef.Name}, kubeConfigSecretRef.Key, nil
 }
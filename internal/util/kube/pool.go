@@ -0,0 +1,374 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/internal/metrics"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
+)
+
+const (
+	// DefaultRegionProbeInterval is the interval of the probes of the regional clusters
+	// used when no [WithProbeInterval] option is provided.
+	DefaultRegionProbeInterval = 30 * time.Second
+	// DefaultRegionProbeTimeout is the timeout of a single probe of a regional cluster
+	// used when no [WithProbeTimeout] option is provided.
+	DefaultRegionProbeTimeout = 10 * time.Second
+)
+
+// DefaultRegionalClientPool is the process-wide [RegionalClientPool] used by [GetRegionalClient].
+var DefaultRegionalClientPool = NewRegionalClientPool()
+
+// RegionHealth is the result of the last probe of the API server of a regional cluster.
+type RegionHealth struct {
+	// LastProbeTime is the time of the last probe.
+	LastProbeTime time.Time
+	// Err is the error of the last probe, nil if the probe succeeded.
+	Err error
+	// ServerVersion is the version of the API server reported by the last successful probe.
+	ServerVersion string
+	// Latency is the duration of the last successful probe.
+	Latency time.Duration
+	// Reachable is true if the last probe succeeded.
+	Reachable bool
+}
+
+// RegionalClientPool caches the clients of the regional clusters keyed by the name of
+// the [github.com/K0rdent/kcm/api/v1beta1.Region].
+//
+// The clients of a region share the HTTP client and the REST mapper and are rebuilt once the
+// kubeconfig of the region changes. When added to the manager, the pool periodically probes the
+// API servers of the cached regions, tracks their reachability and latency, exposes them as metrics
+// and emits the Region on the channel returned by [RegionalClientPool.HealthEvents] once
+// its reachability changes.
+type RegionalClientPool struct {
+	entries map[string]*regionalEntry
+	pending map[string]struct{} // regions with the events not yet delivered to the channel
+	eventC  chan event.TypedGenericEvent[*kcmv1.Region]
+	probeC  chan struct{}
+
+	probeInterval time.Duration
+	probeTimeout  time.Duration
+
+	mu sync.Mutex
+}
+
+type regionalEntry struct {
+	restCfg    *rest.Config
+	httpClient *http.Client
+	mapper     meta.RESTMapper
+	clients    map[string]client.Client
+	health     *RegionHealth
+
+	kubeconfigHash [sha256.Size]byte
+}
+
+// RegionalClientPoolOpt configures the [RegionalClientPool].
+type RegionalClientPoolOpt func(*RegionalClientPool)
+
+// WithProbeInterval sets the interval of the probes of the regional clusters.
+func WithProbeInterval(d time.Duration) RegionalClientPoolOpt {
+	return func(p *RegionalClientPool) {
+		if d > 0 {
+			p.probeInterval = d
+		}
+	}
+}
+
+// WithProbeTimeout sets the timeout of a single probe of a regional cluster.
+func WithProbeTimeout(d time.Duration) RegionalClientPoolOpt {
+	return func(p *RegionalClientPool) {
+		if d > 0 {
+			p.probeTimeout = d
+		}
+	}
+}
+
+var _ manager.Runnable = (*RegionalClientPool)(nil)
+
+// NewRegionalClientPool constructs a new [RegionalClientPool].
+func NewRegionalClientPool(opts ...RegionalClientPoolOpt) *RegionalClientPool {
+	p := &RegionalClientPool{
+		entries:       make(map[string]*regionalEntry),
+		pending:       make(map[string]struct{}),
+		eventC:        make(chan event.TypedGenericEvent[*kcmv1.Region], 64),
+		probeC:        make(chan struct{}, 1),
+		probeInterval: DefaultRegionProbeInterval,
+		probeTimeout:  DefaultRegionProbeTimeout,
+	}
+
+	for _, o := range opts {
+		o(p)
+	}
+
+	return p
+}
+
+// HealthEvents returns the channel with the Regions the reachability of which has changed.
+// The channel is expected to have a single consumer and is never closed. While the buffer of
+// the channel is full, the events are coalesced per region and delivered after the next probes.
+func (p *RegionalClientPool) HealthEvents() <-chan event.TypedGenericEvent[*kcmv1.Region] {
+	return p.eventC
+}
+
+// Get returns the cached [sigs.k8s.io/controller-runtime/pkg/client.Client] and a copy of the
+// [k8s.io/client-go/rest.Config] of the given [github.com/K0rdent/kcm/api/v1beta1.Region],
+// building them if the region is not cached yet or its kubeconfig has changed.
+//
+// The clients are cached per name of the given scheme.
+func (p *RegionalClientPool) Get(
+	ctx context.Context,
+	mgmtClient client.Client,
+	systemNamespace string,
+	region *kcmv1.Region,
+	scheme schemeutil.RegionalScheme,
+) (client.Client, *rest.Config, error) {
+	kubeconfig, err := GetRegionalKubeconfig(ctx, mgmtClient, systemNamespace, region)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get rest config for the %s region: %w", region.Name, err)
+	}
+
+	hash := sha256.Sum256(kubeconfig)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[region.Name]
+	if !ok || entry.kubeconfigHash != hash {
+		newEntry, err := newRegionalEntry(kubeconfig, hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get rest config for the %s region: %w", region.Name, err)
+		}
+
+		if ok {
+			ctrl.LoggerFrom(ctx).V(1).Info("Kubeconfig of the region has changed, rebuilding clients", "region", region.Name)
+			entry.httpClient.CloseIdleConnections()
+		}
+
+		entry = newEntry
+		p.entries[region.Name] = entry
+		p.requestProbe()
+	}
+
+	cl, ok := entry.clients[scheme.Name]
+	if !ok {
+		s, err := scheme.Build()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get regional scheme %s for the %s region: %w", scheme.Name, region.Name, err)
+		}
+
+		cl, err = client.New(entry.restCfg, client.Options{Scheme: s, Mapper: entry.mapper, HTTPClient: entry.httpClient})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create client for the %s region: %w", region.Name, err)
+		}
+
+		entry.clients[scheme.Name] = cl
+	}
+
+	return cl, rest.CopyConfig(entry.restCfg), nil
+}
+
+// Health returns the result of the last probe of the given region.
+// The second returned value is false if the region has not been probed yet.
+func (p *RegionalClientPool) Health(region string) (RegionHealth, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[region]
+	if !ok || entry.health == nil {
+		return RegionHealth{}, false
+	}
+
+	return *entry.health, true
+}
+
+// Forget drops the cached clients and the health of the given region.
+func (p *RegionalClientPool) Forget(region string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.entries[region]
+	if !ok {
+		return
+	}
+
+	entry.httpClient.CloseIdleConnections()
+	delete(p.entries, region)
+	delete(p.pending, region)
+	metrics.DeleteMetricRegionConnectivity(region)
+}
+
+// Start implements [sigs.k8s.io/controller-runtime/pkg/manager.Runnable] and
+// probes the cached regions until the given context is done.
+func (p *RegionalClientPool) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		p.probeAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-p.probeC:
+		}
+	}
+}
+
+// requestProbe schedules a probe of the cached regions without waiting for the next interval.
+func (p *RegionalClientPool) requestProbe() {
+	select {
+	case p.probeC <- struct{}{}:
+	default:
+	}
+}
+
+func (p *RegionalClientPool) probeAll(ctx context.Context) {
+	p.mu.Lock()
+	entries := make(map[string]*regionalEntry, len(p.entries))
+	for name, entry := range p.entries {
+		entries[name] = entry
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for name, entry := range entries {
+		wg.Go(func() {
+			p.probe(ctx, name, entry)
+		})
+	}
+	wg.Wait()
+
+	p.flushEvents(ctx)
+}
+
+func (p *RegionalClientPool) probe(ctx context.Context, region string, entry *regionalEntry) {
+	l := ctrl.LoggerFrom(ctx).WithValues("region", region)
+
+	health := probeRegion(ctx, entry, p.probeTimeout)
+	if health.Err != nil {
+		l.V(1).Info("Regional cluster is unreachable", "error", health.Err.Error())
+	}
+
+	p.mu.Lock()
+	if p.entries[region] != entry { // forgotten or rebuilt in the meantime
+		p.mu.Unlock()
+		return
+	}
+	if entry.health == nil || entry.health.Reachable != health.Reachable {
+		p.pending[region] = struct{}{}
+	}
+	entry.health = &health
+	p.mu.Unlock()
+
+	metrics.TrackMetricRegionConnectivity(ctx, region, health.Reachable, health.Latency)
+}
+
+// flushEvents sends the events of the pending regions to the channel. The channel might have no consumer,
+// so the regions that do not fit into the buffer stay pending until the next probes rather than blocking them.
+func (p *RegionalClientPool) flushEvents(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for region := range p.pending {
+		select {
+		case p.eventC <- event.TypedGenericEvent[*kcmv1.Region]{Object: &kcmv1.Region{ObjectMeta: metav1.ObjectMeta{Name: region}}}:
+			delete(p.pending, region)
+		default:
+			ctrl.LoggerFrom(ctx).V(1).Info("Deferring region reachability events, the channel is full", "pending", len(p.pending))
+			return
+		}
+	}
+}
+
+// probeRegion requests the version of the API server of the given regional cluster.
+func probeRegion(ctx context.Context, entry *regionalEntry, timeout time.Duration) RegionHealth {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	health := RegionHealth{LastProbeTime: start}
+
+	dc, err := discovery.NewDiscoveryClientForConfigAndClient(entry.restCfg, entry.httpClient)
+	if err != nil {
+		health.Err = fmt.Errorf("failed to create discovery client: %w", err)
+		return health
+	}
+
+	body, err := dc.RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		health.Err = fmt.Errorf("failed to probe API server: %w", err)
+		return health
+	}
+
+	info := new(version.Info)
+	if err := json.Unmarshal(body, info); err != nil {
+		health.Err = fmt.Errorf("failed to decode version of the API server: %w", err)
+		return health
+	}
+
+	health.Latency = time.Since(start)
+	health.ServerVersion = info.GitVersion
+	health.Reachable = true
+
+	return health
+}
+
+func newRegionalEntry(kubeconfig []byte, hash [sha256.Size]byte) (*regionalEntry, error) {
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rest config from the given kubeconfig data: %w", err)
+	}
+
+	httpClient, err := rest.HTTPClientFor(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	mapper, err := apiutil.NewDynamicRESTMapper(restCfg, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST mapper: %w", err)
+	}
+
+	return &regionalEntry{
+		restCfg:        restCfg,
+		httpClient:     httpClient,
+		mapper:         mapper,
+		clients:        make(map[string]client.Client),
+		kubeconfigHash: hash,
+	}, nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
+)
+
+func TestRegionalClientPool_Get(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		secretName      = "region-kubeconfig"
+	)
+
+	region := &kcmv1.Region{
+		ObjectMeta: metav1.ObjectMeta{Name: "region"},
+		Spec: kcmv1.RegionSpec{
+			KubeConfig: &fluxmeta.SecretKeyReference{Name: secretName, Key: "value"},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: systemNamespace},
+		Data:       map[string][]byte{"value": poolTestKubeconfig("https://first.example.com")},
+	}
+	mgmtClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+
+	p := NewRegionalClientPool()
+
+	first, restCfg, err := p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestScheme)
+	require.NoError(t, err)
+	require.Equal(t, "https://first.example.com", restCfg.Host)
+
+	again, _, err := p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestScheme)
+	require.NoError(t, err)
+	require.Same(t, first, again, "client must be reused for the same kubeconfig and scheme")
+
+	other, _, err := p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestOtherScheme)
+	require.NoError(t, err)
+	require.NotSame(t, first, other, "clients must be cached per scheme")
+
+	restCfg.Host = "https://mutated.example.com"
+	_, restCfg, err = p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestScheme)
+	require.NoError(t, err)
+	require.Equal(t, "https://first.example.com", restCfg.Host, "cached rest config must not be shared with callers")
+
+	secret.Data["value"] = poolTestKubeconfig("https://second.example.com")
+	require.NoError(t, mgmtClient.Update(t.Context(), secret))
+
+	rebuilt, restCfg, err := p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestScheme)
+	require.NoError(t, err)
+	require.NotSame(t, first, rebuilt, "client must be rebuilt once the kubeconfig changes")
+	require.Equal(t, "https://second.example.com", restCfg.Host)
+
+	p.Forget(region.Name)
+	afterForget, _, err := p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestScheme)
+	require.NoError(t, err)
+	require.NotSame(t, rebuilt, afterForget, "client must be rebuilt once the region is forgotten")
+
+	_, _, err = p.Get(t.Context(), mgmtClient, "another-namespace", region, poolTestScheme)
+	require.ErrorContains(t, err, "failed to get rest config for the region region")
+}
+
+func TestRegionalClientPool_Probe(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		secretName      = "region-kubeconfig"
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(version.Info{GitVersion: "v1.34.0"})
+	}))
+	defer srv.Close()
+
+	region := &kcmv1.Region{
+		ObjectMeta: metav1.ObjectMeta{Name: "region"},
+		Spec: kcmv1.RegionSpec{
+			KubeConfig: &fluxmeta.SecretKeyReference{Name: secretName, Key: "value"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: systemNamespace},
+		Data:       map[string][]byte{"value": poolTestKubeconfig(srv.URL)},
+	}
+	mgmtClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+
+	p := NewRegionalClientPool(WithProbeTimeout(time.Second))
+
+	_, ok := p.Health(region.Name)
+	require.False(t, ok, "unknown region must have no health")
+
+	_, _, err := p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestScheme)
+	require.NoError(t, err)
+
+	_, ok = p.Health(region.Name)
+	require.False(t, ok, "region must have no health before the first probe")
+
+	p.probeAll(t.Context())
+
+	health, ok := p.Health(region.Name)
+	require.True(t, ok)
+	require.True(t, health.Reachable)
+	require.NoError(t, health.Err)
+	require.Equal(t, "v1.34.0", health.ServerVersion)
+	require.Positive(t, health.Latency)
+	requireHealthEvent(t, p, region.Name)
+
+	p.probeAll(t.Context())
+	require.Empty(t, p.HealthEvents(), "unchanged reachability must not be emitted")
+
+	srv.Close()
+	p.probeAll(t.Context())
+
+	health, ok = p.Health(region.Name)
+	require.True(t, ok)
+	require.False(t, health.Reachable)
+	require.Error(t, health.Err)
+	requireHealthEvent(t, p, region.Name)
+
+	p.Forget(region.Name)
+	_, ok = p.Health(region.Name)
+	require.False(t, ok, "forgotten region must have no health")
+}
+
+func TestRegionalClientPool_ProbeWithoutConsumer(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		secretName      = "region-kubeconfig"
+	)
+
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // the region is unreachable
+
+	region := &kcmv1.Region{
+		ObjectMeta: metav1.ObjectMeta{Name: "region"},
+		Spec: kcmv1.RegionSpec{
+			KubeConfig: &fluxmeta.SecretKeyReference{Name: secretName, Key: "value"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: systemNamespace},
+		Data:       map[string][]byte{"value": poolTestKubeconfig(srv.URL)},
+	}
+	mgmtClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(secret).Build()
+
+	p := NewRegionalClientPool(WithProbeTimeout(time.Second))
+	_, _, err := p.Get(t.Context(), mgmtClient, systemNamespace, region, poolTestScheme)
+	require.NoError(t, err)
+
+	other := &kcmv1.Region{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	for range cap(p.eventC) {
+		p.eventC <- event.TypedGenericEvent[*kcmv1.Region]{Object: other}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.probeAll(t.Context())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "probe must not block on the full events channel")
+	}
+
+	health, ok := p.Health(region.Name)
+	require.True(t, ok)
+	require.False(t, health.Reachable)
+
+	for range cap(p.eventC) {
+		requireHealthEvent(t, p, other.Name)
+	}
+
+	p.probeAll(t.Context())
+	requireHealthEvent(t, p, region.Name)
+	require.Empty(t, p.HealthEvents(), "delivered event must not be emitted again")
+}
+
+func requireHealthEvent(t *testing.T, p *RegionalClientPool, region string) {
+	t.Helper()
+
+	select {
+	case ev := <-p.HealthEvents():
+		require.Equal(t, region, ev.Object.Name)
+	default:
+		require.Fail(t, "expected health event for the region", region)
+	}
+}
+
+func poolTestKubeconfig(server string) []byte {
+	return fmt.Appendf(nil, `apiVersion: v1
+kind: Config
+clusters:
+- name: region
+  cluster:
+    server: %s
+users:
+- name: region
+  user:
+    token: "fake"
+contexts:
+- name: region
+  context:
+    cluster: region
+    user: region
+current-context: region
+`, server)
+}
+
+// the schemes are built by the closures of the same function literal,
+// the clients must be still cached per scheme name.
+var poolTestScheme, poolTestOtherScheme = newPoolTestScheme("test", clientgoscheme.AddToScheme), newPoolTestScheme("other", kcmv1.AddToScheme)
+
+func newPoolTestScheme(name string, addToScheme func(*runtime.Scheme) error) schemeutil.RegionalScheme {
+	return schemeutil.RegionalScheme{
+		Name: name,
+		Build: func() (*runtime.Scheme, error) {
+			scheme := runtime.NewScheme()
+			if err := addToScheme(scheme); err != nil {
+				return nil, err
+			}
+			return scheme, nil
+		},
+	}
+}
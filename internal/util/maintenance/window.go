@@ -0,0 +1,123 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance provides helpers to evaluate [kcmv1.MaintenanceWindow] objects
+// restricting when the disruptive changes are applied.
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// ErrInvalidNamespaceWindow is returned if the maintenance window annotations of a namespace are invalid.
+// Unlike the windows defined in the specs, the annotations are not validated on admission.
+var ErrInvalidNamespaceWindow = errors.New("invalid maintenance window annotations")
+
+// State is the state of a maintenance window at a given time.
+type State struct {
+	// NextOpening is the time the window opens next, zero if the window is open.
+	NextOpening time.Time
+	// Open is whether the window is open.
+	Open bool
+}
+
+// Evaluate returns the state of the given maintenance window at the given time.
+// Nil window is considered as always open.
+func Evaluate(window *kcmv1.MaintenanceWindow, now time.Time) (State, error) {
+	if window == nil {
+		return State{Open: true}, nil
+	}
+
+	schedule, err := cron.ParseStandard(window.Schedule)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to parse maintenance window schedule %q: %w", window.Schedule, err)
+	}
+
+	// the first opening after the duration ago, the window is open if it is not after now
+	if start := schedule.Next(now.Add(-window.Duration.Duration)); !start.After(now) {
+		return State{Open: true}, nil
+	}
+
+	return State{NextOpening: schedule.Next(now)}, nil
+}
+
+// Validate checks that the given maintenance window, if any, has a valid schedule and a positive duration.
+func Validate(window *kcmv1.MaintenanceWindow) error {
+	if window == nil {
+		return nil
+	}
+	if _, err := cron.ParseStandard(window.Schedule); err != nil {
+		return fmt.Errorf("invalid maintenance window schedule %q: %w", window.Schedule, err)
+	}
+	if window.Duration.Duration <= 0 {
+		return fmt.Errorf("maintenance window duration must be positive, got %s", window.Duration.Duration)
+	}
+	return nil
+}
+
+// FromNamespace returns the maintenance window defined by the annotations
+// of the given namespace, nil if the namespace does not define any.
+// Returns an error wrapping [ErrInvalidNamespaceWindow] if the annotations are invalid,
+// including the case of the missing duration.
+func FromNamespace(namespace *corev1.Namespace) (*kcmv1.MaintenanceWindow, error) {
+	schedule := namespace.Annotations[kcmv1.MaintenanceWindowScheduleAnnotation]
+	if schedule == "" {
+		return nil, nil //nolint:nilnil // no window is defined
+	}
+
+	window := &kcmv1.MaintenanceWindow{Schedule: schedule}
+	if v := namespace.Annotations[kcmv1.MaintenanceWindowDurationAnnotation]; v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w of namespace %s: failed to parse %s annotation: %w", ErrInvalidNamespaceWindow, namespace.Name, kcmv1.MaintenanceWindowDurationAnnotation, err)
+		}
+		window.Duration = metav1.Duration{Duration: d}
+	}
+
+	if err := Validate(window); err != nil {
+		return nil, fmt.Errorf("%w of namespace %s: %w", ErrInvalidNamespaceWindow, namespace.Name, err)
+	}
+
+	return window, nil
+}
+
+// ForClusterDeployment returns the maintenance window applicable to the given [kcmv1.ClusterDeployment]:
+// the one defined in its spec, otherwise the one defined by the annotations of its namespace, if any.
+func ForClusterDeployment(ctx context.Context, c client.Client, cd *kcmv1.ClusterDeployment) (*kcmv1.MaintenanceWindow, error) {
+	if cd.Spec.MaintenanceWindow != nil {
+		return cd.Spec.MaintenanceWindow, nil
+	}
+
+	namespace := new(corev1.Namespace)
+	if err := c.Get(ctx, client.ObjectKey{Name: cd.Namespace}, namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", cd.Namespace, err)
+	}
+
+	return FromNamespace(namespace)
+}
+
+// PendingMessage returns the message describing the changes pending until the window opens.
+func PendingMessage(what string, state State) string {
+	return fmt.Sprintf("%s pending until maintenance window opens at %s", what, state.NextOpening.UTC().Format(time.RFC3339))
+}
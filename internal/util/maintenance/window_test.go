@@ -0,0 +1,106 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	// every Saturday at 02:00 for 4 hours
+	window := &kcmv1.MaintenanceWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+	saturday := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.Local)
+	nextSaturday := saturday.AddDate(0, 0, 7).Add(2 * time.Hour)
+
+	for _, tc := range []struct {
+		name   string
+		window *kcmv1.MaintenanceWindow
+		now    time.Time
+		want   State
+	}{
+		{name: "no window", now: saturday, want: State{Open: true}},
+		{name: "before opening", window: window, now: saturday.Add(time.Hour), want: State{NextOpening: saturday.Add(2 * time.Hour)}},
+		{name: "at opening", window: window, now: saturday.Add(2 * time.Hour), want: State{Open: true}},
+		{name: "open", window: window, now: saturday.Add(5 * time.Hour), want: State{Open: true}},
+		{name: "at closing", window: window, now: saturday.Add(6 * time.Hour), want: State{NextOpening: nextSaturday}},
+		{name: "closed", window: window, now: saturday.AddDate(0, 0, 3), want: State{NextOpening: nextSaturday}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Evaluate(tc.window, tc.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Open != tc.want.Open || !got.NextOpening.Equal(tc.want.NextOpening) {
+				t.Errorf("Evaluate() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := Evaluate(&kcmv1.MaintenanceWindow{Schedule: "invalid"}, saturday); err == nil {
+		t.Error("expected error on invalid schedule")
+	}
+}
+
+func TestFromNamespace(t *testing.T) {
+	t.Parallel()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	window, err := FromNamespace(namespace)
+	if err != nil || window != nil {
+		t.Fatalf("FromNamespace() = %v, %v, want nil, nil", window, err)
+	}
+
+	namespace.Annotations = map[string]string{
+		kcmv1.MaintenanceWindowScheduleAnnotation: "0 2 * * 6",
+		kcmv1.MaintenanceWindowDurationAnnotation: "4h",
+	}
+	window, err = FromNamespace(namespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if window.Schedule != "0 2 * * 6" || window.Duration.Duration != 4*time.Hour {
+		t.Errorf("FromNamespace() = %+v, want schedule 0 2 * * 6 and duration 4h", window)
+	}
+
+	for name, annotations := range map[string]map[string]string{
+		"invalid duration": {
+			kcmv1.MaintenanceWindowScheduleAnnotation: "0 2 * * 6",
+			kcmv1.MaintenanceWindowDurationAnnotation: "four hours",
+		},
+		"missing duration": {
+			kcmv1.MaintenanceWindowScheduleAnnotation: "0 2 * * 6",
+		},
+		"invalid schedule": {
+			kcmv1.MaintenanceWindowScheduleAnnotation: "every saturday",
+			kcmv1.MaintenanceWindowDurationAnnotation: "4h",
+		},
+	} {
+		namespace.Annotations = annotations
+		if _, err := FromNamespace(namespace); !errors.Is(err, ErrInvalidNamespaceWindow) {
+			t.Errorf("%s: FromNamespace() error = %v, want %v", name, err, ErrInvalidNamespaceWindow)
+		}
+	}
+}
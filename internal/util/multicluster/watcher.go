@@ -0,0 +1,460 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multicluster provides a controller-runtime [sigs.k8s.io/controller-runtime/pkg/manager.Runnable]
+// that keeps informers on the management cluster and on the clusters of every
+// [github.com/K0rdent/kcm/api/v1beta1.Region], maps the observed changes to the
+// objects of interest and emits them as [sigs.k8s.io/controller-runtime/pkg/event.TypedGenericEvent]
+// on a typed channel.
+package multicluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	schemeutil "github.com/K0rdent/kcm/internal/util/scheme"
+)
+
+const (
+	// DefaultResyncInterval is the interval of the synchronization of the regional
+	// informers with the existing Regions used when no [WithResyncInterval] option is provided.
+	DefaultResyncInterval = 30 * time.Second
+	// DefaultBufferSize is the capacity of the emitted event channel used
+	// when no [WithBufferSize] option is provided.
+	DefaultBufferSize = 64
+	// DefaultName is the logger name used when no [WithName] option is provided.
+	DefaultName = "multicluster-watcher"
+)
+
+// MapFunc maps an object observed on the cluster of the given region to the
+// objects to be emitted. Empty region stands for the management cluster.
+//
+// The returned slice must not contain nil entries.
+type MapFunc[T client.Object] func(ctx context.Context, region string, obj client.Object) []T
+
+// InformersFunc constructs the informers for a regional cluster given its
+// rest config and scheme. The informers are started by the [Watcher].
+type InformersFunc func(restCfg *rest.Config, scheme *runtime.Scheme) (cache.Informers, error)
+
+// Watcher is a controller-runtime [sigs.k8s.io/controller-runtime/pkg/manager.Runnable]
+// that watches the given object kinds on the management cluster and on the clusters
+// of every [github.com/K0rdent/kcm/api/v1beta1.Region], and emits the objects returned
+// by the [MapFunc] for each observed change.
+//
+// The management cluster is watched via the given informers, normally the cache
+// of the manager. The regional informers are created once a Region becomes Ready,
+// recreated once its kubeconfig changes and stopped once the Region is gone or
+// no longer Ready. The informers are synchronized on every change of a Region
+// and every resync interval. Kinds which are not served by a cluster yet are
+// retried on the next synchronization.
+//
+// The objects listed by the informers on their start are not emitted, the
+// consumers are expected to handle the initial state on their own.
+type Watcher[T client.Object] struct {
+	mgmtClient    client.Client
+	mgmtInformers cache.Informers
+	newInformers  InformersFunc
+	getScheme     func() (*runtime.Scheme, error)
+	mapFunc       MapFunc[T]
+
+	eventC  chan event.TypedGenericEvent[T]
+	syncC   chan struct{}
+	objects []client.Object
+	regions map[string]*regionInformers
+
+	systemNamespace string
+	name            string
+	resyncInterval  time.Duration
+
+	mgmtRegistrations []registration
+	started           atomic.Bool
+}
+
+// regionInformers is a set of running informers of a single regional cluster.
+type regionInformers struct {
+	cancel         context.CancelFunc
+	kubeconfigHash [sha256.Size]byte
+}
+
+type registration struct {
+	informer cache.Informer
+	handle   toolscache.ResourceEventHandlerRegistration
+}
+
+// config holds construction-time options for a [Watcher]; kept private so
+// [Option] stays non-generic and callers don't have to spell out T.
+type config struct {
+	newInformers   InformersFunc
+	getScheme      func() (*runtime.Scheme, error)
+	name           string
+	resyncInterval time.Duration
+	bufferSize     int
+}
+
+// Option configures a [Watcher] at construction time.
+type Option func(*config)
+
+// WithResyncInterval sets the interval of the synchronization of the regional
+// informers with the existing Regions. The option is a no-op when d is
+// non-positive, leaving [DefaultResyncInterval] in effect.
+func WithResyncInterval(d time.Duration) Option {
+	return func(c *config) {
+		if d > 0 {
+			c.resyncInterval = d
+		}
+	}
+}
+
+// WithBufferSize sets the capacity of the emitted event channel. The option
+// is a no-op when n is non-positive, leaving [DefaultBufferSize] in effect.
+func WithBufferSize(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.bufferSize = n
+		}
+	}
+}
+
+// WithName sets the logger name attached to the watcher's context.
+func WithName(name string) Option {
+	return func(c *config) {
+		if name != "" {
+			c.name = name
+		}
+	}
+}
+
+// WithRegionalScheme sets the function returning the scheme of the regional
+// clusters, it must contain the watched kinds. Defaults to [schemeutil.GetRegionalScheme].
+func WithRegionalScheme(getScheme func() (*runtime.Scheme, error)) Option {
+	return func(c *config) {
+		if getScheme != nil {
+			c.getScheme = getScheme
+		}
+	}
+}
+
+// WithInformersFunc sets the function constructing the regional informers.
+// Defaults to a new [sigs.k8s.io/controller-runtime/pkg/cache.Cache].
+func WithInformersFunc(newInformers InformersFunc) Option {
+	return func(c *config) {
+		if newInformers != nil {
+			c.newInformers = newInformers
+		}
+	}
+}
+
+// NewWatcher returns a [Watcher] of the given object kinds emitting the objects
+// returned by the given map function. The returned watcher must be registered
+// with a manager via [sigs.k8s.io/controller-runtime/pkg/manager.Manager.Add]
+// before its channel is wired into a controller.
+//
+// NewWatcher panics when mapFunc is nil or no objects are given; both are programmer errors.
+func NewWatcher[T client.Object](
+	mgmtClient client.Client,
+	mgmtInformers cache.Informers,
+	systemNamespace string,
+	mapFunc MapFunc[T],
+	objects []client.Object,
+	opts ...Option,
+) *Watcher[T] {
+	if mapFunc == nil || len(objects) == 0 {
+		panic("multicluster: NewWatcher called with nil MapFunc or no objects")
+	}
+
+	cfg := config{
+		newInformers:   defaultInformersFunc,
+		getScheme:      schemeutil.GetRegionalScheme,
+		name:           DefaultName,
+		resyncInterval: DefaultResyncInterval,
+		bufferSize:     DefaultBufferSize,
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return &Watcher[T]{
+		mgmtClient:      mgmtClient,
+		mgmtInformers:   mgmtInformers,
+		newInformers:    cfg.newInformers,
+		getScheme:       cfg.getScheme,
+		mapFunc:         mapFunc,
+		eventC:          make(chan event.TypedGenericEvent[T], cfg.bufferSize),
+		syncC:           make(chan struct{}, 1),
+		objects:         objects,
+		regions:         make(map[string]*regionInformers),
+		systemNamespace: systemNamespace,
+		name:            cfg.name,
+		resyncInterval:  cfg.resyncInterval,
+	}
+}
+
+func defaultInformersFunc(restCfg *rest.Config, scheme *runtime.Scheme) (cache.Informers, error) {
+	return cache.New(restCfg, cache.Options{Scheme: scheme})
+}
+
+var _ manager.Runnable = (*Watcher[client.Object])(nil)
+
+// GetEventChannel returns the channel of emitted
+// [sigs.k8s.io/controller-runtime/pkg/event.TypedGenericEvent].
+//
+// Unlike the channel of the poller, the returned channel is never closed since
+// the handlers of the informers of the management cluster may outlive the watcher.
+func (w *Watcher[T]) GetEventChannel() <-chan event.TypedGenericEvent[T] {
+	return w.eventC
+}
+
+// Start implements [sigs.k8s.io/controller-runtime/pkg/manager.Runnable].
+//
+// It runs until ctx is canceled, synchronizing the informers with the existing
+// Regions on every change of a Region and every resync interval. The regional
+// informers are stopped and the handlers of the management informers are removed
+// once it returns.
+//
+// Start refuses to run a second time and returns an error.
+func (w *Watcher[T]) Start(ctx context.Context) error {
+	if !w.started.CompareAndSwap(false, true) {
+		return errors.New("multicluster: watcher cannot be started twice")
+	}
+
+	l := ctrl.LoggerFrom(ctx).WithName(w.name)
+	ctx = ctrl.LoggerInto(ctx, l)
+
+	l.Info("Starting multicluster watcher", "resync_interval", w.resyncInterval, "event_chan_cap", cap(w.eventC))
+
+	defer func() { w.removeHandlers(w.mgmtRegistrations) }()
+
+	regionInformer, err := w.mgmtInformers.GetInformer(ctx, &kcmv1.Region{}, cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("failed to get Region informer: %w", err)
+	}
+	regionHandle, err := regionInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { w.requestSync() },
+		UpdateFunc: func(any, any) { w.requestSync() },
+		DeleteFunc: func(any) { w.requestSync() },
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add Region event handler: %w", err)
+	}
+	defer func() { _ = regionInformer.RemoveEventHandler(regionHandle) }()
+
+	ticker := time.NewTicker(w.resyncInterval)
+	defer ticker.Stop()
+
+	for {
+		w.sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-w.syncC:
+		}
+	}
+}
+
+// requestSync schedules the synchronization of the informers unless one is already scheduled.
+func (w *Watcher[T]) requestSync() {
+	select {
+	case w.syncC <- struct{}{}:
+	default:
+	}
+}
+
+// sync registers the handlers on the management informers unless registered yet,
+// and starts, restarts or stops the regional informers according to the existing Regions.
+func (w *Watcher[T]) sync(ctx context.Context) {
+	l := ctrl.LoggerFrom(ctx)
+
+	if w.mgmtRegistrations == nil {
+		registrations, err := w.addHandlers(ctx, w.mgmtInformers, "")
+		if err != nil {
+			l.Error(err, "failed to watch the management cluster, retrying on the next resync")
+		}
+		w.mgmtRegistrations = registrations
+	}
+
+	regions := new(kcmv1.RegionList)
+	if err := w.mgmtClient.List(ctx, regions); err != nil {
+		l.Error(err, "failed to list Regions")
+		return
+	}
+
+	existing := make(map[string]struct{}, len(regions.Items))
+	for i := range regions.Items {
+		region := &regions.Items[i]
+		if !region.DeletionTimestamp.IsZero() || !apimeta.IsStatusConditionTrue(region.Status.Conditions, kcmv1.ReadyCondition) {
+			continue
+		}
+		existing[region.Name] = struct{}{}
+
+		if err := w.syncRegion(ctx, region); err != nil {
+			l.Error(err, "failed to watch the regional cluster, retrying on the next resync", "region", region.Name)
+		}
+	}
+
+	for name, rgn := range w.regions {
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		l.Info("Stopping regional informers", "region", name)
+		rgn.cancel()
+		delete(w.regions, name)
+	}
+}
+
+// syncRegion starts the informers of the given Region, or restarts them if its kubeconfig has changed.
+func (w *Watcher[T]) syncRegion(ctx context.Context, region *kcmv1.Region) error {
+	kubeconfig, err := kubeutil.GetRegionalKubeconfig(ctx, w.mgmtClient, w.systemNamespace, region)
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig of the %s region: %w", region.Name, err)
+	}
+
+	hash := sha256.Sum256(kubeconfig)
+	current, ok := w.regions[region.Name]
+	if ok && current.kubeconfigHash == hash {
+		return nil
+	}
+
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to build rest config of the %s region: %w", region.Name, err)
+	}
+
+	scheme, err := w.getScheme()
+	if err != nil {
+		return fmt.Errorf("failed to get regional scheme for the %s region: %w", region.Name, err)
+	}
+
+	informers, err := w.newInformers(restCfg, scheme)
+	if err != nil {
+		return fmt.Errorf("failed to create informers for the %s region: %w", region.Name, err)
+	}
+
+	rgnCtx, cancel := context.WithCancel(ctx)
+	if _, err := w.addHandlers(rgnCtx, informers, region.Name); err != nil {
+		cancel()
+		return err
+	}
+
+	if ok {
+		ctrl.LoggerFrom(ctx).Info("Restarting regional informers with the updated kubeconfig", "region", region.Name)
+		current.cancel()
+	} else {
+		ctrl.LoggerFrom(ctx).Info("Starting regional informers", "region", region.Name)
+	}
+
+	w.regions[region.Name] = &regionInformers{cancel: cancel, kubeconfigHash: hash}
+	go func() {
+		if err := informers.Start(rgnCtx); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "regional informers stopped", "region", region.Name)
+		}
+	}()
+
+	return nil
+}
+
+// addHandlers adds the event handlers to the informers of all of the watched kinds.
+// Either all of the handlers are added or none.
+func (w *Watcher[T]) addHandlers(ctx context.Context, informers cache.Informers, region string) ([]registration, error) {
+	registrations := make([]registration, 0, len(w.objects))
+	for _, obj := range w.objects {
+		informer, err := informers.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+		if err != nil {
+			w.removeHandlers(registrations)
+			return nil, fmt.Errorf("failed to get informer for %T: %w", obj, err)
+		}
+
+		handle, err := informer.AddEventHandler(&eventHandler[T]{ctx: ctx, watcher: w, region: region})
+		if err != nil {
+			w.removeHandlers(registrations)
+			return nil, fmt.Errorf("failed to add event handler for %T: %w", obj, err)
+		}
+		registrations = append(registrations, registration{informer: informer, handle: handle})
+	}
+
+	return registrations, nil
+}
+
+func (*Watcher[T]) removeHandlers(registrations []registration) {
+	for _, r := range registrations {
+		_ = r.informer.RemoveEventHandler(r.handle)
+	}
+}
+
+// emit sends the objects the given object maps to, blocking until they are sent or ctx is canceled.
+func (w *Watcher[T]) emit(ctx context.Context, region string, obj any) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	o, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+
+	for _, mapped := range w.mapFunc(ctx, region, o) {
+		select {
+		case <-ctx.Done():
+			return
+		case w.eventC <- event.TypedGenericEvent[T]{Object: mapped}:
+		}
+	}
+}
+
+// eventHandler implements [toolscache.ResourceEventHandler] emitting the
+// mapped objects of the changed objects of a single cluster.
+type eventHandler[T client.Object] struct {
+	ctx     context.Context //nolint:containedctx // the handler is bound to the lifetime of the informers
+	watcher *Watcher[T]
+	region  string
+}
+
+func (h *eventHandler[T]) OnAdd(obj any, isInInitialList bool) {
+	if isInInitialList {
+		return
+	}
+	h.watcher.emit(h.ctx, h.region, obj)
+}
+
+func (h *eventHandler[T]) OnUpdate(oldObj, newObj any) {
+	oldO, oldOk := oldObj.(client.Object)
+	newO, newOk := newObj.(client.Object)
+	if oldOk && newOk && oldO.GetResourceVersion() == newO.GetResourceVersion() {
+		// periodic resync, nothing has changed
+		return
+	}
+	h.watcher.emit(h.ctx, h.region, newObj)
+}
+
+func (h *eventHandler[T]) OnDelete(obj any) {
+	h.watcher.emit(h.ctx, h.region, obj)
+}
@@ -0,0 +1,247 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+const (
+	testSystemNamespace = "kcm-system"
+	testRegion          = "region1"
+)
+
+func newKubeconfig(server string) []byte {
+	return fmt.Appendf(nil, `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`, server)
+}
+
+func newRegionObjects(kubeconfig []byte) []client.Object {
+	return []client.Object{
+		&kcmv1.Region{
+			ObjectMeta: metav1.ObjectMeta{Name: testRegion},
+			Spec:       kcmv1.RegionSpec{KubeConfig: &fluxmeta.SecretKeyReference{Name: "region1-kubeconfig", Key: "value"}},
+			Status: kcmv1.RegionStatus{Conditions: []metav1.Condition{{
+				Type: kcmv1.ReadyCondition, Status: metav1.ConditionTrue, Reason: kcmv1.SucceededReason,
+			}}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testSystemNamespace, Name: "region1-kubeconfig"},
+			Data:       map[string][]byte{"value": kubeconfig},
+		},
+	}
+}
+
+// mapToSelf maps the given ConfigMap to itself with the region recorded in the annotations.
+func mapToSelf(_ context.Context, region string, obj client.Object) []*corev1.ConfigMap {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return nil
+	}
+	cm = cm.DeepCopy()
+	cm.Annotations = map[string]string{"region": region}
+	return []*corev1.ConfigMap{cm}
+}
+
+func fakeInformer(t *testing.T, informers *informertest.FakeInformers) *controllertest.FakeInformer {
+	t.Helper()
+
+	informer, err := informers.FakeInformerFor(t.Context(), &corev1.ConfigMap{})
+	if err != nil {
+		t.Fatalf("failed to get fake informer: %v", err)
+	}
+	return informer
+}
+
+func (w *Watcher[T]) receive(t *testing.T) T {
+	t.Helper()
+
+	select {
+	case e := <-w.eventC:
+		return e.Object
+	default:
+		var zero T
+		t.Fatal("expected an event, got none")
+		return zero
+	}
+}
+
+func (w *Watcher[T]) expectNoEvents(t *testing.T) {
+	t.Helper()
+
+	if n := len(w.eventC); n > 0 {
+		t.Fatalf("expected no events, got %d", n)
+	}
+}
+
+func TestNewWatcher_InvalidArgumentsPanic(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic on no objects, got none")
+		}
+	}()
+	_ = NewWatcher(nil, nil, testSystemNamespace, mapToSelf, nil)
+}
+
+func TestWatcher_Sync(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(newRegionObjects(newKubeconfig("https://region1:6443"))...).Build()
+	mgmtInformers := &informertest.FakeInformers{}
+
+	var (
+		rgnInformers []*informertest.FakeInformers
+		rgnHosts     []string
+	)
+	newInformers := func(restCfg *rest.Config, _ *runtime.Scheme) (cache.Informers, error) {
+		informers := &informertest.FakeInformers{}
+		rgnInformers = append(rgnInformers, informers)
+		rgnHosts = append(rgnHosts, restCfg.Host)
+		return informers, nil
+	}
+
+	w := NewWatcher(cl, mgmtInformers, testSystemNamespace, mapToSelf, []client.Object{&corev1.ConfigMap{}}, WithInformersFunc(newInformers))
+	w.sync(ctx)
+
+	if len(rgnInformers) != 1 || rgnHosts[0] != "https://region1:6443" {
+		t.Fatalf("expected regional informers of https://region1:6443 to be created, got %v", rgnHosts)
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm", ResourceVersion: "1"}}
+
+	fakeInformer(t, mgmtInformers).Add(cm)
+	if got := w.receive(t); got.Annotations["region"] != "" || got.Name != cm.Name {
+		t.Fatalf("expected the ConfigMap from the management cluster, got %v", got)
+	}
+
+	// periodic resync does not emit anything
+	fakeInformer(t, rgnInformers[0]).Update(cm, cm)
+	w.expectNoEvents(t)
+
+	updated := cm.DeepCopy()
+	updated.ResourceVersion = "2"
+	fakeInformer(t, rgnInformers[0]).Update(cm, updated)
+	if got := w.receive(t); got.Annotations["region"] != testRegion {
+		t.Fatalf("expected the ConfigMap from the %s region, got %v", testRegion, got)
+	}
+
+	fakeInformer(t, rgnInformers[0]).Delete(updated)
+	if got := w.receive(t); got.Annotations["region"] != testRegion {
+		t.Fatalf("expected the deleted ConfigMap from the %s region, got %v", testRegion, got)
+	}
+
+	// nothing has changed, the informers are kept
+	w.sync(ctx)
+	if len(rgnInformers) != 1 {
+		t.Fatalf("expected the regional informers to be kept, got %d created", len(rgnInformers))
+	}
+
+	// the kubeconfig has changed, the informers are recreated
+	secret := newRegionObjects(newKubeconfig("https://region1-new:6443"))[1]
+	if err := cl.Update(ctx, secret); err != nil {
+		t.Fatalf("failed to update Secret: %v", err)
+	}
+	stale := w.regions[testRegion]
+	w.sync(ctx)
+	if len(rgnInformers) != 2 || rgnHosts[1] != "https://region1-new:6443" {
+		t.Fatalf("expected regional informers of https://region1-new:6443 to be created, got %v", rgnHosts)
+	}
+	if w.regions[testRegion] == stale {
+		t.Fatal("expected the stale regional informers to be replaced")
+	}
+
+	// the region is not ready, the informers are stopped
+	region := new(kcmv1.Region)
+	if err := cl.Get(ctx, client.ObjectKey{Name: testRegion}, region); err != nil {
+		t.Fatalf("failed to get Region: %v", err)
+	}
+	region.Status.Conditions[0].Status = metav1.ConditionFalse
+	if err := cl.Update(ctx, region); err != nil {
+		t.Fatalf("failed to update Region: %v", err)
+	}
+	w.sync(ctx)
+	if len(w.regions) != 0 {
+		t.Fatalf("expected the regional informers to be stopped, got %d regions", len(w.regions))
+	}
+
+	// the region is ready again, the informers are started
+	region.Status.Conditions[0].Status = metav1.ConditionTrue
+	if err := cl.Update(ctx, region); err != nil {
+		t.Fatalf("failed to update Region: %v", err)
+	}
+	w.sync(ctx)
+	if len(rgnInformers) != 3 || len(w.regions) != 1 {
+		t.Fatalf("expected the regional informers to be started, got %d created", len(rgnInformers))
+	}
+
+	// the region is gone, the informers are stopped
+	if err := cl.Delete(ctx, &kcmv1.Region{ObjectMeta: metav1.ObjectMeta{Name: testRegion}}); err != nil {
+		t.Fatalf("failed to delete Region: %v", err)
+	}
+	w.sync(ctx)
+	if len(w.regions) != 0 {
+		t.Fatalf("expected the regional informers to be stopped, got %d regions", len(w.regions))
+	}
+}
+
+func TestEventHandler_SkipsInitialList(t *testing.T) {
+	t.Parallel()
+
+	w := NewWatcher(nil, nil, testSystemNamespace, mapToSelf, []client.Object{&corev1.ConfigMap{}})
+	h := &eventHandler[*corev1.ConfigMap]{ctx: t.Context(), watcher: w}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}}
+	h.OnAdd(cm, true)
+	w.expectNoEvents(t)
+
+	h.OnAdd(cm, false)
+	w.receive(t)
+
+	h.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "default/cm", Obj: cm})
+	if got := w.receive(t); got.Name != cm.Name {
+		t.Fatalf("expected the ConfigMap from the tombstone, got %v", got)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package placement provides helpers to evaluate [kcmv1.ClusterPlacement] objects
+// restricting the [kcmv1.Region] objects a [kcmv1.ClusterDeployment] is placed into.
+package placement
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	validationutil "github.com/K0rdent/kcm/internal/util/validation"
+)
+
+// ErrNoMatchingRegion is returned by [Select] when none of the Regions satisfies
+// the placement or none of them has a suitable Credential.
+var ErrNoMatchingRegion = errors.New("no matching region")
+
+// Validate checks that the given placement, if any, has a valid region selector and preference.
+func Validate(placement *kcmv1.ClusterPlacement) error {
+	if placement == nil {
+		return nil
+	}
+	if _, err := selector(placement); err != nil {
+		return err
+	}
+	switch placement.Preference {
+	case kcmv1.PlacementPreferenceNone, kcmv1.PlacementPreferenceLeastLoaded:
+	default:
+		return fmt.Errorf("unsupported placement preference %q", placement.Preference)
+	}
+	return nil
+}
+
+// FromNamespace returns the placement defined by the annotations
+// of the given namespace, nil if the namespace does not define any.
+func FromNamespace(namespace *corev1.Namespace) (*kcmv1.ClusterPlacement, error) {
+	selectorValue, hasSelector := namespace.Annotations[kcmv1.PlacementRegionSelectorAnnotation]
+	preference, hasPreference := namespace.Annotations[kcmv1.PlacementPreferenceAnnotation]
+	if !hasSelector && !hasPreference {
+		return nil, nil //nolint:nilnil // no placement is defined
+	}
+
+	placement := &kcmv1.ClusterPlacement{Preference: kcmv1.PlacementPreference(preference)}
+	if hasSelector {
+		sel, err := metav1.ParseToLabelSelector(selectorValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation of namespace %s: %w", kcmv1.PlacementRegionSelectorAnnotation, namespace.Name, err)
+		}
+		placement.RegionSelector = sel
+	}
+
+	if err := Validate(placement); err != nil {
+		return nil, fmt.Errorf("invalid placement defined by the annotations of namespace %s: %w", namespace.Name, err)
+	}
+
+	return placement, nil
+}
+
+// ForClusterDeployment returns the placement applicable to the given [kcmv1.ClusterDeployment]:
+// the one defined in its spec, otherwise the one defined by the annotations of its namespace, if any.
+// A missing namespace defines no placement.
+func ForClusterDeployment(ctx context.Context, c client.Client, cd *kcmv1.ClusterDeployment) (*kcmv1.ClusterPlacement, error) {
+	if cd.Spec.Placement != nil {
+		return cd.Spec.Placement, nil
+	}
+
+	namespace := new(corev1.Namespace)
+	if err := c.Get(ctx, client.ObjectKey{Name: cd.Namespace}, namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil //nolint:nilnil // no placement is defined
+		}
+		return nil, fmt.Errorf("failed to get namespace %s: %w", cd.Namespace, err)
+	}
+
+	return FromNamespace(namespace)
+}
+
+// Allows checks that the given [kcmv1.Region] satisfies the given placement.
+// Nil region stands for the management cluster which never satisfies a placement.
+// Nil placement allows any region.
+func Allows(placement *kcmv1.ClusterPlacement, region *kcmv1.Region) error {
+	if placement == nil {
+		return nil
+	}
+	if region == nil {
+		return errors.New("the management cluster does not satisfy the placement")
+	}
+
+	sel, err := selector(placement)
+	if err != nil {
+		return err
+	}
+	if !sel.Matches(labels.Set(region.Labels)) {
+		return fmt.Errorf("the Region %s does not match the placement region selector %q", region.Name, sel.String())
+	}
+
+	return nil
+}
+
+// Select chooses a [kcmv1.Region] satisfying the given placement and a [kcmv1.Credential]
+// from the namespace of the given [kcmv1.ClusterDeployment] valid in that region and
+// supported by the given [kcmv1.ClusterTemplate].
+//
+// Only Ready Regions which are neither paused nor being deleted are considered.
+// Returns an error wrapping [ErrNoMatchingRegion] if there is no such pair.
+func Select(
+	ctx context.Context,
+	c client.Client,
+	systemNamespace string,
+	cd *kcmv1.ClusterDeployment,
+	clusterTemplate *kcmv1.ClusterTemplate,
+	placement *kcmv1.ClusterPlacement,
+) (*kcmv1.Region, *kcmv1.Credential, error) {
+	sel, err := selector(placement)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	regions := new(kcmv1.RegionList)
+	if err := c.List(ctx, regions, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list Regions: %w", err)
+	}
+
+	candidates := make([]*kcmv1.Region, 0, len(regions.Items))
+	for i := range regions.Items {
+		if isSchedulable(&regions.Items[i]) {
+			candidates = append(candidates, &regions.Items[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("%w: none of the Ready Regions matches the selector %q", ErrNoMatchingRegion, sel.String())
+	}
+
+	var loads map[string]int
+	if placement != nil && placement.Preference == kcmv1.PlacementPreferenceLeastLoaded {
+		if loads, err = regionLoads(ctx, c); err != nil {
+			return nil, nil, err
+		}
+	}
+	sortRegions(candidates, loads)
+
+	creds := new(kcmv1.CredentialList)
+	if err := c.List(ctx, creds, client.InNamespace(cd.Namespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list Credentials in namespace %s: %w", cd.Namespace, err)
+	}
+	slices.SortFunc(creds.Items, func(a, b kcmv1.Credential) int { return strings.Compare(a.Name, b.Name) })
+
+	var rejected []string
+	for _, region := range candidates {
+		for i := range creds.Items {
+			cred := &creds.Items[i]
+			if cred.Spec.Region != region.Name || !cred.DeletionTimestamp.IsZero() {
+				continue
+			}
+
+			candidate := cd.DeepCopy()
+			candidate.Spec.Credential = cred.Name
+			if err := validationutil.ClusterDeployCredential(ctx, c, systemNamespace, candidate, clusterTemplate); err != nil {
+				rejected = append(rejected, err.Error())
+				continue
+			}
+
+			return region, cred, nil
+		}
+	}
+
+	names := make([]string, 0, len(candidates))
+	for _, region := range candidates {
+		names = append(names, region.Name)
+	}
+	err = fmt.Errorf("%w: none of the Regions %v has a suitable Credential in namespace %s", ErrNoMatchingRegion, names, cd.Namespace)
+	if len(rejected) > 0 {
+		err = fmt.Errorf("%w: %s", err, strings.Join(rejected, "; "))
+	}
+
+	return nil, nil, err
+}
+
+// isSchedulable reports whether new ClusterDeployments can be placed into the given [kcmv1.Region].
+func isSchedulable(region *kcmv1.Region) bool {
+	if !region.DeletionTimestamp.IsZero() || region.IsAutoPaused() {
+		return false
+	}
+	if _, paused := region.Annotations[kcmv1.RegionPauseAnnotation]; paused {
+		return false
+	}
+	return apimeta.IsStatusConditionTrue(region.Status.Conditions, kcmv1.ReadyCondition)
+}
+
+// regionLoads returns the number of ClusterDeployments placed into each region.
+func regionLoads(ctx context.Context, c client.Client) (map[string]int, error) {
+	cds := new(kcmv1.ClusterDeploymentList)
+	if err := c.List(ctx, cds); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	loads := make(map[string]int)
+	for _, cd := range cds.Items {
+		if cd.Status.Region != "" {
+			loads[cd.Status.Region]++
+		}
+	}
+
+	return loads, nil
+}
+
+// sortRegions orders the given regions by the given loads, if any, and then by name.
+func sortRegions(regions []*kcmv1.Region, loads map[string]int) {
+	slices.SortFunc(regions, func(a, b *kcmv1.Region) int {
+		return cmp.Or(cmp.Compare(loads[a.Name], loads[b.Name]), strings.Compare(a.Name, b.Name))
+	})
+}
+
+func selector(placement *kcmv1.ClusterPlacement) (labels.Selector, error) {
+	if placement == nil || placement.RegionSelector == nil {
+		return labels.Everything(), nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(placement.RegionSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid placement region selector: %w", err)
+	}
+
+	return sel, nil
+}
@@ -0,0 +1,269 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package placement
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/objects/credential"
+	"github.com/K0rdent/kcm/test/objects/management"
+	"github.com/K0rdent/kcm/test/objects/region"
+	"github.com/K0rdent/kcm/test/objects/template"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestFromNamespace(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		want        *kcmv1.ClusterPlacement
+		wantErr     bool
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name:        "selector only",
+			annotations: map[string]string{kcmv1.PlacementRegionSelectorAnnotation: "tier=prod"},
+			want: &kcmv1.ClusterPlacement{
+				RegionSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+			},
+		},
+		{
+			name:        "preference only",
+			annotations: map[string]string{kcmv1.PlacementPreferenceAnnotation: string(kcmv1.PlacementPreferenceLeastLoaded)},
+			want:        &kcmv1.ClusterPlacement{Preference: kcmv1.PlacementPreferenceLeastLoaded},
+		},
+		{
+			name:        "invalid selector",
+			annotations: map[string]string{kcmv1.PlacementRegionSelectorAnnotation: "tier in prod"},
+			wantErr:     true,
+		},
+		{
+			name:        "unsupported preference",
+			annotations: map[string]string{kcmv1.PlacementPreferenceAnnotation: "MostLoaded"},
+			wantErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FromNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Annotations: tc.annotations}})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+				t.Errorf("expected placement %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	t.Parallel()
+
+	placement := &kcmv1.ClusterPlacement{
+		RegionSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		placement *kcmv1.ClusterPlacement
+		region    *kcmv1.Region
+		wantErr   bool
+	}{
+		{
+			name:   "no placement allows management",
+			region: nil,
+		},
+		{
+			name:      "management never satisfies placement",
+			placement: &kcmv1.ClusterPlacement{},
+			wantErr:   true,
+		},
+		{
+			name:      "matching region",
+			placement: placement,
+			region:    region.New(region.WithLabels(map[string]string{"tier": "prod"})),
+		},
+		{
+			name:      "not matching region",
+			placement: placement,
+			region:    region.New(region.WithLabels(map[string]string{"tier": "dev"})),
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := Allows(tc.placement, tc.region); (err != nil) != tc.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	const (
+		systemNamespace = "kcm-system"
+		kubeconfigName  = "region-kubeconfig"
+	)
+
+	ready := metav1.Condition{Type: kcmv1.ReadyCondition, Status: metav1.ConditionTrue, Reason: kcmv1.AllComponentsHealthyReason}
+	prod := map[string]string{"tier": "prod"}
+
+	newRegion := func(name string, labels map[string]string, opts ...region.Opt) *kcmv1.Region {
+		return region.New(append([]region.Opt{
+			region.WithName(name),
+			region.WithLabels(labels),
+			region.WithKubeConfigSecretReference(kubeconfigName, "value"),
+			region.WithConditions(ready),
+		}, opts...)...)
+	}
+	newCredential := func(name, rgn string) *kcmv1.Credential {
+		return credential.NewCredential(
+			credential.WithName(name),
+			credential.WithRegion(rgn),
+			credential.WithReady(true),
+			credential.WithIdentityRef(&corev1.ObjectReference{Kind: "Secret", Name: name}),
+		)
+	}
+
+	kubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: kubeconfigName, Namespace: systemNamespace},
+		Data: map[string][]byte{"value": []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: region
+  cluster:
+    server: https://region.example.com
+users:
+- name: region
+  user:
+    token: fake
+contexts:
+- name: region
+  context:
+    cluster: region
+    user: region
+current-context: region
+`)},
+	}
+	clusterTemplate := template.NewClusterTemplate(
+		template.WithProvidersStatus("infrastructure-internal"),
+		template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+	)
+
+	for _, tc := range []struct {
+		name       string
+		placement  *kcmv1.ClusterPlacement
+		objects    []runtime.Object
+		wantRegion string
+		wantCred   string
+	}{
+		{
+			name:      "first matching region by name",
+			placement: &kcmv1.ClusterPlacement{RegionSelector: &metav1.LabelSelector{MatchLabels: prod}},
+			objects: []runtime.Object{
+				newRegion("dev", map[string]string{"tier": "dev"}), newCredential("dev-cred", "dev"),
+				newRegion("prod-b", prod), newCredential("prod-b-cred", "prod-b"),
+				newRegion("prod-a", prod), newCredential("prod-a-cred", "prod-a"),
+			},
+			wantRegion: "prod-a",
+			wantCred:   "prod-a-cred",
+		},
+		{
+			name:      "least loaded region",
+			placement: &kcmv1.ClusterPlacement{Preference: kcmv1.PlacementPreferenceLeastLoaded},
+			objects: []runtime.Object{
+				newRegion("a", nil), newCredential("a-cred", "a"),
+				newRegion("b", nil), newCredential("b-cred", "b"),
+				clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("existing"), clusterdeployment.WithRegion("a")),
+			},
+			wantRegion: "b",
+			wantCred:   "b-cred",
+		},
+		{
+			name:      "paused, not ready and credential-less regions are skipped",
+			placement: &kcmv1.ClusterPlacement{},
+			objects: []runtime.Object{
+				newRegion("a-paused", nil, func(r *kcmv1.Region) {
+					r.Annotations = map[string]string{kcmv1.RegionPauseAnnotation: "true"}
+				}), newCredential("a-cred", "a-paused"),
+				newRegion("b-auto-paused", nil, region.WithConditions(ready,
+					metav1.Condition{Type: kcmv1.PausedCondition, Status: metav1.ConditionTrue, Reason: kcmv1.RegionUnreachableReason},
+				)), newCredential("b-cred", "b-auto-paused"),
+				newRegion("c-not-ready", nil, region.WithConditions()), newCredential("c-cred", "c-not-ready"),
+				newRegion("d-no-credential", nil),
+				newRegion("e", nil), newCredential("e-cred", "e"),
+			},
+			wantRegion: "e",
+			wantCred:   "e-cred",
+		},
+		{
+			name:      "no suitable credential",
+			placement: &kcmv1.ClusterPlacement{},
+			objects: []runtime.Object{
+				newRegion("a", nil),
+				credential.NewCredential(credential.WithName("not-ready"), credential.WithRegion("a"),
+					credential.WithIdentityRef(&corev1.ObjectReference{Kind: "Secret", Name: "not-ready"})),
+			},
+		},
+		{
+			name:      "no matching region",
+			placement: &kcmv1.ClusterPlacement{RegionSelector: &metav1.LabelSelector{MatchLabels: prod}},
+			objects:   []runtime.Object{newRegion("a", nil), newCredential("a-cred", "a")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(append(tc.objects, management.NewManagement(), kubeconfig)...).
+				Build()
+
+			cd := clusterdeployment.NewClusterDeployment(clusterdeployment.WithPlacement(tc.placement))
+
+			rgn, cred, err := Select(t.Context(), cl, systemNamespace, cd, clusterTemplate, tc.placement)
+			if tc.wantRegion == "" {
+				if !errors.Is(err, ErrNoMatchingRegion) {
+					t.Fatalf("expected %v, got %v", ErrNoMatchingRegion, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rgn.Name != tc.wantRegion || cred.Name != tc.wantCred {
+				t.Errorf("expected region %s and credential %s, got %s and %s", tc.wantRegion, tc.wantCred, rgn.Name, cred.Name)
+			}
+		})
+	}
+}
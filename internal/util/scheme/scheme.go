@@ -63,6 +63,21 @@ func getManagementScheme() (*runtime.Scheme, error) {
 	return s, nil
 }
 
+// RegionalScheme is a named builder of the scheme of the clients of the regional clusters.
+// The Name identifies the set of the types added by the Build function, thus the clients
+// built with the schemes of the same name are considered interchangeable.
+type RegionalScheme struct {
+	Build func() (*runtime.Scheme, error)
+	Name  string
+}
+
+var (
+	// Regional is the [RegionalScheme] built by [GetRegionalScheme].
+	Regional = RegionalScheme{Name: "regional", Build: GetRegionalScheme}
+	// RegionalWithSveltos is the [RegionalScheme] built by [GetRegionalSchemeWithSveltos].
+	RegionalWithSveltos = RegionalScheme{Name: "regional-sveltos", Build: GetRegionalSchemeWithSveltos}
+)
+
 func GetRegionalScheme() (*runtime.Scheme, error) {
 	return buildRegionalScheme(nil)
 }
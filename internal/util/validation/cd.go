@@ -91,7 +91,7 @@ func isCredIdentitySupportsClusterTemplate(ctx context.Context, mgmtClient clien
 	}
 
 	const secretKind = "Secret"
-	rgnClient, err := kubeutil.GetRegionalClientByRegionName(ctx, mgmtClient, systemNamespace, cred.Spec.Region, schemeutil.GetRegionalScheme)
+	rgnClient, err := kubeutil.GetRegionalClientByRegionName(ctx, mgmtClient, systemNamespace, cred.Spec.Region, schemeutil.Regional)
 	if err != nil {
 		return fmt.Errorf("failed to get client for %s region: %w", cred.Spec.Region, err)
 	}
@@ -31,6 +31,10 @@ type ComponentsManager interface {
 	Components() kcmv1.ComponentsCommonSpec
 }
 
+type kcmComponentInfoGetter interface {
+	KCMComponentInfo(release *kcmv1.Release, kcmReleaseName string) kcmv1.KCMComponentInfo
+}
+
 // ErrProviderIsNotReady signals if the corresponding [github.com/K0rdent/kcm/api/v1beta1.ProviderTemplate] is not yet ready.
 var ErrProviderIsNotReady = errors.New("provider is not yet ready")
 
@@ -164,6 +168,31 @@ func getIncompatibleContractsForProviderTemplates(
 	return strings.TrimSuffix(incompatibleContracts.String(), ", "), nil
 }
 
+// ComponentsTemplates returns the names of the [github.com/K0rdent/kcm/api/v1beta1.ProviderTemplate] objects
+// the components of the given [github.com/K0rdent/kcm/api/v1beta1.Management] or
+// [github.com/K0rdent/kcm/api/v1beta1.Region] are deployed with according to the given
+// [github.com/K0rdent/kcm/api/v1beta1.Release], keyed by the component names.
+func ComponentsTemplates(release *kcmv1.Release, obj ComponentsManager) map[string]string {
+	templates := make(map[string]string, len(obj.Components().Providers)+2)
+
+	kcmName, kcmTemplate := kcmv1.CoreKCMName, release.Spec.KCM.Template
+	if withKCM, ok := obj.(kcmComponentInfoGetter); ok {
+		info := withKCM.KCMComponentInfo(release, "")
+		kcmName, kcmTemplate = info.ChartName, info.DefaultTemplate
+	}
+	if obj.Components().Core != nil && obj.Components().Core.KCM.Template != "" {
+		kcmTemplate = obj.Components().Core.KCM.Template
+	}
+	templates[kcmName] = kcmTemplate
+	templates[kcmv1.CoreCAPIName] = findCAPITemplateName(release, obj)
+
+	for _, p := range obj.Components().Providers {
+		templates[p.Name] = findProviderTemplateName(release, p)
+	}
+
+	return templates
+}
+
 func findCAPITemplateName(release *kcmv1.Release, obj ComponentsManager) string {
 	if obj.Components().Core != nil && obj.Components().Core.CAPI.Template != "" {
 		return obj.Components().Core.CAPI.Template
@@ -117,6 +117,21 @@ func validateServiceTemplateChain(ctx context.Context, cl client.Client, svc kcm
 	return errs
 }
 
+// StateManagementProviderExists validates that the [github.com/K0rdent/kcm/api/v1beta1.StateManagementProvider]
+// resolved from the given [github.com/K0rdent/kcm/api/v1beta1.ServiceSpec] exists.
+func StateManagementProviderExists(ctx context.Context, cl client.Client, serviceSpec kcmv1.ServiceSpec) error {
+	providerConfig, err := serviceset.StateManagementProviderConfigFromServiceSpec(serviceSpec)
+	if err != nil {
+		return fmt.Errorf("failed to convert ServiceSpec to provider config: %w", err)
+	}
+
+	if err := cl.Get(ctx, client.ObjectKey{Name: providerConfig.Name}, new(kcmv1.StateManagementProvider)); err != nil {
+		return fmt.Errorf("failed to get StateManagementProvider %s: %w", providerConfig.Name, err)
+	}
+
+	return nil
+}
+
 // ValidateServiceDependencyOverall calls all of the functions
 // related to service dependency validation one by one.
 func ValidateServiceDependencyOverall(services []kcmv1.Service) error {
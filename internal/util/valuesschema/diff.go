@@ -0,0 +1,299 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valuesschema provides helpers to compare the values JSON schemas of the templates
+// and to migrate the values between the templates.
+package valuesschema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapKey is the key of the ConfigMap data holding the values JSON schema of a template.
+const ConfigMapKey = "schema"
+
+// ChangeKind is the kind of a breaking change of a values schema.
+type ChangeKind string
+
+const (
+	// ChangeRemoved denotes a removed property.
+	ChangeRemoved ChangeKind = "Removed"
+	// ChangeTypeChanged denotes a property whose type no longer accepts some of the previously allowed types.
+	ChangeTypeChanged ChangeKind = "TypeChanged"
+	// ChangeRequired denotes a newly required property.
+	ChangeRequired ChangeKind = "Required"
+	// ChangeEnumNarrowed denotes a property no longer accepting some of the previously allowed values.
+	ChangeEnumNarrowed ChangeKind = "EnumNarrowed"
+)
+
+// Change is a breaking change of a values schema.
+type Change struct {
+	// Kind is the kind of the change.
+	Kind ChangeKind
+	// Path is the dot-separated path of the changed property, "[]" denotes the items of an array.
+	Path string
+	// Types are the types accepted by the new schema, set for [ChangeTypeChanged].
+	Types []string
+	// Values are the values no longer accepted by the new schema, set for [ChangeEnumNarrowed].
+	Values []any
+}
+
+// String returns the human-readable description of the change.
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeRemoved:
+		return c.Path + ": removed"
+	case ChangeTypeChanged:
+		return c.Path + ": type changed to " + strings.Join(c.Types, " or ")
+	case ChangeRequired:
+		return c.Path + ": became required"
+	case ChangeEnumNarrowed:
+		return fmt.Sprintf("%s: values %v are no longer allowed", c.Path, c.Values)
+	default:
+		return c.Path + ": " + string(c.Kind)
+	}
+}
+
+// Get returns the values JSON schema stored in the ConfigMap with the given name, nil if the name is empty
+// or the ConfigMap does not exist.
+func Get(ctx context.Context, c client.Reader, namespace, configMapName string) ([]byte, error) {
+	if configMapName == "" {
+		return nil, nil
+	}
+
+	cm := new(corev1.ConfigMap)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: configMapName}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get schema ConfigMap %s/%s: %w", namespace, configMapName, err)
+	}
+
+	return []byte(cm.Data[ConfigMapKey]), nil
+}
+
+// Diff returns the breaking changes between the given old and new values JSON schemas.
+// A change is breaking if values valid against the old schema may be invalid against the new one
+// or silently ignored by the chart. The references within the schemas are not resolved.
+// Returns no changes if any of the schemas is empty.
+func Diff(oldSchema, newSchema []byte) ([]Change, error) {
+	if len(oldSchema) == 0 || len(newSchema) == 0 {
+		return nil, nil
+	}
+
+	var oldObj, newObj map[string]any
+	if err := json.Unmarshal(oldSchema, &oldObj); err != nil {
+		return nil, fmt.Errorf("failed to parse old schema: %w", err)
+	}
+	if err := json.Unmarshal(newSchema, &newObj); err != nil {
+		return nil, fmt.Errorf("failed to parse new schema: %w", err)
+	}
+
+	var changes []Change
+	diff("", oldObj, newObj, &changes)
+	return changes, nil
+}
+
+func diff(path string, oldObj, newObj map[string]any, changes *[]Change) {
+	if oldTypes, newTypes := schemaTypes(oldObj), schemaTypes(newObj); len(newTypes) > 0 {
+		if len(oldTypes) == 0 || slices.ContainsFunc(oldTypes, func(t string) bool { return !typeAllowed(t, newTypes) }) {
+			*changes = append(*changes, Change{Kind: ChangeTypeChanged, Path: pathOrRoot(path), Types: newTypes})
+		}
+	}
+
+	if newEnum, ok := newObj["enum"].([]any); ok {
+		oldEnum, _ := oldObj["enum"].([]any)
+		var removed []any
+		for _, v := range oldEnum {
+			if !slices.ContainsFunc(newEnum, func(nv any) bool { return reflect.DeepEqual(v, nv) }) {
+				removed = append(removed, v)
+			}
+		}
+		if len(removed) > 0 {
+			*changes = append(*changes, Change{Kind: ChangeEnumNarrowed, Path: pathOrRoot(path), Values: removed})
+		}
+	}
+
+	oldRequired, newRequired := stringSlice(oldObj["required"]), stringSlice(newObj["required"])
+	for _, name := range newRequired {
+		if !slices.Contains(oldRequired, name) {
+			*changes = append(*changes, Change{Kind: ChangeRequired, Path: join(path, name)})
+		}
+	}
+
+	oldProps, _ := oldObj["properties"].(map[string]any)
+	newProps, _ := newObj["properties"].(map[string]any)
+	for _, name := range sortedKeys(oldProps) {
+		oldProp, _ := oldProps[name].(map[string]any)
+		newProp, ok := newProps[name].(map[string]any)
+		if !ok {
+			*changes = append(*changes, Change{Kind: ChangeRemoved, Path: join(path, name)})
+			continue
+		}
+		diff(join(path, name), oldProp, newProp, changes)
+	}
+
+	oldItems, _ := oldObj["items"].(map[string]any)
+	newItems, _ := newObj["items"].(map[string]any)
+	if oldItems != nil && newItems != nil {
+		diff(path+"[]", oldItems, newItems, changes)
+	}
+}
+
+// Affects returns whether the given change makes the given values, merged with the chart defaults, incompatible.
+func (c Change) Affects(values map[string]any) bool {
+	segments := split(c.Path)
+	if c.Kind == ChangeRequired {
+		name := segments[len(segments)-1]
+		for _, parent := range lookup(values, segments[:len(segments)-1]) {
+			if obj, ok := parent.(map[string]any); ok {
+				if _, set := obj[name]; !set {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	found := lookup(values, segments)
+	switch c.Kind {
+	case ChangeRemoved:
+		return len(found) > 0
+	case ChangeTypeChanged:
+		return slices.ContainsFunc(found, func(v any) bool { return !typeAllowed(jsonType(v), c.Types) })
+	case ChangeEnumNarrowed:
+		return slices.ContainsFunc(found, func(v any) bool {
+			return slices.ContainsFunc(c.Values, func(removed any) bool { return reflect.DeepEqual(v, removed) })
+		})
+	default:
+		return len(found) > 0
+	}
+}
+
+// lookup returns the values found at the given path segments, the "[]" suffix of a segment
+// denotes the items of an array.
+func lookup(v any, segments []string) []any {
+	if len(segments) == 0 {
+		return []any{v}
+	}
+
+	key, items := strings.CutSuffix(segments[0], "[]")
+	if key != "" {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if v, ok = obj[key]; !ok {
+			return nil
+		}
+	}
+	if !items {
+		return lookup(v, segments[1:])
+	}
+
+	arr, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	var result []any
+	for _, item := range arr {
+		result = append(result, lookup(item, segments[1:])...)
+	}
+	return result
+}
+
+func schemaTypes(obj map[string]any) []string {
+	switch t := obj["type"].(type) {
+	case string:
+		return []string{t}
+	case []any:
+		return stringSlice(t)
+	default:
+		return nil
+	}
+}
+
+func typeAllowed(t string, allowed []string) bool {
+	return slices.Contains(allowed, t) || (t == "integer" && slices.Contains(allowed, "number"))
+}
+
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func stringSlice(v any) []string {
+	arr, _ := v.([]any)
+	result := make([]string, 0, len(arr))
+	for _, item := range arr {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func split(path string) []string {
+	if path == "." {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
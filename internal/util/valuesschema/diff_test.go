@@ -0,0 +1,149 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valuesschema
+
+import (
+	"reflect"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+const (
+	testOldSchema = `{
+  "type": "object",
+  "properties": {
+    "replicas": {"type": "integer"},
+    "instanceType": {"type": "string"},
+    "network": {"type": "object", "properties": {"mode": {"type": "string", "enum": ["a", "b", "c"]}}},
+    "workers": {"type": "array", "items": {"type": "object", "properties": {"size": {"type": "string"}}}}
+  }
+}`
+	testNewSchema = `{
+  "type": "object",
+  "required": ["region"],
+  "properties": {
+    "replicas": {"type": "string"},
+    "controlPlane": {"type": "object", "properties": {"instanceType": {"type": "string"}}},
+    "region": {"type": "string"},
+    "network": {"type": "object", "properties": {"mode": {"type": "string", "enum": ["a", "b"]}}},
+    "workers": {"type": "array", "items": {"type": "object", "required": ["name"], "properties": {"size": {"type": "string"}, "name": {"type": "string"}}}}
+  }
+}`
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	changes, err := Diff([]byte(testOldSchema), []byte(testNewSchema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"region: became required",
+		"instanceType: removed",
+		"network.mode: values [c] are no longer allowed",
+		"replicas: type changed to string",
+		"workers[].name: became required",
+	}
+	got := make([]string, 0, len(changes))
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %q, want %q", got, want)
+	}
+
+	if changes, err := Diff(nil, []byte(testNewSchema)); err != nil || changes != nil {
+		t.Errorf("Diff() with empty schema = %v, %v, want no changes", changes, err)
+	}
+	if _, err := Diff([]byte("{"), []byte(testNewSchema)); err == nil {
+		t.Error("expected error on invalid schema")
+	}
+}
+
+func TestUpgradeChanges(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		config     string
+		defaults   string
+		migrations []kcmv1.ValuesMigration
+		want       []string
+	}{
+		{
+			name:     "unaffected configuration",
+			config:   `{"replicas": "3", "network": {"mode": "a"}}`,
+			defaults: `{"region": "eu"}`,
+		},
+		{
+			name:   "affected configuration",
+			config: `{"replicas": 3, "instanceType": "large", "network": {"mode": "c"}, "workers": [{"name": "w1"}, {"size": "xl"}]}`,
+			want: []string{
+				"region: became required",
+				"instanceType: removed",
+				"network.mode: values [c] are no longer allowed",
+				"replicas: type changed to string",
+				"workers[].name: became required",
+			},
+		},
+		{
+			name:   "migrated configuration",
+			config: `{"instanceType": "large"}`,
+			migrations: []kcmv1.ValuesMigration{
+				{Type: kcmv1.ValuesMigrationMove, From: "instanceType", To: "controlPlane.instanceType", FromTemplates: []string{"source"}},
+				{Type: kcmv1.ValuesMigrationDefault, To: "region", Value: &apiextv1.JSON{Raw: []byte(`"us"`)}, FromVersions: "< 2.0.0"},
+			},
+		},
+		{
+			name:   "migrations written for other templates",
+			config: `{"instanceType": "large"}`,
+			migrations: []kcmv1.ValuesMigration{
+				{Type: kcmv1.ValuesMigrationMove, From: "instanceType", To: "controlPlane.instanceType", FromTemplates: []string{"other"}},
+				{Type: kcmv1.ValuesMigrationDefault, To: "region", Value: &apiextv1.JSON{Raw: []byte(`"us"`)}, FromVersions: ">= 2.0.0"},
+			},
+			want: []string{"region: became required", "instanceType: removed"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := &kcmv1.ClusterTemplate{Spec: kcmv1.ClusterTemplateSpec{ValuesMigrations: tc.migrations}}
+			if tc.defaults != "" {
+				target.Status.Config = &apiextv1.JSON{Raw: []byte(tc.defaults)}
+			}
+
+			source := &kcmv1.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: "source"}, Status: kcmv1.ClusterTemplateStatus{
+				TemplateStatusCommon: kcmv1.TemplateStatusCommon{ChartVersion: "1.0.0"},
+			}}
+			changes, err := UpgradeChanges([]byte(testOldSchema), []byte(testNewSchema), &apiextv1.JSON{Raw: []byte(tc.config)}, source, target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("UpgradeChanges() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
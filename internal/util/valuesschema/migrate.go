@@ -0,0 +1,170 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valuesschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// ApplicableMigrations returns the values migrations of the target ClusterTemplate
+// which have been written for the upgrades from the source ClusterTemplate.
+func ApplicableMigrations(source, target *kcmv1.ClusterTemplate) ([]kcmv1.ValuesMigration, error) {
+	var applicable []kcmv1.ValuesMigration
+	for i, m := range target.Spec.ValuesMigrations {
+		ok, err := appliesTo(m, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match values migration %d of the ClusterTemplate %s: %w", i, target.Name, err)
+		}
+		if ok {
+			applicable = append(applicable, m)
+		}
+	}
+	return applicable, nil
+}
+
+// ValidateMigrations validates the scope of the given values migrations.
+func ValidateMigrations(migrations []kcmv1.ValuesMigration) error {
+	var errs error
+	for i, m := range migrations {
+		if m.FromVersions == "" {
+			continue
+		}
+		if _, err := semver.NewConstraint(m.FromVersions); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid fromVersions %q of values migration %d: %w", m.FromVersions, i, err))
+		}
+	}
+	return errs
+}
+
+// appliesTo reports whether the given migration has been written for the given source ClusterTemplate.
+// A migration without any scope applies to none of the ClusterTemplates.
+func appliesTo(m kcmv1.ValuesMigration, source *kcmv1.ClusterTemplate) (bool, error) {
+	if len(m.FromTemplates) == 0 && m.FromVersions == "" {
+		return false, nil
+	}
+	if len(m.FromTemplates) > 0 && !slices.Contains(m.FromTemplates, source.Name) {
+		return false, nil
+	}
+	if m.FromVersions == "" {
+		return true, nil
+	}
+
+	constraint, err := semver.NewConstraint(m.FromVersions)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse fromVersions %q: %w", m.FromVersions, err)
+	}
+	version, err := semver.NewVersion(source.Status.ChartVersion)
+	if err != nil {
+		return false, nil //nolint:nilerr // the source without a valid version does not match any constraint
+	}
+	return constraint.Check(version), nil
+}
+
+// Migrate applies the given migrations in order to the given values and returns whether the values have changed.
+func Migrate(values map[string]any, migrations []kcmv1.ValuesMigration) (bool, error) {
+	changed := false
+	for i, m := range migrations {
+		c, err := migrate(values, m)
+		if err != nil {
+			return false, fmt.Errorf("failed to apply values migration %d (%s %s to %s): %w", i, m.Type, m.From, m.To, err)
+		}
+		changed = changed || c
+	}
+	return changed, nil
+}
+
+func migrate(values map[string]any, m kcmv1.ValuesMigration) (bool, error) {
+	switch m.Type {
+	case kcmv1.ValuesMigrationRename, kcmv1.ValuesMigrationMove:
+		from := strings.Split(m.From, ".")
+		parent, ok := getObject(values, from[:len(from)-1])
+		if !ok {
+			return false, nil
+		}
+		v, ok := parent[from[len(from)-1]]
+		if !ok {
+			return false, nil
+		}
+		delete(parent, from[len(from)-1])
+
+		to := strings.Split(m.To, ".")
+		if m.Type == kcmv1.ValuesMigrationRename {
+			to = append(from[:len(from)-1:len(from)-1], m.To)
+		}
+		if _, err := setDefault(values, to, v); err != nil {
+			return false, err
+		}
+		return true, nil
+	case kcmv1.ValuesMigrationDefault:
+		if m.Value == nil {
+			return false, nil
+		}
+		var v any
+		if err := json.Unmarshal(m.Value.Raw, &v); err != nil {
+			return false, fmt.Errorf("failed to parse value: %w", err)
+		}
+		return setDefault(values, strings.Split(m.To, "."), v)
+	default:
+		return false, fmt.Errorf("unknown migration type %s", m.Type)
+	}
+}
+
+// getObject returns the nested object at the given path segments.
+func getObject(values map[string]any, segments []string) (map[string]any, bool) {
+	obj := values
+	for _, key := range segments {
+		next, ok := obj[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		obj = next
+	}
+	return obj, true
+}
+
+// setDefault sets the value at the given path segments, creating the intermediate objects,
+// unless a value is already set there.
+func setDefault(values map[string]any, segments []string, v any) (bool, error) {
+	obj := values
+	for i, key := range segments[:len(segments)-1] {
+		next, ok := obj[key]
+		if !ok {
+			nextObj := make(map[string]any)
+			obj[key] = nextObj
+			obj = nextObj
+			continue
+		}
+		nextObj, ok := next.(map[string]any)
+		if !ok {
+			return false, fmt.Errorf("value at %s is not an object", strings.Join(segments[:i+1], "."))
+		}
+		obj = nextObj
+	}
+
+	key := segments[len(segments)-1]
+	if _, ok := obj[key]; ok {
+		return false, nil
+	}
+	obj[key] = v
+	return true, nil
+}
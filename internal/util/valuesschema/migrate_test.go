@@ -0,0 +1,152 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valuesschema
+
+import (
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+func TestMigrateConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		config     string
+		migrations []kcmv1.ValuesMigration
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "rename",
+			config:     `{"controlPlane":{"flavor":"large"}}`,
+			migrations: []kcmv1.ValuesMigration{{Type: kcmv1.ValuesMigrationRename, From: "controlPlane.flavor", To: "instanceType"}},
+			want:       `{"controlPlane":{"instanceType":"large"}}`,
+		},
+		{
+			name:       "move",
+			config:     `{"flavor":"large","region":"eu"}`,
+			migrations: []kcmv1.ValuesMigration{{Type: kcmv1.ValuesMigrationMove, From: "flavor", To: "controlPlane.instanceType"}},
+			want:       `{"controlPlane":{"instanceType":"large"},"region":"eu"}`,
+		},
+		{
+			name:       "move keeps the destination",
+			config:     `{"flavor":"large","controlPlane":{"instanceType":"small"}}`,
+			migrations: []kcmv1.ValuesMigration{{Type: kcmv1.ValuesMigrationMove, From: "flavor", To: "controlPlane.instanceType"}},
+			want:       `{"controlPlane":{"instanceType":"small"}}`,
+		},
+		{
+			name:   "default",
+			config: `{"workers":{"replicas":1}}`,
+			migrations: []kcmv1.ValuesMigration{
+				{Type: kcmv1.ValuesMigrationDefault, To: "workers.replicas", Value: &apiextv1.JSON{Raw: []byte(`3`)}},
+				{Type: kcmv1.ValuesMigrationDefault, To: "workers.image", Value: &apiextv1.JSON{Raw: []byte(`{"tag":"v1"}`)}},
+			},
+			want: `{"workers":{"image":{"tag":"v1"},"replicas":1}}`,
+		},
+		{
+			name:       "missing source",
+			config:     `{"region":"eu"}`,
+			migrations: []kcmv1.ValuesMigration{{Type: kcmv1.ValuesMigrationRename, From: "controlPlane.flavor", To: "instanceType"}},
+			want:       `{"region":"eu"}`,
+		},
+		{
+			name:       "destination parent is not an object",
+			config:     `{"flavor":"large","controlPlane":"small"}`,
+			migrations: []kcmv1.ValuesMigration{{Type: kcmv1.ValuesMigrationMove, From: "flavor", To: "controlPlane.instanceType"}},
+			wantErr:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &apiextv1.JSON{Raw: []byte(tc.config)}
+			got, changed, err := MigrateConfig(config, tc.migrations)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got.Raw) != tc.want {
+				t.Errorf("MigrateConfig() = %s, want %s", got.Raw, tc.want)
+			}
+			if changed != (tc.want != tc.config) {
+				t.Errorf("MigrateConfig() changed = %t", changed)
+			}
+		})
+	}
+}
+
+func TestApplicableMigrations(t *testing.T) {
+	t.Parallel()
+
+	source := &kcmv1.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-1-0-0"},
+		Status:     kcmv1.ClusterTemplateStatus{TemplateStatusCommon: kcmv1.TemplateStatusCommon{ChartVersion: "1.0.0"}},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		migration kcmv1.ValuesMigration
+		want      bool
+		wantErr   bool
+	}{
+		{name: "matching template", migration: kcmv1.ValuesMigration{FromTemplates: []string{"aws-0-9-0", "aws-1-0-0"}}, want: true},
+		{name: "other template", migration: kcmv1.ValuesMigration{FromTemplates: []string{"aws-0-9-0"}}},
+		{name: "matching version", migration: kcmv1.ValuesMigration{FromVersions: "< 1.1.0"}, want: true},
+		{name: "other version", migration: kcmv1.ValuesMigration{FromVersions: "< 1.0.0"}},
+		{name: "matching template of other version", migration: kcmv1.ValuesMigration{FromTemplates: []string{"aws-1-0-0"}, FromVersions: ">= 2.0.0"}},
+		{name: "no scope", migration: kcmv1.ValuesMigration{}},
+		{name: "invalid version constraint", migration: kcmv1.ValuesMigration{FromVersions: "not a constraint"}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := &kcmv1.ClusterTemplate{Spec: kcmv1.ClusterTemplateSpec{ValuesMigrations: []kcmv1.ValuesMigration{tc.migration}}}
+			got, err := ApplicableMigrations(source, target)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (len(got) == 1) != tc.want {
+				t.Errorf("ApplicableMigrations() = %v, want applicable %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateMigrations(t *testing.T) {
+	t.Parallel()
+
+	if err := ValidateMigrations([]kcmv1.ValuesMigration{{FromTemplates: []string{"aws-1-0-0"}}, {FromVersions: "~1.2"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateMigrations([]kcmv1.ValuesMigration{{FromVersions: "not a constraint"}}); err == nil {
+		t.Error("expected error for the invalid fromVersions")
+	}
+}
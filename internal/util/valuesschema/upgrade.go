@@ -0,0 +1,113 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valuesschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+)
+
+// MigrateConfig applies the given migrations to the given configuration,
+// returns the migrated configuration and whether it has changed.
+func MigrateConfig(config *apiextv1.JSON, migrations []kcmv1.ValuesMigration) (*apiextv1.JSON, bool, error) {
+	if config == nil || len(migrations) == 0 {
+		return config, false, nil
+	}
+
+	values, err := decode(config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed, err := Migrate(values, migrations)
+	if err != nil || !changed {
+		return config, false, err
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal migrated configuration: %w", err)
+	}
+	return &apiextv1.JSON{Raw: raw}, true, nil
+}
+
+// UpgradeChanges returns the breaking changes between the given old and new values schemas
+// affecting the given configuration once migrated with the migrations of the new template
+// applicable to the old one and merged with the default values of the new template.
+func UpgradeChanges(oldSchema, newSchema []byte, config *apiextv1.JSON, oldTemplate, newTemplate *kcmv1.ClusterTemplate) ([]Change, error) {
+	changes, err := Diff(oldSchema, newSchema)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+
+	values, err := decode(config)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := ApplicableMigrations(oldTemplate, newTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := Migrate(values, migrations); err != nil {
+		return nil, err
+	}
+
+	defaults, err := decode(newTemplate.Status.Config)
+	if err != nil {
+		return nil, err
+	}
+	values = merge(defaults, values)
+
+	var affecting []Change
+	for _, c := range changes {
+		if c.Affects(values) {
+			affecting = append(affecting, c)
+		}
+	}
+	return affecting, nil
+}
+
+func decode(config *apiextv1.JSON) (map[string]any, error) {
+	values := make(map[string]any)
+	if config == nil || len(config.Raw) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+	if values == nil {
+		values = make(map[string]any)
+	}
+	return values, nil
+}
+
+// merge merges the given values into the given defaults the same way Helm does:
+// the nested objects are merged while any other values replace the defaults.
+func merge(defaults, values map[string]any) map[string]any {
+	for k, v := range values {
+		vObj, vIsObj := v.(map[string]any)
+		dObj, dIsObj := defaults[k].(map[string]any)
+		if vIsObj && dIsObj {
+			defaults[k] = merge(dObj, vObj)
+			continue
+		}
+		defaults[k] = v
+	}
+	return defaults
+}
@@ -16,18 +16,27 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
+	maintenanceutil "github.com/K0rdent/kcm/internal/util/maintenance"
+	placementutil "github.com/K0rdent/kcm/internal/util/placement"
 	validationutil "github.com/K0rdent/kcm/internal/util/validation"
+	valuesschemautil "github.com/K0rdent/kcm/internal/util/valuesschema"
 )
 
 type ClusterDeploymentValidator struct {
@@ -39,7 +48,10 @@ type ClusterDeploymentValidator struct {
 
 const invalidClusterDeploymentMsg = "the ClusterDeployment is invalid"
 
-var errClusterUpgradeForbidden = errors.New("cluster upgrade is forbidden")
+var (
+	errClusterUpgradeForbidden = errors.New("cluster upgrade is forbidden")
+	errTemplateDeprecated      = errors.New("deprecated templates cannot be newly referenced")
+)
 
 func (v *ClusterDeploymentValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	v.Client = mgr.GetClient()
@@ -85,6 +97,18 @@ func (v *ClusterDeploymentValidator) ValidateCreate(ctx context.Context, cluster
 		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
 	}
 
+	if err := maintenanceutil.Validate(clusterDeployment.Spec.MaintenanceWindow); err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	}
+
+	if err := v.validatePlacement(ctx, clusterDeployment); err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	}
+
+	if _, err := v.validateTemplatesDeprecation(ctx, nil, clusterDeployment); err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	}
+
 	return nil, nil
 }
 
@@ -118,6 +142,8 @@ func (v *ClusterDeploymentValidator) ValidateUpdate(ctx context.Context, oldClus
 			return admission.Warnings{"Failed to validate k8s version compatibility with ServiceTemplates"}, fmt.Errorf("failed to validate k8s compatibility: %w", err)
 		}
 
+		warnings = append(warnings, v.upgradeBreakingChanges(ctx, newClusterDeployment.Namespace, oldTemplate, template, newClusterDeployment.Spec.Config)...)
+
 		if newClusterDeployment.Spec.DryRun {
 			if w := v.detectHelmChartNameChange(ctx, oldClusterDeployment.Namespace, oldTemplate, template); len(w) > 0 {
 				warnings = append(warnings, w...)
@@ -149,6 +175,25 @@ func (v *ClusterDeploymentValidator) ValidateUpdate(ctx context.Context, oldClus
 		}
 	}
 
+	if !equality.Semantic.DeepEqual(oldClusterDeployment.Spec.MaintenanceWindow, newClusterDeployment.Spec.MaintenanceWindow) {
+		if err := maintenanceutil.Validate(newClusterDeployment.Spec.MaintenanceWindow); err != nil {
+			return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+		}
+	}
+
+	if oldClusterDeployment.Spec.Credential != newClusterDeployment.Spec.Credential ||
+		!equality.Semantic.DeepEqual(oldClusterDeployment.Spec.Placement, newClusterDeployment.Spec.Placement) {
+		if err := v.validatePlacement(ctx, newClusterDeployment); err != nil {
+			return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+		}
+	}
+
+	w, err := v.validateTemplatesDeprecation(ctx, oldClusterDeployment, newClusterDeployment)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	}
+	warnings = append(warnings, w...)
+
 	return warnings, nil
 }
 
@@ -159,6 +204,14 @@ func (v *ClusterDeploymentValidator) ValidateDelete(ctx context.Context, cluster
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type.
 func (v *ClusterDeploymentValidator) Default(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	if err := v.defaultCredential(ctx, clusterDeployment); err != nil {
+		return err
+	}
+
+	if err := v.migrateConfig(ctx, clusterDeployment); err != nil {
+		return err
+	}
+
 	// Only apply defaults when there's no configuration provided;
 	// if template ref is empty, then nothing to default
 	if clusterDeployment.Spec.Config != nil || clusterDeployment.Spec.Template == "" {
@@ -184,6 +237,201 @@ func (v *ClusterDeploymentValidator) Default(ctx context.Context, clusterDeploym
 	return nil
 }
 
+// migrateConfig applies the values migrations of the new ClusterTemplate written for the old one
+// to the configuration of the ClusterDeployment being upgraded to it.
+func (v *ClusterDeploymentValidator) migrateConfig(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	if clusterDeployment.Spec.Config == nil || clusterDeployment.Spec.Template == "" {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Update || len(req.OldObject.Raw) == 0 {
+		return nil //nolint:nilerr // migrations are applied only on update
+	}
+
+	oldClusterDeployment := new(kcmv1.ClusterDeployment)
+	if err := json.Unmarshal(req.OldObject.Raw, oldClusterDeployment); err != nil {
+		return fmt.Errorf("failed to decode the old ClusterDeployment %s: %w", client.ObjectKeyFromObject(clusterDeployment), err)
+	}
+	if oldClusterDeployment.Spec.Template == clusterDeployment.Spec.Template {
+		return nil
+	}
+
+	template, err := v.getClusterDeploymentTemplate(ctx, clusterDeployment.Namespace, clusterDeployment.Spec.Template)
+	if err != nil {
+		return fmt.Errorf("failed to get ClusterTemplate for the ClusterDeployment %s: %w", client.ObjectKeyFromObject(clusterDeployment), err)
+	}
+
+	if len(template.Spec.ValuesMigrations) == 0 {
+		return nil
+	}
+
+	oldTemplate, err := v.getClusterDeploymentTemplate(ctx, clusterDeployment.Namespace, oldClusterDeployment.Spec.Template)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", clusterDeployment.Namespace, oldClusterDeployment.Spec.Template, err)
+		}
+		// the migrations scoped by the name still apply if the old ClusterTemplate is gone
+		oldTemplate = &kcmv1.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Namespace: clusterDeployment.Namespace, Name: oldClusterDeployment.Spec.Template}}
+	}
+
+	migrations, err := valuesschemautil.ApplicableMigrations(oldTemplate, template)
+	if err != nil {
+		return fmt.Errorf("failed to migrate the configuration of the ClusterDeployment %s to the ClusterTemplate %s: %w", client.ObjectKeyFromObject(clusterDeployment), template.Name, err)
+	}
+	config, _, err := valuesschemautil.MigrateConfig(clusterDeployment.Spec.Config, migrations)
+	if err != nil {
+		return fmt.Errorf("failed to migrate the configuration of the ClusterDeployment %s to the ClusterTemplate %s: %w", client.ObjectKeyFromObject(clusterDeployment), template.Name, err)
+	}
+	clusterDeployment.Spec.Config = config
+
+	return nil
+}
+
+// upgradeBreakingChanges returns warnings for the breaking changes of the values schema
+// of the new ClusterTemplate affecting the configuration of the ClusterDeployment.
+func (v *ClusterDeploymentValidator) upgradeBreakingChanges(ctx context.Context, namespace, oldTemplateName string, newTemplate *kcmv1.ClusterTemplate, config *apiextv1.JSON) admission.Warnings {
+	oldTemplate, err := v.getClusterDeploymentTemplate(ctx, namespace, oldTemplateName)
+	if err != nil {
+		return nil
+	}
+
+	oldSchema, err := valuesschemautil.Get(ctx, v.Client, namespace, oldTemplate.Status.SchemaConfigMapName)
+	if err != nil {
+		return nil
+	}
+	newSchema, err := valuesschemautil.Get(ctx, v.Client, namespace, newTemplate.Status.SchemaConfigMapName)
+	if err != nil {
+		return nil
+	}
+
+	changes, err := valuesschemautil.UpgradeChanges(oldSchema, newSchema, config, oldTemplate, newTemplate)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Failed to compare the values schemas of the ClusterTemplates %s and %s: %v", oldTemplateName, newTemplate.Name, err)}
+	}
+
+	warnings := make(admission.Warnings, 0, len(changes))
+	for _, c := range changes {
+		warnings = append(warnings, fmt.Sprintf("The configuration is incompatible with the ClusterTemplate %s: %s", newTemplate.Name, c))
+	}
+	return warnings
+}
+
+// defaultCredential places the ClusterDeployment without a Credential into a Region
+// satisfying its placement, if any, and sets the Credential valid there.
+func (v *ClusterDeploymentValidator) defaultCredential(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	if clusterDeployment.Spec.Credential != "" || clusterDeployment.Spec.Template == "" {
+		return nil
+	}
+
+	clusterPlacement, err := placementutil.ForClusterDeployment(ctx, v.Client, clusterDeployment)
+	if err != nil {
+		return fmt.Errorf("failed to get placement of the ClusterDeployment %s: %w", client.ObjectKeyFromObject(clusterDeployment), err)
+	}
+	if clusterPlacement == nil {
+		return nil
+	}
+
+	if err := placementutil.Validate(clusterPlacement); err != nil {
+		return err
+	}
+
+	template, err := v.getClusterDeploymentTemplate(ctx, clusterDeployment.Namespace, clusterDeployment.Spec.Template)
+	if err != nil {
+		return fmt.Errorf("failed to get ClusterTemplate for the ClusterDeployment %s: %w", client.ObjectKeyFromObject(clusterDeployment), err)
+	}
+
+	if err := isClusterTemplateValid(template); err != nil {
+		return err
+	}
+
+	_, cred, err := placementutil.Select(ctx, v.Client, v.SystemNamespace, clusterDeployment, template, clusterPlacement)
+	if err != nil {
+		return fmt.Errorf("failed to place the ClusterDeployment %s: %w", client.ObjectKeyFromObject(clusterDeployment), err)
+	}
+
+	clusterDeployment.Spec.Credential = cred.Name
+
+	return nil
+}
+
+// validatePlacement checks that the Region of the Credential of the ClusterDeployment
+// satisfies the placement of the ClusterDeployment, if any.
+func (v *ClusterDeploymentValidator) validatePlacement(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	if err := placementutil.Validate(clusterDeployment.Spec.Placement); err != nil {
+		return err
+	}
+
+	clusterPlacement, err := placementutil.ForClusterDeployment(ctx, v.Client, clusterDeployment)
+	if err != nil {
+		return err
+	}
+	if clusterPlacement == nil {
+		return nil
+	}
+
+	cred := new(kcmv1.Credential)
+	credKey := client.ObjectKey{Namespace: clusterDeployment.Namespace, Name: clusterDeployment.Spec.Credential}
+	if err := v.Get(ctx, credKey, cred); err != nil {
+		return fmt.Errorf("failed to get Credential %s: %w", credKey, err)
+	}
+
+	var region *kcmv1.Region
+	if cred.Spec.Region != "" {
+		region = new(kcmv1.Region)
+		if err := v.Get(ctx, client.ObjectKey{Name: cred.Spec.Region}, region); err != nil {
+			return fmt.Errorf("failed to get Region %s: %w", cred.Spec.Region, err)
+		}
+	}
+
+	if err := placementutil.Allows(clusterPlacement, region); err != nil {
+		return fmt.Errorf("the Credential %s violates the placement: %w", credKey, err)
+	}
+
+	return nil
+}
+
+// validateTemplatesDeprecation forbids the newly referenced deprecated or reached end of life templates
+// and returns warnings for the deprecated templates which are already in use. The old object is nil on create.
+func (v *ClusterDeploymentValidator) validateTemplatesDeprecation(ctx context.Context, oldClusterDeployment, newClusterDeployment *kcmv1.ClusterDeployment) (admission.Warnings, error) {
+	inUse := make(map[string]struct{})
+	if oldClusterDeployment != nil {
+		inUse[kcmv1.ClusterTemplateKind+"/"+oldClusterDeployment.Spec.Template] = struct{}{}
+		for _, svc := range oldClusterDeployment.Spec.ServiceSpec.Services {
+			inUse[kcmv1.ServiceTemplateKind+"/"+svc.Template] = struct{}{}
+		}
+	}
+
+	serviceTemplates := make([]string, 0, len(newClusterDeployment.Spec.ServiceSpec.Services))
+	for _, svc := range newClusterDeployment.Spec.ServiceSpec.Services {
+		serviceTemplates = append(serviceTemplates, svc.Template)
+	}
+
+	templates, err := deprecationutil.Resolve(ctx, v.Client, newClusterDeployment.Namespace, newClusterDeployment.Spec.Template, serviceTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		warnings admission.Warnings
+		errs     error
+		now      = time.Now()
+	)
+	for _, t := range deprecationutil.Deprecated(templates) {
+		if _, ok := inUse[t.Kind+"/"+t.Name]; ok {
+			warnings = append(warnings, t.Describe(now))
+			continue
+		}
+		errs = errors.Join(errs, errors.New(t.Describe(now)))
+	}
+
+	if errs != nil {
+		return nil, errors.Join(errTemplateDeprecated, errs)
+	}
+
+	return warnings, nil
+}
+
 func (v *ClusterDeploymentValidator) getClusterDeploymentTemplate(ctx context.Context, templateNamespace, templateName string) (tpl *kcmv1.ClusterTemplate, err error) {
 	tpl = new(kcmv1.ClusterTemplate)
 	return tpl, v.Get(ctx, client.ObjectKey{Namespace: templateNamespace, Name: templateName}, tpl)
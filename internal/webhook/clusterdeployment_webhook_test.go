@@ -15,6 +15,7 @@
 package webhook
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -93,6 +94,24 @@ var (
 	providerInterface = providerinterface.NewAWSProviderInterface()
 )
 
+const testRegionKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: region
+  cluster:
+    server: https://region.example.com
+users:
+- name: region
+  user:
+    token: fake
+contexts:
+- name: region
+  context:
+    cluster: region
+    user: region
+current-context: region
+`
+
 func TestClusterDeploymentValidateCreate(t *testing.T) {
 	ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
 		AdmissionRequest: admissionv1.AdmissionRequest{
@@ -219,6 +238,32 @@ func TestClusterDeploymentValidateCreate(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "should fail if the ClusterTemplate is deprecated",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				providerInterface,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithAnnotations(map[string]string{
+						kcmv1.TemplateDeprecatedAnnotation:  "true",
+						kcmv1.TemplateReplacementAnnotation: newTemplateName,
+					}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: deprecated templates cannot be newly referenced\nClusterTemplate %s is deprecated, use %s instead", testTemplateName, newTemplateName),
+		},
 		{
 			name: "should fail if the ServiceTemplates are not found in same namespace",
 			ClusterDeployment: clusterdeployment.NewClusterDeployment(
@@ -449,6 +494,55 @@ func TestClusterDeploymentValidateCreate(t *testing.T) {
 			},
 			err: fmt.Sprintf("the ClusterDeployment is invalid: provider %s does not support ClusterIdentity Kind %s from the Credential %s/%s", "infrastructure-aws", "SomeOtherDummyClusterStaticIdentity", metav1.NamespaceDefault, testCredentialName),
 		},
+		{
+			name: "should fail if the credential violates the placement",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithPlacement(&kcmv1.ClusterPlacement{}),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				providerInterface,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: the Credential %s/%s violates the placement: the management cluster does not satisfy the placement", metav1.NamespaceDefault, testCredentialName),
+		},
+		{
+			name: "should fail if the namespace placement is invalid",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				providerInterface,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:        metav1.NamespaceDefault,
+					Annotations: map[string]string{kcmv1.PlacementRegionSelectorAnnotation: "tier in prod"},
+				}},
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: failed to parse %s annotation of namespace %s", kcmv1.PlacementRegionSelectorAnnotation, metav1.NamespaceDefault),
+		},
 	}
 
 	for _, tt := range tests {
@@ -583,6 +677,53 @@ func TestClusterDeploymentValidateUpdate(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "update spec.template: should fail if the new template is deprecated",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAvailableUpgrades([]string{newTemplateName}),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(newTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred, providerInterface,
+				template.NewClusterTemplate(
+					template.WithName(newTemplateName),
+					template.WithAnnotations(map[string]string{kcmv1.TemplateEndOfLifeAnnotation: "2000-01-01"}),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: deprecated templates cannot be newly referenced\nClusterTemplate %s has reached end of life on 2000-01-01", newTemplateName),
+		},
+		{
+			name: "should succeed with a warning if the template in use is deprecated",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithDryRun(true),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithAnnotations(map[string]string{kcmv1.TemplateDeprecatedAnnotation: "unmaintained"}),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			warnings: admission.Warnings{fmt.Sprintf("ClusterTemplate %s is deprecated: unmaintained", testTemplateName)},
+		},
 		{
 			name:                      "update spec.template: should succeed if upgrade sequence validation is skipped",
 			skipUpgradePathValidation: true,
@@ -1241,6 +1382,65 @@ func TestClusterDeploymentDefault(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "should set the credential according to the placement",
+			input: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(clusterDeploymentConfig),
+				clusterdeployment.WithPlacement(&kcmv1.ClusterPlacement{}),
+			),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(clusterDeploymentConfig),
+				clusterdeployment.WithPlacement(&kcmv1.ClusterPlacement{}),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				region.New(
+					region.WithName(testRegionName),
+					region.WithKubeConfigSecretReference("region-kubeconfig", "value"),
+					region.WithConditions(metav1.Condition{Type: kcmv1.ReadyCondition, Status: metav1.ConditionTrue}),
+				),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "region-kubeconfig", Namespace: testSystemNamespace},
+					Data:       map[string][]byte{"value": []byte(testRegionKubeconfig)},
+				},
+				credential.NewCredential(
+					credential.WithName(testCredentialName),
+					credential.WithReady(true),
+					credential.WithRegion(testRegionName),
+					credential.WithIdentityRef(&corev1.ObjectReference{Kind: "Secret", Name: "secret"}),
+				),
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus("infrastructure-internal"),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+		},
+		{
+			name: "should fail if no region satisfies the placement",
+			input: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(clusterDeploymentConfig),
+				clusterdeployment.WithPlacement(&kcmv1.ClusterPlacement{}),
+			),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(clusterDeploymentConfig),
+				clusterdeployment.WithPlacement(&kcmv1.ClusterPlacement{}),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus("infrastructure-internal"),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: fmt.Sprintf(`failed to place the ClusterDeployment %s/%s: no matching region: none of the Ready Regions matches the selector ""`, metav1.NamespaceDefault, clusterdeployment.DefaultName),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1249,7 +1449,7 @@ func TestClusterDeploymentDefault(t *testing.T) {
 				WithScheme(scheme.Scheme).
 				WithRuntimeObjects(tt.existingObjects...).
 				Build()
-			validator := &ClusterDeploymentValidator{Client: c}
+			validator := &ClusterDeploymentValidator{Client: c, SystemNamespace: testSystemNamespace}
 			err := validator.Default(ctx, tt.input)
 			if tt.err != "" {
 				g.Expect(err).To(HaveOccurred())
@@ -1263,3 +1463,89 @@ func TestClusterDeploymentDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterDeploymentDefaultMigratesConfig(t *testing.T) {
+	oldClusterDeployment := clusterdeployment.NewClusterDeployment(
+		clusterdeployment.WithClusterTemplate(testTemplateName),
+		clusterdeployment.WithCredential(testCredentialName),
+		clusterdeployment.WithConfig(`{"flavor":"large"}`),
+	)
+	oldRaw, err := json.Marshal(oldClusterDeployment)
+	if err != nil {
+		t.Fatalf("failed to marshal ClusterDeployment: %v", err)
+	}
+
+	newTemplate := template.NewClusterTemplate(
+		template.WithName(newTemplateName),
+		template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+	)
+	newTemplate.Spec.ValuesMigrations = []kcmv1.ValuesMigration{
+		{Type: kcmv1.ValuesMigrationMove, From: "flavor", To: "controlPlane.instanceType", FromTemplates: []string{testTemplateName}},
+	}
+	otherTemplate := template.NewClusterTemplate(
+		template.WithName("other-template"),
+		template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+	)
+	otherTemplate.Spec.ValuesMigrations = []kcmv1.ValuesMigration{
+		{Type: kcmv1.ValuesMigrationMove, From: "flavor", To: "controlPlane.instanceType", FromTemplates: []string{"unrelated-template"}},
+	}
+
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		template  string
+		config    string
+	}{
+		{
+			name:      "should not migrate the configuration with the migrations written for other templates",
+			operation: admissionv1.Update,
+			template:  otherTemplate.Name,
+			config:    `{"flavor":"large"}`,
+		},
+		{
+			name:      "should migrate the configuration on upgrade",
+			operation: admissionv1.Update,
+			template:  newTemplateName,
+			config:    `{"controlPlane":{"instanceType":"large"}}`,
+		},
+		{
+			name:      "should not migrate the configuration if the template is unchanged",
+			operation: admissionv1.Update,
+			template:  testTemplateName,
+			config:    `{"flavor":"large"}`,
+		},
+		{
+			name:      "should not migrate the configuration on create",
+			operation: admissionv1.Create,
+			template:  newTemplateName,
+			config:    `{"flavor":"large"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: tt.operation,
+					OldObject: runtime.RawExtension{Raw: oldRaw},
+				},
+			})
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(newTemplate, otherTemplate).
+				Build()
+			validator := &ClusterDeploymentValidator{Client: c, SystemNamespace: testSystemNamespace}
+
+			cd := clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(tt.template),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithConfig(`{"flavor":"large"}`),
+			)
+			g.Expect(validator.Default(ctx, cd)).To(Succeed())
+			g.Expect(string(cd.Spec.Config.Raw)).To(Equal(tt.config))
+		})
+	}
+}
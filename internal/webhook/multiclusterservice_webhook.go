@@ -0,0 +1,148 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	maintenanceutil "github.com/K0rdent/kcm/internal/util/maintenance"
+	validationutil "github.com/K0rdent/kcm/internal/util/validation"
+)
+
+type MultiClusterServiceValidator struct {
+	client.Client
+
+	SystemNamespace string
+}
+
+const invalidMultiClusterServiceMsg = "the MultiClusterService is invalid"
+
+func (v *MultiClusterServiceValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	v.Client = mgr.GetClient()
+	return ctrl.NewWebhookManagedBy(mgr, &kcmv1.MultiClusterService{}).
+		WithValidator(v).
+		WithDefaulter(v).
+		Complete()
+}
+
+var (
+	_ admission.Validator[*kcmv1.MultiClusterService] = &MultiClusterServiceValidator{}
+	_ admission.Defaulter[*kcmv1.MultiClusterService] = &MultiClusterServiceValidator{}
+)
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (v *MultiClusterServiceValidator) ValidateCreate(ctx context.Context, mcs *kcmv1.MultiClusterService) (admission.Warnings, error) {
+	if err := v.validateSpec(ctx, nil, mcs); err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidMultiClusterServiceMsg, err)
+	}
+
+	return v.templatesDeprecationWarnings(ctx, mcs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v *MultiClusterServiceValidator) ValidateUpdate(ctx context.Context, oldMCS, newMCS *kcmv1.MultiClusterService) (admission.Warnings, error) {
+	if err := v.validateSpec(ctx, oldMCS, newMCS); err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidMultiClusterServiceMsg, err)
+	}
+
+	return v.templatesDeprecationWarnings(ctx, newMCS)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (v *MultiClusterServiceValidator) ValidateDelete(ctx context.Context, mcs *kcmv1.MultiClusterService) (admission.Warnings, error) {
+	return nil, validationutil.ValidateMCSDelete(ctx, v.Client, mcs)
+}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (*MultiClusterServiceValidator) Default(_ context.Context, mcs *kcmv1.MultiClusterService) error {
+	// the provider configuration is defined explicitly, hence the default provider is meant,
+	// without the configuration the provider is resolved from the deprecated fields, thus left as is
+	provider := &mcs.Spec.ServiceSpec.Provider
+	if provider.Name == "" && provider.Config != nil {
+		provider.Name = kubeutil.DefaultStateManagementProvider
+	}
+
+	return nil
+}
+
+// validateSpec validates the spec of the given MultiClusterService, the checks querying
+// other objects are performed only for the fields changed since the given old object, if any.
+func (v *MultiClusterServiceValidator) validateSpec(ctx context.Context, oldMCS, mcs *kcmv1.MultiClusterService) error {
+	if _, err := metav1.LabelSelectorAsSelector(&mcs.Spec.ClusterSelector); err != nil {
+		return fmt.Errorf("invalid cluster selector: %w", err)
+	}
+
+	if err := maintenanceutil.Validate(mcs.Spec.MaintenanceWindow); err != nil {
+		return err
+	}
+
+	services := mcs.Spec.ServiceSpec.Services
+	if oldMCS == nil || !equality.Semantic.DeepEqual(oldMCS.Spec.ServiceSpec.Services, services) {
+		if err := validationutil.ServicesHaveValidTemplates(ctx, v.Client, services, v.SystemNamespace); err != nil {
+			return err
+		}
+
+		if err := validationutil.ValidateServiceDependencyOverall(services); err != nil {
+			return err
+		}
+	}
+
+	if oldMCS == nil || !equality.Semantic.DeepEqual(oldMCS.Spec.ServiceSpec.Provider, mcs.Spec.ServiceSpec.Provider) {
+		if err := validationutil.StateManagementProviderExists(ctx, v.Client, mcs.Spec.ServiceSpec); err != nil {
+			return err
+		}
+	}
+
+	if oldMCS == nil || !equality.Semantic.DeepEqual(oldMCS.Spec.DependsOn, mcs.Spec.DependsOn) {
+		if err := validationutil.ValidateMCSDependencyOverall(ctx, v.Client, mcs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// templatesDeprecationWarnings returns warnings for the deprecated ServiceTemplates referenced by the given MultiClusterService.
+// Unlike the ClusterDeployment, the deprecated templates are not forbidden since the object is cluster-wide.
+func (v *MultiClusterServiceValidator) templatesDeprecationWarnings(ctx context.Context, mcs *kcmv1.MultiClusterService) (admission.Warnings, error) {
+	serviceTemplates := make([]string, 0, len(mcs.Spec.ServiceSpec.Services))
+	for _, svc := range mcs.Spec.ServiceSpec.Services {
+		serviceTemplates = append(serviceTemplates, svc.Template)
+	}
+
+	templates, err := deprecationutil.Resolve(ctx, v.Client, v.SystemNamespace, "", serviceTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidMultiClusterServiceMsg, err)
+	}
+
+	var warnings admission.Warnings
+	now := time.Now()
+	for _, t := range deprecationutil.Deprecated(templates) {
+		warnings = append(warnings, t.Describe(now))
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2026
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
+	kubeutil "github.com/K0rdent/kcm/internal/util/kube"
+	"github.com/K0rdent/kcm/test/objects/multiclusterservice"
+	"github.com/K0rdent/kcm/test/objects/template"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestMultiClusterServiceValidateCreate(t *testing.T) {
+	ctx := t.Context()
+
+	const (
+		systemNamespace = template.DefaultNamespace
+		testTemplate    = "template-1-0-0"
+	)
+
+	validTemplate := template.NewServiceTemplate(
+		template.WithName(testTemplate),
+		template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+	)
+	provider := &kcmv1.StateManagementProvider{ObjectMeta: metav1.ObjectMeta{Name: kubeutil.DefaultStateManagementProvider}}
+
+	tests := []struct {
+		name            string
+		mcs             *kcmv1.MultiClusterService
+		existingObjects []runtime.Object
+		err             string
+		warnings        []string
+	}{
+		{
+			name: "should fail if the cluster selector is invalid",
+			mcs: multiclusterservice.NewMultiClusterService(func(mcs *kcmv1.MultiClusterService) {
+				mcs.Spec.ClusterSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Unknown"}}
+			}),
+			existingObjects: []runtime.Object{provider},
+			err:             `the MultiClusterService is invalid: invalid cluster selector: "Unknown" is not a valid label selector operator`,
+		},
+		{
+			name:            "should fail if the ServiceTemplate does not exist",
+			mcs:             multiclusterservice.NewMultiClusterService(multiclusterservice.WithServiceTemplate(testTemplate)),
+			existingObjects: []runtime.Object{provider},
+			err:             `the MultiClusterService is invalid: some services have invalid templates` + "\n" + `failed to get ServiceTemplate default/template-1-0-0: servicetemplates.k0rdent.mirantis.com "template-1-0-0" not found`,
+		},
+		{
+			name: "should fail if the StateManagementProvider does not exist",
+			mcs: multiclusterservice.NewMultiClusterService(multiclusterservice.WithServiceTemplate(testTemplate), func(mcs *kcmv1.MultiClusterService) {
+				mcs.Spec.ServiceSpec.Provider.Name = "unknown"
+			}),
+			existingObjects: []runtime.Object{validTemplate, provider},
+			err:             `the MultiClusterService is invalid: failed to get StateManagementProvider unknown: statemanagementproviders.k0rdent.mirantis.com "unknown" not found`,
+		},
+		{
+			name: "should fail if the dependency does not exist",
+			mcs: multiclusterservice.NewMultiClusterService(multiclusterservice.WithServiceTemplate(testTemplate), func(mcs *kcmv1.MultiClusterService) {
+				mcs.Spec.DependsOn = []string{"absent"}
+			}),
+			existingObjects: []runtime.Object{validTemplate, provider},
+			err:             "the MultiClusterService is invalid: failed MCS dependency validation: dependency /absent of /multiclusterservice is not defined",
+		},
+		{
+			name:            "should succeed",
+			mcs:             multiclusterservice.NewMultiClusterService(multiclusterservice.WithServiceTemplate(testTemplate)),
+			existingObjects: []runtime.Object{validTemplate, provider},
+		},
+		{
+			name: "should succeed with a warning if the ServiceTemplate is deprecated",
+			mcs:  multiclusterservice.NewMultiClusterService(multiclusterservice.WithServiceTemplate(testTemplate)),
+			existingObjects: []runtime.Object{
+				template.NewServiceTemplate(
+					template.WithName(testTemplate),
+					template.WithAnnotations(map[string]string{
+						kcmv1.TemplateDeprecatedAnnotation:  "true",
+						kcmv1.TemplateEndOfLifeAnnotation:   "2100-01-01",
+						kcmv1.TemplateReplacementAnnotation: "template-2-0-0",
+					}),
+					template.WithValidationStatus(kcmv1.TemplateValidationStatus{Valid: true}),
+				),
+				provider,
+			},
+			warnings: []string{"ServiceTemplate template-1-0-0 is deprecated and reaches end of life on 2100-01-01, use template-2-0-0 instead"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &MultiClusterServiceValidator{Client: c, SystemNamespace: systemNamespace}
+			warn, err := validator.ValidateCreate(ctx, tt.mcs)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			if len(tt.warnings) > 0 {
+				g.Expect(warn).To(BeEquivalentTo(tt.warnings))
+			} else {
+				g.Expect(warn).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestMultiClusterServiceValidateDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	dependency := multiclusterservice.NewMultiClusterService(multiclusterservice.WithName("dependency"))
+	dependent := multiclusterservice.NewMultiClusterService(multiclusterservice.WithName("dependent"), func(mcs *kcmv1.MultiClusterService) {
+		mcs.Spec.DependsOn = []string{dependency.Name}
+	})
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(dependency, dependent).Build()
+	validator := &MultiClusterServiceValidator{Client: c}
+
+	_, err := validator.ValidateDelete(ctx, dependency)
+	g.Expect(err).To(MatchError("failed to delete MultiClusterService /dependency because 1 other MultiClusterServices depend on it"))
+
+	_, err = validator.ValidateDelete(ctx, dependent)
+	g.Expect(err).To(Succeed())
+}
+
+func TestMultiClusterServiceDefault(t *testing.T) {
+	g := NewWithT(t)
+
+	mcs := multiclusterservice.NewMultiClusterService()
+	g.Expect((&MultiClusterServiceValidator{}).Default(t.Context(), mcs)).To(Succeed())
+	g.Expect(mcs.Spec.ServiceSpec.Provider.Name).To(BeEmpty())
+
+	mcs.Spec.ServiceSpec.Provider.Config = &apiextv1.JSON{Raw: []byte(`{}`)}
+	g.Expect((&MultiClusterServiceValidator{}).Default(t.Context(), mcs)).To(Succeed())
+	g.Expect(mcs.Spec.ServiceSpec.Provider.Name).To(Equal(kubeutil.DefaultStateManagementProvider))
+}
@@ -30,6 +30,8 @@ import (
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
 	"github.com/K0rdent/kcm/internal/helm"
+	deprecationutil "github.com/K0rdent/kcm/internal/util/deprecation"
+	valuesschemautil "github.com/K0rdent/kcm/internal/util/valuesschema"
 )
 
 var errTemplateDeletionForbidden = errors.New("template deletion is forbidden")
@@ -61,13 +63,13 @@ var (
 )
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (*ClusterTemplateValidator) ValidateCreate(_ context.Context, _ *kcmv1.ClusterTemplate) (admission.Warnings, error) {
-	return nil, nil
+func (*ClusterTemplateValidator) ValidateCreate(_ context.Context, template *kcmv1.ClusterTemplate) (admission.Warnings, error) {
+	return nil, errors.Join(deprecationutil.Validate(template), valuesschemautil.ValidateMigrations(template.Spec.ValuesMigrations))
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (*ClusterTemplateValidator) ValidateUpdate(_ context.Context, _, _ *kcmv1.ClusterTemplate) (admission.Warnings, error) {
-	return nil, nil
+func (*ClusterTemplateValidator) ValidateUpdate(_ context.Context, _, newTemplate *kcmv1.ClusterTemplate) (admission.Warnings, error) {
+	return nil, errors.Join(deprecationutil.Validate(newTemplate), valuesschemautil.ValidateMigrations(newTemplate.Spec.ValuesMigrations))
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -118,13 +120,13 @@ var (
 )
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (*ServiceTemplateValidator) ValidateCreate(_ context.Context, _ *kcmv1.ServiceTemplate) (admission.Warnings, error) {
-	return nil, nil
+func (*ServiceTemplateValidator) ValidateCreate(_ context.Context, template *kcmv1.ServiceTemplate) (admission.Warnings, error) {
+	return nil, deprecationutil.Validate(template)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (*ServiceTemplateValidator) ValidateUpdate(_ context.Context, _, _ *kcmv1.ServiceTemplate) (admission.Warnings, error) {
-	return nil, nil
+func (*ServiceTemplateValidator) ValidateUpdate(_ context.Context, _, newTemplate *kcmv1.ServiceTemplate) (admission.Warnings, error) {
+	return nil, deprecationutil.Validate(newTemplate)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -123,3 +123,9 @@ func WithRegion(region string) Opt {
 		p.Status.Region = region
 	}
 }
+
+func WithPlacement(placement *kcmv1.ClusterPlacement) Opt {
+	return func(p *kcmv1.ClusterDeployment) {
+		p.Spec.Placement = placement
+	}
+}
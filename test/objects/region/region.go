@@ -98,3 +98,9 @@ func WithComponentsStatus(components map[string]kcmv1.ComponentStatus) Opt {
 		p.Status.Components = components
 	}
 }
+
+func WithConditions(conditions ...metav1.Condition) Opt {
+	return func(p *kcmv1.Region) {
+		p.Status.Conditions = conditions
+	}
+}
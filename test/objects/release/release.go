@@ -72,6 +72,12 @@ func WithCAPITemplateName(v string) Opt {
 	}
 }
 
+func WithRegionalTemplateName(v string) Opt {
+	return func(r *kcmv1.Release) {
+		r.Spec.Regional.Template = v
+	}
+}
+
 func WithProviders(v ...kcmv1.NamedProviderTemplate) Opt {
 	return func(r *kcmv1.Release) {
 		r.Spec.Providers = v
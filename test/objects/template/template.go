@@ -16,10 +16,12 @@ package template //nolint:revive // it is okay for now
 
 import (
 	"fmt"
+	"time"
 
 	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1beta1"
@@ -111,6 +113,30 @@ func WithNamespace(namespace string) Opt {
 	}
 }
 
+func WithLabels(labels map[string]string) Opt {
+	return func(t Template) {
+		t.SetLabels(labels)
+	}
+}
+
+func WithUID(uid types.UID) Opt {
+	return func(t Template) {
+		t.SetUID(uid)
+	}
+}
+
+func WithCreationTimestamp(created time.Time) Opt {
+	return func(t Template) {
+		t.SetCreationTimestamp(metav1.NewTime(created))
+	}
+}
+
+func WithAnnotations(annotations map[string]string) Opt {
+	return func(t Template) {
+		t.SetAnnotations(annotations)
+	}
+}
+
 func WithOwnerReference(ownerRef []metav1.OwnerReference) Opt {
 	return func(t Template) {
 		t.SetOwnerReferences(ownerRef)
@@ -198,6 +224,13 @@ func WithClusterStatusK8sVersion(v string) Opt {
 	}
 }
 
+func WithChartVersionStatus(version string) Opt {
+	return func(t Template) {
+		status := t.GetCommonStatus()
+		status.ChartVersion = version
+	}
+}
+
 func WithStatusChartRef(chartRef *helmcontrollerv2.CrossNamespaceSourceReference) Opt {
 	return func(t Template) {
 		status := t.GetCommonStatus()